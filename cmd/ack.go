@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"go-rmq-monitor/internal/ack"
+	"go-rmq-monitor/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+var ackCmd = &cobra.Command{
+	Use:   "ack <queue>",
+	Short: "Acknowledge an alerting queue",
+	Long: `Acknowledge an alerting queue on a running monitor.
+
+The acknowledgment is shared by all notifiers and lasts until the queue recovers.
+The recovery notification includes who acknowledged the incident.
+
+Examples:
+  go-rmq-monitor ack orders --comment "consumer redeploy in progress"
+  go-rmq-monitor ack orders --by alice --server http://monitor.internal:9090`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAck,
+}
+
+var (
+	ackBy        string
+	ackComment   string
+	ackServerURL string
+	ackToken     string
+)
+
+func init() {
+	rootCmd.AddCommand(ackCmd)
+	ackCmd.Flags().StringVar(&ackBy, "by", os.Getenv("USER"), "Who is acknowledging the alert")
+	ackCmd.Flags().StringVar(&ackComment, "comment", "", "Optional comment stored with the acknowledgment")
+	ackCmd.Flags().StringVar(&ackServerURL, "server", "", "Monitor server URL (default derived from server.listen_address in config)")
+	addAPIClientFlags(ackCmd)
+	ackCmd.Flags().StringVar(&ackToken, "token", "", "API token with the silencer role (default is $RMQ_MONITOR_TOKEN)")
+}
+
+func runAck(cmd *cobra.Command, args []string) error {
+	queueName := args[0]
+	if ackBy == "" {
+		return fmt.Errorf("--by is required")
+	}
+
+	serverURL, serverCert := ackServerURL, ""
+	if serverURL == "" {
+		configPath := cfgFile
+		if configPath == "" {
+			configPath = "config.yaml"
+		}
+
+		cfg, err := config.LoadInstance(configPath, instanceName)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		serverURL = localServerURL(cfg.Server)
+		serverCert = cfg.Server.TLS.CertFile
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"by":      ackBy,
+		"source":  ack.SourceCLI,
+		"comment": ackComment,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	endpoint := strings.TrimRight(serverURL, "/") + "/api/acks/" + url.PathEscape(queueName)
+	req, err := newAPIRequest(http.MethodPost, endpoint, apiToken(ackToken), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient(serverCert)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach monitor: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("acknowledgment rejected (%d): %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	fmt.Printf("✅ Acknowledged queue %s as %s\n", queueName, ackBy)
+	return nil
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/feedback"
+	"go-rmq-monitor/internal/history"
+	"go-rmq-monitor/internal/tuning"
+
+	"github.com/spf13/cobra"
+)
+
+var analyzeConfigCmd = &cobra.Command{
+	Use:   "analyze-config",
+	Short: "Suggest detection settings from stored queue history",
+	Long: `Replay stored queue history (see the history config section) through the
+analyzer with the current settings and suggest per-queue detection parameters
+(min_message_count, min_consume_rate, threshold_checks) based on observed
+distributions. Alerts marked as false positives (see the false-positive command)
+are taken into account so they would not fire again. Queues likely to cause
+false positives are flagged.
+
+Examples:
+  go-rmq-monitor analyze-config
+  go-rmq-monitor analyze-config --since 72h --queue orders`,
+	Args: cobra.NoArgs,
+	RunE: runAnalyzeConfig,
+}
+
+var (
+	analyzeHistoryPath  string
+	analyzeFeedbackPath string
+	analyzeSince        time.Duration
+	analyzeQueues       []string
+)
+
+func init() {
+	rootCmd.AddCommand(analyzeConfigCmd)
+	analyzeConfigCmd.Flags().StringVar(&analyzeHistoryPath, "history", "", "History file (default is history.file_path from config)")
+	analyzeConfigCmd.Flags().StringVar(&analyzeFeedbackPath, "feedback", "", "False positive marks file (default is feedback.file_path from config)")
+	analyzeConfigCmd.Flags().DurationVar(&analyzeSince, "since", 7*24*time.Hour, "How much history to analyze")
+	analyzeConfigCmd.Flags().StringSliceVarP(&analyzeQueues, "queue", "q", nil, "Only analyze these queues")
+}
+
+func runAnalyzeConfig(cmd *cobra.Command, args []string) error {
+	configPath := cfgFile
+	if configPath == "" {
+		configPath = "config.yaml"
+	}
+
+	cfg, err := config.LoadInstance(configPath, instanceName)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	historyPath := analyzeHistoryPath
+	if historyPath == "" {
+		historyPath = cfg.History.FilePath
+	}
+
+	feedbackPath := analyzeFeedbackPath
+	if feedbackPath == "" {
+		feedbackPath = cfg.Feedback.FilePath
+	}
+
+	records, err := history.Load(historyPath, time.Now().Add(-analyzeSince))
+	if errors.Is(err, fs.ErrNotExist) {
+		records = make(map[string][]history.Record)
+	} else if err != nil {
+		return err
+	}
+
+	// False positive marks are institutional memory and are never aged out
+	marks, err := feedback.Load(feedbackPath, time.Time{})
+	if err != nil {
+		return err
+	}
+
+	if len(analyzeQueues) > 0 {
+		selectedRecords := make(map[string][]history.Record)
+		selectedMarks := make(map[string][]feedback.Mark)
+		for _, name := range analyzeQueues {
+			if queueRecords, exists := records[name]; exists {
+				selectedRecords[name] = queueRecords
+			}
+			if queueMarks, exists := marks[name]; exists {
+				selectedMarks[name] = queueMarks
+			}
+		}
+		records, marks = selectedRecords, selectedMarks
+	}
+
+	if len(records) == 0 && len(marks) == 0 {
+		fmt.Println("No history or false positive marks found. Enable history in config and let the monitor run for a while.")
+		return nil
+	}
+
+	suggestions := tuning.Analyze(records, marks, cfg)
+
+	fmt.Printf("📊 Analyzed %d queue(s) from %s (last %s)\n\n", len(suggestions), historyPath, analyzeSince)
+	changed := make([]tuning.Suggestion, 0)
+	for _, suggestion := range suggestions {
+		marker := "✓"
+		if suggestion.LikelyFalsePositives {
+			marker = "⚠️"
+		}
+		fmt.Printf("%s %s (%d samples, %d alert(s), %d self-resolved, %d marked false positive)\n",
+			marker, suggestion.QueueName, suggestion.Samples, suggestion.AlertEpisodes, suggestion.ShortEpisodes, suggestion.FalsePositives)
+		for _, note := range suggestion.Notes {
+			fmt.Printf("    - %s\n", note)
+		}
+		if suggestion.Changed() {
+			changed = append(changed, suggestion)
+		}
+	}
+
+	if len(changed) == 0 {
+		fmt.Println("\n✅ Current settings look fine for the observed history.")
+		return nil
+	}
+
+	fmt.Println("\n💡 Suggested queue settings:")
+	fmt.Println()
+	fmt.Println("  queues:")
+	for _, suggestion := range changed {
+		fmt.Print(formatSuggestion(suggestion))
+	}
+	return nil
+}
+
+// formatSuggestion renders a suggestion as a config snippet, listing only changed values
+func formatSuggestion(suggestion tuning.Suggestion) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "    - name: %q\n", suggestion.QueueName)
+	if suggestion.Suggested.ThresholdChecks != suggestion.Current.ThresholdChecks {
+		fmt.Fprintf(&b, "      threshold_checks: %d   # was %d\n", suggestion.Suggested.ThresholdChecks, suggestion.Current.ThresholdChecks)
+	}
+	if suggestion.Suggested.MinMessageCount != suggestion.Current.MinMessageCount {
+		fmt.Fprintf(&b, "      min_message_count: %d   # was %d\n", suggestion.Suggested.MinMessageCount, suggestion.Current.MinMessageCount)
+	}
+	if suggestion.Suggested.MinConsumeRate != suggestion.Current.MinConsumeRate {
+		fmt.Fprintf(&b, "      min_consume_rate: %g   # was %g\n", suggestion.Suggested.MinConsumeRate, suggestion.Current.MinConsumeRate)
+	}
+	return b.String()
+}
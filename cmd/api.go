@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"go-rmq-monitor/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+// Client TLS settings shared by commands talking to a running monitor
+var (
+	apiCACert     string
+	apiClientCert string
+	apiClientKey  string
+)
+
+// addAPIClientFlags registers the TLS flags used to reach a monitor's server
+func addAPIClientFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&apiCACert, "ca-cert", "", "CA certificate to verify the monitor server (default is server.tls.cert_file when reachable)")
+	cmd.Flags().StringVar(&apiClientCert, "client-cert", "", "Client certificate when the server requires mTLS")
+	cmd.Flags().StringVar(&apiClientKey, "client-key", "", "Client key when the server requires mTLS")
+}
+
+// newAPIClient builds an HTTP client for the monitor's control API
+// serverCert, if set, is trusted when no --ca-cert is given (self-signed local servers)
+func newAPIClient(serverCert string) (*http.Client, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	caFile := apiCACert
+	if caFile == "" {
+		caFile = serverCert
+	}
+	if caFile != "" {
+		caPEM, err := os.ReadFile(caFile)
+		if err != nil && apiCACert != "" {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		if err == nil {
+			roots, err := x509.SystemCertPool()
+			if err != nil {
+				roots = x509.NewCertPool()
+			}
+			roots.AppendCertsFromPEM(caPEM)
+			tlsConfig.RootCAs = roots
+		}
+	}
+
+	if apiClientCert != "" || apiClientKey != "" {
+		cert, err := tls.LoadX509KeyPair(apiClientCert, apiClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}, nil
+}
+
+// newAPIRequest builds a JSON request to the monitor's control API
+// The token is sent as a bearer token when set
+func newAPIRequest(method, endpoint, token string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req, nil
+}
+
+// apiToken returns the token flag value, falling back to $RMQ_MONITOR_TOKEN
+func apiToken(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	return os.Getenv("RMQ_MONITOR_TOKEN")
+}
+
+// localServerURL converts the server's listen address like ":9090" into a URL reachable from this host
+func localServerURL(serverCfg config.ServerConfig) string {
+	scheme := "http://"
+	if serverCfg.TLS.Enabled() {
+		scheme = "https://"
+	}
+
+	host, port, err := net.SplitHostPort(serverCfg.ListenAddress)
+	if err != nil {
+		return scheme + serverCfg.ListenAddress
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return scheme + net.JoinHostPort(host, port)
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"go-rmq-monitor/internal/audit"
+	"go-rmq-monitor/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Query the operator action audit log",
+	Long: `Query the audit log of operator actions (silences, acknowledgments, false
+positive marks, manual checks and quarantines) recorded by a monitor with audit enabled.
+
+Examples:
+  go-rmq-monitor audit --since 24h
+  go-rmq-monitor audit --actor alice --action ack
+  go-rmq-monitor audit --queue orders --output json`,
+	Args: cobra.NoArgs,
+	RunE: runAudit,
+}
+
+var (
+	auditFilePath string
+	auditSince    time.Duration
+	auditActor    string
+	auditAction   string
+	auditQueue    string
+	auditOutput   string
+)
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+	auditCmd.Flags().StringVar(&auditFilePath, "file", "", "Audit log file (default is audit.file_path from config)")
+	auditCmd.Flags().DurationVar(&auditSince, "since", 0, "Only show actions newer than this (default is all)")
+	auditCmd.Flags().StringVar(&auditActor, "actor", "", "Only show actions by this actor or API token name")
+	auditCmd.Flags().StringVar(&auditAction, "action", "", "Only show this action (ack, false_positive, silence_create, silence_expire, manual_check, quarantine)")
+	auditCmd.Flags().StringVarP(&auditQueue, "queue", "q", "", "Only show actions affecting this queue")
+	auditCmd.Flags().StringVarP(&auditOutput, "output", "o", "text", "Output format: text or json")
+}
+
+func runAudit(cmd *cobra.Command, args []string) error {
+	path := auditFilePath
+	if path == "" {
+		configPath := cfgFile
+		if configPath == "" {
+			configPath = "config.yaml"
+		}
+
+		cfg, err := config.LoadInstance(configPath, instanceName)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		path = cfg.Audit.FilePath
+	}
+
+	filter := audit.Filter{
+		Action: auditAction,
+		Actor:  auditActor,
+		Queue:  auditQueue,
+	}
+	if auditSince > 0 {
+		filter.Since = time.Now().Add(-auditSince)
+	}
+
+	entries, err := audit.Query(path, filter)
+	if err != nil {
+		return err
+	}
+
+	switch auditOutput {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	case "text":
+		if len(entries) == 0 {
+			fmt.Println("No matching audit entries.")
+			return nil
+		}
+		for _, entry := range entries {
+			fmt.Println(formatAuditEntry(entry))
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q (text, json)", auditOutput)
+	}
+}
+
+// formatAuditEntry renders an audit entry as a single line
+func formatAuditEntry(entry audit.Entry) string {
+	actor := entry.Actor
+	if actor == "" {
+		actor = "-"
+	}
+	if entry.Identity != "" && entry.Identity != entry.Actor {
+		actor += " (token " + entry.Identity + ")"
+	}
+
+	line := fmt.Sprintf("%s  %-15s %-25s", entry.Timestamp.Local().Format("2006-01-02 15:04:05"), entry.Action, actor)
+	if len(entry.Queues) > 0 {
+		line += " queues=" + strings.Join(entry.Queues, ",")
+	}
+	if entry.Source != "" {
+		line += " source=" + entry.Source
+	}
+
+	keys := make([]string, 0, len(entry.Details))
+	for key := range entry.Details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		line += fmt.Sprintf(" %s=%q", key, entry.Details[key])
+	}
+	return line
+}
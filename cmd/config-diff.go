@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/discovery"
+	"go-rmq-monitor/internal/rabbitmq"
+
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the config file",
+}
+
+var configDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare the configured queues with the live broker",
+	Long: `Compare the configured queues with the queues in the configured vhost and report:
+
+  - configured queues that do not exist on the broker
+  - unmonitored queues that share a naming convention prefix with a configured
+    queue (the name up to the first '.', '-', '_' or ':') or match --pattern
+  - overrides that change nothing: queue settings equal to what the queue
+    inherits, unused profiles and priority classes, and depends_on or silences
+    naming queues that do not exist
+
+Unmonitored queues are only reported when monitor.queues lists queues; without
+a list every queue is monitored. Use --exit-code to fail in CI when the config
+has drifted from the broker.
+
+Examples:
+  go-rmq-monitor config diff
+  go-rmq-monitor config diff --pattern 'payments.*' --pattern '*.commands' --exit-code`,
+	Args: cobra.NoArgs,
+	RunE: runConfigDiff,
+}
+
+var (
+	configDiffPatterns []string
+	configDiffExitCode bool
+)
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configDiffCmd)
+	configDiffCmd.Flags().StringArrayVar(&configDiffPatterns, "pattern", nil, "Report unmonitored queues matching this glob pattern (repeatable)")
+	configDiffCmd.Flags().BoolVar(&configDiffExitCode, "exit-code", false, "Exit with an error when differences are found")
+}
+
+func runConfigDiff(cmd *cobra.Command, args []string) error {
+	for _, pattern := range configDiffPatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid --pattern %q", pattern)
+		}
+	}
+
+	configPath := cfgFile
+	if configPath == "" {
+		configPath = "config.yaml"
+	}
+
+	cfg, err := config.LoadInstance(configPath, instanceName)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	client, err := rabbitmq.NewClient(&cfg.RabbitMQ)
+	if err != nil {
+		return fmt.Errorf("failed to create RabbitMQ client: %w", err)
+	}
+	queues, err := client.GetQueues()
+	if err != nil {
+		return fmt.Errorf("failed to fetch queues: %w", err)
+	}
+
+	diff := discovery.Compare(cfg, queues, configDiffPatterns)
+	fmt.Printf("🔍 %s against vhost '%s' (%d configured, %d live queues)\n", configPath, cfg.RabbitMQ.VHost, len(cfg.Monitor.Queues), len(queues))
+	if diff.Empty() {
+		fmt.Println("\n✅ Config matches the broker")
+		return nil
+	}
+
+	printDiffSection("❌ Configured but missing on the broker", "-", diff.Missing)
+	printDiffSection("⚠️  Unmonitored queues", "+", diff.Unmonitored)
+	printDiffSection("💤 Unused overrides", "~", diff.Unused)
+
+	if configDiffExitCode {
+		return fmt.Errorf("config differs from the broker: %d missing, %d unmonitored, %d unused",
+			len(diff.Missing), len(diff.Unmonitored), len(diff.Unused))
+	}
+	return nil
+}
+
+// printDiffSection prints a titled list, skipping empty sections
+func printDiffSection(title, marker string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Printf("\n%s (%d):\n", title, len(lines))
+	for _, line := range lines {
+		fmt.Printf("  %s %s\n", marker, line)
+	}
+}
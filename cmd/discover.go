@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/discovery"
+	"go-rmq-monitor/internal/rabbitmq"
+
+	"github.com/spf13/cobra"
+)
+
+var discoverCmd = &cobra.Command{
+	Use:   "discover",
+	Short: "Inspect the broker and propose queue monitoring settings",
+	Long: `List the queues in the configured vhost grouped by naming convention (the
+name up to the first '.', '-', '_' or ':'), with the settings proposed for each.
+
+With --emit-config, a ready-to-edit config.yaml monitoring every discovered
+queue is written instead, or with --format hcl a Terraform locals block for
+rendering the queues with yamlencode. Dead-letter queues are observed without
+notifications, queues with consumers expect at least their current consumer
+count, and queues with a large steady backlog get a higher min_message_count.
+Auto-created queues matched by monitor.auto_exclude, such as exclusive RPC
+reply queues and broker-named amq.gen-* queues, are skipped.
+
+Examples:
+  go-rmq-monitor discover
+  go-rmq-monitor discover --emit-config -o config.generated.yaml
+  go-rmq-monitor discover --emit-config --format hcl -o rabbitmq_monitor_queues.tf`,
+	Args: cobra.NoArgs,
+	RunE: runDiscover,
+}
+
+var (
+	discoverEmitConfig bool
+	discoverFormat     string
+	discoverOutput     string
+)
+
+func init() {
+	rootCmd.AddCommand(discoverCmd)
+	discoverCmd.Flags().BoolVar(&discoverEmitConfig, "emit-config", false, "Write a config with the proposed queue blocks")
+	discoverCmd.Flags().StringVar(&discoverFormat, "format", "yaml", "Emitted config format: yaml or hcl")
+	discoverCmd.Flags().StringVarP(&discoverOutput, "output", "o", "", "Write the emitted config to a file instead of stdout")
+}
+
+func runDiscover(cmd *cobra.Command, args []string) error {
+	if discoverFormat != "yaml" && discoverFormat != "hcl" {
+		return fmt.Errorf("--format must be yaml or hcl")
+	}
+
+	configPath := cfgFile
+	if configPath == "" {
+		configPath = "config.yaml"
+	}
+
+	cfg, err := config.LoadInstance(configPath, instanceName)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	client, err := rabbitmq.NewClient(&cfg.RabbitMQ)
+	if err != nil {
+		return fmt.Errorf("failed to create RabbitMQ client: %w", err)
+	}
+	queues, err := client.GetQueues()
+	if err != nil {
+		return fmt.Errorf("failed to fetch queues: %w", err)
+	}
+
+	result := discovery.Discover(queues, cfg.Monitor.Detection, cfg.Monitor.AutoExclude)
+	if !discoverEmitConfig {
+		printDiscovery(result, cfg.RabbitMQ.VHost)
+		return nil
+	}
+
+	var out io.Writer = os.Stdout
+	if discoverOutput != "" {
+		file, err := os.Create(discoverOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if discoverFormat == "hcl" {
+		err = discovery.WriteHCL(out, result)
+	} else {
+		err = discovery.WriteYAML(out, cfg, result)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	if discoverOutput != "" {
+		fmt.Printf("✓ Wrote %d queue group(s) to %s\n", len(result.Groups), discoverOutput)
+	}
+	return nil
+}
+
+// printDiscovery lists the discovered groups and the settings proposed per queue
+func printDiscovery(result discovery.Result, vhost string) {
+	if len(result.Groups) == 0 {
+		fmt.Printf("No queues found in vhost '%s'\n", vhost)
+		return
+	}
+	fmt.Printf("📊 Queues in vhost '%s' by naming convention:\n", vhost)
+	for _, group := range result.Groups {
+		fmt.Printf("\n  %s (%d queue(s))\n", group.Name, len(group.Suggestions))
+		for _, suggestion := range group.Suggestions {
+			queue := suggestion.Queue
+			fmt.Printf("    %-40s %8d ready  %3d consumers", queue.Name, queue.MessagesReady, queue.Consumers)
+			if suggestion.Config.ObserveOnly {
+				fmt.Print("  observe only")
+			}
+			fmt.Println()
+		}
+	}
+	if len(result.ServerNamed) > 0 {
+		fmt.Printf("\nSkipped %d auto-created queue(s)\n", len(result.ServerNamed))
+	}
+	fmt.Println("\n💡 Run with --emit-config to generate a config for these queues")
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/feedback"
+
+	"github.com/spf13/cobra"
+)
+
+var falsePositiveCmd = &cobra.Command{
+	Use:   "false-positive <queue>",
+	Short: "Mark a queue's alert as a false positive",
+	Long: `Mark the current or most recent alert of a queue as a false positive on a running monitor.
+
+The monitor records the mark together with the queue metrics and detection
+settings at the time. The analyze-config command uses recorded marks to
+propose thresholds that would not have raised the alert.
+
+Examples:
+  go-rmq-monitor false-positive orders --comment "nightly batch import"
+  go-rmq-monitor false-positive orders --by alice --server http://monitor.internal:9090`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFalsePositive,
+}
+
+var (
+	falsePositiveBy        string
+	falsePositiveComment   string
+	falsePositiveServerURL string
+	falsePositiveToken     string
+)
+
+func init() {
+	rootCmd.AddCommand(falsePositiveCmd)
+	falsePositiveCmd.Flags().StringVar(&falsePositiveBy, "by", os.Getenv("USER"), "Who is marking the alert")
+	falsePositiveCmd.Flags().StringVar(&falsePositiveComment, "comment", "", "Why the alert was a false positive")
+	falsePositiveCmd.Flags().StringVar(&falsePositiveServerURL, "server", "", "Monitor server URL (default derived from server.listen_address in config)")
+	addAPIClientFlags(falsePositiveCmd)
+	falsePositiveCmd.Flags().StringVar(&falsePositiveToken, "token", "", "API token with the silencer role (default is $RMQ_MONITOR_TOKEN)")
+}
+
+func runFalsePositive(cmd *cobra.Command, args []string) error {
+	queueName := args[0]
+	if falsePositiveBy == "" {
+		return fmt.Errorf("--by is required")
+	}
+
+	serverURL, serverCert := falsePositiveServerURL, ""
+	if serverURL == "" {
+		configPath := cfgFile
+		if configPath == "" {
+			configPath = "config.yaml"
+		}
+
+		cfg, err := config.LoadInstance(configPath, instanceName)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		serverURL = localServerURL(cfg.Server)
+		serverCert = cfg.Server.TLS.CertFile
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"by":      falsePositiveBy,
+		"source":  feedback.SourceCLI,
+		"comment": falsePositiveComment,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	endpoint := strings.TrimRight(serverURL, "/") + "/api/false-positives/" + url.PathEscape(queueName)
+	req, err := newAPIRequest(http.MethodPost, endpoint, apiToken(falsePositiveToken), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient(serverCert)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach monitor: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("mark rejected (%d): %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	fmt.Printf("✅ Marked alert for queue %s as a false positive\n", queueName)
+	return nil
+}
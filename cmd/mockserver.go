@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"go-rmq-monitor/internal/mockserver"
+
+	"github.com/spf13/cobra"
+)
+
+var mockserverCmd = &cobra.Command{
+	Use:   "mockserver",
+	Short: "Serve a fake RabbitMQ management API replaying scripted queue metrics",
+	Long: `Serve a fake management API whose queues follow scripted metric timelines, so
+detection and notification formatting can be developed and demoed without a
+broker. Point rabbitmq.host and rabbitmq.port at the server and run the monitor
+as usual; any username and password are accepted.
+
+A script lists queues with timeline points, each holding the queue's ready and
+unacked counts, consumers and rates for a duration. ready_to ramps the ready
+count across the point, and with loop: true timelines restart after their last
+point. Without --script a demo with a healthy queue, a queue that gets stuck
+and recovers, and a queue losing its consumers is served.
+
+Example script:
+  vhost: /
+  loop: true
+  queues:
+    - name: payments
+      timeline:
+        - {for: 1m, ready: 5, consumers: 2, publish_rate: 10, consume_rate: 10}
+        - {for: 3m, ready: 20, ready_to: 400, consumers: 2, publish_rate: 10}
+        - {for: 1m, ready: 400, ready_to: 0, consumers: 2, publish_rate: 10, consume_rate: 17}
+
+Examples:
+  go-rmq-monitor mockserver
+  go-rmq-monitor mockserver --script scenarios/stuck.yaml --listen :15673`,
+	Args: cobra.NoArgs,
+	RunE: runMockserver,
+}
+
+var (
+	mockserverScript string
+	mockserverListen string
+)
+
+func init() {
+	rootCmd.AddCommand(mockserverCmd)
+	mockserverCmd.Flags().StringVar(&mockserverScript, "script", "", "Script of queue metric timelines (default is a built-in demo)")
+	mockserverCmd.Flags().StringVar(&mockserverListen, "listen", "localhost:15672", "Address to serve the fake management API on")
+}
+
+func runMockserver(cmd *cobra.Command, args []string) error {
+	script := mockserver.Demo()
+	if mockserverScript != "" {
+		var err error
+		if script, err = mockserver.LoadScript(mockserverScript); err != nil {
+			return err
+		}
+	}
+
+	listener, err := net.Listen("tcp", mockserverListen)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", mockserverListen, err)
+	}
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	fmt.Printf("🧪 Serving %d scripted queue(s) in vhost '%s' on %s\n", len(script.Queues), script.VHost, listener.Addr())
+	fmt.Println("\nPoint the monitor at it with:")
+	fmt.Printf("  rabbitmq:\n    host: %q\n    port: %s\n    vhost: %q\n", host, port, script.VHost)
+
+	server := &http.Server{
+		Handler:           mockserver.New(script).Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return server.Serve(listener)
+}
@@ -45,13 +45,13 @@ func runMonitor(cmd *cobra.Command, args []string) error {
 		configPath = "config.yaml"
 	}
 
-	cfg, err := config.Load(configPath)
+	cfg, err := config.LoadInstance(configPath, instanceName)
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
 	// Create and lock PID file to prevent multiple instances
-	pidFilePath := pidfile.GetDefaultPath(configPath)
+	pidFilePath := pidfile.GetInstancePath(configPath, cfg.Instance)
 	pid := pidfile.New(pidFilePath)
 	if err := pid.Create(); err != nil {
 		return fmt.Errorf("failed to create PID file: %w", err)
@@ -72,14 +72,24 @@ func runMonitor(cmd *cobra.Command, args []string) error {
 	}
 	defer log.Close()
 
-	log.Info("Starting RabbitMQ monitor", map[string]interface{}{
+	fields := map[string]interface{}{
 		"vhost":    cfg.RabbitMQ.VHost,
 		"interval": cfg.Monitor.Interval.String(),
 		"host":     cfg.RabbitMQ.Host,
-	})
+	}
+	if cfg.Instance != "" {
+		fields["instance"] = cfg.Instance
+		fields["pid_file"] = pidFilePath
+	}
+	log.Info("Starting RabbitMQ monitor", fields)
+
+	return runService(cfg, log, verbose)
+}
 
+// runService runs the monitor until it fails or a shutdown signal arrives
+func runService(cfg *config.Config, log *logger.Logger, verbosity int) error {
 	// Create monitor service
-	monitorService, err := monitor.New(cfg, log, verbose)
+	monitorService, err := monitor.New(cfg, log, verbosity)
 	if err != nil {
 		return fmt.Errorf("failed to create monitor: %w", err)
 	}
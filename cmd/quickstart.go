@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/discovery"
+	"go-rmq-monitor/internal/logger"
+	"go-rmq-monitor/internal/rabbitmq"
+
+	"github.com/spf13/cobra"
+)
+
+var quickstartCmd = &cobra.Command{
+	Use:   "quickstart",
+	Short: "Monitor a broker without a config file, logging alerts to stdout",
+	Long: `Connect with the given host and credentials, discover the queues in the vhost
+and start monitoring them with conservative detection settings embedded in the
+binary. Alerts are logged to stdout only: no notifications are sent and
+nothing is written to disk. The config file is not read.
+
+Queues get the settings 'discover' would propose: dead-letter queues are
+observed without alerting and queues with consumers expect at least their
+current consumer count. Queues created later are not picked up; run
+'discover --emit-config' for a config to keep.
+
+Examples:
+  go-rmq-monitor quickstart
+  go-rmq-monitor quickstart --host rabbitmq.internal --username monitor --vhost orders
+  RABBITMQ_PASSWORD=secret go-rmq-monitor quickstart --host rabbitmq.internal --tls --port 443`,
+	Args: cobra.NoArgs,
+	RunE: runQuickstart,
+}
+
+var (
+	quickstartHost     string
+	quickstartPort     int
+	quickstartUsername string
+	quickstartPassword string
+	quickstartVHost    string
+	quickstartTLS      bool
+	quickstartVerbose  int
+)
+
+func init() {
+	rootCmd.AddCommand(quickstartCmd)
+	quickstartCmd.Flags().StringVar(&quickstartHost, "host", "localhost", "RabbitMQ management API host")
+	quickstartCmd.Flags().IntVar(&quickstartPort, "port", 15672, "RabbitMQ management API port")
+	quickstartCmd.Flags().StringVar(&quickstartUsername, "username", "guest", "RabbitMQ username")
+	quickstartCmd.Flags().StringVar(&quickstartPassword, "password", "", "RabbitMQ password (default is $RABBITMQ_PASSWORD, or guest)")
+	quickstartCmd.Flags().StringVar(&quickstartVHost, "vhost", "/", "Virtual host to monitor")
+	quickstartCmd.Flags().BoolVar(&quickstartTLS, "tls", false, "Connect to the management API over HTTPS")
+	quickstartCmd.Flags().CountVarP(&quickstartVerbose, "verbose", "v", "Increase verbosity (-v, -vv, -vvv)")
+}
+
+func runQuickstart(cmd *cobra.Command, args []string) error {
+	password := quickstartPassword
+	if password == "" {
+		password = os.Getenv("RABBITMQ_PASSWORD")
+	}
+	if password == "" {
+		password = "guest"
+	}
+
+	cfg, err := config.Quickstart(config.RabbitMQConfig{
+		Host:     quickstartHost,
+		Port:     quickstartPort,
+		Username: quickstartUsername,
+		Password: password,
+		VHost:    quickstartVHost,
+		UseTLS:   quickstartTLS,
+	})
+	if err != nil {
+		return fmt.Errorf("invalid quickstart settings: %w", err)
+	}
+	if quickstartVerbose >= 3 {
+		cfg.Logging.Level = "debug"
+	}
+
+	client, err := rabbitmq.NewClient(&cfg.RabbitMQ)
+	if err != nil {
+		return fmt.Errorf("failed to create RabbitMQ client: %w", err)
+	}
+	queues, err := client.GetQueues()
+	if err != nil {
+		return fmt.Errorf("failed to fetch queues: %w", err)
+	}
+
+	result := discovery.Discover(queues, cfg.Monitor.Detection, cfg.Monitor.AutoExclude)
+	observed := 0
+	for _, group := range result.Groups {
+		for _, suggestion := range group.Suggestions {
+			cfg.Monitor.Queues = append(cfg.Monitor.Queues, suggestion.Config)
+			if suggestion.Config.ObserveOnly {
+				observed++
+			}
+		}
+	}
+
+	if len(cfg.Monitor.Queues) == 0 {
+		fmt.Printf("⚠️  No queues found in vhost '%s'; monitoring every queue as it appears\n", cfg.RabbitMQ.VHost)
+	} else {
+		fmt.Printf("🚀 Monitoring %d queue(s) in vhost '%s' on %s every %s (%d dead-letter queue(s) observed only)\n",
+			len(cfg.Monitor.Queues), cfg.RabbitMQ.VHost, cfg.RabbitMQ.Host, cfg.Monitor.Interval, observed)
+	}
+	fmt.Printf("   Stuck queues alert after %d checks with at least %d ready messages; alerts are logged here. Press Ctrl+C to stop.\n\n",
+		cfg.Monitor.Detection.ThresholdChecks, cfg.Monitor.Detection.MinMessageCount)
+
+	log, err := logger.New(cfg.Logging)
+	if err != nil {
+		return fmt.Errorf("failed to initialize logger: %w", err)
+	}
+	defer log.Close()
+
+	return runService(cfg, log, quickstartVerbose)
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/report"
+
+	"github.com/spf13/cobra"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Render an HTML report with charts from stored queue history",
+	Long: `Render an HTML report of stored queue history (see the history config section)
+for operations reviews: alerts and time spent alerting per queue, peak and
+average backlog, rates, and a backlog chart per queue with its alerting periods
+shaded. The monitor renders the same report weekly with reports.enabled.
+
+Each queue is compared with the same window in previous weeks (reports.baseline_weeks,
+or --baseline-weeks), so regressions such as a release that doubled a backlog stand out.
+
+With --pdf the report is also converted with reports.pdf_command, and with
+--email it is sent to reports.email.to.
+
+Examples:
+  go-rmq-monitor report --output report.html
+  go-rmq-monitor report --window 720h --output month.html --pdf
+  go-rmq-monitor report --email`,
+	Args: cobra.NoArgs,
+	RunE: runReport,
+}
+
+var (
+	reportHistoryPath string
+	reportWindow      time.Duration
+	reportOutput      string
+	reportPDF         bool
+	reportEmail       bool
+	reportBaseline    int
+)
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.Flags().StringVar(&reportHistoryPath, "history", "", "History file (default is history.file_path from config)")
+	reportCmd.Flags().DurationVar(&reportWindow, "window", 7*24*time.Hour, "How much history the report covers, ending now")
+	reportCmd.Flags().StringVarP(&reportOutput, "output", "o", "report.html", "File the HTML report is written to")
+	reportCmd.Flags().BoolVar(&reportPDF, "pdf", false, "Also convert the report to PDF with reports.pdf_command")
+	reportCmd.Flags().BoolVar(&reportEmail, "email", false, "Email the report to reports.email.to")
+	reportCmd.Flags().IntVar(&reportBaseline, "baseline-weeks", 0, "Previous weeks the report is compared with, 0 disables (default is reports.baseline_weeks from config)")
+}
+
+func runReport(cmd *cobra.Command, args []string) error {
+	configPath := cfgFile
+	if configPath == "" {
+		configPath = "config.yaml"
+	}
+
+	cfg, err := config.LoadInstance(configPath, instanceName)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	historyPath := reportHistoryPath
+	if historyPath == "" {
+		historyPath = cfg.History.FilePath
+	}
+	if reportPDF && len(cfg.Reports.PDFCommand) == 0 {
+		return fmt.Errorf("--pdf requires reports.pdf_command")
+	}
+	if reportEmail && !cfg.Reports.Email.Enabled() {
+		return fmt.Errorf("--email requires reports.email.to")
+	}
+
+	baselineWeeks := cfg.Reports.BaselineWeeks
+	if cmd.Flags().Changed("baseline-weeks") {
+		baselineWeeks = reportBaseline
+	}
+	if baselineWeeks < 0 {
+		return fmt.Errorf("--baseline-weeks must not be negative")
+	}
+
+	display := cfg.Notifications.Display
+	built, err := report.Generate(historyPath, reportWindow, time.Now(), baselineWeeks)
+	if err != nil {
+		return err
+	}
+	page, err := built.HTML(display.Location(), display.NumberFormat())
+	if err != nil {
+		return err
+	}
+
+	delivery := report.Delivery{
+		HTMLPath: reportOutput,
+		Subject:  report.Subject(built, display.Location()),
+	}
+	if reportPDF {
+		delivery.PDFCommand = cfg.Reports.PDFCommand
+	}
+	if reportEmail {
+		delivery.Email = cfg.Reports.Email
+	}
+	files, err := report.Deliver(page, delivery)
+	for _, file := range files {
+		fmt.Printf("📄 Wrote %s\n", file)
+	}
+	if err != nil {
+		return err
+	}
+	if reportEmail {
+		fmt.Printf("📧 Emailed to %d recipient(s)\n", len(cfg.Reports.Email.To))
+	}
+	fmt.Printf("   %d queue(s), %d alert(s) between %s and %s\n", len(built.Queues), built.Incidents,
+		built.From.In(display.Location()).Format(time.RFC3339), built.To.In(display.Location()).Format(time.RFC3339))
+	return nil
+}
@@ -7,7 +7,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var cfgFile string
+var (
+	cfgFile      string
+	instanceName string
+)
 
 var rootCmd = &cobra.Command{
 	Use:   "go-rmq-monitor",
@@ -25,4 +28,5 @@ func Execute() {
 
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "config file (default is ./config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&instanceName, "instance", "", "name of this monitor among several on one host, keeping PID file, logs and state apart (overrides instance in the config)")
 }
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/ruletest"
+
+	"github.com/spf13/cobra"
+)
+
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Work with the detection settings and rules",
+}
+
+var rulesTestCmd = &cobra.Command{
+	Use:   "test <fixtures.yaml>...",
+	Short: "Check detection settings and rules against scenario fixtures",
+	Long: `Replay scenario fixtures through the configured detection settings and
+monitor.rules and check the verdicts they expect. Each scenario is a sequence
+of checks of one queue, taken monitor.interval apart (or the fixture file's
+interval), starting from a fresh analyzer. No broker is contacted; plugins are
+not loaded. Exits with an error when a scenario fails, for use in CI.
+
+A fixtures file lists scenarios:
+
+  interval: 1m
+  scenarios:
+    - name: slow drain alerts after 3 checks
+      queue: orders          # Settings of this queue apply, global ones if unlisted
+      checks:
+        - {messages_ready: 6000, publish_rate: 10, consume_rate: 2, consumers: 2, repeat: 2}
+        - {messages_ready: 7000, publish_rate: 10, consume_rate: 2, consumers: 2, expect: alerting}
+      expect: alerting       # alerting, not_alerting or recovering after the last check
+      reason: "rule slow-drain"
+
+Examples:
+  go-rmq-monitor rules test fixtures/orders.yaml
+  go-rmq-monitor rules test --config staging.yaml -v fixtures/*.yaml`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runRulesTest,
+}
+
+var rulesTestVerbose bool
+
+func init() {
+	rootCmd.AddCommand(rulesCmd)
+	rulesCmd.AddCommand(rulesTestCmd)
+	rulesTestCmd.Flags().BoolVarP(&rulesTestVerbose, "verbose", "v", false, "Print the verdict after every check")
+}
+
+func runRulesTest(cmd *cobra.Command, args []string) error {
+	configPath := cfgFile
+	if configPath == "" {
+		configPath = "config.yaml"
+	}
+
+	cfg, err := config.LoadInstance(configPath, instanceName)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	total, failed := 0, 0
+	for _, path := range args {
+		suite, err := ruletest.Load(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		results, err := ruletest.Run(cfg, suite)
+		if err != nil {
+			return fmt.Errorf("failed to compile detection rules: %w", err)
+		}
+
+		fmt.Printf("📋 %s\n", path)
+		for _, result := range results {
+			total++
+			if result.Passed {
+				fmt.Printf("  ✅ %s\n", result.Scenario)
+			} else {
+				failed++
+				fmt.Printf("  ❌ %s\n", result.Scenario)
+				for _, failure := range result.Failures {
+					fmt.Printf("     - %s\n", failure)
+				}
+			}
+			if rulesTestVerbose {
+				fmt.Printf("     verdicts: %s\n", strings.Join(result.Verdicts, ", "))
+				if result.Reason != "" {
+					fmt.Printf("     reason: %s\n", result.Reason)
+				}
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d scenarios failed", failed, total)
+	}
+	fmt.Printf("\n✅ All %d scenarios passed\n", total)
+	return nil
+}
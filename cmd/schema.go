@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"go-rmq-monitor/internal/sink"
+
+	"github.com/spf13/cobra"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON schema of published events",
+	Long: `Print the JSON schema of the events sent to event sinks, webhooks and the
+live event stream, so downstream consumers can validate payloads and generate types.
+
+Every event carries schema_version. Fields are only added within a major version,
+so a consumer validating against the 1.x schema accepts every 1.x event.
+CloudEvents carry the schema's $id as their dataschema attribute.
+
+Examples:
+  go-rmq-monitor schema
+  go-rmq-monitor schema --output event.schema.json`,
+	Args: cobra.NoArgs,
+	RunE: runSchema,
+}
+
+var schemaOutput string
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+	schemaCmd.Flags().StringVarP(&schemaOutput, "output", "o", "", "File the schema is written to (default is stdout)")
+}
+
+func runSchema(cmd *cobra.Command, args []string) error {
+	if schemaOutput == "" {
+		fmt.Print(sink.JSONSchema)
+		return nil
+	}
+	if err := os.WriteFile(schemaOutput, []byte(sink.JSONSchema), 0644); err != nil {
+		return fmt.Errorf("failed to write schema: %w", err)
+	}
+	fmt.Printf("📄 Wrote event schema %s to %s\n", sink.SchemaVersion, schemaOutput)
+	return nil
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/monitor"
+
+	"github.com/spf13/cobra"
+)
+
+var selfTestCmd = &cobra.Command{
+	Use:   "self-test",
+	Short: "Send a synthetic stuck and recovery alert to the test channel",
+	Long: `Play a synthetic stuck queue through the analyzer with the configured detection
+settings until it alerts, then drain it until it recovers, and send both
+notifications through the Slack formatter to the test channel. No broker is
+contacted. Backlog growth and rates are randomized on every run.
+
+The test channel is notifications.self_test.webhook_urls, or --webhook. With
+notifications.self_test.enabled the monitor runs the same test on its own every
+interval and exports rmq_monitor_last_self_test_success_timestamp_seconds.
+
+Examples:
+  go-rmq-monitor self-test
+  go-rmq-monitor self-test --webhook https://hooks.slack.com/services/T000/B000/XXX`,
+	Args: cobra.NoArgs,
+	RunE: runSelfTest,
+}
+
+var selfTestWebhooks []string
+
+func init() {
+	rootCmd.AddCommand(selfTestCmd)
+	selfTestCmd.Flags().StringArrayVar(&selfTestWebhooks, "webhook", nil, "Slack webhook of the test channel (repeatable, default is notifications.self_test.webhook_urls)")
+}
+
+func runSelfTest(cmd *cobra.Command, args []string) error {
+	configPath := cfgFile
+	if configPath == "" {
+		configPath = "config.yaml"
+	}
+
+	cfg, err := config.LoadInstance(configPath, instanceName)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	webhookURLs := selfTestWebhooks
+	if len(webhookURLs) == 0 {
+		webhookURLs = cfg.Notifications.SelfTest.WebhookURLs
+	}
+	if len(webhookURLs) == 0 {
+		return fmt.Errorf("no test channel: set notifications.self_test.webhook_urls or pass --webhook")
+	}
+
+	fmt.Printf("🧪 Self-testing the alert pipeline with synthetic queue '%s'...\n", cfg.Notifications.SelfTest.QueueName)
+	report := monitor.RunSelfTest(cfg, webhookURLs)
+	if len(report.Transitions) > 0 {
+		fmt.Printf("  Analyzer: %s\n", strings.Join(report.Transitions, " → "))
+	}
+	fmt.Printf("  Notifications sent: %d to %d webhook(s)\n", report.Sent, len(webhookURLs))
+	if report.Err != nil {
+		return fmt.Errorf("self-test failed: %w", report.Err)
+	}
+	fmt.Println("✅ Self-test passed")
+	return nil
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/monitor"
+
+	"github.com/spf13/cobra"
+)
+
+var canaryCmd = &cobra.Command{
+	Use:   "selftest",
+	Short: "Validate a deployment end to end with a temporary canary queue",
+	Long: `Declare a temporary canary queue, publish a message to it over AMQP, wait
+until the management API reports it, consume it over AMQP, delete the queue and
+send the self-test notifications to the test channel, then print a pass/fail
+matrix.
+
+The queue is declared and deleted through the management API; the message is
+published with a publisher confirm and consumed with an acknowledgement on
+rabbitmq.amqp_port (default 5672, or 5671 with use_tls), so the monitor's
+credentials must be allowed to configure, write and read queues named
+rmq-monitor.canary.* in the vhost. A queue left behind by an interrupted run is
+deleted by the broker after 10 minutes unused.
+
+The test channel is notifications.self_test.webhook_urls, or --webhook; without
+one the notification step is skipped. Unlike self-test, which only plays a
+synthetic alert through the formatter, this command contacts the broker.
+
+Examples:
+  go-rmq-monitor selftest
+  go-rmq-monitor selftest --webhook https://hooks.slack.com/services/T000/B000/XXX --timeout 1m`,
+	Args: cobra.NoArgs,
+	RunE: runCanary,
+}
+
+var (
+	canaryWebhooks []string
+	canaryTimeout  time.Duration
+)
+
+func init() {
+	rootCmd.AddCommand(canaryCmd)
+	canaryCmd.Flags().StringArrayVar(&canaryWebhooks, "webhook", nil, "Slack webhook of the test channel (repeatable, default is notifications.self_test.webhook_urls)")
+	canaryCmd.Flags().DurationVar(&canaryTimeout, "timeout", 30*time.Second, "How long to wait for the publish confirm, the management API and the delivery of the canary message")
+}
+
+func runCanary(cmd *cobra.Command, args []string) error {
+	configPath := cfgFile
+	if configPath == "" {
+		configPath = "config.yaml"
+	}
+
+	cfg, err := config.LoadInstance(configPath, instanceName)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	webhookURLs := canaryWebhooks
+	if len(webhookURLs) == 0 {
+		webhookURLs = cfg.Notifications.SelfTest.WebhookURLs
+	}
+
+	fmt.Printf("🐤 Canary test of %s vhost '%s'...\n", cfg.RabbitMQ.Host, cfg.RabbitMQ.VHost)
+	report := monitor.RunCanary(cfg, webhookURLs, canaryTimeout)
+	fmt.Printf("  Queue: %s\n\n", report.Queue)
+
+	failed := 0
+	for _, step := range report.Steps {
+		result := "✅ pass"
+		switch {
+		case step.Skipped:
+			result = "⏭️  skip"
+		case !step.Passed:
+			result = "❌ fail"
+			failed++
+		}
+		duration := ""
+		if !step.Skipped {
+			duration = step.Duration.Round(time.Millisecond).String()
+		}
+		fmt.Printf("  %-18s %s  %8s  %s\n", step.Name, result, duration, step.Detail)
+	}
+	fmt.Println()
+
+	if !report.Passed() {
+		return fmt.Errorf("canary test failed: %d of %d step(s) failed", failed, len(report.Steps))
+	}
+	fmt.Println("✅ Canary test passed")
+	return nil
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/sink"
+
+	"github.com/spf13/cobra"
+)
+
+var tailCmd = &cobra.Command{
+	Use:   "tail",
+	Short: "Stream alerts and state changes from a running monitor",
+	Long: `Connect to a running monitor's GET /api/v1/tail endpoint and print queue state
+changes (queue_stuck, queue_recovered) and check cycles (check) as they happen,
+until interrupted. Events are Server-Sent Events; other tools can read the same
+stream with curl -N.
+
+Examples:
+  go-rmq-monitor tail
+  go-rmq-monitor tail --queue 'orders.*' --type queue_stuck,queue_recovered
+  go-rmq-monitor tail --server https://monitor.internal:9090 --output json | jq .`,
+	Args: cobra.NoArgs,
+	RunE: runTail,
+}
+
+var (
+	tailServerURL string
+	tailToken     string
+	tailQueue     string
+	tailTypes     string
+	tailOutput    string
+)
+
+func init() {
+	rootCmd.AddCommand(tailCmd)
+	tailCmd.Flags().StringVar(&tailServerURL, "server", "", "Monitor server URL (default derived from server.listen_address in config)")
+	addAPIClientFlags(tailCmd)
+	tailCmd.Flags().StringVar(&tailToken, "token", "", "API token when server auth is enabled (default is $RMQ_MONITOR_TOKEN)")
+	tailCmd.Flags().StringVarP(&tailQueue, "queue", "q", "", "Only stream events of queues matching this glob pattern")
+	tailCmd.Flags().StringVar(&tailTypes, "type", "", "Only stream these comma-separated event types (queue_stuck, queue_recovered, check)")
+	tailCmd.Flags().StringVarP(&tailOutput, "output", "o", "text", "Output format: text or json (one event per line)")
+}
+
+func runTail(cmd *cobra.Command, args []string) error {
+	if tailOutput != "text" && tailOutput != "json" {
+		return fmt.Errorf("unknown output format %q (text, json)", tailOutput)
+	}
+
+	serverURL, serverCert := tailServerURL, ""
+	if serverURL == "" {
+		configPath := cfgFile
+		if configPath == "" {
+			configPath = "config.yaml"
+		}
+
+		cfg, err := config.LoadInstance(configPath, instanceName)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		serverURL = localServerURL(cfg.Server)
+		serverCert = cfg.Server.TLS.CertFile
+	}
+
+	query := url.Values{}
+	if tailQueue != "" {
+		query.Set("queue", tailQueue)
+	}
+	if tailTypes != "" {
+		query.Set("type", tailTypes)
+	}
+	endpoint := strings.TrimRight(serverURL, "/") + "/api/v1/tail"
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+	req, err := newAPIRequest(http.MethodGet, endpoint, apiToken(tailToken), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	client, err := newAPIClient(serverCert)
+	if err != nil {
+		return err
+	}
+	client.Timeout = 0 // The stream stays open until interrupted
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach monitor: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("stream rejected (%d): %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	if tailOutput == "text" {
+		fmt.Printf("📡 Streaming events from %s (Ctrl+C to stop)\n", serverURL)
+	}
+
+	// Events are "data:" lines terminated by a blank line; comments start with ':'
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		if tailOutput == "json" {
+			fmt.Println(data)
+			continue
+		}
+		var event sink.Event
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			continue
+		}
+		fmt.Println(formatTailEvent(event))
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("stream interrupted: %w", err)
+	}
+	return fmt.Errorf("monitor closed the stream")
+}
+
+// formatTailEvent renders an event as one line in local time
+func formatTailEvent(event sink.Event) string {
+	timestamp := event.Timestamp.Local().Format("15:04:05")
+	switch event.Type {
+	case sink.TypeQueueStuck:
+		return fmt.Sprintf("%s 🔴 %s stuck: %s (ready=%d consumers=%d consume=%.2f/s publish=%.2f/s)",
+			timestamp, event.Queue, event.Reason, event.MessagesReady, event.Consumers, event.ConsumeRate, event.PublishRate)
+	case sink.TypeQueueRecovered:
+		stuckFor := time.Duration(event.StuckDurationSeconds * float64(time.Second)).Round(time.Second)
+		return fmt.Sprintf("%s ✅ %s recovered after %s (ready=%d consumers=%d)",
+			timestamp, event.Queue, stuckFor, event.MessagesReady, event.Consumers)
+	case sink.TypeCheck:
+		line := fmt.Sprintf("%s 🔄 check %s: %d/%d queues alerting in %.2fs",
+			timestamp, event.CheckID, event.AlertingQueues, event.TrackedQueues, event.DurationSeconds)
+		if event.Error != "" {
+			line += " ❌ " + event.Error
+		}
+		return line
+	}
+	return fmt.Sprintf("%s %s %s", timestamp, event.Type, event.Queue)
+}
@@ -25,7 +25,7 @@ func runTest(cmd *cobra.Command, args []string) error {
 		configPath = "config.yaml"
 	}
 
-	cfg, err := config.Load(configPath)
+	cfg, err := config.LoadInstance(configPath, instanceName)
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/rabbitmq"
+	"go-rmq-monitor/internal/tui"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+var topCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Interactive live view of queue metrics and health",
+	Long: `Show live queue metrics, health states and recent alerts in an interactive terminal UI.
+
+Health states are computed with the configured detection settings from the
+metrics polled during this session.
+
+Keys:
+  s      cycle sort column (name, messages, consumers, consume rate, state)
+  r      reverse sort order
+  t      toggle the 24h state timeline read from history.file_path
+  /      filter queues by name (enter to apply, esc to clear)
+  ↑ ↓    scroll the queue table, also j/k, pgup/pgdn and home/end
+  q      quit`,
+	RunE: runTop,
+}
+
+var topRefresh time.Duration
+
+func init() {
+	rootCmd.AddCommand(topCmd)
+	topCmd.Flags().DurationVar(&topRefresh, "refresh", 5*time.Second, "How often to poll queue metrics")
+}
+
+func runTop(cmd *cobra.Command, args []string) error {
+	configPath := cfgFile
+	if configPath == "" {
+		configPath = "config.yaml"
+	}
+
+	cfg, err := config.LoadInstance(configPath, instanceName)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if topRefresh <= 0 {
+		return fmt.Errorf("--refresh must be positive")
+	}
+
+	client, err := rabbitmq.NewClient(&cfg.RabbitMQ)
+	if err != nil {
+		return err
+	}
+
+	program := tea.NewProgram(tui.NewTopModel(client, cfg, topRefresh), tea.WithAltScreen())
+	if _, err := program.Run(); err != nil {
+		return fmt.Errorf("failed to run TUI: %w", err)
+	}
+	return nil
+}
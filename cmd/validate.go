@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/monitor"
+
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the config file and optionally ping notification channels",
+	Long: `Load and validate the config file, including webhook URL syntax.
+
+With --ping, every enabled notification channel (Slack, Google Chat, Zulip and
+Twilio) is checked without posting a message: webhooks receive an empty payload
+that live webhooks reject, and APIs are only read. The command fails if any
+channel is unreachable or its credentials are rejected.
+
+Examples:
+  go-rmq-monitor validate
+  go-rmq-monitor validate --ping -c /etc/rabbitmq-monitor/config.yaml`,
+	Args: cobra.NoArgs,
+	RunE: runValidate,
+}
+
+var validatePing bool
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+	validateCmd.Flags().BoolVar(&validatePing, "ping", false, "Check that every notification channel is reachable")
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	configPath := cfgFile
+	if configPath == "" {
+		configPath = "config.yaml"
+	}
+
+	cfg, err := config.LoadInstance(configPath, instanceName)
+	if err != nil {
+		return fmt.Errorf("❌ %w", err)
+	}
+	fmt.Printf("✓ %s is valid\n", configPath)
+	if features := cfg.EnabledFeatures(); len(features) > 0 {
+		fmt.Printf("  features: %s\n", strings.Join(features, ", "))
+	}
+	if cfg.Instance != "" {
+		fmt.Printf("  instance: %s (log %s, history %s)\n", cfg.Instance, cfg.Logging.FilePath, cfg.History.FilePath)
+	}
+
+	if !validatePing {
+		return nil
+	}
+
+	checks := monitor.CheckNotificationChannels(cfg)
+	if len(checks) == 0 {
+		fmt.Println("\nNo notification channels enabled")
+		return nil
+	}
+	fmt.Println("\n📡 Notification channels:")
+	failed := 0
+	for _, check := range checks {
+		name := check.Channel
+		if check.Target != "" {
+			name += " " + check.Target
+		}
+		if check.Err != nil {
+			failed++
+			fmt.Printf("  ❌ %s: %v\n", name, check.Err)
+			continue
+		}
+		fmt.Printf("  ✓ %s\n", name)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d notification channel checks failed", failed, len(checks))
+	}
+	return nil
+}
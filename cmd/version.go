@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"go-rmq-monitor/internal/buildinfo"
+	"go-rmq-monitor/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print version and build information",
+	Long: `Print version and build information.
+
+Enabled features are read from the config file when it can be loaded.
+
+Examples:
+  go-rmq-monitor version
+  go-rmq-monitor version --output json`,
+	Args: cobra.NoArgs,
+	RunE: runVersion,
+}
+
+var versionOutput string
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+	versionCmd.Flags().StringVarP(&versionOutput, "output", "o", "text", "Output format: text or json")
+}
+
+func runVersion(cmd *cobra.Command, args []string) error {
+	info := buildinfo.Get()
+
+	// Features depend on config; a missing config is not an error here
+	configPath := cfgFile
+	if configPath == "" {
+		configPath = "config.yaml"
+	}
+	if cfg, err := config.LoadInstance(configPath, instanceName); err == nil {
+		info.Features = cfg.EnabledFeatures()
+	}
+
+	switch versionOutput {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(info)
+	case "text":
+		fmt.Printf("go-rmq-monitor version %s\n", info.Version)
+		fmt.Printf("commit: %s\n", info.Commit)
+		fmt.Printf("built: %s\n", info.BuildDate)
+		fmt.Printf("go: %s (%s)\n", info.GoVersion, info.Platform)
+		if info.ModuleSum != "" {
+			fmt.Printf("module: %s %s\n", info.Module, info.ModuleSum)
+		}
+		if len(info.Features) > 0 {
+			fmt.Printf("features: %s\n", strings.Join(info.Features, ", "))
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %q (text, json)", versionOutput)
+	}
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/numfmt"
+	"go-rmq-monitor/internal/rabbitmq"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch <queue> [queue...]",
+	Short: "Poll selected queues and print metric deltas",
+	Long: `Poll selected queues at a short interval and print what changed since the
+previous poll, e.g. "ready 1200 (+120)" or "consume 5.20→0.00/s".
+
+Growing backlogs and dropping rates are highlighted, which makes it easy to
+observe a suspected-stuck queue while debugging.
+
+Examples:
+  go-rmq-monitor watch orders
+  go-rmq-monitor watch orders payments --interval 1s`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runWatch,
+}
+
+var (
+	watchInterval time.Duration
+	watchNoColor  bool
+)
+
+var (
+	watchBadStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	watchGoodStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	watchDimStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+)
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+	watchCmd.Flags().DurationVarP(&watchInterval, "interval", "i", 2*time.Second, "Polling interval")
+	watchCmd.Flags().BoolVar(&watchNoColor, "no-color", false, "Disable colored output")
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	configPath := cfgFile
+	if configPath == "" {
+		configPath = "config.yaml"
+	}
+
+	cfg, err := config.LoadInstance(configPath, instanceName)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if watchInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	client, err := rabbitmq.NewClient(&cfg.RabbitMQ)
+	if err != nil {
+		return err
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	numbers := cfg.Notifications.Display.NumberFormat()
+	previous := make(map[string]rabbitmq.QueueInfo)
+	for {
+		now := time.Now()
+		for _, queueName := range args {
+			queue, err := client.GetQueue(queueName)
+			if err != nil {
+				fmt.Printf("%s %-30s %s\n", now.Format("15:04:05"), queueName, watchColor(watchBadStyle, err.Error()))
+				continue
+			}
+
+			prev, seen := previous[queueName]
+			fmt.Printf("%s %-30s %s\n", now.Format("15:04:05"), queueName, formatWatchLine(*queue, prev, seen, numbers))
+			previous[queueName] = *queue
+		}
+
+		select {
+		case <-ticker.C:
+		case <-sigChan:
+			return nil
+		}
+	}
+}
+
+// formatWatchLine renders the queue metrics with deltas against the previous poll
+func formatWatchLine(current, prev rabbitmq.QueueInfo, seen bool, numbers numfmt.Format) string {
+	if !seen {
+		return fmt.Sprintf("ready %s  consumers %d  consume %s/s  ack %s/s  publish %s/s",
+			numbers.Int(current.MessagesReady), current.Consumers, numbers.Float(current.ConsumeRate, 2),
+			numbers.Float(current.AckRate, 2), numbers.Float(current.PublishRate, 2))
+	}
+
+	return fmt.Sprintf("ready %s%s  consumers %d%s  consume %s  ack %s  publish %s",
+		numbers.Int(current.MessagesReady), formatCountDelta(current.MessagesReady-prev.MessagesReady, true, numbers),
+		current.Consumers, formatCountDelta(current.Consumers-prev.Consumers, false, numbers),
+		formatRateDelta(prev.ConsumeRate, current.ConsumeRate, false, numbers),
+		formatRateDelta(prev.AckRate, current.AckRate, false, numbers),
+		formatRateDelta(prev.PublishRate, current.PublishRate, true, numbers))
+}
+
+// formatCountDelta renders a count change; growthIsBad selects which direction is highlighted red
+func formatCountDelta(delta int, growthIsBad bool, numbers numfmt.Format) string {
+	if delta == 0 {
+		return ""
+	}
+	text := " (" + numbers.Int(delta) + ")"
+	if delta > 0 {
+		text = " (+" + numbers.Int(delta) + ")"
+	}
+	if (delta > 0) == growthIsBad {
+		return watchColor(watchBadStyle, text)
+	}
+	return watchColor(watchGoodStyle, text)
+}
+
+// formatRateDelta renders a rate, showing the transition when it changed noticeably
+func formatRateDelta(prev, current float64, growthIsBad bool, numbers numfmt.Format) string {
+	if abs(current-prev) < 0.01 {
+		return watchColor(watchDimStyle, numbers.Float(current, 2)+"/s")
+	}
+	text := numbers.Float(prev, 2) + "→" + numbers.Float(current, 2) + "/s"
+	if (current > prev) == growthIsBad {
+		return watchColor(watchBadStyle, text)
+	}
+	return watchColor(watchGoodStyle, text)
+}
+
+// watchColor applies a style unless colors are disabled
+func watchColor(style lipgloss.Style, text string) string {
+	if watchNoColor {
+		return text
+	}
+	return style.Render(text)
+}
+
+// abs returns the absolute value of a float
+func abs(value float64) float64 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,151 @@
+package ack
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"go-rmq-monitor/internal/server"
+)
+
+// Sources an acknowledgment can come from
+const (
+	SourceAPI   = "api"
+	SourceCLI   = "cli"
+	SourceSlack = "slack"
+)
+
+// ErrNotAlerting is returned when acknowledging a queue that is not alerting
+var ErrNotAlerting = errors.New("queue is not alerting")
+
+// Ack records who acknowledged a queue's current incident
+type Ack struct {
+	QueueName string    `json:"queue"`
+	By        string    `json:"by"`
+	Source    string    `json:"source"`
+	Comment   string    `json:"comment,omitempty"`
+	Identity  string    `json:"identity,omitempty"` // Authenticated API token name, if API auth is enabled
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Store holds acknowledgments shared by all notifiers
+// An acknowledgment lasts until the queue recovers
+type Store struct {
+	isAlerting func(queueName string) bool
+	onAck      func(Ack)
+	acks       map[string]Ack
+	mu         sync.RWMutex
+}
+
+// New creates a new acknowledgment store
+// isAlerting is used to reject acknowledgments for queues that are not alerting
+// onAck, if not nil, is called after every recorded acknowledgment
+func New(isAlerting func(queueName string) bool, onAck func(Ack)) *Store {
+	return &Store{
+		isAlerting: isAlerting,
+		onAck:      onAck,
+		acks:       make(map[string]Ack),
+	}
+}
+
+// Acknowledge records an acknowledgment for an alerting queue
+// identity is the authenticated API token name, empty when API auth is disabled
+func (s *Store) Acknowledge(queueName, by, source, comment, identity string, now time.Time) (Ack, error) {
+	if !s.isAlerting(queueName) {
+		return Ack{}, ErrNotAlerting
+	}
+
+	ack := Ack{
+		QueueName: queueName,
+		By:        by,
+		Source:    source,
+		Comment:   comment,
+		Identity:  identity,
+		Timestamp: now,
+	}
+
+	s.mu.Lock()
+	s.acks[queueName] = ack
+	s.mu.Unlock()
+
+	if s.onAck != nil {
+		s.onAck(ack)
+	}
+	return ack, nil
+}
+
+// Get returns the acknowledgment for a queue, if any
+func (s *Store) Get(queueName string) (Ack, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ack, exists := s.acks[queueName]
+	return ack, exists
+}
+
+// Clear removes and returns the acknowledgment for a queue
+// Called when the queue recovers and its incident ends
+func (s *Store) Clear(queueName string) (Ack, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ack, exists := s.acks[queueName]
+	delete(s.acks, queueName)
+	return ack, exists
+}
+
+// List returns all active acknowledgments ordered by queue name
+func (s *Store) List() []Ack {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]Ack, 0, len(s.acks))
+	for _, ack := range s.acks {
+		result = append(result, ack)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].QueueName < result[j].QueueName
+	})
+	return result
+}
+
+// ackRequest is the JSON body accepted by the acknowledgment endpoint
+type ackRequest struct {
+	By      string `json:"by"`
+	Source  string `json:"source"`
+	Comment string `json:"comment"`
+}
+
+// HandleAcknowledge acknowledges the queue in the request path
+// Registered as POST /api/acks/{queue}
+func (s *Store) HandleAcknowledge(w http.ResponseWriter, r *http.Request) {
+	var req ackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	if req.By == "" {
+		http.Error(w, "\"by\" is required", http.StatusBadRequest)
+		return
+	}
+	if req.Source != SourceCLI {
+		req.Source = SourceAPI
+	}
+
+	ack, err := s.Acknowledge(r.PathValue("queue"), req.By, req.Source, req.Comment, server.Identity(r), time.Now())
+	if errors.Is(err, ErrNotAlerting) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ack)
+}
+
+// HandleList returns all active acknowledgments as JSON
+// Registered as GET /api/acks
+func (s *Store) HandleList(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.List())
+}
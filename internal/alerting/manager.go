@@ -0,0 +1,306 @@
+package alerting
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"go-rmq-monitor/internal/config"
+)
+
+// stuckLogInterval is the minimum time between two stuck queue log entries for the same queue
+const stuckLogInterval = 5 * time.Minute
+
+// Reasons a notification is not sent
+const (
+	SkipRecoveryDisabled = "recovery notifications disabled"
+	SkipCooldown         = "cooldown active"
+	SkipQuietHours       = "quiet hours"
+)
+
+// Incident is a queue's alerting period, from its alerting transition to its recovery
+type Incident struct {
+	ID         string // Correlates the incident's logs, notifications and events, e.g. inc-1f2e3d4c
+	QueueName  string
+	Since      time.Time
+	Suppressed bool // Folded into a root cause, cluster-wide or silenced alert, so its recovery is not notified either
+}
+
+// Route is where a notification is sent
+type Route struct {
+	WebhookURLs []string
+	Team        string
+	Severity    string
+	Quiet       bool // Quiet hours hold back this non-critical notification
+}
+
+// Decision is the outcome of evaluating a transition notification
+type Decision struct {
+	Send     bool
+	Skip     string        // Why the notification is not sent, empty if it is
+	Cooldown time.Duration // Cooldown that applied to the decision
+	Since    time.Duration // Time since the queue's previous notification, zero if none
+	Route    Route
+}
+
+// Manager owns the notification lifecycle of queue incidents
+// It tracks open incidents, applies cooldowns and routes notifications to their channels
+type Manager struct {
+	cfg        *config.Config
+	incidents  map[string]*Incident
+	lastSent   map[string]time.Time // Last notification per queue, kept across incidents for cooldowns
+	lastLogged map[string]time.Time // Last stuck log entry per queue
+	typeSent   map[string]time.Time // Last firing notification per non-queue alert, keyed by type and key
+	typeHeld   map[string]bool      // Non-queue alerts whose firing notification the cooldown held back
+	mu         sync.Mutex
+}
+
+// New creates an alert manager for the given configuration
+func New(cfg *config.Config) *Manager {
+	return &Manager{
+		cfg:        cfg,
+		incidents:  make(map[string]*Incident),
+		lastSent:   make(map[string]time.Time),
+		lastLogged: make(map[string]time.Time),
+		typeSent:   make(map[string]time.Time),
+		typeHeld:   make(map[string]bool),
+	}
+}
+
+// Open starts an incident for a queue that became alerting
+func (m *Manager) Open(queueName string, now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, exists := m.incidents[queueName]; !exists {
+		m.incidents[queueName] = &Incident{ID: newIncidentID(), QueueName: queueName, Since: now}
+	}
+}
+
+// IncidentID returns the ID of a queue's open incident, empty if it has none
+func (m *Manager) IncidentID(queueName string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if incident, exists := m.incidents[queueName]; exists {
+		return incident.ID
+	}
+	return ""
+}
+
+// newIncidentID returns a random incident ID
+func newIncidentID() string {
+	buf := make([]byte, 4)
+	rand.Read(buf)
+	return "inc-" + hex.EncodeToString(buf)
+}
+
+// Resolve ends a queue's incident and returns it
+func (m *Manager) Resolve(queueName string) (Incident, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	incident, exists := m.incidents[queueName]
+	if !exists {
+		return Incident{}, false
+	}
+	delete(m.incidents, queueName)
+	return *incident, true
+}
+
+// Suppress marks a queue's incident as covered by another notification
+// Opens the incident if the queue's alerting transition has not been seen yet
+func (m *Manager) Suppress(queueName string, now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	incident, exists := m.incidents[queueName]
+	if !exists {
+		incident = &Incident{ID: newIncidentID(), QueueName: queueName, Since: now}
+		m.incidents[queueName] = incident
+	}
+	incident.Suppressed = true
+}
+
+// IsSuppressed reports whether a queue's open incident is covered by another notification
+func (m *Manager) IsSuppressed(queueName string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	incident, exists := m.incidents[queueName]
+	return exists && incident.Suppressed
+}
+
+// ShouldLogStuck reports whether a stuck queue is due for another log entry
+// Limits repeated stuck queue log entries to one per stuckLogInterval
+func (m *Manager) ShouldLogStuck(queueName string, now time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if now.Sub(m.lastLogged[queueName]) < stuckLogInterval {
+		return false
+	}
+	m.lastLogged[queueName] = now
+	return true
+}
+
+// Decide evaluates whether a queue's transition is notified and where
+// Priority class settings override global notification settings
+func (m *Manager) Decide(queueName, priority string, alerting bool, now time.Time) Decision {
+	slackCfg := m.cfg.Notifications.Slack
+	class, hasClass := m.cfg.Monitor.GetPriorityClass(priority)
+
+	decision := Decision{Cooldown: slackCfg.AlertCooldown}
+	if hasClass && class.AlertCooldown != nil {
+		decision.Cooldown = *class.AlertCooldown
+	}
+	if !alerting {
+		if !slackCfg.SendRecovery {
+			decision.Skip = SkipRecoveryDisabled
+			return decision
+		}
+		decision.Cooldown = slackCfg.RecoveryCooldown
+		if hasClass && class.RecoveryCooldown != nil {
+			decision.Cooldown = *class.RecoveryCooldown
+		}
+	}
+
+	m.mu.Lock()
+	lastSent, notified := m.lastSent[queueName]
+	m.mu.Unlock()
+	if notified {
+		decision.Since = now.Sub(lastSent)
+		if decision.Since < decision.Cooldown {
+			decision.Skip = SkipCooldown
+			return decision
+		}
+	}
+
+	decision.Route = m.Route(queueName, priority, now)
+	if decision.Route.Quiet && len(decision.Route.WebhookURLs) == 0 {
+		decision.Skip = SkipQuietHours
+		return decision
+	}
+	decision.Send = true
+	return decision
+}
+
+// Sent records a delivered notification so later ones respect the cooldown
+func (m *Manager) Sent(queueName string, now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastSent[queueName] = now
+}
+
+// DecideAlert evaluates whether a transition of a non-queue alert is notified and where
+// The alert is identified by its type (cluster, connections, vhosts or churn) and a key within
+// the type, such as the vhost; each type has its own cooldown between firing notifications,
+// and a recovery is only notified when its firing notification was
+func (m *Manager) DecideAlert(alertType, key, priority string, firing bool, now time.Time) Decision {
+	settings := m.cfg.Notifications.AlertTypes.Get(alertType)
+	id := alertType + "\x00" + key
+	decision := Decision{Cooldown: settings.Cooldown}
+
+	m.mu.Lock()
+	if firing {
+		if lastSent, notified := m.typeSent[id]; notified {
+			decision.Since = now.Sub(lastSent)
+			if decision.Since < decision.Cooldown {
+				m.typeHeld[id] = true
+				m.mu.Unlock()
+				decision.Skip = SkipCooldown
+				return decision
+			}
+		}
+	} else if m.typeHeld[id] {
+		delete(m.typeHeld, id)
+		m.mu.Unlock()
+		decision.Skip = SkipCooldown
+		return decision
+	}
+	m.mu.Unlock()
+
+	if !firing && !m.cfg.Notifications.Slack.SendRecovery {
+		decision.Skip = SkipRecoveryDisabled
+		return decision
+	}
+	decision.Route = m.RouteAlert(alertType, priority, now)
+	if decision.Route.Quiet && len(decision.Route.WebhookURLs) == 0 {
+		decision.Skip = SkipQuietHours
+		return decision
+	}
+	decision.Send = true
+	return decision
+}
+
+// SentAlert records a delivered firing notification of a non-queue alert for its type's cooldown
+func (m *Manager) SentAlert(alertType, key string, now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	id := alertType + "\x00" + key
+	m.typeSent[id] = now
+	delete(m.typeHeld, id)
+}
+
+// RouteAlert returns the webhooks for a non-queue alert's notifications
+// The type's priority overrides the alert's own, and its webhooks replace the priority class
+// and global webhooks; quiet hours and webhook filters still apply
+func (m *Manager) RouteAlert(alertType, priority string, now time.Time) Route {
+	settings := m.cfg.Notifications.AlertTypes.Get(alertType)
+	if settings.Priority != "" {
+		priority = settings.Priority
+	}
+	route := m.Route("", priority, now)
+	if len(settings.WebhookURLs) > 0 {
+		route.WebhookURLs = m.ApplyQuietHours(settings.WebhookURLs, route.Severity, now)
+		route.WebhookURLs = m.FilterWebhooks(route.WebhookURLs, "", route.Severity)
+	}
+	return route
+}
+
+// Route returns the webhooks for a queue's notifications
+// Routed to the owning team's webhooks or the priority class webhooks if configured,
+// and during quiet hours only to the channels that always notify
+func (m *Manager) Route(queueName, priority string, now time.Time) Route {
+	route := Route{
+		WebhookURLs: m.cfg.Notifications.Slack.WebhookURLs,
+		Severity:    config.GetSeverity(priority),
+	}
+	team, hasTeam := m.cfg.GetTeam(queueName)
+	if hasTeam {
+		route.Team = team.Name
+	}
+	if hasTeam && len(team.WebhookURLs) > 0 {
+		route.WebhookURLs = team.WebhookURLs
+	} else if class, hasClass := m.cfg.Monitor.GetPriorityClass(priority); hasClass && len(class.WebhookURLs) > 0 {
+		route.WebhookURLs = class.WebhookURLs
+	}
+	route.WebhookURLs = m.ApplyQuietHours(route.WebhookURLs, route.Severity, now)
+	route.WebhookURLs = m.FilterWebhooks(route.WebhookURLs, queueName, route.Severity)
+	route.Quiet = route.Severity != config.SeverityCritical && m.cfg.Notifications.QuietHours.IsActive(now)
+	return route
+}
+
+// ApplyQuietHours filters webhooks for non-critical alerts during quiet hours
+// Only webhooks configured to always notify are kept
+func (m *Manager) ApplyQuietHours(webhookURLs []string, severity string, now time.Time) []string {
+	quietHours := m.cfg.Notifications.QuietHours
+	if severity == config.SeverityCritical || !quietHours.IsActive(now) {
+		return webhookURLs
+	}
+
+	allowed := make([]string, 0)
+	for _, url := range webhookURLs {
+		if quietHours.AlwaysNotifies(url) {
+			allowed = append(allowed, url)
+		}
+	}
+	return allowed
+}
+
+// FilterWebhooks drops webhooks whose severity or queue filters exclude an alert
+func (m *Manager) FilterWebhooks(webhookURLs []string, queueName, severity string) []string {
+	allowed := make([]string, 0, len(webhookURLs))
+	for _, url := range webhookURLs {
+		if webhook, ok := m.cfg.Notifications.Slack.Webhook(url); ok && !webhook.Accepts(queueName, severity) {
+			continue
+		}
+		allowed = append(allowed, url)
+	}
+	return allowed
+}
@@ -0,0 +1,275 @@
+package alerting
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go-rmq-monitor/internal/config"
+)
+
+// baseTime is a Wednesday at noon UTC, outside the quiet hours used in tests
+var baseTime = time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+
+func durationPtr(d time.Duration) *time.Duration {
+	return &d
+}
+
+func testConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.Notifications.Slack = config.SlackConfig{
+		WebhookURLs:      []string{"https://hooks.example/global"},
+		AlertCooldown:    10 * time.Minute,
+		SendRecovery:     true,
+		RecoveryCooldown: time.Minute,
+	}
+	cfg.Notifications.QuietHours = config.QuietHoursConfig{
+		Enabled:                 true,
+		Start:                   "22:00",
+		End:                     "07:00",
+		Timezone:                "UTC",
+		AlwaysNotifyWebhookURLs: []string{"https://hooks.example/oncall"},
+	}
+	cfg.Monitor.PriorityClasses = map[string]config.PriorityClassConfig{
+		config.PriorityCritical: {
+			AlertCooldown: durationPtr(time.Minute),
+			WebhookURLs:   []string{"https://hooks.example/critical"},
+		},
+		config.PriorityLow: {
+			RecoveryCooldown: durationPtr(time.Hour),
+			WebhookURLs:      []string{"https://hooks.example/low", "https://hooks.example/oncall"},
+		},
+	}
+	cfg.Teams = []config.TeamConfig{{
+		Name:        "payments",
+		WebhookURLs: []string{"https://hooks.example/payments"},
+		Queues:      []config.QueueConfig{{Name: "payments.settle"}},
+	}}
+	return cfg
+}
+
+func TestIncidentLifecycle(t *testing.T) {
+	m := New(testConfig())
+
+	if id := m.IncidentID("orders"); id != "" {
+		t.Fatalf("IncidentID before Open = %q, want empty", id)
+	}
+	m.Open("orders", baseTime)
+	id := m.IncidentID("orders")
+	if id == "" {
+		t.Fatal("IncidentID after Open is empty")
+	}
+	m.Open("orders", baseTime.Add(time.Minute))
+	if again := m.IncidentID("orders"); again != id {
+		t.Errorf("IncidentID after second Open = %q, want %q", again, id)
+	}
+
+	incident, ok := m.Resolve("orders")
+	if !ok {
+		t.Fatal("Resolve found no incident")
+	}
+	if incident.ID != id || incident.QueueName != "orders" || !incident.Since.Equal(baseTime) || incident.Suppressed {
+		t.Errorf("Resolve = %+v, want ID %q since %v, not suppressed", incident, id, baseTime)
+	}
+	if _, ok := m.Resolve("orders"); ok {
+		t.Error("second Resolve found an incident")
+	}
+}
+
+func TestSuppress(t *testing.T) {
+	m := New(testConfig())
+
+	m.Open("orders", baseTime)
+	if m.IsSuppressed("orders") {
+		t.Error("IsSuppressed after Open")
+	}
+	m.Suppress("orders", baseTime.Add(time.Minute))
+	if !m.IsSuppressed("orders") {
+		t.Error("IsSuppressed after Suppress = false")
+	}
+	incident, _ := m.Resolve("orders")
+	if !incident.Suppressed || !incident.Since.Equal(baseTime) {
+		t.Errorf("Resolve = %+v, want suppressed since %v", incident, baseTime)
+	}
+
+	// Suppressing before the alerting transition opens the incident
+	m.Suppress("invoices", baseTime)
+	if m.IncidentID("invoices") == "" || !m.IsSuppressed("invoices") {
+		t.Error("Suppress without an open incident did not open a suppressed one")
+	}
+}
+
+func TestShouldLogStuck(t *testing.T) {
+	m := New(testConfig())
+
+	steps := []struct {
+		offset time.Duration
+		want   bool
+	}{
+		{0, true},
+		{time.Minute, false},
+		{stuckLogInterval - time.Second, false},
+		{stuckLogInterval, true},
+		{stuckLogInterval + time.Minute, false},
+	}
+	for _, step := range steps {
+		if got := m.ShouldLogStuck("orders", baseTime.Add(step.offset)); got != step.want {
+			t.Errorf("ShouldLogStuck at +%s = %v, want %v", step.offset, got, step.want)
+		}
+	}
+	if !m.ShouldLogStuck("invoices", baseTime.Add(time.Minute)) {
+		t.Error("ShouldLogStuck is not tracked per queue")
+	}
+}
+
+func TestDecideCooldowns(t *testing.T) {
+	tests := []struct {
+		name     string
+		priority string
+		alerting bool
+		lastSent time.Duration // Before the decision, 0 for never
+		send     bool
+		skip     string
+		cooldown time.Duration
+	}{
+		{name: "first alert", alerting: true, send: true, cooldown: 10 * time.Minute},
+		{name: "alert within cooldown", alerting: true, lastSent: 5 * time.Minute, skip: SkipCooldown, cooldown: 10 * time.Minute},
+		{name: "alert after cooldown", alerting: true, lastSent: 10 * time.Minute, send: true, cooldown: 10 * time.Minute},
+		{name: "class alert cooldown", priority: config.PriorityCritical, alerting: true, lastSent: 2 * time.Minute, send: true, cooldown: time.Minute},
+		{name: "recovery within cooldown", lastSent: 30 * time.Second, skip: SkipCooldown, cooldown: time.Minute},
+		{name: "recovery after cooldown", lastSent: 5 * time.Minute, send: true, cooldown: time.Minute},
+		{name: "class recovery cooldown", priority: config.PriorityLow, lastSent: 5 * time.Minute, skip: SkipCooldown, cooldown: time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(testConfig())
+			if tt.lastSent > 0 {
+				m.Sent("orders", baseTime.Add(-tt.lastSent))
+			}
+			decision := m.Decide("orders", tt.priority, tt.alerting, baseTime)
+			if decision.Send != tt.send || decision.Skip != tt.skip || decision.Cooldown != tt.cooldown {
+				t.Errorf("Decide = send %v, skip %q, cooldown %s; want send %v, skip %q, cooldown %s",
+					decision.Send, decision.Skip, decision.Cooldown, tt.send, tt.skip, tt.cooldown)
+			}
+			if tt.lastSent > 0 && decision.Since != tt.lastSent {
+				t.Errorf("Decide Since = %s, want %s", decision.Since, tt.lastSent)
+			}
+		})
+	}
+}
+
+func TestDecideRecoveryDisabled(t *testing.T) {
+	cfg := testConfig()
+	cfg.Notifications.Slack.SendRecovery = false
+	m := New(cfg)
+
+	if decision := m.Decide("orders", "", false, baseTime); decision.Send || decision.Skip != SkipRecoveryDisabled {
+		t.Errorf("Decide recovery = send %v, skip %q; want skip %q", decision.Send, decision.Skip, SkipRecoveryDisabled)
+	}
+}
+
+func TestRoute(t *testing.T) {
+	night := time.Date(2026, 3, 4, 23, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		queue    string
+		priority string
+		now      time.Time
+		webhooks []string
+		team     string
+		severity string
+		quiet    bool
+	}{
+		{name: "global", queue: "orders", now: baseTime, webhooks: []string{"https://hooks.example/global"}, severity: config.SeverityWarning},
+		{name: "priority class", queue: "orders", priority: config.PriorityCritical, now: baseTime, webhooks: []string{"https://hooks.example/critical"}, severity: config.SeverityCritical},
+		{name: "team over class", queue: "payments.settle", priority: config.PriorityCritical, now: baseTime, webhooks: []string{"https://hooks.example/payments"}, team: "payments", severity: config.SeverityCritical},
+		{name: "quiet hours hold warnings", queue: "orders", now: night, webhooks: []string{}, severity: config.SeverityWarning, quiet: true},
+		{name: "quiet hours keep always-notify", queue: "orders", priority: config.PriorityLow, now: night, webhooks: []string{"https://hooks.example/oncall"}, severity: config.SeverityWarning, quiet: true},
+		{name: "quiet hours pass critical", queue: "orders", priority: config.PriorityCritical, now: night, webhooks: []string{"https://hooks.example/critical"}, severity: config.SeverityCritical},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := New(testConfig()).Route(tt.queue, tt.priority, tt.now)
+			if !reflect.DeepEqual(route.WebhookURLs, tt.webhooks) || route.Team != tt.team || route.Severity != tt.severity || route.Quiet != tt.quiet {
+				t.Errorf("Route = %+v, want webhooks %v, team %q, severity %q, quiet %v", route, tt.webhooks, tt.team, tt.severity, tt.quiet)
+			}
+		})
+	}
+}
+
+func TestDecideQuietHours(t *testing.T) {
+	night := time.Date(2026, 3, 4, 23, 0, 0, 0, time.UTC)
+	m := New(testConfig())
+
+	if decision := m.Decide("orders", "", true, night); decision.Send || decision.Skip != SkipQuietHours {
+		t.Errorf("Decide warning at night = send %v, skip %q; want skip %q", decision.Send, decision.Skip, SkipQuietHours)
+	}
+	if decision := m.Decide("orders", config.PriorityLow, true, night); !decision.Send {
+		t.Errorf("Decide with an always-notify webhook at night = skip %q, want send", decision.Skip)
+	}
+}
+
+func TestFilterWebhooks(t *testing.T) {
+	cfg := testConfig()
+	cfg.Notifications.Slack.Webhooks = []config.SlackWebhookConfig{
+		{URL: "https://hooks.example/pager", MinSeverity: config.SeverityCritical},
+		{URL: "https://hooks.example/orders", Queues: []string{"orders.*"}},
+	}
+	m := New(cfg)
+	webhooks := []string{"https://hooks.example/global", "https://hooks.example/pager", "https://hooks.example/orders"}
+
+	tests := []struct {
+		queue    string
+		severity string
+		want     []string
+	}{
+		{"orders.created", config.SeverityCritical, webhooks},
+		{"orders.created", config.SeverityWarning, []string{"https://hooks.example/global", "https://hooks.example/orders"}},
+		{"invoices", config.SeverityWarning, []string{"https://hooks.example/global"}},
+		// Alerts not about a queue pass queue filters
+		{"", config.SeverityWarning, []string{"https://hooks.example/global", "https://hooks.example/orders"}},
+	}
+	for _, tt := range tests {
+		if got := m.FilterWebhooks(webhooks, tt.queue, tt.severity); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FilterWebhooks(%q, %q) = %v, want %v", tt.queue, tt.severity, got, tt.want)
+		}
+	}
+}
+
+func TestDecideAlert(t *testing.T) {
+	cfg := testConfig()
+	cfg.Notifications.AlertTypes.Cluster = config.AlertTypeConfig{
+		Cooldown:    30 * time.Minute,
+		Priority:    config.PriorityCritical,
+		WebhookURLs: []string{"https://hooks.example/platform"},
+	}
+	m := New(cfg)
+
+	decision := m.DecideAlert("cluster", "rabbit@a", "", true, baseTime)
+	if !decision.Send || decision.Route.Severity != config.SeverityCritical || !reflect.DeepEqual(decision.Route.WebhookURLs, []string{"https://hooks.example/platform"}) {
+		t.Fatalf("first firing = %+v, want critical send to the type's webhook", decision)
+	}
+	m.SentAlert("cluster", "rabbit@a", baseTime)
+
+	// Another key of the type has its own cooldown
+	if decision := m.DecideAlert("cluster", "rabbit@b", "", true, baseTime.Add(time.Minute)); !decision.Send {
+		t.Errorf("firing of another key = skip %q, want send", decision.Skip)
+	}
+
+	// A firing held back by the cooldown also holds back its recovery
+	if decision := m.DecideAlert("cluster", "rabbit@a", "", true, baseTime.Add(10*time.Minute)); decision.Skip != SkipCooldown {
+		t.Errorf("firing within cooldown = skip %q, want %q", decision.Skip, SkipCooldown)
+	}
+	if decision := m.DecideAlert("cluster", "rabbit@a", "", false, baseTime.Add(11*time.Minute)); decision.Skip != SkipCooldown {
+		t.Errorf("recovery of a held firing = skip %q, want %q", decision.Skip, SkipCooldown)
+	}
+
+	// After the cooldown both are notified again
+	if decision := m.DecideAlert("cluster", "rabbit@a", "", true, baseTime.Add(31*time.Minute)); !decision.Send {
+		t.Errorf("firing after cooldown = skip %q, want send", decision.Skip)
+	}
+	m.SentAlert("cluster", "rabbit@a", baseTime.Add(31*time.Minute))
+	if decision := m.DecideAlert("cluster", "rabbit@a", "", false, baseTime.Add(32*time.Minute)); !decision.Send {
+		t.Errorf("recovery of a notified firing = skip %q, want send", decision.Skip)
+	}
+}
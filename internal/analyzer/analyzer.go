@@ -1,6 +1,9 @@
 package analyzer
 
 import (
+	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -8,24 +11,48 @@ import (
 	"go-rmq-monitor/internal/rabbitmq"
 )
 
+// ReasonRedeliveryStorm prefixes the stuck reason of queues in a redelivery storm
+const ReasonRedeliveryStorm = "redelivery storm"
+
+// IsRedeliveryStorm reports whether a stuck reason describes a redelivery storm
+func IsRedeliveryStorm(reason string) bool {
+	return strings.HasPrefix(reason, ReasonRedeliveryStorm)
+}
+
+// stalledAckRatio is the share of the deliver rate below which acks count as stalled
+const stalledAckRatio = 0.01
+
 // QueueState tracks the state of a queue over time
 type QueueState struct {
 	QueueName        string
 	History          []QueueSnapshot
 	ConsecutiveStuck int
-	LastAlertTime    time.Time
-	LastSlackAlert   time.Time     // Track last Slack notification time
 	LastKnownState   string        // "not_alerting" or "alerting"
 	StuckSince       time.Time     // When queue became alerting (for recovery duration)
+	LowPublishSince  time.Time     // When publishing fell below the expected rate, zero if it did not
+	RecoveringSince  time.Time     // When an alerting queue's backlog started decreasing, zero if it is not
+	HealthScore      int           // 0 (stuck) to 100 (healthy), from the latest check
+	Rebuilding       bool          // History was discarded after a gap and is not yet long enough to judge the queue
 }
 
 // QueueSnapshot represents queue metrics at a point in time
 type QueueSnapshot struct {
-	Timestamp     time.Time
-	MessagesReady int
-	ConsumeRate   float64
-	AckRate       float64
-	Consumers     int
+	Timestamp       time.Time
+	MessagesReady   int
+	MessagesUnacked int
+	ConsumeRate     float64
+	AckRate         float64
+	PublishRate     float64
+	RedeliverRate   float64
+	Consumers       int
+	// Share of time consumers could take deliveries (0-1), -1 if not reported
+	ConsumerUtilisation float64
+	// Ready messages at or above the queue's high priority, -1 if not tracked
+	HighPriorityDepth int
+	// Messages expired unprocessed per second, measured or estimated for TTL queues
+	ExpireRate float64
+	// Consumer identities violating the queue's rule, empty without a rule
+	IdentityViolations []string
 }
 
 // StuckQueueAlert contains information about a stuck queue
@@ -48,17 +75,26 @@ type StuckQueueAlert struct {
 type StateTransition struct {
 	QueueName     string
 	FromState     string // "not_alerting" or "alerting"
-	ToState       string // "not_alerting" or "alerting"
+	ToState       string // "not_alerting", "alerting" or "recovering"
 	Timestamp     time.Time
 	StuckDuration time.Duration // For alerting→not_alerting transitions
 	QueueInfo     rabbitmq.QueueInfo
-	Reason        string // Reason for the transition (for alerting state)
+	Reason        string  // Reason for the transition (for alerting state)
+	DrainRate     float64 // Backlog decrease in msg/s (for recovering state)
 }
 
 // AnalysisResult contains both alerts and state transitions
 type AnalysisResult struct {
 	StuckAlerts     []StuckQueueAlert
 	Transitions     []StateTransition
+	// Alerting queues whose backlog started decreasing, with ToState "recovering"
+	// The queues stay alerting until the detector no longer considers them stuck
+	Recovering      []StateTransition
+}
+
+// IsRecovering reports whether an alerting queue's backlog is decreasing
+func (s *QueueState) IsRecovering() bool {
+	return !s.RecoveringSince.IsZero()
 }
 
 // Analyzer analyzes queue health and detects stuck queues
@@ -66,6 +102,8 @@ type Analyzer struct {
 	defaultConfig *config.DetectionConfig
 	queueConfigs  map[string]config.DetectionConfig // Per-queue configs
 	states        map[string]*QueueState
+	rules         []Rule           // User-defined detection rules, run when no built-in check matches
+	detectors     []PluginDetector // Detection checks from plugins, run after the rules
 	mu            sync.RWMutex
 }
 
@@ -85,6 +123,20 @@ func (a *Analyzer) SetQueueConfig(queueName string, cfg config.DetectionConfig)
 	a.queueConfigs[queueName] = cfg
 }
 
+// ReplaceQueueConfigs replaces all per-queue detection configs, e.g. after a config update
+func (a *Analyzer) ReplaceQueueConfigs(configs map[string]config.DetectionConfig) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.queueConfigs = configs
+}
+
+// Forget drops the state of a queue that is no longer monitored
+func (a *Analyzer) Forget(queueName string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.states, queueName)
+}
+
 // getConfigForQueue returns the detection config for a specific queue
 func (a *Analyzer) getConfigForQueue(queueName string) config.DetectionConfig {
 	if cfg, exists := a.queueConfigs[queueName]; exists {
@@ -93,14 +145,26 @@ func (a *Analyzer) getConfigForQueue(queueName string) config.DetectionConfig {
 	return *a.defaultConfig
 }
 
+// GetQueueConfig returns the detection config in effect for a queue
+func (a *Analyzer) GetQueueConfig(queueName string) config.DetectionConfig {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.getConfigForQueue(queueName)
+}
+
 // Analyze processes queue information and detects stuck queues
 func (a *Analyzer) Analyze(queues []rabbitmq.QueueInfo) AnalysisResult {
+	return a.AnalyzeAt(queues, time.Now())
+}
+
+// AnalyzeAt is Analyze for a check taken at the given time, used to replay recorded or synthetic checks
+func (a *Analyzer) AnalyzeAt(queues []rabbitmq.QueueInfo, now time.Time) AnalysisResult {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
 	alerts := make([]StuckQueueAlert, 0)
 	transitions := make([]StateTransition, 0)
-	now := time.Now()
+	recovering := make([]StateTransition, 0)
 
 	for _, queue := range queues {
 		// Get queue-specific config
@@ -118,26 +182,62 @@ func (a *Analyzer) Analyze(queues []rabbitmq.QueueInfo) AnalysisResult {
 
 		// Add current snapshot
 		snapshot := QueueSnapshot{
-			Timestamp:     now,
-			MessagesReady: queue.MessagesReady,
-			ConsumeRate:   queue.ConsumeRate,
-			AckRate:       queue.AckRate,
-			Consumers:     queue.Consumers,
+			Timestamp:       now,
+			MessagesReady:   queue.MessagesReady,
+			MessagesUnacked: queue.MessagesUnacked,
+			ConsumeRate:     queue.ConsumeRate,
+			AckRate:         queue.AckRate,
+			PublishRate:     queue.PublishRate,
+			RedeliverRate:   queue.RedeliverRate,
+			Consumers:       queue.Consumers,
+
+			ConsumerUtilisation: queue.ConsumerUtilisation,
+			HighPriorityDepth:   -1,
+		}
+		if queueConfig.HighPriority > 0 && queue.PriorityLengths != nil {
+			snapshot.HighPriorityDepth = rabbitmq.DepthAtOrAbove(queue.PriorityLengths, queueConfig.HighPriority)
+		}
+		snapshot.ExpireRate = queue.ExpireRate
+		snapshot.IdentityViolations = queue.IdentityViolations
+		if !queue.ExpiryTracked && queue.MessageTTL > 0 && len(state.History) > 0 {
+			snapshot.ExpireRate = estimateExpireRate(state.History[len(state.History)-1], snapshot)
 		}
-		state.History = append(state.History, snapshot)
+		record(state, snapshot, queueConfig)
 
-		// Keep only recent history (threshold_checks + 1 to allow comparison)
-		maxHistory := queueConfig.ThresholdChecks + 1
-		if len(state.History) > maxHistory {
-			state.History = state.History[len(state.History)-maxHistory:]
+		// After a gap, the queue keeps its state until enough checks compare recent metrics
+		if state.Rebuilding {
+			if len(state.History) < queueConfig.ThresholdChecks {
+				continue
+			}
+			state.Rebuilding = false
 		}
 
 		// Check if queue is stuck (using queue-specific config)
-		if isStuck, reason := a.isQueueStuck(state, queueConfig); isStuck {
+		if isStuck, reason, threshold := a.detect(state, queueConfig); isStuck {
 			state.ConsecutiveStuck++
+
+			// An alerting queue whose backlog shrinks is recovering, though still stuck by the window
+			if state.LastKnownState == "alerting" {
+				drainRate, draining := drainRate(state, queueConfig)
+				if draining && !state.IsRecovering() {
+					state.RecoveringSince = now
+					recovering = append(recovering, StateTransition{
+						QueueName:     queue.Name,
+						FromState:     "alerting",
+						ToState:       "recovering",
+						Timestamp:     now,
+						StuckDuration: now.Sub(state.StuckSince),
+						QueueInfo:     queue,
+						Reason:        fmt.Sprintf("backlog decreasing at %.2f msg/s", drainRate),
+						DrainRate:     drainRate,
+					})
+				} else if !draining {
+					state.RecoveringSince = time.Time{}
+				}
+			}
 			
 			// Check for state transition: not_alerting → alerting
-			if state.LastKnownState != "alerting" && state.ConsecutiveStuck >= queueConfig.ThresholdChecks {
+			if state.LastKnownState != "alerting" && state.ConsecutiveStuck >= threshold {
 				// State changed from not_alerting to alerting
 				transition := StateTransition{
 					QueueName: queue.Name,
@@ -153,26 +253,22 @@ func (a *Analyzer) Analyze(queues []rabbitmq.QueueInfo) AnalysisResult {
 			}
 			
 			// Only alert if we've crossed the threshold
-			if state.ConsecutiveStuck >= queueConfig.ThresholdChecks {
-				// Avoid duplicate alerts within 5 minutes
-				if now.Sub(state.LastAlertTime) >= 5*time.Minute {
-					alert := StuckQueueAlert{
-						QueueName:        queue.Name,
-						Timestamp:        now,
-						MessagesReady:    queue.MessagesReady,
-						Consumers:        queue.Consumers,
-						ConsumeRate:      queue.ConsumeRate,
-						AckRate:          queue.AckRate,
-						ConsecutiveStuck: state.ConsecutiveStuck,
-						Reason:           reason,
-						// Include detection parameters for context
-						ThresholdChecks:  queueConfig.ThresholdChecks,
-						MinMessageCount:  queueConfig.MinMessageCount,
-						MinConsumeRate:   queueConfig.MinConsumeRate,
-					}
-					alerts = append(alerts, alert)
-					state.LastAlertTime = now
+			if state.ConsecutiveStuck >= threshold {
+				alert := StuckQueueAlert{
+					QueueName:        queue.Name,
+					Timestamp:        now,
+					MessagesReady:    queue.MessagesReady,
+					Consumers:        queue.Consumers,
+					ConsumeRate:      queue.ConsumeRate,
+					AckRate:          queue.AckRate,
+					ConsecutiveStuck: state.ConsecutiveStuck,
+					Reason:           reason,
+					// Include detection parameters for context
+					ThresholdChecks:  threshold,
+					MinMessageCount:  queueConfig.MinMessageCount,
+					MinConsumeRate:   queueConfig.MinConsumeRate,
 				}
+				alerts = append(alerts, alert)
 			}
 		} else {
 			// Queue is not alerting
@@ -194,15 +290,90 @@ func (a *Analyzer) Analyze(queues []rabbitmq.QueueInfo) AnalysisResult {
 			
 			// Reset counter if queue is not alerting
 			state.ConsecutiveStuck = 0
+			state.RecoveringSince = time.Time{}
 		}
 	}
 
 	return AnalysisResult{
 		StuckAlerts: alerts,
 		Transitions: transitions,
+		Recovering:  recovering,
+	}
+}
+
+// record appends a snapshot to a queue's history and updates the state derived from it
+func record(state *QueueState, snapshot QueueSnapshot, cfg config.DetectionConfig) {
+	state.History = append(state.History, snapshot)
+
+	// Track how long publishing has been below the expected rate
+	if cfg.MinPublishRate > 0 && snapshot.PublishRate < cfg.MinPublishRate {
+		if state.LowPublishSince.IsZero() {
+			state.LowPublishSince = snapshot.Timestamp
+		}
+	} else {
+		state.LowPublishSince = time.Time{}
+	}
+
+	// Keep only recent history (threshold_checks + 1 to allow comparison)
+	maxHistory := cfg.ThresholdChecks + 1
+	if len(state.History) > maxHistory {
+		state.History = state.History[len(state.History)-maxHistory:]
+	}
+
+	state.HealthScore = HealthScore(state.History, cfg)
+}
+
+// DiscardHistory drops a queue's snapshots after a gap in its checks, such as an API outage,
+// so checks after the gap are not compared with metrics from hours before
+// The queue keeps its alerting state, without alerts or transitions, until threshold_checks
+// checks after the gap let it be judged again; a queue that was not alerting starts counting anew
+func (a *Analyzer) DiscardHistory(queueName string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	state, exists := a.states[queueName]
+	if !exists || len(state.History) == 0 {
+		return
+	}
+	state.History = state.History[:0]
+	state.LowPublishSince = time.Time{}
+	state.Rebuilding = true
+	if state.LastKnownState != "alerting" {
+		state.ConsecutiveStuck = 0
 	}
 }
 
+// Backfill seeds an untracked queue's history with past snapshots, oldest first
+// Snapshots are evaluated like checks to build up the consecutive stuck count, but never
+// cause a transition: the queue starts alerting at the earliest on the next live check
+func (a *Analyzer) Backfill(queueName string, snapshots []QueueSnapshot) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, exists := a.states[queueName]; exists || len(snapshots) == 0 {
+		return
+	}
+	queueConfig := a.getConfigForQueue(queueName)
+	state := &QueueState{
+		QueueName: queueName,
+		History:   make([]QueueSnapshot, 0, len(snapshots)),
+	}
+	threshold := queueConfig.ThresholdChecks
+	for _, snapshot := range snapshots {
+		record(state, snapshot, queueConfig)
+		var isStuck bool
+		if isStuck, _, threshold = a.detect(state, queueConfig); isStuck {
+			state.ConsecutiveStuck++
+		} else {
+			state.ConsecutiveStuck = 0
+		}
+	}
+	if state.ConsecutiveStuck >= threshold {
+		state.ConsecutiveStuck = threshold - 1
+	}
+	a.states[queueName] = state
+}
+
 // isQueueStuck determines if a queue is stuck based on its history
 func (a *Analyzer) isQueueStuck(state *QueueState, cfg config.DetectionConfig) (bool, string) {
 	// Need enough history to make a determination
@@ -212,6 +383,51 @@ func (a *Analyzer) isQueueStuck(state *QueueState, cfg config.DetectionConfig) (
 
 	latest := state.History[len(state.History)-1]
 
+	// Check 0: Consumers receive messages but never ack them
+	// Runs before the message count filter since delivered messages are no longer ready
+	if cfg.DetectAckStall && a.isAckStalled(state, cfg) {
+		return true, "consumers receiving messages but not acking (unacked messages growing)"
+	}
+
+	// Check 0b: Most deliveries are redeliveries, typically a poison message being rejected and requeued
+	if cfg.RedeliveryStormRatio > 0 && latest.Consumers > 0 && latest.ConsumeRate > 0 {
+		if ratio := latest.RedeliverRate / latest.ConsumeRate; ratio >= cfg.RedeliveryStormRatio {
+			return true, fmt.Sprintf("%s: %.0f%% of deliveries are redeliveries", ReasonRedeliveryStorm, ratio*100)
+		}
+	}
+
+	// Check 0c: High-priority messages pile up, which total depth hides behind low-priority backlog
+	if cfg.MaxHighPriorityDepth > 0 && latest.HighPriorityDepth > cfg.MaxHighPriorityDepth {
+		return true, fmt.Sprintf("high-priority backlog of %d messages (priority >= %d) above %d", latest.HighPriorityDepth, cfg.HighPriority, cfg.MaxHighPriorityDepth)
+	}
+
+	// Check 0d: Fewer consumers than declared, caught before messages accumulate
+	if cfg.ExpectedConsumers > 0 && latest.Consumers < cfg.ExpectedConsumers {
+		return true, fmt.Sprintf("only %d of %d expected consumers attached", latest.Consumers, cfg.ExpectedConsumers)
+	}
+
+	// Check 0e: Producers stopped publishing, which leaves the queue empty and otherwise healthy
+	if cfg.MinPublishRate > 0 && !state.LowPublishSince.IsZero() {
+		if window := latest.Timestamp.Sub(state.LowPublishSince); window >= cfg.PublishWindow {
+			return true, fmt.Sprintf("publish rate %.2f msg/s below expected %.2f msg/s for %s", latest.PublishRate, cfg.MinPublishRate, window.Round(time.Second))
+		}
+	}
+
+	// Check 0f: Composite health score below the configured minimum
+	if cfg.MinHealthScore > 0 && state.HealthScore < cfg.MinHealthScore {
+		return true, fmt.Sprintf("health score %d below %d", state.HealthScore, cfg.MinHealthScore)
+	}
+
+	// Check 0g: Messages expire before they are consumed, which keeps the backlog below the message count filter
+	if cfg.MaxExpireRate > 0 && latest.ExpireRate > cfg.MaxExpireRate {
+		return true, fmt.Sprintf("messages expiring unprocessed at %.2f msg/s, above %.2f msg/s", latest.ExpireRate, cfg.MaxExpireRate)
+	}
+
+	// Check 0h: Consumers of the wrong application or user, e.g. draining another environment's queue
+	if len(latest.IdentityViolations) > 0 {
+		return true, "consumer identity mismatch: " + strings.Join(latest.IdentityViolations, "; ")
+	}
+
 	// Ignore queues with few messages (or empty queues)
 	if latest.MessagesReady <= cfg.MinMessageCount {
 		return false, ""
@@ -244,6 +460,61 @@ func (a *Analyzer) isQueueStuck(state *QueueState, cfg config.DetectionConfig) (
 	return false, ""
 }
 
+// drainRate returns how fast the backlog shrank since the previous check
+// Reports draining only while the backlog is still above the message count filter
+func drainRate(state *QueueState, cfg config.DetectionConfig) (float64, bool) {
+	if len(state.History) < 2 {
+		return 0, false
+	}
+	previous := state.History[len(state.History)-2]
+	latest := state.History[len(state.History)-1]
+	if latest.MessagesReady >= previous.MessagesReady || latest.MessagesReady <= cfg.MinMessageCount {
+		return 0, false
+	}
+	elapsed := latest.Timestamp.Sub(previous.Timestamp).Seconds()
+	if elapsed <= 0 {
+		return 0, false
+	}
+	return float64(previous.MessagesReady-latest.MessagesReady) / elapsed, true
+}
+
+// estimateExpireRate derives the expiry rate of a TTL queue between two snapshots
+// Whatever was published but neither processed nor left in the queue must have expired
+// Processing uses the ack rate, or the deliver rate for auto-ack consumers
+func estimateExpireRate(previous, current QueueSnapshot) float64 {
+	elapsed := current.Timestamp.Sub(previous.Timestamp).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	depthChange := float64(current.MessagesReady+current.MessagesUnacked-previous.MessagesReady-previous.MessagesUnacked) / elapsed
+	processed := current.AckRate
+	if processed == 0 {
+		processed = current.ConsumeRate
+	}
+	return max(current.PublishRate-processed-depthChange, 0)
+}
+
+// isAckStalled checks if messages are delivered at a healthy rate while acks stay near zero
+// Requires unacked messages to grow so consumers in no-ack mode are not flagged
+func (a *Analyzer) isAckStalled(state *QueueState, cfg config.DetectionConfig) bool {
+	latest := state.History[len(state.History)-1]
+	if latest.Consumers == 0 || latest.ConsumeRate <= 0 || latest.ConsumeRate < cfg.MinConsumeRate {
+		return false
+	}
+	if latest.AckRate > latest.ConsumeRate*stalledAckRatio {
+		return false
+	}
+	if latest.MessagesUnacked <= cfg.MinMessageCount {
+		return false
+	}
+
+	recentHistory := state.History
+	if len(recentHistory) > cfg.ThresholdChecks {
+		recentHistory = recentHistory[len(recentHistory)-cfg.ThresholdChecks:]
+	}
+	return latest.MessagesUnacked > recentHistory[0].MessagesUnacked
+}
+
 // isMessageCountStagnant checks if message count is stable or increasing
 func (a *Analyzer) isMessageCountStagnant(state *QueueState, cfg config.DetectionConfig) bool {
 	if len(state.History) < 2 {
@@ -310,6 +581,95 @@ func (a *Analyzer) GetState(queueName string) (*QueueState, bool) {
 	return state, exists
 }
 
+// Snapshot returns copies of all tracked queue states ordered by queue name
+// Safe to inspect while analysis continues (used by the debug endpoint)
+func (a *Analyzer) Snapshot() []QueueState {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	result := make([]QueueState, 0, len(a.states))
+	for _, state := range a.states {
+		snapshot := *state
+		snapshot.History = append([]QueueSnapshot(nil), state.History...)
+		result = append(result, snapshot)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].QueueName < result[j].QueueName
+	})
+	return result
+}
+
+// SnapshotQueue returns a copy of a single queue's state
+func (a *Analyzer) SnapshotQueue(queueName string) (QueueState, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	state, exists := a.states[queueName]
+	if !exists {
+		return QueueState{}, false
+	}
+	snapshot := *state
+	snapshot.History = append([]QueueSnapshot(nil), state.History...)
+	return snapshot, true
+}
+
+// GetAlertingQueues returns the names of queues currently alerting
+// and the total number of tracked queues
+func (a *Analyzer) GetAlertingQueues() ([]string, int) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	alerting := make([]string, 0)
+	for name, state := range a.states {
+		if state.LastKnownState == "alerting" {
+			alerting = append(alerting, name)
+		}
+	}
+	sort.Strings(alerting)
+	return alerting, len(a.states)
+}
+
+// GetRecoveringQueues returns the names of alerting queues whose backlog is decreasing
+func (a *Analyzer) GetRecoveringQueues() []string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	recovering := make([]string, 0)
+	for name, state := range a.states {
+		if state.LastKnownState == "alerting" && state.IsRecovering() {
+			recovering = append(recovering, name)
+		}
+	}
+	sort.Strings(recovering)
+	return recovering
+}
+
+// Suspicious returns the queues that were stuck in their latest check but are not alerting yet
+func (a *Analyzer) Suspicious() map[string]bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	suspicious := make(map[string]bool)
+	for name, state := range a.states {
+		if state.ConsecutiveStuck > 0 && state.LastKnownState != "alerting" {
+			suspicious[name] = true
+		}
+	}
+	return suspicious
+}
+
+// HealthScores returns the latest health score of every tracked queue
+func (a *Analyzer) HealthScores() map[string]int {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	scores := make(map[string]int, len(a.states))
+	for name, state := range a.states {
+		scores[name] = state.HealthScore
+	}
+	return scores
+}
+
 // GetQueueState returns the current state for a queue (for Slack notifications)
 func (a *Analyzer) GetQueueState(queueName string) *QueueState {
 	a.mu.RLock()
@@ -0,0 +1,130 @@
+package analyzer
+
+import (
+	"fmt"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/rules"
+	"go-rmq-monitor/pluginapi"
+)
+
+// Rule is a compiled user-defined detection rule
+type Rule struct {
+	config.RuleConfig
+	Program *rules.Program
+}
+
+// CompileRules compiles the configured detection rules
+func CompileRules(cfgs []config.RuleConfig) ([]Rule, error) {
+	compiled := make([]Rule, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		program, err := rules.Compile(cfg.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %w", cfg.Name, err)
+		}
+		compiled = append(compiled, Rule{RuleConfig: cfg, Program: program})
+	}
+	return compiled, nil
+}
+
+// PluginDetector is a detection check loaded from a plugin
+type PluginDetector struct {
+	Name     string
+	Detector pluginapi.Detector
+}
+
+// SetRules replaces the user-defined detection rules
+func (a *Analyzer) SetRules(compiled []Rule) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.rules = compiled
+}
+
+// SetPluginDetectors replaces the detection checks loaded from plugins
+func (a *Analyzer) SetPluginDetectors(detectors []PluginDetector) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.detectors = detectors
+}
+
+// detect runs the built-in checks, then the rules covering the queue, then plugin detectors
+// Returns whether the queue is stuck, why, and the consecutive checks it must be stuck for to alert:
+// the "for" clause of a matching rule, or threshold_checks
+func (a *Analyzer) detect(state *QueueState, cfg config.DetectionConfig) (bool, string, int) {
+	if isStuck, reason := a.isQueueStuck(state, cfg); isStuck {
+		return true, reason, cfg.ThresholdChecks
+	}
+
+	if len(a.rules) > 0 {
+		samples := make([]rules.Sample, len(state.History))
+		for i, snapshot := range state.History {
+			samples[i] = ruleSample(snapshot)
+		}
+		for _, rule := range a.rules {
+			if !rule.Applies(state.QueueName) || !rule.Program.Eval(samples) {
+				continue
+			}
+			threshold := rule.Program.Checks()
+			if threshold == 0 {
+				threshold = cfg.ThresholdChecks
+			}
+			return true, fmt.Sprintf("rule %s: %s", rule.Name, rule.Program), threshold
+		}
+	}
+
+	if len(a.detectors) > 0 {
+		history := make([]pluginapi.Snapshot, len(state.History))
+		for i, snapshot := range state.History {
+			history[i] = pluginSnapshot(snapshot)
+		}
+		for _, detector := range a.detectors {
+			if isStuck, reason := runDetector(detector, state.QueueName, history); isStuck {
+				return true, fmt.Sprintf("plugin %s: %s", detector.Name, reason), cfg.ThresholdChecks
+			}
+		}
+	}
+	return false, "", cfg.ThresholdChecks
+}
+
+// runDetector runs a plugin detector, treating a panic as no detection
+func runDetector(detector PluginDetector, queueName string, history []pluginapi.Snapshot) (isStuck bool, reason string) {
+	defer func() {
+		if recover() != nil {
+			isStuck, reason = false, ""
+		}
+	}()
+	return detector.Detector.Detect(queueName, history)
+}
+
+// pluginSnapshot converts a snapshot for plugin detectors
+func pluginSnapshot(snapshot QueueSnapshot) pluginapi.Snapshot {
+	return pluginapi.Snapshot{
+		Timestamp:           snapshot.Timestamp,
+		MessagesReady:       snapshot.MessagesReady,
+		MessagesUnacked:     snapshot.MessagesUnacked,
+		Consumers:           snapshot.Consumers,
+		PublishRate:         snapshot.PublishRate,
+		ConsumeRate:         snapshot.ConsumeRate,
+		AckRate:             snapshot.AckRate,
+		RedeliverRate:       snapshot.RedeliverRate,
+		ExpireRate:          snapshot.ExpireRate,
+		ConsumerUtilisation: snapshot.ConsumerUtilisation,
+	}
+}
+
+// ruleSample exposes a snapshot's fields to rules under their expression names
+func ruleSample(snapshot QueueSnapshot) rules.Sample {
+	return rules.Sample{
+		"messages_ready":       float64(snapshot.MessagesReady),
+		"messages_unacked":     float64(snapshot.MessagesUnacked),
+		"messages":             float64(snapshot.MessagesReady + snapshot.MessagesUnacked),
+		"consumers":            float64(snapshot.Consumers),
+		"publish_rate":         snapshot.PublishRate,
+		"consume_rate":         snapshot.ConsumeRate,
+		"ack_rate":             snapshot.AckRate,
+		"redeliver_rate":       snapshot.RedeliverRate,
+		"expire_rate":          snapshot.ExpireRate,
+		"consumer_utilisation": snapshot.ConsumerUtilisation,
+		"high_priority_depth":  float64(snapshot.HighPriorityDepth),
+	}
+}
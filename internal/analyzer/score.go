@@ -0,0 +1,67 @@
+package analyzer
+
+import (
+	"math"
+
+	"go-rmq-monitor/internal/config"
+)
+
+// Maximum deduction per health score component
+const (
+	scoreDepthWeight       = 25.0
+	scoreTrendWeight       = 20.0
+	scoreRateWeight        = 20.0
+	scoreConsumersWeight   = 25.0
+	scoreUtilisationWeight = 10.0
+)
+
+// HealthScore rates a queue from 0 (stuck) to 100 (healthy) from its recent history
+// Combines backlog depth, its trend, consume rate, consumer presence and consumer utilisation
+func HealthScore(history []QueueSnapshot, cfg config.DetectionConfig) int {
+	if len(history) == 0 {
+		return 100
+	}
+	latest := history[len(history)-1]
+	backlog := latest.MessagesReady > cfg.MinMessageCount
+
+	score := 100.0
+
+	// Depth: deduct gradually up to ten times the tolerated backlog
+	if backlog {
+		tolerated := math.Max(float64(cfg.MinMessageCount), 1)
+		excess := (float64(latest.MessagesReady) - tolerated) / (9 * tolerated)
+		score -= scoreDepthWeight * math.Min(excess, 1)
+	}
+
+	// Trend: a growing backlog is worse than a flat one
+	if first := history[0]; backlog && len(history) > 1 {
+		if latest.MessagesReady > first.MessagesReady {
+			score -= scoreTrendWeight
+		} else if latest.MessagesReady == first.MessagesReady {
+			score -= scoreTrendWeight / 2
+		}
+	}
+
+	// Rates: consumption below the expected rate while messages wait
+	if backlog && cfg.MinConsumeRate > 0 {
+		rate := math.Max(latest.ConsumeRate, latest.AckRate)
+		if rate < cfg.MinConsumeRate {
+			score -= scoreRateWeight * (1 - rate/cfg.MinConsumeRate)
+		}
+	}
+
+	// Consumers: missing consumers, or fewer than expected
+	switch {
+	case latest.Consumers == 0 && (backlog || cfg.ExpectedConsumers > 0):
+		score -= scoreConsumersWeight
+	case latest.Consumers < cfg.ExpectedConsumers:
+		score -= scoreConsumersWeight * float64(cfg.ExpectedConsumers-latest.Consumers) / float64(cfg.ExpectedConsumers)
+	}
+
+	// Utilisation: consumers attached to a backlog but rarely able to take deliveries
+	if backlog && latest.Consumers > 0 && latest.ConsumerUtilisation >= 0 {
+		score -= scoreUtilisationWeight * (1 - math.Min(latest.ConsumerUtilisation, 1))
+	}
+
+	return int(math.Round(math.Max(score, 0)))
+}
@@ -0,0 +1,144 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Operator actions recorded in the audit log
+const (
+	ActionAck           = "ack"
+	ActionFalsePositive = "false_positive"
+	ActionSilence       = "silence_create"
+	ActionSilenceExpire = "silence_expire"
+	ActionManualCheck   = "manual_check"
+	ActionQuarantine    = "quarantine"
+	ActionConfigUpdate  = "config_update"
+)
+
+// SourceAPI marks actions made directly through the control API
+const SourceAPI = "api"
+
+// SourceMonitor marks remediations performed automatically by the monitor
+const SourceMonitor = "monitor"
+
+// Entry is a single operator action
+type Entry struct {
+	Timestamp time.Time         `json:"timestamp"`
+	Action    string            `json:"action"`
+	Actor     string            `json:"actor"`              // Who the request claims to act for
+	Identity  string            `json:"identity,omitempty"` // Authenticated API token name, if API auth is enabled
+	Source    string            `json:"source,omitempty"`   // api, cli, slack or monitor
+	Queues    []string          `json:"queues,omitempty"`
+	Details   map[string]string `json:"details,omitempty"`
+}
+
+// Log appends operator actions to a dedicated JSON lines file
+// The file is opened append-only and never rewritten by the monitor
+type Log struct {
+	file *os.File
+	mu   sync.Mutex
+}
+
+// Open opens the audit log for appending
+func Open(path string) (*Log, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	return &Log{file: file}, nil
+}
+
+// Record appends an entry, syncing it to disk before returning
+func (l *Log) Record(entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, err := l.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	return l.file.Sync()
+}
+
+// Close closes the audit log
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
+
+// Filter selects audit entries; empty fields match everything
+type Filter struct {
+	Since  time.Time
+	Action string
+	Actor  string // Matches either the actor or the authenticated identity
+	Queue  string
+}
+
+// Matches reports whether an entry passes the filter
+func (f Filter) Matches(entry Entry) bool {
+	if entry.Timestamp.Before(f.Since) {
+		return false
+	}
+	if f.Action != "" && entry.Action != f.Action {
+		return false
+	}
+	if f.Actor != "" && entry.Actor != f.Actor && entry.Identity != f.Actor {
+		return false
+	}
+	if f.Queue != "" {
+		found := false
+		for _, queue := range entry.Queues {
+			if queue == f.Queue {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Query reads all entries matching the filter in chronological order
+func Query(path string, filter Filter) ([]Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	entries := make([]Entry, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// Skip partially written or corrupt lines
+			continue
+		}
+		if filter.Matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	return entries, nil
+}
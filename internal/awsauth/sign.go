@@ -0,0 +1,37 @@
+package awsauth
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Sign adds AWS Signature Version 4 headers to a request for the given service and region,
+// using the provider's current credentials
+// The body is read and restored, so the request can still be sent
+func (p *Provider) Sign(req *http.Request, service, region string) error {
+	creds, err := p.Retrieve(req.Context())
+	if err != nil {
+		return err
+	}
+
+	var body []byte
+	if req.Body != nil {
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read request body: %w", err)
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	sum := sha256.Sum256(body)
+
+	if err := p.signer.SignHTTP(req.Context(), creds, req, hex.EncodeToString(sum[:]), service, region, time.Now()); err != nil {
+		return fmt.Errorf("failed to sign %s request: %w", service, err)
+	}
+	return nil
+}
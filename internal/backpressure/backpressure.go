@@ -0,0 +1,113 @@
+// Package backpressure publishes a per-queue flag producers poll to slow down or shed publishing to unhealthy queues
+package backpressure
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Reasons a queue signals backpressure
+const (
+	ReasonStuck   = "stuck"
+	ReasonBacklog = "backlog"
+)
+
+// Signal is the backpressure state of a queue as of its last check
+type Signal struct {
+	Queue         string    `json:"queue"`
+	Backpressure  bool      `json:"backpressure"`
+	Reason        string    `json:"reason,omitempty"` // stuck or backlog while signalling
+	MessagesReady int       `json:"messages_ready"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// Board holds the latest signal of every checked queue
+type Board struct {
+	maxMessagesReady int
+	signals          map[string]Signal
+	mu               sync.RWMutex
+}
+
+// New creates a board that signals stuck queues and, unless maxMessagesReady is 0,
+// queues with more ready messages than maxMessagesReady
+func New(maxMessagesReady int) *Board {
+	return &Board{
+		maxMessagesReady: maxMessagesReady,
+		signals:          make(map[string]Signal),
+	}
+}
+
+// Evaluate returns the signal of a queue from its check
+func (b *Board) Evaluate(queue string, messagesReady int, stuck bool, now time.Time) Signal {
+	signal := Signal{Queue: queue, MessagesReady: messagesReady, UpdatedAt: now}
+	switch {
+	case stuck:
+		signal.Backpressure = true
+		signal.Reason = ReasonStuck
+	case b.maxMessagesReady > 0 && messagesReady > b.maxMessagesReady:
+		signal.Backpressure = true
+		signal.Reason = ReasonBacklog
+	}
+	return signal
+}
+
+// Update stores the signals of the queues checked in a cycle
+// Queues not checked keep their previous signal
+func (b *Board) Update(signals []Signal) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, signal := range signals {
+		b.signals[signal.Queue] = signal
+	}
+}
+
+// Forget drops the signals of queues not in keep, such as deleted queues
+func (b *Board) Forget(keep map[string]bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for name := range b.signals {
+		if !keep[name] {
+			delete(b.signals, name)
+		}
+	}
+}
+
+// Get returns the signal of a queue
+func (b *Board) Get(queue string) (Signal, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	signal, exists := b.signals[queue]
+	return signal, exists
+}
+
+// HandleGet returns the signal of the queue in the request path as JSON
+// Unknown queues return 404, so producers can decide whether to publish without a signal
+// Registered as GET /api/backpressure/{queue}
+func (b *Board) HandleGet(w http.ResponseWriter, r *http.Request) {
+	signal, exists := b.Get(r.PathValue("queue"))
+	if !exists {
+		http.Error(w, "queue not monitored", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(signal)
+}
+
+// HandleList returns the signals of all checked queues as JSON
+// Registered as GET /api/backpressure
+func (b *Board) HandleList(w http.ResponseWriter, r *http.Request) {
+	b.mu.RLock()
+	signals := make([]Signal, 0, len(b.signals))
+	for _, signal := range b.signals {
+		signals = append(signals, signal)
+	}
+	b.mu.RUnlock()
+	sort.Slice(signals, func(i, j int) bool { return signals[i].Queue < signals[j].Queue })
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(signals)
+}
@@ -0,0 +1,187 @@
+package backpressure
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Redis mirrors signals to Redis keys using the RESP protocol
+// Each queue's key holds 1 while it signals backpressure and 0 otherwise, and expires after the TTL
+// so producers stop backing off when the monitor stops updating it
+// The connection is opened on first use and reopened after a failure
+type Redis struct {
+	address   string
+	useTLS    bool
+	username  string
+	password  string
+	database  int
+	keyPrefix string
+	ttl       time.Duration
+	timeout   time.Duration
+	conn      net.Conn
+	reader    *bufio.Reader
+	mu        sync.Mutex
+}
+
+// NewRedis creates a writer for the server at serverURL
+// serverURL is redis://host:port/db or rediss://host:port/db for TLS; credentials may be part of the URL
+func NewRedis(serverURL, keyPrefix string, ttl, timeout time.Duration) (*Redis, error) {
+	parsed, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url: %w", err)
+	}
+	if parsed.Scheme != "redis" && parsed.Scheme != "rediss" {
+		return nil, fmt.Errorf("redis url must use the redis:// or rediss:// scheme")
+	}
+
+	address := parsed.Host
+	if parsed.Port() == "" {
+		address = net.JoinHostPort(parsed.Hostname(), "6379")
+	}
+	r := &Redis{
+		address:   address,
+		useTLS:    parsed.Scheme == "rediss",
+		keyPrefix: keyPrefix,
+		ttl:       ttl,
+		timeout:   timeout,
+	}
+	if parsed.User != nil {
+		r.username = parsed.User.Username()
+		r.password, _ = parsed.User.Password()
+	}
+	if db := strings.Trim(parsed.Path, "/"); db != "" {
+		r.database, err = strconv.Atoi(db)
+		if err != nil || r.database < 0 {
+			return nil, fmt.Errorf("redis url database must be a number")
+		}
+	}
+	return r, nil
+}
+
+// Key returns the key a queue's signal is written to
+func (r *Redis) Key(queue string) string {
+	return r.keyPrefix + queue
+}
+
+// Write sets the keys of the signals in one pipeline
+func (r *Redis) Write(signals []Signal) error {
+	if len(signals) == 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.conn == nil {
+		if err := r.connect(); err != nil {
+			return err
+		}
+	}
+	commands := make([][]string, 0, len(signals))
+	ttl := strconv.FormatInt(r.ttl.Milliseconds(), 10)
+	for _, signal := range signals {
+		value := "0"
+		if signal.Backpressure {
+			value = "1"
+		}
+		commands = append(commands, []string{"SET", r.Key(signal.Queue), value, "PX", ttl})
+	}
+	if err := r.pipeline(commands); err != nil {
+		r.closeConn()
+		return err
+	}
+	return nil
+}
+
+// Close closes the server connection
+func (r *Redis) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closeConn()
+	return nil
+}
+
+// connect opens the connection, authenticates and selects the database (caller must hold the lock)
+func (r *Redis) connect() error {
+	conn, err := net.DialTimeout("tcp", r.address, r.timeout)
+	if err != nil {
+		return fmt.Errorf("failed to connect to redis: %w", err)
+	}
+	if r.useTLS {
+		host, _, _ := net.SplitHostPort(r.address)
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: host})
+		tlsConn.SetDeadline(time.Now().Add(r.timeout))
+		if err := tlsConn.Handshake(); err != nil {
+			conn.Close()
+			return fmt.Errorf("redis tls handshake failed: %w", err)
+		}
+		conn = tlsConn
+	}
+	r.conn = conn
+	r.reader = bufio.NewReader(conn)
+
+	var setup [][]string
+	switch {
+	case r.username != "" && r.password != "":
+		setup = append(setup, []string{"AUTH", r.username, r.password})
+	case r.password != "":
+		setup = append(setup, []string{"AUTH", r.password})
+	}
+	if r.database != 0 {
+		setup = append(setup, []string{"SELECT", strconv.Itoa(r.database)})
+	}
+	if err := r.pipeline(setup); err != nil {
+		r.closeConn()
+		return err
+	}
+	return nil
+}
+
+// pipeline writes the commands at once and reads a reply for each (caller must hold the lock)
+func (r *Redis) pipeline(commands [][]string) error {
+	if len(commands) == 0 {
+		return nil
+	}
+	r.conn.SetDeadline(time.Now().Add(r.timeout))
+
+	var b strings.Builder
+	for _, command := range commands {
+		fmt.Fprintf(&b, "*%d\r\n", len(command))
+		for _, arg := range command {
+			fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+		}
+	}
+	if _, err := r.conn.Write([]byte(b.String())); err != nil {
+		return fmt.Errorf("failed to write to redis: %w", err)
+	}
+
+	var firstErr error
+	for range commands {
+		line, err := r.reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read redis reply: %w", err)
+		}
+		line = strings.TrimSpace(line)
+		// SET, AUTH and SELECT answer with simple strings or errors
+		if strings.HasPrefix(line, "-") && firstErr == nil {
+			firstErr = fmt.Errorf("redis error: %s", strings.TrimPrefix(line, "-"))
+		}
+	}
+	return firstErr
+}
+
+// closeConn drops the connection so the next write reconnects (caller must hold the lock)
+func (r *Redis) closeConn() {
+	if r.conn != nil {
+		r.conn.Close()
+		r.conn = nil
+		r.reader = nil
+	}
+}
@@ -0,0 +1,50 @@
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Values set at build time through main package ldflags
+var (
+	version = "dev"
+	commit  = "none"
+	date    = "unknown"
+)
+
+// Info describes the running binary
+type Info struct {
+	Version   string   `json:"version"`
+	Commit    string   `json:"commit"`
+	BuildDate string   `json:"build_date"`
+	GoVersion string   `json:"go_version"`
+	Module    string   `json:"module"`
+	ModuleSum string   `json:"module_sum,omitempty"`
+	Platform  string   `json:"platform"`
+	Features  []string `json:"features,omitempty"`
+}
+
+// Set records the version details injected at build time
+func Set(v, c, d string) {
+	version = v
+	commit = c
+	date = d
+}
+
+// Get returns build information for the running binary
+func Get() Info {
+	info := Info{
+		Version:   version,
+		Commit:    commit,
+		BuildDate: date,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	if build, ok := debug.ReadBuildInfo(); ok {
+		info.Module = build.Main.Path
+		info.ModuleSum = build.Main.Sum
+	}
+
+	return info
+}
@@ -0,0 +1,78 @@
+// Package capacity estimates the throughput queue consumers can sustain from stored history
+package capacity
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"go-rmq-monitor/internal/history"
+)
+
+// DefaultMinSamples is the fewest busy samples an estimate is based on unless configured otherwise
+const DefaultMinSamples = 20
+
+// sustainedPercentile is the percentile of busy consume rates taken as capacity, so short spikes do not count
+const sustainedPercentile = 0.95
+
+// Estimate is a queue's estimated consume capacity
+type Estimate struct {
+	Queue    string
+	Capacity float64 // Sustainable consume rate in msg/s
+	Samples  int     // Busy samples the estimate is based on
+}
+
+// Utilisation returns a publish rate relative to the capacity, where 1 means consumers can only just keep up
+func (e Estimate) Utilisation(publishRate float64) float64 {
+	if e.Capacity <= 0 {
+		return 0
+	}
+	return publishRate / e.Capacity
+}
+
+// EstimateQueue estimates a queue's capacity from its records
+// Only busy samples count, taken while messages were waiting and consumers ran as fast as they could:
+// a queue that never built a backlog says nothing about how much more its consumers could take
+// Returns false with fewer than minSamples busy samples
+func EstimateQueue(queue string, records []history.Record, minSamples int) (Estimate, bool) {
+	var rates []float64
+	for _, record := range records {
+		if record.MessagesReady > 0 && record.Consumers > 0 && record.ConsumeRate > 0 {
+			rates = append(rates, record.ConsumeRate)
+		}
+	}
+	if len(rates) == 0 || len(rates) < minSamples {
+		return Estimate{}, false
+	}
+	return Estimate{
+		Queue:    queue,
+		Capacity: percentile(rates, sustainedPercentile),
+		Samples:  len(rates),
+	}, true
+}
+
+// Load estimates the capacity of every queue with enough busy samples in the history file since the given time
+func Load(historyPath string, since time.Time, minSamples int) (map[string]Estimate, error) {
+	records, err := history.Load(historyPath, since)
+	if err != nil {
+		return nil, err
+	}
+	estimates := make(map[string]Estimate)
+	for queue, queueRecords := range records {
+		if estimate, ok := EstimateQueue(queue, queueRecords, minSamples); ok {
+			estimates[queue] = estimate
+		}
+	}
+	return estimates, nil
+}
+
+// percentile returns the p-th percentile (0..1) of values
+func percentile(values []float64, p float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	index := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	return sorted[index]
+}
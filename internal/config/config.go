@@ -2,17 +2,57 @@ package config
 
 import (
 	"fmt"
+	"hash/fnv"
+	"net"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	"go-rmq-monitor/internal/numfmt"
+	"go-rmq-monitor/internal/queuename"
+	"go-rmq-monitor/internal/rules"
+
 	"github.com/spf13/viper"
 )
 
 // Config represents the application configuration
 type Config struct {
+	Instance      string              `mapstructure:"instance"` // Name of this monitor among several on one host, empty for a single one
 	RabbitMQ      RabbitMQConfig      `mapstructure:"rabbitmq"`
 	Monitor       MonitorConfig       `mapstructure:"monitor"`
 	Logging       LoggingConfig       `mapstructure:"logging"`
 	Notifications NotificationsConfig `mapstructure:"notifications"`
+	Server        ServerConfig        `mapstructure:"server"`
+	Heartbeats    HeartbeatsConfig    `mapstructure:"heartbeats"`
+	Backpressure  BackpressureConfig  `mapstructure:"backpressure"`
+	BrokerEvents  BrokerEventsConfig  `mapstructure:"broker_events"`
+	History       HistoryConfig       `mapstructure:"history"`
+	Textfile      TextfileConfig      `mapstructure:"textfile"`
+	EventSinks    EventSinksConfig    `mapstructure:"event_sinks"`
+	Feedback      FeedbackConfig      `mapstructure:"feedback"`
+	Audit         AuditConfig         `mapstructure:"audit"`
+	Enrichment    EnrichmentConfig    `mapstructure:"enrichment"`
+	Privacy       PrivacyConfig       `mapstructure:"privacy"`
+	Quarantine    QuarantineConfig    `mapstructure:"quarantine"`
+	Streams       StreamsConfig       `mapstructure:"streams"`
+	Protocols     []ProtocolConfig    `mapstructure:"protocols"`
+	VHosts        []VHostRuleConfig   `mapstructure:"vhosts"`
+	Services      []ServiceConfig     `mapstructure:"services"`
+	Churn         ChurnConfig         `mapstructure:"churn"`
+	Comparison    ComparisonConfig    `mapstructure:"comparison"`
+	DeadLetters   DeadLetterConfig    `mapstructure:"dead_letters"`
+	SLO           SLOConfig           `mapstructure:"slo"`
+	Capacity      CapacityConfig      `mapstructure:"capacity"`
+	Scaling       ScalingConfig       `mapstructure:"scaling"`
+	Reports       ReportsConfig       `mapstructure:"reports"`
+	Sharding      ShardingConfig      `mapstructure:"sharding"`
+	Silences      []SilenceConfig     `mapstructure:"silences"`
+	SilenceSync   SilenceSyncConfig   `mapstructure:"silence_sync"`
+	Plugins       []PluginConfig      `mapstructure:"plugins"`
+	Teams         []TeamConfig        `mapstructure:"-"` // Loaded from monitor.teams_dir
 }
 
 // RabbitMQConfig contains RabbitMQ connection details
@@ -23,13 +63,171 @@ type RabbitMQConfig struct {
 	Password string `mapstructure:"password"`
 	VHost    string `mapstructure:"vhost"`
 	UseTLS   bool   `mapstructure:"use_tls"`
+	AMQPPort int    `mapstructure:"amqp_port"` // AMQP listener used by the selftest command, 0 for 5672 or 5671 with TLS
+	// Secret files read instead of username and password, and re-read when the credentials are rejected
+	UsernameFile string `mapstructure:"username_file"`
+	PasswordFile string `mapstructure:"password_file"`
 }
 
 // MonitorConfig contains monitoring behavior settings
 type MonitorConfig struct {
-	Interval  time.Duration   `mapstructure:"interval"`
-	Detection DetectionConfig `mapstructure:"detection"`
-	Queues    []QueueConfig   `mapstructure:"queues"`
+	Interval        time.Duration                  `mapstructure:"interval"`
+	Detection       DetectionConfig                `mapstructure:"detection"`
+	PriorityClasses map[string]PriorityClassConfig `mapstructure:"priority_classes"`
+	Profiles        map[string]ProfileConfig       `mapstructure:"profiles"`
+	Queues          []QueueConfig                  `mapstructure:"queues"`
+	TeamsDir        string                         `mapstructure:"teams_dir"`         // Directory of per-team config fragments
+	BackfillHistory bool                           `mapstructure:"backfill_history"`  // Seed history from management API samples on startup
+	BurstInterval   time.Duration                  `mapstructure:"burst_interval"`    // Check queues that look stuck this often until confirmed (0 disables)
+	MaxMissedChecks int                            `mapstructure:"max_missed_checks"` // Missed checks after which a queue's history is discarded (0 disables)
+	IdleBackoff     IdleBackoffConfig              `mapstructure:"idle_backoff"`
+	ClockSkew       ClockSkewConfig                `mapstructure:"clock_skew"`
+	AutoExclude     AutoExcludeConfig              `mapstructure:"auto_exclude"`
+	NodeMaintenance NodeMaintenanceConfig          `mapstructure:"node_maintenance"`
+	Rules           []RuleConfig                   `mapstructure:"rules"` // User-defined detection expressions
+}
+
+// RuleConfig is a user-defined detection rule, see the rules package for the expression syntax
+// A queue is stuck while any built-in check or rule matches it
+type RuleConfig struct {
+	Name       string   `mapstructure:"name"`
+	Expression string   `mapstructure:"expression"` // e.g. "messages_ready > 5000 && consume_rate < publish_rate * 0.5 for 3 checks"
+	Queues     []string `mapstructure:"queues"`     // Glob patterns of the queues the rule applies to, empty for all
+}
+
+// Applies reports whether the rule covers a queue
+func (r RuleConfig) Applies(queueName string) bool {
+	if len(r.Queues) == 0 {
+		return true
+	}
+	for _, pattern := range r.Queues {
+		if matched, _ := path.Match(pattern, queueName); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// IdleBackoffConfig lengthens the check interval of queues that stay idle
+type IdleBackoffConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`
+	After       time.Duration `mapstructure:"after"`        // Idle time before each doubling of the interval
+	MaxInterval time.Duration `mapstructure:"max_interval"` // Longest backed-off interval
+}
+
+// ClockSkewConfig compares broker timestamps with the local clock
+type ClockSkewConfig struct {
+	Enabled bool          `mapstructure:"enabled"`
+	MaxSkew time.Duration `mapstructure:"max_skew"` // Difference from the broker clock to warn at
+}
+
+// Ways of handling alerts for queues homed on a node in maintenance mode
+const (
+	MaintenanceAnnotate = "annotate" // Send alerts noting the node is in maintenance mode
+	MaintenanceSuppress = "suppress" // Hold alerts until the grace period after maintenance ends
+)
+
+// NodeMaintenanceConfig handles queues whose node is drained for a rolling upgrade
+type NodeMaintenanceConfig struct {
+	Enabled bool          `mapstructure:"enabled"`
+	Action  string        `mapstructure:"action"` // annotate or suppress
+	Grace   time.Duration `mapstructure:"grace"`  // Keep handling queues this long after their node leaves maintenance
+}
+
+// AutoExcludeConfig leaves out auto-created queues that churn constantly, such as RPC reply queues
+// Only applies when all queues are monitored and to discovery; listed queues are always monitored
+type AutoExcludeConfig struct {
+	Exclusive  bool     `mapstructure:"exclusive"`   // Queues owned by a single connection
+	AutoDelete bool     `mapstructure:"auto_delete"` // Queues deleted once their last consumer leaves
+	Patterns   []string `mapstructure:"patterns"`    // Glob patterns of generated queue names
+}
+
+// Excludes reports whether a queue is left out as auto-created
+func (a AutoExcludeConfig) Excludes(queueName string, exclusive, autoDelete bool) bool {
+	if (a.Exclusive && exclusive) || (a.AutoDelete && autoDelete) {
+		return true
+	}
+	for _, pattern := range a.Patterns {
+		if matched, _ := path.Match(pattern, queueName); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// ProfileConfig is a named set of detection overrides that queues can reference
+// Unset fields fall back to the priority class or global defaults
+type ProfileConfig struct {
+	CheckInterval   *time.Duration `mapstructure:"check_interval,omitempty"`
+	ThresholdChecks *int           `mapstructure:"threshold_checks,omitempty"`
+	MinMessageCount *int           `mapstructure:"min_message_count,omitempty"`
+	MinConsumeRate  *float64       `mapstructure:"min_consume_rate,omitempty"`
+}
+
+// Priority class names that queues can be assigned to
+const (
+	PriorityCritical = "critical"
+	PriorityHigh     = "high"
+	PriorityNormal   = "normal"
+	PriorityLow      = "low"
+)
+
+// PriorityClassConfig contains defaults shared by all queues of a priority class
+// Unset fields fall back to the global detection and notification settings
+type PriorityClassConfig struct {
+	ThresholdChecks  *int           `mapstructure:"threshold_checks,omitempty"`
+	MinMessageCount  *int           `mapstructure:"min_message_count,omitempty"`
+	MinConsumeRate   *float64       `mapstructure:"min_consume_rate,omitempty"`
+	AlertCooldown    *time.Duration `mapstructure:"alert_cooldown,omitempty"`
+	RecoveryCooldown *time.Duration `mapstructure:"recovery_cooldown,omitempty"`
+	WebhookURLs      []string       `mapstructure:"webhook_urls"`
+}
+
+// isValidPriority reports whether the given name is a known priority class
+func isValidPriority(priority string) bool {
+	switch priority {
+	case PriorityCritical, PriorityHigh, PriorityNormal, PriorityLow:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetSeverity returns the alert severity for a priority class
+func GetSeverity(priority string) string {
+	if priority == PriorityCritical {
+		return SeverityCritical
+	}
+	return SeverityWarning
+}
+
+// GetPriorityClass returns the settings for a priority class, if configured
+func (m *MonitorConfig) GetPriorityClass(priority string) (PriorityClassConfig, bool) {
+	class, exists := m.PriorityClasses[priority]
+	return class, exists
+}
+
+// GetClassDetectionConfig returns the detection defaults for a priority class
+// Applies class overrides on top of global defaults
+func (m *MonitorConfig) GetClassDetectionConfig(priority string) DetectionConfig {
+	config := m.Detection
+
+	class, exists := m.GetPriorityClass(priority)
+	if !exists {
+		return config
+	}
+
+	if class.ThresholdChecks != nil {
+		config.ThresholdChecks = *class.ThresholdChecks
+	}
+	if class.MinMessageCount != nil {
+		config.MinMessageCount = *class.MinMessageCount
+	}
+	if class.MinConsumeRate != nil {
+		config.MinConsumeRate = *class.MinConsumeRate
+	}
+
+	return config
 }
 
 // QueueConfig represents a queue to monitor with optional overrides
@@ -39,6 +237,83 @@ type QueueConfig struct {
 	ThresholdChecks *int           `mapstructure:"threshold_checks,omitempty"`
 	MinMessageCount *int           `mapstructure:"min_message_count,omitempty"`
 	MinConsumeRate  *float64       `mapstructure:"min_consume_rate,omitempty"`
+	Enabled         *bool          `mapstructure:"enabled,omitempty"`
+	ObserveOnly     bool           `mapstructure:"observe_only"`
+	Priority        string         `mapstructure:"priority"`
+	Profile         string         `mapstructure:"profile"`
+	ExpectHeartbeat bool           `mapstructure:"expect_heartbeat"`
+	DependsOn       []string       `mapstructure:"depends_on"`
+	// Classic priority queues: priorities at or above HighPriority count as high priority
+	HighPriority         *int `mapstructure:"high_priority,omitempty"`
+	MaxHighPriorityDepth *int `mapstructure:"max_high_priority_depth,omitempty"`
+	// Alert when fewer consumers are attached, even if the queue is empty
+	ExpectedConsumers *int `mapstructure:"expected_consumers,omitempty"`
+	MinHealthScore    *int `mapstructure:"min_health_score,omitempty"`
+	// Alert when publishing stays below this rate for the window (dead producers)
+	MinPublishRate *float64       `mapstructure:"min_publish_rate,omitempty"`
+	PublishWindow  *time.Duration `mapstructure:"publish_window,omitempty"`
+	// Alert when messages expire unprocessed faster than this (msg/s); the rate is read from
+	// the publish rate of a queue receiving only the expired messages, or estimated for TTL queues
+	MaxExpireRate       *float64 `mapstructure:"max_expire_rate,omitempty"`
+	ExpiryTrackingQueue string   `mapstructure:"expiry_tracking_queue"`
+	// Alert when consumers from other applications or users attach, e.g. a staging worker on a production queue
+	ConsumerIdentities *ConsumerIdentityConfig `mapstructure:"consumer_identities,omitempty"`
+}
+
+// ConsumerIdentityConfig lists the identities allowed to consume from a queue as glob patterns
+// Applications match the client-provided connection name; an empty list allows any
+type ConsumerIdentityConfig struct {
+	Applications []string `mapstructure:"applications"`
+	Users        []string `mapstructure:"users"`
+	RequireAll   bool     `mapstructure:"require_all"` // Also alert when a listed application or user has no consumer
+}
+
+// Allows reports whether a consumer of the application and user may attach
+func (c *ConsumerIdentityConfig) Allows(application, user string) bool {
+	return (len(c.Applications) == 0 || matchesPattern(c.Applications, application)) &&
+		(len(c.Users) == 0 || matchesPattern(c.Users, user))
+}
+
+// Missing returns the listed application and user patterns that none of the consumers match
+// applications and users hold the identity of each attached consumer
+func (c *ConsumerIdentityConfig) Missing(applications, users []string) []string {
+	var missing []string
+	for _, pattern := range c.Applications {
+		if !anyMatches(pattern, applications) {
+			missing = append(missing, "application "+pattern)
+		}
+	}
+	for _, pattern := range c.Users {
+		if !anyMatches(pattern, users) {
+			missing = append(missing, "user "+pattern)
+		}
+	}
+	return missing
+}
+
+// anyMatches reports whether one of the values matches the glob pattern
+func anyMatches(pattern string, values []string) bool {
+	for _, value := range values {
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPattern reports whether a value matches one of the glob patterns
+func matchesPattern(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// TracksPriorities reports whether per-priority backlog is fetched for the queue
+func (q *QueueConfig) TracksPriorities() bool {
+	return q.HighPriority != nil
 }
 
 // DetectionConfig contains stuck queue detection parameters
@@ -46,6 +321,21 @@ type DetectionConfig struct {
 	ThresholdChecks int     `mapstructure:"threshold_checks"`
 	MinMessageCount int     `mapstructure:"min_message_count"`
 	MinConsumeRate  float64 `mapstructure:"min_consume_rate"`
+	DetectAckStall  bool    `mapstructure:"detect_ack_stall"` // Flag consumers that receive messages but never ack
+	// Share of deliveries that are redeliveries to flag a redelivery storm (0 disables)
+	RedeliveryStormRatio float64 `mapstructure:"redelivery_storm_ratio"`
+	// Alert when the queue's composite health score (0-100) drops below this (0 disables)
+	MinHealthScore int `mapstructure:"min_health_score"`
+	// Alert when messages expire unprocessed faster than this many msg/s (0 disables)
+	MaxExpireRate float64 `mapstructure:"max_expire_rate"`
+	// Per-queue only: high-priority band and its maximum depth (0 disables)
+	HighPriority         int `mapstructure:"-"`
+	MaxHighPriorityDepth int `mapstructure:"-"`
+	// Per-queue only: minimum number of attached consumers (0 disables)
+	ExpectedConsumers int `mapstructure:"-"`
+	// Per-queue only: expected minimum publish rate and how long it may be missed (0 disables)
+	MinPublishRate float64       `mapstructure:"-"`
+	PublishWindow  time.Duration `mapstructure:"-"`
 }
 
 // GetDetectionConfig returns the effective detection config for a queue
@@ -63,6 +353,27 @@ func (q *QueueConfig) GetDetectionConfig(globalDefaults DetectionConfig) Detecti
 	if q.MinConsumeRate != nil {
 		config.MinConsumeRate = *q.MinConsumeRate
 	}
+	if q.HighPriority != nil {
+		config.HighPriority = *q.HighPriority
+	}
+	if q.MaxHighPriorityDepth != nil {
+		config.MaxHighPriorityDepth = *q.MaxHighPriorityDepth
+	}
+	if q.ExpectedConsumers != nil {
+		config.ExpectedConsumers = *q.ExpectedConsumers
+	}
+	if q.MinPublishRate != nil {
+		config.MinPublishRate = *q.MinPublishRate
+	}
+	if q.MinHealthScore != nil {
+		config.MinHealthScore = *q.MinHealthScore
+	}
+	if q.PublishWindow != nil {
+		config.PublishWindow = *q.PublishWindow
+	}
+	if q.MaxExpireRate != nil {
+		config.MaxExpireRate = *q.MaxExpireRate
+	}
 
 	return config
 }
@@ -76,42 +387,1016 @@ func (q *QueueConfig) GetCheckInterval(globalDefault time.Duration) time.Duratio
 	return globalDefault
 }
 
+// IsEnabled reports whether the queue should be monitored
+// Queues are enabled unless explicitly disabled in config
+func (q *QueueConfig) IsEnabled() bool {
+	return q.Enabled == nil || *q.Enabled
+}
+
+// GetQueueDetectionConfig returns the effective detection config for a queue
+// Layers global defaults, priority class, profile and queue overrides in that order
+func (m *MonitorConfig) GetQueueDetectionConfig(q *QueueConfig) DetectionConfig {
+	config := m.GetClassDetectionConfig(q.Priority)
+
+	if profile, exists := m.Profiles[q.Profile]; exists {
+		if profile.ThresholdChecks != nil {
+			config.ThresholdChecks = *profile.ThresholdChecks
+		}
+		if profile.MinMessageCount != nil {
+			config.MinMessageCount = *profile.MinMessageCount
+		}
+		if profile.MinConsumeRate != nil {
+			config.MinConsumeRate = *profile.MinConsumeRate
+		}
+	}
+
+	return q.GetDetectionConfig(config)
+}
+
+// GetQueueCheckInterval returns the effective check interval for a queue
+// Uses the queue interval, then the profile interval, then the global default
+func (m *MonitorConfig) GetQueueCheckInterval(q *QueueConfig) time.Duration {
+	interval := m.Interval
+	if profile, exists := m.Profiles[q.Profile]; exists && profile.CheckInterval != nil {
+		interval = *profile.CheckInterval
+	}
+	return q.GetCheckInterval(interval)
+}
+
 // LoggingConfig contains logging configuration
 type LoggingConfig struct {
-	FilePath string `mapstructure:"file_path"`
-	Level    string `mapstructure:"level"`
-	Format   string `mapstructure:"format"`
+	FilePath string            `mapstructure:"file_path"`
+	Level    string            `mapstructure:"level"`
+	Format   string            `mapstructure:"format"`
+	Sampling LogSamplingConfig `mapstructure:"sampling"`
+}
+
+// LogSamplingConfig limits how often repetitive log messages are written
+type LogSamplingConfig struct {
+	Interval time.Duration `mapstructure:"interval"` // Shortest time between two entries of a message for one queue (0 disables)
+	Messages []string      `mapstructure:"messages"` // Sampled messages; warnings and errors are never sampled
 }
 
 // NotificationsConfig contains notification settings
 type NotificationsConfig struct {
-	Slack SlackConfig `mapstructure:"slack"`
+	Slack            SlackConfig            `mapstructure:"slack"`
+	GoogleChat       GoogleChatConfig       `mapstructure:"google_chat"`
+	Zulip            ZulipConfig            `mapstructure:"zulip"`
+	Twilio           TwilioConfig           `mapstructure:"twilio"`
+	StormSuppression StormSuppressionConfig `mapstructure:"storm_suppression"`
+	AlertTypes       AlertTypesConfig       `mapstructure:"alert_types"`
+	QuietHours       QuietHoursConfig       `mapstructure:"quiet_hours"`
+	Acks             AcksConfig             `mapstructure:"acks"`
+	Reminders        RemindersConfig        `mapstructure:"reminders"`
+	SelfTest         SelfTestConfig         `mapstructure:"self_test"`
+	Display          DisplayConfig          `mapstructure:"display"`
+	StartupCheck     string                 `mapstructure:"startup_check"` // off, warn or fail
+	// Alert when a queue notification takes longer from detection to delivery (0 disables)
+	MaxDeliveryLatency time.Duration `mapstructure:"max_delivery_latency"`
+}
+
+// DisplayConfig controls how timestamps appear in notifications
+type DisplayConfig struct {
+	Timezone           string                  `mapstructure:"timezone"`            // IANA name, e.g. "Europe/Amsterdam"
+	TimeFormat         string                  `mapstructure:"time_format"`         // Go time layout
+	Language           string                  `mapstructure:"language"`            // Built-in template language: en, nl, de, fr
+	ThousandsSeparator *string                 `mapstructure:"thousands_separator"` // Overrides the language's separator, may be empty
+	DecimalSeparator   *string                 `mapstructure:"decimal_separator"`   // Overrides the language's separator
+	ChannelLanguages   []ChannelLanguageConfig `mapstructure:"channel_languages"`
+	Templates          TemplatesConfig         `mapstructure:"templates"`
+	QueueNames         QueueNamesConfig        `mapstructure:"queue_names"`
+}
+
+// QueueNamesConfig shortens long generated queue names in notifications and the dashboard
+// Logs, metrics and event payloads keep the full names
+type QueueNamesConfig struct {
+	Aliases     []QueueAliasConfig `mapstructure:"aliases"`
+	StripHashes bool               `mapstructure:"strip_hashes"` // Drop hash and UUID suffixes, e.g. "-5f2c9a1b"
+	MaxLength   int                `mapstructure:"max_length"`   // Shorten longer names in the middle, 0 for no limit
+}
+
+// QueueAliasConfig shows queues matching a name or glob pattern under a fixed name
+type QueueAliasConfig struct {
+	Queue string `mapstructure:"queue"`
+	Name  string `mapstructure:"name"`
+}
+
+// minQueueNameLength keeps shortened queue names recognizable
+const minQueueNameLength = 8
+
+// ChannelLanguageConfig overrides the template language for one webhook
+type ChannelLanguageConfig struct {
+	WebhookURL string `mapstructure:"webhook_url"`
+	Language   string `mapstructure:"language"`
+}
+
+// TemplatesConfig points to custom Go text/template files for queue alerts
+type TemplatesConfig struct {
+	Alerting string `mapstructure:"alerting"`
+	Recovery string `mapstructure:"recovery"`
+}
+
+// WebhookLanguages returns the per-webhook language overrides keyed by URL
+func (d DisplayConfig) WebhookLanguages() map[string]string {
+	languages := make(map[string]string, len(d.ChannelLanguages))
+	for _, channel := range d.ChannelLanguages {
+		languages[channel.WebhookURL] = channel.Language
+	}
+	return languages
+}
+
+// QueueNamer returns the display name mapping of queues, nil when names are shown unchanged
+func (d DisplayConfig) QueueNamer() *queuename.Namer {
+	aliases := make([]queuename.Alias, 0, len(d.QueueNames.Aliases))
+	for _, alias := range d.QueueNames.Aliases {
+		aliases = append(aliases, queuename.Alias{Pattern: alias.Queue, Name: alias.Name})
+	}
+	return queuename.New(aliases, d.QueueNames.StripHashes, d.QueueNames.MaxLength)
+}
+
+// NumberOverride returns the configured digit separators, or nil to follow each channel's language
+func (d DisplayConfig) NumberOverride() *numfmt.Format {
+	if d.ThousandsSeparator == nil && d.DecimalSeparator == nil {
+		return nil
+	}
+	format := numfmt.ForLanguage(d.Language)
+	if d.ThousandsSeparator != nil {
+		format.Thousands = *d.ThousandsSeparator
+	}
+	if d.DecimalSeparator != nil {
+		format.Decimal = *d.DecimalSeparator
+	}
+	return &format
+}
+
+// NumberFormat returns the digit separators for output outside notifications, such as the dashboard
+func (d DisplayConfig) NumberFormat() numfmt.Format {
+	if override := d.NumberOverride(); override != nil {
+		return *override
+	}
+	return numfmt.ForLanguage(d.Language)
+}
+
+// Location returns the display timezone, falling back to UTC
+func (d DisplayConfig) Location() *time.Location {
+	location, err := time.LoadLocation(d.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// AcksConfig contains alert acknowledgment settings
+type AcksConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// RemindersConfig contains settings for repeating alerts of long-stuck queues
+type RemindersConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Interval time.Duration `mapstructure:"interval"` // Time between reminders of one queue
+}
+
+// SelfTestConfig schedules synthetic stuck and recovery alerts to a test channel
+type SelfTestConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`
+	Interval    time.Duration `mapstructure:"interval"`     // Time between self-tests
+	WebhookURLs []string      `mapstructure:"webhook_urls"` // Slack webhooks of the test channel
+	QueueName   string        `mapstructure:"queue_name"`   // Name of the synthetic queue, never read from the broker
+}
+
+// Alert severities used by quiet hours
+// Queues in the critical priority class raise critical alerts, all others raise warnings
+const (
+	SeverityCritical = "critical"
+	SeverityWarning  = "warning"
+)
+
+// QuietHoursConfig contains settings for muting non-critical notifications overnight
+type QuietHoursConfig struct {
+	Enabled                 bool     `mapstructure:"enabled"`
+	Start                   string   `mapstructure:"start"`    // "HH:MM"
+	End                     string   `mapstructure:"end"`      // "HH:MM"
+	Timezone                string   `mapstructure:"timezone"` // IANA name, e.g. "Europe/Berlin"
+	AlwaysNotifyWebhookURLs []string `mapstructure:"always_notify_webhook_urls"`
+}
+
+// SlackWebhookURLs returns every distinct Slack webhook in the config
+// Includes the global, priority class, team and always-notify webhooks
+func (c *Config) SlackWebhookURLs() []string {
+	urls := make([]string, 0, len(c.Notifications.Slack.WebhookURLs))
+	seen := make(map[string]bool)
+	add := func(webhookURLs []string) {
+		for _, webhookURL := range webhookURLs {
+			if webhookURL != "" && !seen[webhookURL] {
+				seen[webhookURL] = true
+				urls = append(urls, webhookURL)
+			}
+		}
+	}
+	add(c.Notifications.Slack.WebhookURLs)
+	for _, priority := range []string{PriorityCritical, PriorityHigh, PriorityNormal, PriorityLow} {
+		add(c.Monitor.PriorityClasses[priority].WebhookURLs)
+	}
+	for _, team := range c.Teams {
+		add(team.WebhookURLs)
+	}
+	add(c.Notifications.QuietHours.AlwaysNotifyWebhookURLs)
+	return urls
+}
+
+// validateWebhookURL checks that a webhook is an absolute http(s) URL
+func validateWebhookURL(field, webhookURL string) error {
+	parsed, err := url.Parse(webhookURL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return fmt.Errorf("%s contains an invalid webhook URL, expected an absolute http(s) URL", field)
+	}
+	return nil
+}
+
+// IsActive reports whether quiet hours are in effect at the given time
+// Windows crossing midnight (e.g. 22:00-07:00) are supported
+func (q *QuietHoursConfig) IsActive(now time.Time) bool {
+	if !q.Enabled {
+		return false
+	}
+
+	location, err := time.LoadLocation(q.Timezone)
+	if err != nil {
+		location = time.Local
+	}
+	start, errStart := parseClock(q.Start)
+	end, errEnd := parseClock(q.End)
+	if errStart != nil || errEnd != nil {
+		return false
+	}
+
+	local := now.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	if start <= end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// AlwaysNotifies reports whether the webhook receives notifications during quiet hours
+func (q *QuietHoursConfig) AlwaysNotifies(webhookURL string) bool {
+	for _, url := range q.AlwaysNotifyWebhookURLs {
+		if url == webhookURL {
+			return true
+		}
+	}
+	return false
+}
+
+// parseClock parses "HH:MM" into minutes since midnight
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// StormSuppressionConfig contains settings for collapsing mass alerts into one
+// cluster-wide alert when a large share of queues get stuck at once
+type StormSuppressionConfig struct {
+	Enabled          bool    `mapstructure:"enabled"`
+	ThresholdPercent float64 `mapstructure:"threshold_percent"`
+	MinQueues        int     `mapstructure:"min_queues"`
+}
+
+// AlertTypesConfig contains notification settings for alerts not tied to a queue
+type AlertTypesConfig struct {
+	Cluster     AlertTypeConfig `mapstructure:"cluster"`     // Cluster-wide problems from storm suppression
+	Connections AlertTypeConfig `mapstructure:"connections"` // Low protocol connection counts
+	VHosts      AlertTypeConfig `mapstructure:"vhosts"`      // Vhost-wide limits
+	Services    AlertTypeConfig `mapstructure:"services"`    // Service-wide limits across grouped queues
+	Churn       AlertTypeConfig `mapstructure:"churn"`       // Queue churn
+	Comparison  AlertTypeConfig `mapstructure:"comparison"`  // Queues diverging from their namesakes
+	DeadLetter  AlertTypeConfig `mapstructure:"dead_letter"` // Growing dead-letter queues
+	Auth        AlertTypeConfig `mapstructure:"auth"`        // Management API rejecting the monitor's credentials
+	Latency     AlertTypeConfig `mapstructure:"latency"`     // Notifications delivered slower than allowed
+}
+
+// AlertTypeConfig sets the cooldown, severity and routing of one alert type
+// Queue alert cooldowns and priority classes do not apply to these alerts
+type AlertTypeConfig struct {
+	Cooldown    time.Duration `mapstructure:"cooldown"`     // Minimum time between two firing notifications of the same alert, 0 for none
+	Priority    string        `mapstructure:"priority"`     // Overrides the rule's priority, which sets the severity; empty to keep it
+	WebhookURLs []string      `mapstructure:"webhook_urls"` // Replaces the priority class and global webhooks
+}
+
+// Get returns the settings of an alert type by name: cluster, connections, vhosts, services, churn, comparison, dead_letter, auth or latency
+func (c AlertTypesConfig) Get(alertType string) AlertTypeConfig {
+	switch alertType {
+	case "cluster":
+		return c.Cluster
+	case "connections":
+		return c.Connections
+	case "vhosts":
+		return c.VHosts
+	case "services":
+		return c.Services
+	case "churn":
+		return c.Churn
+	case "comparison":
+		return c.Comparison
+	case "dead_letter":
+		return c.DeadLetter
+	case "auth":
+		return c.Auth
+	case "latency":
+		return c.Latency
+	}
+	return AlertTypeConfig{}
 }
 
 // SlackConfig contains Slack notification settings
 type SlackConfig struct {
+	Enabled             bool                 `mapstructure:"enabled"`
+	WebhookURLs         []string             `mapstructure:"webhook_urls"`
+	Webhooks            []SlackWebhookConfig `mapstructure:"webhooks"` // Webhooks with per-webhook settings
+	AlertCooldown       time.Duration        `mapstructure:"alert_cooldown"`
+	SendRecovery        bool                 `mapstructure:"send_recovery"`
+	RecoveryCooldown    time.Duration        `mapstructure:"recovery_cooldown"`
+	SendRecovering      bool                 `mapstructure:"send_recovering"` // Notify when an alerting queue's backlog starts decreasing
+	Timeout             time.Duration        `mapstructure:"timeout"`
+	AckButton           bool                 `mapstructure:"ack_button"`
+	FalsePositiveButton bool                 `mapstructure:"false_positive_button"`
+	SigningSecret       string               `mapstructure:"signing_secret"`
+}
+
+// SlackWebhookConfig is a Slack webhook with settings for its audience
+// Settings apply wherever the URL is used: globally, for a priority class or for a team
+type SlackWebhookConfig struct {
+	URL         string   `mapstructure:"url"`
+	Channel     string   `mapstructure:"channel"`      // Channel override, honored by legacy webhooks
+	Mention     string   `mapstructure:"mention"`      // e.g. "@here" or "<!subteam^S0123ABC>"
+	MinSeverity string   `mapstructure:"min_severity"` // warning or critical, defaults to warning
+	Queues      []string `mapstructure:"queues"`       // Queue name patterns, all queues if empty
+}
+
+// Webhook returns the per-webhook settings of a URL, if any
+func (s *SlackConfig) Webhook(webhookURL string) (SlackWebhookConfig, bool) {
+	for _, webhook := range s.Webhooks {
+		if webhook.URL == webhookURL {
+			return webhook, true
+		}
+	}
+	return SlackWebhookConfig{}, false
+}
+
+// Accepts reports whether a webhook receives an alert of the given severity for a queue
+// Alerts not about a single queue, with an empty queue name, pass any queue filter
+func (w SlackWebhookConfig) Accepts(queueName, severity string) bool {
+	if w.MinSeverity == SeverityCritical && severity != SeverityCritical {
+		return false
+	}
+	if queueName == "" || len(w.Queues) == 0 {
+		return true
+	}
+	for _, pattern := range w.Queues {
+		if matched, _ := path.Match(pattern, queueName); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// GoogleChatConfig contains Google Chat incoming webhook settings
+// Cooldowns and recovery settings are shared with Slack
+type GoogleChatConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`
+	WebhookURLs []string      `mapstructure:"webhook_urls"`
+	Timeout     time.Duration `mapstructure:"timeout"`
+}
+
+// ZulipConfig contains Zulip bot settings
+// Cooldowns and recovery settings are shared with Slack
+type ZulipConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Site     string        `mapstructure:"site"` // e.g. https://example.zulipchat.com
+	BotEmail string        `mapstructure:"bot_email"`
+	APIKey   string        `mapstructure:"api_key"`
+	Stream   string        `mapstructure:"stream"`
+	Topic    string        `mapstructure:"topic"` // Defaults to the queue name
+	Timeout  time.Duration `mapstructure:"timeout"`
+}
+
+// TwilioConfig contains settings for last-resort SMS on critical alerts
+type TwilioConfig struct {
+	Enabled    bool          `mapstructure:"enabled"`
+	AccountSID string        `mapstructure:"account_sid"`
+	AuthToken  string        `mapstructure:"auth_token"`
+	From       string        `mapstructure:"from"`         // Twilio phone number in E.164 format
+	To         []string      `mapstructure:"to"`           // Recipient phone numbers in E.164 format
+	MaxPerHour int           `mapstructure:"max_per_hour"` // Messages allowed per rolling hour, one per recipient
+	Timeout    time.Duration `mapstructure:"timeout"`
+}
+
+// ServerConfig contains settings for the embedded HTTP server
+type ServerConfig struct {
+	Enabled       bool             `mapstructure:"enabled"`
+	ListenAddress string           `mapstructure:"listen_address"`
+	Debug         bool             `mapstructure:"debug"` // Expose /debug/pprof and /debug/state
+	Auth          ServerAuthConfig `mapstructure:"auth"`
+	TLS           ServerTLSConfig  `mapstructure:"tls"`
+}
+
+// ServerTLSConfig contains TLS settings for the embedded HTTP server
+// Setting client_ca_file requires clients to present a certificate signed by that CA (mTLS)
+type ServerTLSConfig struct {
+	CertFile     string `mapstructure:"cert_file"`
+	KeyFile      string `mapstructure:"key_file"`
+	ClientCAFile string `mapstructure:"client_ca_file"`
+}
+
+// Enabled reports whether the server should serve HTTPS
+func (t ServerTLSConfig) Enabled() bool {
+	return t.CertFile != ""
+}
+
+// Roles that API tokens can be granted, each including the permissions of the previous one
+const (
+	RoleReadOnly = "read_only" // View state
+	RoleSilencer = "silencer"  // Also create silences, acknowledgments and false positive marks
+	RoleAdmin    = "admin"     // Also trigger checks and use debug endpoints
+)
+
+// ServerAuthConfig contains token-based access control for the control API
+// Without tokens only read_only endpoints are served
+type ServerAuthConfig struct {
+	Tokens         []APITokenConfig `mapstructure:"tokens"`
+	ProtectMetrics bool             `mapstructure:"protect_metrics"` // Also require a read_only token for /metrics
+}
+
+// APITokenConfig grants a role to the holder of a bearer token
+type APITokenConfig struct {
+	Name  string `mapstructure:"name"` // Identity of the token holder
+	Token string `mapstructure:"token"`
+	Role  string `mapstructure:"role"`
+}
+
+// isValidLanguage reports whether a built-in notification template exists for the language
+func isValidLanguage(language string) bool {
+	switch language {
+	case "en", "nl", "de", "fr":
+		return true
+	default:
+		return false
+	}
+}
+
+// isValidRole reports whether the given name is a known API role
+func isValidRole(role string) bool {
+	switch role {
+	case RoleReadOnly, RoleSilencer, RoleAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
+// HeartbeatsConfig contains consumer heartbeat integration settings
+type HeartbeatsConfig struct {
+	Enabled bool          `mapstructure:"enabled"`
+	MaxAge  time.Duration `mapstructure:"max_age"`
+}
+
+// BackpressureConfig contains settings for the per-queue backpressure flag producers poll
+type BackpressureConfig struct {
+	Enabled          bool                    `mapstructure:"enabled"`
+	MaxMessagesReady int                     `mapstructure:"max_messages_ready"` // Also signal queues with a larger backlog, 0 for stuck queues only
+	Redis            BackpressureRedisConfig `mapstructure:"redis"`
+}
+
+// BackpressureRedisConfig contains settings for mirroring backpressure flags to Redis keys
+type BackpressureRedisConfig struct {
+	URL       string        `mapstructure:"url"`        // redis:// or rediss://, empty to only serve the HTTP endpoint
+	KeyPrefix string        `mapstructure:"key_prefix"` // Prepended to the queue name
+	TTL       time.Duration `mapstructure:"ttl"`        // Keys expire when the monitor stops updating them
+	Timeout   time.Duration `mapstructure:"timeout"`
+}
+
+// BrokerEventsConfig contains settings for correlating alerts with broker events
+type BrokerEventsConfig struct {
+	Enabled bool          `mapstructure:"enabled"`
+	Window  time.Duration `mapstructure:"window"`
+}
+
+// HistoryConfig contains settings for storing queue samples on disk
+// Stored history is used by the analyze-config command
+type HistoryConfig struct {
+	Enabled   bool          `mapstructure:"enabled"`
+	FilePath  string        `mapstructure:"file_path"`
+	Retention time.Duration `mapstructure:"retention"`
+}
+
+// TextfileConfig contains settings for exporting metrics to a file after every check
+// The file is meant for the node_exporter textfile collector
+type TextfileConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	FilePath string `mapstructure:"file_path"` // Must end in .prom to be picked up by the collector
+}
+
+// EventSinksConfig contains settings for publishing structured queue events to data platforms
+type EventSinksConfig struct {
+	Kafka       KafkaSinkConfig       `mapstructure:"kafka"`
+	NATS        NATSSinkConfig        `mapstructure:"nats"`
+	Webhook     WebhookSinkConfig     `mapstructure:"webhook"`
+	SNS         SNSSinkConfig         `mapstructure:"sns"`
+	EventBridge EventBridgeSinkConfig `mapstructure:"eventbridge"`
+	AWS         AWSConfig             `mapstructure:"aws"`
+	CloudEvents CloudEventsSinkConfig `mapstructure:"cloudevents"`
+	CheckEvents bool                  `mapstructure:"check_events"` // Also publish one event per check cycle
+	Timeout     time.Duration         `mapstructure:"timeout"`
+}
+
+// WebhookSinkConfig posts events as JSON to generic HTTP endpoints
+type WebhookSinkConfig struct {
+	Enabled       bool     `mapstructure:"enabled"`
+	URLs          []string `mapstructure:"urls"`
+	SigningSecret string   `mapstructure:"signing_secret"` // Signs requests with an HMAC header if set
+}
+
+// SNSSinkConfig publishes events to an Amazon SNS topic
+type SNSSinkConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	TopicARN string `mapstructure:"topic_arn"` // The topic's region is used
+}
+
+// EventBridgeSinkConfig puts events on an Amazon EventBridge event bus
+type EventBridgeSinkConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	EventBus string `mapstructure:"event_bus"` // Name or ARN
+	Source   string `mapstructure:"source"`    // Source field of the events
+}
+
+// AWSConfig contains settings shared by the AWS sinks
+// Credentials come from the default chain: environment, web identity, shared file, ECS or EC2 role
+type AWSConfig struct {
+	Region  string `mapstructure:"region"`  // Defaults to $AWS_REGION
+	Profile string `mapstructure:"profile"` // Shared credentials file profile, defaults to $AWS_PROFILE
+}
+
+// CloudEventsSinkConfig wraps published events in CloudEvents 1.0 envelopes
+type CloudEventsSinkConfig struct {
+	Enabled    bool   `mapstructure:"enabled"`
+	Source     string `mapstructure:"source"`      // CloudEvents source attribute
+	TypePrefix string `mapstructure:"type_prefix"` // Event types become <type_prefix>.queue_stuck etc.
+}
+
+// KafkaSinkConfig publishes events to a Kafka topic through the Confluent REST proxy
+type KafkaSinkConfig struct {
+	Enabled      bool   `mapstructure:"enabled"`
+	RestProxyURL string `mapstructure:"rest_proxy_url"`
+	Topic        string `mapstructure:"topic"`
+	Format       string `mapstructure:"format"` // json or avro (requires the schema registry)
+}
+
+// NATSSinkConfig publishes events to a NATS subject
+type NATSSinkConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	URL     string `mapstructure:"url"` // nats://[user:password@]host:port or tls://...
+	Subject string `mapstructure:"subject"`
+	Token   string `mapstructure:"token"`
+}
+
+// Enabled reports whether any event sink is configured
+func (e EventSinksConfig) Enabled() bool {
+	return e.Kafka.Enabled || e.NATS.Enabled || e.Webhook.Enabled || e.SNS.Enabled || e.EventBridge.Enabled
+}
+
+// FeedbackConfig contains settings for recording false positive alerts
+// Recorded marks are used by the analyze-config command
+type FeedbackConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	FilePath string `mapstructure:"file_path"`
+}
+
+// AuditConfig contains settings for the operator action audit log
+type AuditConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	FilePath string `mapstructure:"file_path"`
+}
+
+// SLOConfig contains settings for per-queue availability objectives
+// Availability is the share of the month a queue is not alerting
+type SLOConfig struct {
+	Enabled        bool          `mapstructure:"enabled"`
+	FilePath       string        `mapstructure:"file_path"`        // Current month's accounting
+	Target         float64       `mapstructure:"target"`           // Availability objective in percent, e.g. 99.5
+	Queues         []string      `mapstructure:"queues"`           // Queues with an objective, empty = all monitored queues
+	BurnRateWindow time.Duration `mapstructure:"burn_rate_window"` // Window burn rates are computed over
+	BurnRateAlert  float64       `mapstructure:"burn_rate_alert"`  // Alert above this burn rate (0 disables)
+	MonthlyReport  bool          `mapstructure:"monthly_report"`   // Send last month's report on the first check of a month
+}
+
+// CapacityConfig estimates each queue's sustainable consume rate from stored history
+// Utilisation is the publish rate relative to it, a signal before backlogs start
+type CapacityConfig struct {
 	Enabled          bool          `mapstructure:"enabled"`
-	WebhookURLs      []string      `mapstructure:"webhook_urls"`
-	AlertCooldown    time.Duration `mapstructure:"alert_cooldown"`
-	SendRecovery     bool          `mapstructure:"send_recovery"`
-	RecoveryCooldown time.Duration `mapstructure:"recovery_cooldown"`
-	Timeout          time.Duration `mapstructure:"timeout"`
+	Window           time.Duration `mapstructure:"window"`            // History estimates are based on
+	Refresh          time.Duration `mapstructure:"refresh"`           // How often estimates are recomputed from history
+	MinSamples       int           `mapstructure:"min_samples"`       // Busy samples a queue needs for an estimate
+	AlertUtilisation float64       `mapstructure:"alert_utilisation"` // Alert above this utilisation, e.g. 0.8 (0 disables)
+	ThresholdChecks  int           `mapstructure:"threshold_checks"`  // Consecutive checks above it before alerting
 }
 
-// Load reads and parses the configuration file
-func Load(configPath string) (*Config, error) {
-	v := viper.New()
+// ScalingConfig publishes desired consumer counts of scaling targets for autoscalers such as KEDA or the HPA
+type ScalingConfig struct {
+	Enabled   bool                  `mapstructure:"enabled"`
+	DrainTime time.Duration         `mapstructure:"drain_time"` // Time a backlog should drain in, unless set per target
+	Targets   []ScalingTargetConfig `mapstructure:"targets"`
+}
 
-	// Set defaults
-	setDefaults(v)
+// ScalingTargetConfig is a consumer deployment scaled on the queues it consumes from
+type ScalingTargetConfig struct {
+	Name         string        `mapstructure:"name"`
+	Queues       []string      `mapstructure:"queues"`        // Glob patterns of the queues the deployment consumes from
+	ConsumerRate float64       `mapstructure:"consumer_rate"` // Messages per second one consumer handles
+	DrainTime    time.Duration `mapstructure:"drain_time"`    // Overrides scaling.drain_time
+	MinConsumers int           `mapstructure:"min_consumers"`
+	MaxConsumers int           `mapstructure:"max_consumers"` // 0 for no upper bound
+}
 
-	// Set config file path
-	v.SetConfigFile(configPath)
-	v.SetConfigType("yaml")
+// Includes reports whether a queue is consumed by the target
+func (t ScalingTargetConfig) Includes(queueName string) bool {
+	return matchesPattern(t.Queues, queueName)
+}
+
+// GetDrainTime returns the time a target's backlog should drain in
+func (s ScalingConfig) GetDrainTime(target ScalingTargetConfig) time.Duration {
+	if target.DrainTime > 0 {
+		return target.DrainTime
+	}
+	return s.DrainTime
+}
+
+// ReportsConfig contains settings for weekly HTML reports rendered from stored history
+type ReportsConfig struct {
+	Enabled       bool              `mapstructure:"enabled"`
+	Weekday       string            `mapstructure:"weekday"`        // Day the report is generated, e.g. monday
+	Hour          int               `mapstructure:"hour"`           // Hour of the day in the display timezone
+	Window        time.Duration     `mapstructure:"window"`         // History covered by a report
+	OutputDir     string            `mapstructure:"output_dir"`     // Directory reports are written to, empty to only email them
+	PDFCommand    []string          `mapstructure:"pdf_command"`    // Converts the HTML to PDF, called with the HTML and PDF paths appended
+	BaselineWeeks int               `mapstructure:"baseline_weeks"` // Previous weeks the report is compared with, 0 disables
+	Email         ReportEmailConfig `mapstructure:"email"`
+}
+
+// ReportEmailConfig contains the SMTP settings reports are emailed with
+type ReportEmailConfig struct {
+	SMTPHost string   `mapstructure:"smtp_host"`
+	SMTPPort int      `mapstructure:"smtp_port"` // STARTTLS is used when the server offers it
+	Username string   `mapstructure:"username"`  // Empty to send without authentication
+	Password string   `mapstructure:"password"`
+	From     string   `mapstructure:"from"`
+	To       []string `mapstructure:"to"` // Recipients, empty to not email reports
+}
+
+// Enabled reports whether reports are emailed
+func (e ReportEmailConfig) Enabled() bool {
+	return len(e.To) > 0
+}
+
+// Previous returns the most recent scheduled report time at or before now
+func (r ReportsConfig) Previous(now time.Time, location *time.Location) time.Time {
+	local := now.In(location)
+	scheduled := time.Date(local.Year(), local.Month(), local.Day(), r.Hour, 0, 0, 0, location)
+	weekday, _ := parseWeekday(r.Weekday)
+	scheduled = scheduled.AddDate(0, 0, -int((local.Weekday()-weekday+7)%7))
+	if scheduled.After(now) {
+		scheduled = scheduled.AddDate(0, 0, -7)
+	}
+	return scheduled
+}
+
+// parseWeekday parses a lowercase or capitalized English day name
+func parseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(name, day.String()) {
+			return day, true
+		}
+	}
+	return time.Sunday, false
+}
+
+// Tracks reports whether a queue has an availability objective
+func (s SLOConfig) Tracks(queueName string) bool {
+	if len(s.Queues) == 0 {
+		return true
+	}
+	for _, name := range s.Queues {
+		if name == queueName {
+			return true
+		}
+	}
+	return false
+}
+
+// PluginConfig loads a Go plugin adding a detection check or notifier, see the pluginapi package
+type PluginConfig struct {
+	Name    string            `mapstructure:"name"`
+	Path    string            `mapstructure:"path"`    // Shared object built with go build -buildmode=plugin
+	Options map[string]string `mapstructure:"options"` // Passed to the plugin's Init; keys are lowercased
+}
+
+// ShardingConfig splits the queues of a vhost between several monitor instances
+// Each instance runs with the same count and its own index
+type ShardingConfig struct {
+	Count int `mapstructure:"count"` // Number of instances, 1 disables sharding
+	Index int `mapstructure:"index"` // This instance's shard, 0 to count-1
+}
+
+// Enabled reports whether the queues are split between instances
+func (s ShardingConfig) Enabled() bool {
+	return s.Count > 1
+}
+
+// Owns reports whether this instance monitors the queue
+// Queues are assigned by a hash of their name, so every instance agrees without coordination
+func (s ShardingConfig) Owns(queueName string) bool {
+	if !s.Enabled() {
+		return true
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(queueName))
+	return int(hash.Sum32()%uint32(s.Count)) == s.Index
+}
+
+// OwnsClusterAlerts reports whether this instance sends alerts that are not tied to a queue
+// Only the first shard does, so they are not sent once per instance
+func (s ShardingConfig) OwnsClusterAlerts() bool {
+	return s.Index == 0
+}
+
+// EnrichmentConfig contains settings for adding broker details to stuck queue alerts
+type EnrichmentConfig struct {
+	ConsumerDetails bool            `mapstructure:"consumer_details"` // List the queue's consumers and their connections
+	PeekMessages    int             `mapstructure:"peek_messages"`    // Number of head messages to describe (0 disables)
+	IdleConnections bool            `mapstructure:"idle_connections"` // Check whether all consumers of a stuck queue share one idle connection
+	Ownership       OwnershipConfig `mapstructure:"ownership"`
+}
+
+// OwnershipConfig contains settings for resolving queue owners from a service catalog
+type OwnershipConfig struct {
+	Enabled   bool                     `mapstructure:"enabled"`
+	Source    string                   `mapstructure:"source"` // "file" or "backstage"
+	File      string                   `mapstructure:"file"`   // YAML mapping for the file source
+	Backstage BackstageOwnershipConfig `mapstructure:"backstage"`
+	Refresh   time.Duration            `mapstructure:"refresh"` // How often the source is refetched
+	Timeout   time.Duration            `mapstructure:"timeout"`
+}
+
+// BackstageOwnershipConfig locates a Backstage catalog and its ownership annotations
+type BackstageOwnershipConfig struct {
+	URL                  string `mapstructure:"url"`
+	Token                string `mapstructure:"token"`
+	QueueAnnotation      string `mapstructure:"queue_annotation"`      // Component annotation listing its queues
+	SlackAnnotation      string `mapstructure:"slack_annotation"`      // Group annotation holding the Slack user group
+	EscalationAnnotation string `mapstructure:"escalation_annotation"` // Group annotation holding the escalation contact
+}
+
+// maxPeekMessages limits how many messages are fetched and requeued per alert
+const maxPeekMessages = 10
+
+// QuarantineConfig contains settings for moving poison messages out of queues in a redelivery storm
+type QuarantineConfig struct {
+	Enabled     bool   `mapstructure:"enabled"`
+	Queue       string `mapstructure:"queue"`        // Target queue, must already exist
+	MaxMessages int    `mapstructure:"max_messages"` // Head messages moved per incident
+	DryRun      bool   `mapstructure:"dry_run"`      // Only report what would be moved
+}
+
+// StreamsConfig contains settings for monitoring stream queues by consumer offset lag
+type StreamsConfig struct {
+	Enabled         bool     `mapstructure:"enabled"`
+	Names           []string `mapstructure:"names"`             // Streams to monitor, empty = all streams in the vhost
+	MaxOffsetLag    int64    `mapstructure:"max_offset_lag"`    // Alert when a consumer group lags more (0 disables)
+	LagGrowthChecks int      `mapstructure:"lag_growth_checks"` // Alert when lag grows this many checks in a row (0 disables)
+	AlertOnDetach   bool     `mapstructure:"alert_on_detach"`   // Alert when a consumer group disappears
+}
+
+// ChurnConfig alerts on the cluster-wide rate queues are created and deleted at
+type ChurnConfig struct {
+	Enabled         bool    `mapstructure:"enabled"`
+	MaxCreated      float64 `mapstructure:"max_created_per_minute"` // Queues created per minute (0 disables)
+	MaxDeleted      float64 `mapstructure:"max_deleted_per_minute"` // Queues deleted per minute (0 disables)
+	ThresholdChecks int     `mapstructure:"threshold_checks"`
+	Priority        string  `mapstructure:"priority"`
+}
+
+// ComparisonConfig compares monitored queues with their namesakes in another vhost or cluster
+// Used during blue/green broker migrations, where both sides should carry the same traffic
+type ComparisonConfig struct {
+	Enabled         bool           `mapstructure:"enabled"`
+	Target          RabbitMQConfig `mapstructure:"target"`       // Unset fields are taken from rabbitmq
+	Queues          []string       `mapstructure:"queues"`       // Glob patterns of queues to compare, empty = all monitored queues
+	Tolerance       float64        `mapstructure:"tolerance"`    // Largest relative difference, 0.5 allows the smaller side to be half the larger
+	MinMessages     int            `mapstructure:"min_messages"` // Backlog differences below this are ignored
+	MinRate         float64        `mapstructure:"min_rate"`     // Rate differences below this are ignored (msg/s)
+	ThresholdChecks int            `mapstructure:"threshold_checks"`
+	Priority        string         `mapstructure:"priority"`
+}
+
+// TargetRabbitMQ returns the connection to compare with, taking unset fields from the monitored broker
+// TLS follows the host: it is only inherited when the target names no host of its own
+func (c ComparisonConfig) TargetRabbitMQ(source RabbitMQConfig) RabbitMQConfig {
+	target := c.Target
+	if target.Host == "" {
+		target.Host = source.Host
+		target.UseTLS = source.UseTLS
+	}
+	if target.Port == 0 {
+		target.Port = source.Port
+	}
+	if target.Username == "" {
+		target.Username = source.Username
+	}
+	if target.Password == "" {
+		target.Password = source.Password
+	}
+	if target.VHost == "" {
+		target.VHost = source.VHost
+	}
+	return target
+}
+
+// DeadLetterConfig pairs queues with their dead-letter queues by naming convention, such as orders and orders.dlq
+// Alerts on a queue include the depth of its dead-letter queue, and growing dead-letter queues alert on their own
+type DeadLetterConfig struct {
+	Enabled         bool     `mapstructure:"enabled"`
+	Suffixes        []string `mapstructure:"suffixes"`   // Appended to a queue name to find its dead-letter queue, tried in order
+	MinGrowth       int      `mapstructure:"min_growth"` // Messages gained since the previous check that count as growth
+	ThresholdChecks int      `mapstructure:"threshold_checks"`
+	Priority        string   `mapstructure:"priority"`
+}
+
+// Compares reports whether a queue is compared with its namesake
+func (c ComparisonConfig) Compares(queueName string) bool {
+	if len(c.Queues) == 0 {
+		return true
+	}
+	for _, pattern := range c.Queues {
+		if matched, _ := path.Match(pattern, queueName); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Monitors reports whether the named stream is monitored
+func (s StreamsConfig) Monitors(name string) bool {
+	if len(s.Names) == 0 {
+		return true
+	}
+	for _, stream := range s.Names {
+		if stream == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ProtocolConfig monitors the connections and auto-created queues of the MQTT or STOMP plugin
+type ProtocolConfig struct {
+	Protocol        string   `mapstructure:"protocol"`        // mqtt or stomp
+	MinConnections  int      `mapstructure:"min_connections"` // Alert when fewer connections are open (0 disables)
+	QueuePatterns   []string `mapstructure:"queue_patterns"`  // Glob patterns of backing queues, defaults per protocol
+	Priority        string   `mapstructure:"priority"`
+	ThresholdChecks *int     `mapstructure:"threshold_checks,omitempty"`
+	MinMessageCount *int     `mapstructure:"min_message_count,omitempty"`
+	MinConsumeRate  *float64 `mapstructure:"min_consume_rate,omitempty"`
+}
+
+// defaultQueuePatterns are the names of queues the plugins create for subscriptions
+var defaultQueuePatterns = map[string][]string{
+	"mqtt":  {"mqtt-subscription-*"},
+	"stomp": {"stomp-subscription-*"},
+}
+
+// Patterns returns the configured queue patterns or the protocol's default
+func (p ProtocolConfig) Patterns() []string {
+	if len(p.QueuePatterns) > 0 {
+		return p.QueuePatterns
+	}
+	return defaultQueuePatterns[p.Protocol]
+}
+
+// Matches reports whether a queue was created for this protocol
+func (p ProtocolConfig) Matches(queueName string) bool {
+	for _, pattern := range p.Patterns() {
+		if matched, _ := path.Match(pattern, queueName); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// GetProtocolDetectionConfig returns the detection config for queues matching a protocol rule
+// Layers global defaults, priority class and the rule's overrides in that order
+func (m *MonitorConfig) GetProtocolDetectionConfig(p ProtocolConfig) DetectionConfig {
+	config := m.GetClassDetectionConfig(p.Priority)
+	if p.ThresholdChecks != nil {
+		config.ThresholdChecks = *p.ThresholdChecks
+	}
+	if p.MinMessageCount != nil {
+		config.MinMessageCount = *p.MinMessageCount
+	}
+	if p.MinConsumeRate != nil {
+		config.MinConsumeRate = *p.MinConsumeRate
+	}
+	return config
+}
+
+// VHostRuleConfig alerts on the totals of a whole vhost, for tenants that own one
+// Limits left at 0 are not checked
+type VHostRuleConfig struct {
+	VHost            string  `mapstructure:"vhost"`
+	MaxMessages      int     `mapstructure:"max_messages"`       // Ready plus unacknowledged messages
+	MaxMessagesReady int     `mapstructure:"max_messages_ready"` // Messages waiting for a consumer
+	MaxPublishRate   float64 `mapstructure:"max_publish_rate"`
+	MinConsumeRate   float64 `mapstructure:"min_consume_rate"` // Only checked while messages are ready
+	Priority         string  `mapstructure:"priority"`
+	ThresholdChecks  *int    `mapstructure:"threshold_checks,omitempty"`
+}
+
+// GetVHostThresholdChecks returns the consecutive breaching checks before a vhost rule alerts
+// Falls back to the rule's priority class or the global detection default
+func (m *MonitorConfig) GetVHostThresholdChecks(rule VHostRuleConfig) int {
+	if rule.ThresholdChecks != nil {
+		return *rule.ThresholdChecks
+	}
+	return m.GetClassDetectionConfig(rule.Priority).ThresholdChecks
+}
+
+// ServiceConfig groups the queues of competing consumers into one logical service, such as orders-0 to orders-15
+// The service is judged by its totals across the queues; limits left at 0 are not checked
+type ServiceConfig struct {
+	Name             string        `mapstructure:"name"`
+	Queues           []string      `mapstructure:"queues"`             // Glob patterns of the service's queues
+	MaxMessagesReady int           `mapstructure:"max_messages_ready"` // Total backlog across the queues
+	MinConsumers     int           `mapstructure:"min_consumers"`      // Total consumers across the queues
+	MinConsumeRate   float64       `mapstructure:"min_consume_rate"`   // Only checked while messages are ready
+	MaxLag           time.Duration `mapstructure:"max_lag"`            // Time to drain the total backlog at the total consume rate
+	Priority         string        `mapstructure:"priority"`
+	ThresholdChecks  *int          `mapstructure:"threshold_checks,omitempty"`
+
+	// Outlier detection flags a single shard stalling while its siblings are healthy
+	OutlierFactor      float64 `mapstructure:"outlier_factor"`                 // Backlog multiple of the siblings' median that makes a shard an outlier, 0 disables
+	OutlierMinMessages *int    `mapstructure:"outlier_min_messages,omitempty"` // Shards with fewer ready messages are never outliers
+}
+
+// Includes reports whether a queue belongs to the service
+func (s ServiceConfig) Includes(queueName string) bool {
+	for _, pattern := range s.Queues {
+		if matched, _ := path.Match(pattern, queueName); matched {
+			return true
+		}
+	}
+	return false
+}
 
-	// Read config file
-	if err := v.ReadInConfig(); err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
+// GetServiceThresholdChecks returns the consecutive breaching checks before a service alerts
+// Falls back to the service's priority class or the global detection default
+func (m *MonitorConfig) GetServiceThresholdChecks(service ServiceConfig) int {
+	if service.ThresholdChecks != nil {
+		return *service.ThresholdChecks
+	}
+	return m.GetClassDetectionConfig(service.Priority).ThresholdChecks
+}
+
+// GetServiceOutlierMinMessages returns the ready messages a shard needs before it can be an outlier
+// Falls back to the service's priority class or the global detection default
+func (m *MonitorConfig) GetServiceOutlierMinMessages(service ServiceConfig) int {
+	if service.OutlierMinMessages != nil {
+		return *service.OutlierMinMessages
+	}
+	return m.GetClassDetectionConfig(service.Priority).MinMessageCount
+}
+
+// maxQuarantineMessages limits how many messages are moved per incident
+const maxQuarantineMessages = 10
+
+// PrivacyConfig contains redaction rules applied to enrichment data before it leaves the monitor
+// Message payloads are never included
+type PrivacyConfig struct {
+	HeaderAllowlist []string `mapstructure:"header_allowlist"` // Message headers that may be shown; all others are dropped
+	MaskIPs         bool     `mapstructure:"mask_ips"`         // Mask consumer peer addresses
+}
+
+// Load reads and parses the configuration file
+func Load(configPath string) (*Config, error) {
+	return LoadInstance(configPath, "")
+}
+
+// decode unmarshals, completes and validates a config read into v
+func decode(v *viper.Viper) (*Config, error) {
+	// Named instances on one host keep their logs and state apart
+	if err := applyInstance(v); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
 	// Unmarshal config
@@ -120,6 +1405,30 @@ func Load(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	// Credentials kept in secret files replace the inline ones
+	if err := cfg.RabbitMQ.LoadCredentials(); err != nil {
+		return nil, err
+	}
+
+	// Structured webhooks are global webhooks too
+	for _, webhook := range cfg.Notifications.Slack.Webhooks {
+		if webhook.URL != "" && !slices.Contains(cfg.Notifications.Slack.WebhookURLs, webhook.URL) {
+			cfg.Notifications.Slack.WebhookURLs = append(cfg.Notifications.Slack.WebhookURLs, webhook.URL)
+		}
+	}
+
+	// Merge per-team config fragments
+	if cfg.Monitor.TeamsDir != "" {
+		teams, err := loadTeams(cfg.Monitor.TeamsDir)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Teams = teams
+		if err := mergeTeams(&cfg); err != nil {
+			return nil, fmt.Errorf("invalid configuration: %w", err)
+		}
+	}
+
 	// Validate config
 	if err := validate(&cfg); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
@@ -136,21 +1445,190 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("rabbitmq.password", "guest")
 	v.SetDefault("rabbitmq.vhost", "/")
 	v.SetDefault("rabbitmq.use_tls", false)
+	v.SetDefault("rabbitmq.amqp_port", 0)
 
 	v.SetDefault("monitor.interval", "60s")
+	v.SetDefault("monitor.backfill_history", false)
+	v.SetDefault("monitor.burst_interval", 0)
+	v.SetDefault("monitor.max_missed_checks", 3)
+	v.SetDefault("monitor.idle_backoff.enabled", false)
+	v.SetDefault("monitor.idle_backoff.after", "30m")
+	v.SetDefault("monitor.idle_backoff.max_interval", "10m")
+	v.SetDefault("monitor.clock_skew.enabled", true)
+	v.SetDefault("monitor.clock_skew.max_skew", "30s")
+	v.SetDefault("monitor.node_maintenance.enabled", true)
+	v.SetDefault("monitor.node_maintenance.action", MaintenanceAnnotate)
+	v.SetDefault("monitor.node_maintenance.grace", "10m")
+	v.SetDefault("monitor.auto_exclude.exclusive", true)
+	v.SetDefault("monitor.auto_exclude.auto_delete", true)
+	v.SetDefault("monitor.auto_exclude.patterns", []string{"amq.gen-*"})
 	v.SetDefault("monitor.detection.threshold_checks", 3)
 	v.SetDefault("monitor.detection.min_message_count", 10)
 	v.SetDefault("monitor.detection.min_consume_rate", 0.1)
+	v.SetDefault("monitor.detection.detect_ack_stall", true)
+	v.SetDefault("monitor.detection.redelivery_storm_ratio", 0)
+	v.SetDefault("monitor.detection.min_health_score", 0)
+	v.SetDefault("monitor.detection.max_expire_rate", 0)
 
 	v.SetDefault("logging.file_path", "/var/log/rabbitmq-monitor/stuck-queues.log")
 	v.SetDefault("logging.level", "info")
 	v.SetDefault("logging.format", "json")
+	v.SetDefault("logging.sampling.interval", "1m")
+	v.SetDefault("logging.sampling.messages", []string{"Checking queue", "Fetched queues", "Monitoring protocol queue", "Queue reports no priority bands"})
 
 	v.SetDefault("notifications.slack.enabled", false)
 	v.SetDefault("notifications.slack.alert_cooldown", "15m")
 	v.SetDefault("notifications.slack.send_recovery", true)
+	v.SetDefault("notifications.slack.send_recovering", false)
 	v.SetDefault("notifications.slack.recovery_cooldown", "5m")
 	v.SetDefault("notifications.slack.timeout", "10s")
+	v.SetDefault("notifications.startup_check", "off")
+	v.SetDefault("notifications.max_delivery_latency", "1m")
+	v.SetDefault("notifications.google_chat.enabled", false)
+	v.SetDefault("notifications.google_chat.timeout", "10s")
+	v.SetDefault("notifications.zulip.enabled", false)
+	v.SetDefault("notifications.zulip.timeout", "10s")
+	v.SetDefault("notifications.twilio.enabled", false)
+	v.SetDefault("notifications.twilio.max_per_hour", 5)
+	v.SetDefault("notifications.twilio.timeout", "10s")
+	v.SetDefault("notifications.storm_suppression.enabled", false)
+	v.SetDefault("notifications.storm_suppression.threshold_percent", 50.0)
+	v.SetDefault("notifications.storm_suppression.min_queues", 3)
+	v.SetDefault("notifications.alert_types.cluster.priority", PriorityCritical)
+	v.SetDefault("notifications.quiet_hours.enabled", false)
+	v.SetDefault("notifications.quiet_hours.start", "22:00")
+	v.SetDefault("notifications.quiet_hours.end", "07:00")
+	v.SetDefault("notifications.quiet_hours.timezone", "Local")
+	v.SetDefault("notifications.acks.enabled", false)
+	v.SetDefault("notifications.reminders.enabled", false)
+	v.SetDefault("notifications.reminders.interval", "2h")
+	v.SetDefault("notifications.self_test.enabled", false)
+	v.SetDefault("notifications.self_test.interval", "168h")
+	v.SetDefault("notifications.self_test.queue_name", "rmq-monitor.self-test")
+
+	v.SetDefault("notifications.display.timezone", "UTC")
+	v.SetDefault("notifications.display.time_format", "2006-01-02 15:04:05 MST")
+	v.SetDefault("notifications.display.language", "en")
+
+	v.SetDefault("server.enabled", false)
+	v.SetDefault("server.listen_address", ":9090")
+	v.SetDefault("server.debug", false)
+
+	v.SetDefault("heartbeats.enabled", false)
+	v.SetDefault("heartbeats.max_age", "2m")
+	v.SetDefault("backpressure.enabled", false)
+	v.SetDefault("backpressure.max_messages_ready", 0)
+	v.SetDefault("backpressure.redis.key_prefix", "rabbitmq-monitor:backpressure:")
+	v.SetDefault("backpressure.redis.ttl", "5m")
+	v.SetDefault("backpressure.redis.timeout", "2s")
+
+	v.SetDefault("broker_events.enabled", false)
+	v.SetDefault("broker_events.window", "15m")
+
+	v.SetDefault("history.enabled", false)
+	v.SetDefault("history.file_path", "/var/lib/rabbitmq-monitor/history.jsonl")
+	v.SetDefault("history.retention", "168h")
+
+	v.SetDefault("textfile.enabled", false)
+	v.SetDefault("textfile.file_path", "/var/lib/node_exporter/textfile_collector/rmq_monitor.prom")
+
+	v.SetDefault("event_sinks.check_events", false)
+	v.SetDefault("event_sinks.timeout", "10s")
+	v.SetDefault("event_sinks.kafka.enabled", false)
+	v.SetDefault("event_sinks.kafka.topic", "rabbitmq-queue-events")
+	v.SetDefault("event_sinks.kafka.format", "json")
+	v.SetDefault("event_sinks.webhook.enabled", false)
+	v.SetDefault("event_sinks.sns.enabled", false)
+	v.SetDefault("event_sinks.eventbridge.enabled", false)
+	v.SetDefault("event_sinks.eventbridge.event_bus", "default")
+	v.SetDefault("event_sinks.eventbridge.source", "go-rmq-monitor")
+	v.SetDefault("event_sinks.cloudevents.enabled", false)
+	v.SetDefault("event_sinks.cloudevents.source", "/go-rmq-monitor")
+	v.SetDefault("event_sinks.cloudevents.type_prefix", "io.go-rmq-monitor")
+	v.SetDefault("event_sinks.nats.enabled", false)
+	v.SetDefault("event_sinks.nats.url", "nats://localhost:4222")
+	v.SetDefault("event_sinks.nats.subject", "rabbitmq.queue.events")
+
+	v.SetDefault("feedback.enabled", false)
+	v.SetDefault("feedback.file_path", "/var/lib/rabbitmq-monitor/false_positives.jsonl")
+
+	v.SetDefault("enrichment.consumer_details", false)
+	v.SetDefault("enrichment.peek_messages", 0)
+	v.SetDefault("enrichment.idle_connections", true)
+	v.SetDefault("enrichment.ownership.enabled", false)
+	v.SetDefault("enrichment.ownership.source", "file")
+	v.SetDefault("enrichment.ownership.refresh", "10m")
+	v.SetDefault("enrichment.ownership.timeout", "10s")
+	v.SetDefault("enrichment.ownership.backstage.queue_annotation", "rabbitmq.com/queues")
+	v.SetDefault("enrichment.ownership.backstage.slack_annotation", "slack.com/user-group")
+	v.SetDefault("enrichment.ownership.backstage.escalation_annotation", "escalation/contact")
+
+	v.SetDefault("streams.enabled", false)
+	v.SetDefault("streams.names", []string{})
+	v.SetDefault("streams.max_offset_lag", 100000)
+	v.SetDefault("streams.lag_growth_checks", 5)
+	v.SetDefault("streams.alert_on_detach", true)
+
+	v.SetDefault("churn.enabled", false)
+	v.SetDefault("churn.max_created_per_minute", 1000)
+	v.SetDefault("churn.max_deleted_per_minute", 1000)
+	v.SetDefault("churn.threshold_checks", 2)
+
+	v.SetDefault("comparison.enabled", false)
+	v.SetDefault("comparison.tolerance", 0.5)
+	v.SetDefault("comparison.min_messages", 100)
+	v.SetDefault("comparison.min_rate", 1)
+	v.SetDefault("comparison.threshold_checks", 3)
+
+	v.SetDefault("dead_letters.enabled", false)
+	v.SetDefault("dead_letters.suffixes", []string{".dlq"})
+	v.SetDefault("dead_letters.min_growth", 1)
+	v.SetDefault("dead_letters.threshold_checks", 1)
+
+	v.SetDefault("quarantine.enabled", false)
+	v.SetDefault("quarantine.max_messages", 1)
+	v.SetDefault("quarantine.dry_run", true)
+
+	v.SetDefault("privacy.header_allowlist", []string{})
+	v.SetDefault("privacy.mask_ips", true)
+
+	v.SetDefault("slo.enabled", false)
+	v.SetDefault("slo.file_path", "/var/lib/rabbitmq-monitor/slo.json")
+	v.SetDefault("slo.target", 99.5)
+	v.SetDefault("slo.queues", []string{})
+	v.SetDefault("slo.burn_rate_window", "1h")
+	v.SetDefault("slo.burn_rate_alert", 14.4)
+	v.SetDefault("slo.monthly_report", true)
+
+	v.SetDefault("capacity.enabled", false)
+	v.SetDefault("capacity.window", "168h")
+	v.SetDefault("capacity.refresh", "1h")
+	v.SetDefault("capacity.min_samples", 20)
+	v.SetDefault("capacity.alert_utilisation", 0)
+	v.SetDefault("capacity.threshold_checks", 10)
+	v.SetDefault("scaling.enabled", false)
+	v.SetDefault("scaling.drain_time", "5m")
+
+	v.SetDefault("reports.enabled", false)
+	v.SetDefault("reports.weekday", "monday")
+	v.SetDefault("reports.hour", 9)
+	v.SetDefault("reports.window", "168h")
+	v.SetDefault("reports.output_dir", "/var/lib/rabbitmq-monitor/reports")
+	v.SetDefault("reports.pdf_command", []string{})
+	v.SetDefault("reports.baseline_weeks", 4)
+	v.SetDefault("reports.email.smtp_port", 587)
+	v.SetDefault("reports.email.to", []string{})
+
+	v.SetDefault("silence_sync.enabled", false)
+	v.SetDefault("silence_sync.queue_label", "queue")
+	v.SetDefault("silence_sync.default_duration", "4h")
+	v.SetDefault("silence_sync.pagerduty_services", map[string][]string{})
+
+	v.SetDefault("sharding.count", 1)
+	v.SetDefault("sharding.index", 0)
+
+	v.SetDefault("audit.enabled", false)
+	v.SetDefault("audit.file_path", "/var/lib/rabbitmq-monitor/audit.jsonl")
 }
 
 // validate performs basic validation on the configuration
@@ -161,19 +1639,848 @@ func validate(cfg *Config) error {
 	if cfg.RabbitMQ.Port <= 0 || cfg.RabbitMQ.Port > 65535 {
 		return fmt.Errorf("rabbitmq.port must be between 1 and 65535")
 	}
+	if cfg.RabbitMQ.AMQPPort < 0 || cfg.RabbitMQ.AMQPPort > 65535 {
+		return fmt.Errorf("rabbitmq.amqp_port must be between 0 and 65535")
+	}
 	if cfg.Monitor.Interval <= 0 {
 		return fmt.Errorf("monitor.interval must be positive")
 	}
+	if cfg.Monitor.BurstInterval < 0 {
+		return fmt.Errorf("monitor.burst_interval must not be negative")
+	}
+	if cfg.Monitor.BurstInterval >= cfg.Monitor.Interval {
+		return fmt.Errorf("monitor.burst_interval must be shorter than monitor.interval")
+	}
+	if cfg.Monitor.MaxMissedChecks < 0 {
+		return fmt.Errorf("monitor.max_missed_checks must not be negative")
+	}
+	if cfg.Monitor.IdleBackoff.Enabled {
+		if cfg.Monitor.IdleBackoff.After <= 0 {
+			return fmt.Errorf("monitor.idle_backoff.after must be positive")
+		}
+		if cfg.Monitor.IdleBackoff.MaxInterval < cfg.Monitor.Interval {
+			return fmt.Errorf("monitor.idle_backoff.max_interval must be at least monitor.interval")
+		}
+	}
+	if cfg.Monitor.ClockSkew.Enabled && cfg.Monitor.ClockSkew.MaxSkew <= 0 {
+		return fmt.Errorf("monitor.clock_skew.max_skew must be positive")
+	}
+	if cfg.Monitor.NodeMaintenance.Enabled {
+		switch cfg.Monitor.NodeMaintenance.Action {
+		case MaintenanceAnnotate, MaintenanceSuppress:
+		default:
+			return fmt.Errorf("monitor.node_maintenance.action must be annotate or suppress")
+		}
+		if cfg.Monitor.NodeMaintenance.Grace < 0 {
+			return fmt.Errorf("monitor.node_maintenance.grace must not be negative")
+		}
+	}
+	for _, pattern := range cfg.Monitor.AutoExclude.Patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("monitor.auto_exclude has invalid pattern %q: %w", pattern, err)
+		}
+	}
+	ruleNames := make(map[string]bool)
+	for i, rule := range cfg.Monitor.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("monitor.rules[%d]: name is required", i)
+		}
+		if ruleNames[rule.Name] {
+			return fmt.Errorf("monitor.rules[%d]: duplicate rule name %q", i, rule.Name)
+		}
+		ruleNames[rule.Name] = true
+		if _, err := rules.Compile(rule.Expression); err != nil {
+			return fmt.Errorf("monitor.rules[%d] (%s): invalid expression: %w", i, rule.Name, err)
+		}
+		for _, pattern := range rule.Queues {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("monitor.rules[%d] (%s) has invalid pattern %q: %w", i, rule.Name, pattern, err)
+			}
+		}
+	}
 	if cfg.Monitor.Detection.ThresholdChecks < 1 {
 		return fmt.Errorf("monitor.detection.threshold_checks must be at least 1")
 	}
-	if cfg.Logging.FilePath == "" {
-		return fmt.Errorf("logging.file_path is required")
+	for name, class := range cfg.Monitor.PriorityClasses {
+		if !isValidPriority(name) {
+			return fmt.Errorf("monitor.priority_classes.%s is not a valid priority class (critical, high, normal, low)", name)
+		}
+		if class.ThresholdChecks != nil && *class.ThresholdChecks < 1 {
+			return fmt.Errorf("monitor.priority_classes.%s.threshold_checks must be at least 1", name)
+		}
+	}
+	for name, profile := range cfg.Monitor.Profiles {
+		if profile.ThresholdChecks != nil && *profile.ThresholdChecks < 1 {
+			return fmt.Errorf("monitor.profiles.%s.threshold_checks must be at least 1", name)
+		}
+		if profile.CheckInterval != nil && *profile.CheckInterval <= 0 {
+			return fmt.Errorf("monitor.profiles.%s.check_interval must be positive", name)
+		}
+	}
+	for _, queue := range cfg.Monitor.Queues {
+		if queue.Priority != "" && !isValidPriority(queue.Priority) {
+			return fmt.Errorf("queue %s has invalid priority %q (critical, high, normal, low)", queue.Name, queue.Priority)
+		}
+		for _, dependency := range queue.DependsOn {
+			if dependency == queue.Name {
+				return fmt.Errorf("queue %s cannot depend on itself", queue.Name)
+			}
+		}
+		if queue.Profile != "" {
+			if _, exists := cfg.Monitor.Profiles[queue.Profile]; !exists {
+				return fmt.Errorf("queue %s references unknown profile %q", queue.Name, queue.Profile)
+			}
+		}
+		if queue.HighPriority != nil && (*queue.HighPriority < 1 || *queue.HighPriority > 255) {
+			return fmt.Errorf("queue %s high_priority must be between 1 and 255", queue.Name)
+		}
+		if queue.MaxHighPriorityDepth != nil {
+			if queue.HighPriority == nil {
+				return fmt.Errorf("queue %s max_high_priority_depth requires high_priority", queue.Name)
+			}
+			if *queue.MaxHighPriorityDepth < 0 {
+				return fmt.Errorf("queue %s max_high_priority_depth must not be negative", queue.Name)
+			}
+		}
+		if queue.ExpectedConsumers != nil && *queue.ExpectedConsumers < 0 {
+			return fmt.Errorf("queue %s expected_consumers must not be negative", queue.Name)
+		}
+		if identities := queue.ConsumerIdentities; identities != nil {
+			if len(identities.Applications) == 0 && len(identities.Users) == 0 {
+				return fmt.Errorf("queue %s consumer_identities must list applications or users", queue.Name)
+			}
+			for _, pattern := range append(append([]string(nil), identities.Applications...), identities.Users...) {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return fmt.Errorf("queue %s has invalid consumer identity pattern %q: %w", queue.Name, pattern, err)
+				}
+			}
+		}
+		if queue.MinHealthScore != nil && (*queue.MinHealthScore < 0 || *queue.MinHealthScore > 100) {
+			return fmt.Errorf("queue %s min_health_score must be between 0 and 100", queue.Name)
+		}
+		if queue.MinPublishRate != nil && *queue.MinPublishRate < 0 {
+			return fmt.Errorf("queue %s min_publish_rate must not be negative", queue.Name)
+		}
+		if queue.PublishWindow != nil {
+			if queue.MinPublishRate == nil {
+				return fmt.Errorf("queue %s publish_window requires min_publish_rate", queue.Name)
+			}
+			if *queue.PublishWindow < 0 {
+				return fmt.Errorf("queue %s publish_window must not be negative", queue.Name)
+			}
+		}
+		if queue.MaxExpireRate != nil && *queue.MaxExpireRate < 0 {
+			return fmt.Errorf("queue %s max_expire_rate must not be negative", queue.Name)
+		}
+		if queue.ExpiryTrackingQueue == queue.Name {
+			return fmt.Errorf("queue %s cannot be its own expiry_tracking_queue", queue.Name)
+		}
+	}
+	if err := validateSilences(cfg); err != nil {
+		return err
+	}
+	if cfg.SilenceSync.Enabled {
+		if !cfg.Server.Enabled {
+			return fmt.Errorf("silence_sync requires server.enabled to receive webhooks")
+		}
+		if cfg.SilenceSync.QueueLabel == "" {
+			return fmt.Errorf("silence_sync.queue_label is required when silence_sync is enabled")
+		}
+		if cfg.SilenceSync.DefaultDuration <= 0 {
+			return fmt.Errorf("silence_sync.default_duration must be positive")
+		}
+	}
+	if cfg.Notifications.StormSuppression.Enabled {
+		if cfg.Notifications.StormSuppression.ThresholdPercent <= 0 || cfg.Notifications.StormSuppression.ThresholdPercent > 100 {
+			return fmt.Errorf("notifications.storm_suppression.threshold_percent must be between 0 and 100")
+		}
+		if cfg.Notifications.StormSuppression.MinQueues < 1 {
+			return fmt.Errorf("notifications.storm_suppression.min_queues must be at least 1")
+		}
+	}
+	for _, alertType := range []string{"cluster", "connections", "vhosts", "services", "churn", "comparison", "dead_letter", "auth", "latency"} {
+		settings := cfg.Notifications.AlertTypes.Get(alertType)
+		if settings.Cooldown < 0 {
+			return fmt.Errorf("notifications.alert_types.%s.cooldown must not be negative", alertType)
+		}
+		if settings.Priority != "" && !isValidPriority(settings.Priority) {
+			return fmt.Errorf("notifications.alert_types.%s has invalid priority %q (critical, high, normal, low)", alertType, settings.Priority)
+		}
+	}
+	if cfg.Notifications.QuietHours.Enabled {
+		if _, err := parseClock(cfg.Notifications.QuietHours.Start); err != nil {
+			return fmt.Errorf("notifications.quiet_hours.start: %w", err)
+		}
+		if _, err := parseClock(cfg.Notifications.QuietHours.End); err != nil {
+			return fmt.Errorf("notifications.quiet_hours.end: %w", err)
+		}
+		if _, err := time.LoadLocation(cfg.Notifications.QuietHours.Timezone); err != nil {
+			return fmt.Errorf("notifications.quiet_hours.timezone: %w", err)
+		}
+	}
+	if cfg.Server.Enabled && cfg.Server.ListenAddress == "" {
+		return fmt.Errorf("server.listen_address is required when server is enabled")
+	}
+	if tlsCfg := cfg.Server.TLS; tlsCfg.CertFile != "" || tlsCfg.KeyFile != "" || tlsCfg.ClientCAFile != "" {
+		if tlsCfg.CertFile == "" || tlsCfg.KeyFile == "" {
+			return fmt.Errorf("server.tls requires both cert_file and key_file")
+		}
+	}
+	if cfg.Server.Auth.ProtectMetrics && len(cfg.Server.Auth.Tokens) == 0 {
+		return fmt.Errorf("server.auth.protect_metrics requires server.auth.tokens")
+	}
+	tokens := make(map[string]bool)
+	for i, token := range cfg.Server.Auth.Tokens {
+		if token.Name == "" || token.Token == "" {
+			return fmt.Errorf("server.auth.tokens[%d] requires name and token", i)
+		}
+		if !isValidRole(token.Role) {
+			return fmt.Errorf("server.auth.tokens[%d] has invalid role %q (read_only, silencer, admin)", i, token.Role)
+		}
+		if tokens[token.Token] {
+			return fmt.Errorf("server.auth.tokens[%d] reuses the token of another entry", i)
+		}
+		tokens[token.Token] = true
+	}
+	if cfg.Heartbeats.Enabled {
+		if !cfg.Server.Enabled {
+			return fmt.Errorf("heartbeats require server.enabled to receive heartbeats")
+		}
+		if cfg.Heartbeats.MaxAge <= 0 {
+			return fmt.Errorf("heartbeats.max_age must be positive")
+		}
+	}
+	if cfg.Backpressure.Enabled {
+		redis := cfg.Backpressure.Redis
+		if !cfg.Server.Enabled && redis.URL == "" {
+			return fmt.Errorf("backpressure requires server.enabled or backpressure.redis.url to publish signals")
+		}
+		if cfg.Backpressure.MaxMessagesReady < 0 {
+			return fmt.Errorf("backpressure.max_messages_ready must not be negative")
+		}
+		if redis.URL != "" {
+			if !strings.HasPrefix(redis.URL, "redis://") && !strings.HasPrefix(redis.URL, "rediss://") {
+				return fmt.Errorf("backpressure.redis.url must use the redis:// or rediss:// scheme")
+			}
+			if redis.TTL <= cfg.Monitor.Interval {
+				return fmt.Errorf("backpressure.redis.ttl must be longer than monitor.interval, or keys expire between checks")
+			}
+			if redis.Timeout <= 0 {
+				return fmt.Errorf("backpressure.redis.timeout must be positive")
+			}
+		}
+	}
+	if cfg.Notifications.Acks.Enabled && !cfg.Server.Enabled {
+		return fmt.Errorf("notifications.acks require server.enabled to receive acknowledgments")
+	}
+	webhooks := make(map[string]bool)
+	for i, webhook := range cfg.Notifications.Slack.Webhooks {
+		if webhook.URL == "" {
+			return fmt.Errorf("notifications.slack.webhooks[%d].url is required", i)
+		}
+		if webhooks[webhook.URL] {
+			return fmt.Errorf("notifications.slack.webhooks[%d] duplicates the url of another entry", i)
+		}
+		webhooks[webhook.URL] = true
+		if webhook.MinSeverity != "" && webhook.MinSeverity != SeverityWarning && webhook.MinSeverity != SeverityCritical {
+			return fmt.Errorf("notifications.slack.webhooks[%d].min_severity must be warning or critical", i)
+		}
+		for _, pattern := range webhook.Queues {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("notifications.slack.webhooks[%d] has invalid queue pattern %q: %w", i, pattern, err)
+			}
+		}
+	}
+	if cfg.Notifications.Slack.Enabled {
+		for _, webhookURL := range cfg.SlackWebhookURLs() {
+			if err := validateWebhookURL("notifications.slack", webhookURL); err != nil {
+				return err
+			}
+		}
+	}
+	switch cfg.Notifications.StartupCheck {
+	case "off", "warn", "fail":
+	default:
+		return fmt.Errorf("notifications.startup_check must be off, warn or fail")
+	}
+	if cfg.Notifications.MaxDeliveryLatency < 0 {
+		return fmt.Errorf("notifications.max_delivery_latency must not be negative")
+	}
+	if googleChat := cfg.Notifications.GoogleChat; googleChat.Enabled {
+		if len(googleChat.WebhookURLs) == 0 {
+			return fmt.Errorf("notifications.google_chat.webhook_urls is required when google_chat is enabled")
+		}
+		for _, webhookURL := range googleChat.WebhookURLs {
+			if err := validateWebhookURL("notifications.google_chat.webhook_urls", webhookURL); err != nil {
+				return err
+			}
+		}
+		if googleChat.Timeout <= 0 {
+			return fmt.Errorf("notifications.google_chat.timeout must be positive")
+		}
+	}
+	if zulip := cfg.Notifications.Zulip; zulip.Enabled {
+		if zulip.Site == "" || zulip.BotEmail == "" || zulip.APIKey == "" || zulip.Stream == "" {
+			return fmt.Errorf("notifications.zulip requires site, bot_email, api_key and stream")
+		}
+		if zulip.Timeout <= 0 {
+			return fmt.Errorf("notifications.zulip.timeout must be positive")
+		}
+	}
+	if twilio := cfg.Notifications.Twilio; twilio.Enabled {
+		if twilio.AccountSID == "" || twilio.AuthToken == "" || twilio.From == "" || len(twilio.To) == 0 {
+			return fmt.Errorf("notifications.twilio requires account_sid, auth_token, from and to")
+		}
+		if twilio.MaxPerHour <= 0 {
+			return fmt.Errorf("notifications.twilio.max_per_hour must be positive")
+		}
+		if twilio.Timeout <= 0 {
+			return fmt.Errorf("notifications.twilio.timeout must be positive")
+		}
+	}
+	if cfg.Notifications.Reminders.Enabled && cfg.Notifications.Reminders.Interval < time.Minute {
+		return fmt.Errorf("notifications.reminders.interval must be at least 1m")
+	}
+	for _, webhookURL := range cfg.Notifications.SelfTest.WebhookURLs {
+		if err := validateWebhookURL("notifications.self_test.webhook_urls", webhookURL); err != nil {
+			return err
+		}
+	}
+	if selfTest := cfg.Notifications.SelfTest; selfTest.Enabled {
+		if len(selfTest.WebhookURLs) == 0 {
+			return fmt.Errorf("notifications.self_test.webhook_urls is required when self_test is enabled")
+		}
+		if selfTest.Interval < time.Hour {
+			return fmt.Errorf("notifications.self_test.interval must be at least 1h")
+		}
+		if selfTest.QueueName == "" {
+			return fmt.Errorf("notifications.self_test.queue_name must not be empty")
+		}
+	}
+	if cfg.Notifications.Slack.AckButton && !cfg.Notifications.Acks.Enabled {
+		return fmt.Errorf("notifications.slack.ack_button requires notifications.acks.enabled")
+	}
+	if cfg.BrokerEvents.Enabled && cfg.BrokerEvents.Window <= 0 {
+		return fmt.Errorf("broker_events.window must be positive")
+	}
+	if cfg.History.Enabled {
+		if cfg.History.FilePath == "" {
+			return fmt.Errorf("history.file_path is required when history is enabled")
+		}
+		if cfg.History.Retention <= 0 {
+			return fmt.Errorf("history.retention must be positive")
+		}
+	}
+	if cfg.Textfile.Enabled && !strings.HasSuffix(cfg.Textfile.FilePath, ".prom") {
+		return fmt.Errorf("textfile.file_path must end in .prom")
+	}
+	if err := validateEventSinks(cfg.EventSinks); err != nil {
+		return err
+	}
+	if cfg.Feedback.Enabled {
+		if !cfg.Server.Enabled {
+			return fmt.Errorf("feedback requires server.enabled to receive false positive marks")
+		}
+		if cfg.Feedback.FilePath == "" {
+			return fmt.Errorf("feedback.file_path is required when feedback is enabled")
+		}
+	}
+	if cfg.SLO.Enabled {
+		if cfg.SLO.FilePath == "" {
+			return fmt.Errorf("slo.file_path is required when slo is enabled")
+		}
+		if cfg.SLO.Target <= 0 || cfg.SLO.Target >= 100 {
+			return fmt.Errorf("slo.target must be between 0 and 100 (exclusive)")
+		}
+		if cfg.SLO.BurnRateAlert < 0 {
+			return fmt.Errorf("slo.burn_rate_alert must not be negative")
+		}
+		if cfg.SLO.BurnRateAlert > 0 && cfg.SLO.BurnRateWindow < cfg.Monitor.Interval {
+			return fmt.Errorf("slo.burn_rate_window must be at least monitor.interval")
+		}
+	}
+	if cfg.Capacity.Enabled {
+		if !cfg.History.Enabled {
+			return fmt.Errorf("capacity requires history.enabled")
+		}
+		if cfg.Capacity.Window <= 0 || cfg.Capacity.Refresh <= 0 {
+			return fmt.Errorf("capacity.window and capacity.refresh must be positive")
+		}
+		if cfg.Capacity.MinSamples < 1 {
+			return fmt.Errorf("capacity.min_samples must be at least 1")
+		}
+		if cfg.Capacity.AlertUtilisation < 0 {
+			return fmt.Errorf("capacity.alert_utilisation must not be negative")
+		}
+		if cfg.Capacity.ThresholdChecks < 1 {
+			return fmt.Errorf("capacity.threshold_checks must be at least 1")
+		}
+	}
+	if cfg.Scaling.Enabled {
+		if !cfg.Server.Enabled {
+			return fmt.Errorf("scaling requires server.enabled to expose the hints")
+		}
+		if cfg.Scaling.DrainTime <= 0 {
+			return fmt.Errorf("scaling.drain_time must be positive")
+		}
+		if len(cfg.Scaling.Targets) == 0 {
+			return fmt.Errorf("scaling requires at least one target")
+		}
+		targets := make(map[string]bool)
+		for i, target := range cfg.Scaling.Targets {
+			if target.Name == "" {
+				return fmt.Errorf("scaling.targets[%d].name is required", i)
+			}
+			if targets[target.Name] {
+				return fmt.Errorf("scaling.targets[%d] duplicates target %s", i, target.Name)
+			}
+			targets[target.Name] = true
+			if len(target.Queues) == 0 {
+				return fmt.Errorf("scaling.targets[%d].queues must name at least one queue pattern", i)
+			}
+			for _, pattern := range target.Queues {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return fmt.Errorf("scaling.targets[%d] has invalid queue pattern %q: %w", i, pattern, err)
+				}
+			}
+			if target.ConsumerRate <= 0 {
+				return fmt.Errorf("scaling.targets[%d].consumer_rate must be positive", i)
+			}
+			if target.DrainTime < 0 || target.MinConsumers < 0 || target.MaxConsumers < 0 {
+				return fmt.Errorf("scaling.targets[%d] drain_time, min_consumers and max_consumers must not be negative", i)
+			}
+			if target.MaxConsumers > 0 && target.MaxConsumers < target.MinConsumers {
+				return fmt.Errorf("scaling.targets[%d].max_consumers must not be below min_consumers", i)
+			}
+		}
+	}
+	if cfg.Reports.Enabled {
+		if !cfg.History.Enabled {
+			return fmt.Errorf("reports requires history.enabled")
+		}
+		if _, ok := parseWeekday(cfg.Reports.Weekday); !ok {
+			return fmt.Errorf("reports.weekday %q is not a day of the week", cfg.Reports.Weekday)
+		}
+		if cfg.Reports.Hour < 0 || cfg.Reports.Hour > 23 {
+			return fmt.Errorf("reports.hour must be between 0 and 23")
+		}
+		if cfg.Reports.Window <= 0 {
+			return fmt.Errorf("reports.window must be positive")
+		}
+		if cfg.Reports.BaselineWeeks < 0 {
+			return fmt.Errorf("reports.baseline_weeks must not be negative")
+		}
+		// The oldest baseline week ends baseline_weeks before the report window
+		if covered := cfg.Reports.Window + time.Duration(cfg.Reports.BaselineWeeks)*7*24*time.Hour; cfg.History.Retention < covered {
+			return fmt.Errorf("history.retention must be at least %s to cover reports.window and reports.baseline_weeks", covered)
+		}
+		if cfg.Reports.OutputDir == "" && !cfg.Reports.Email.Enabled() {
+			return fmt.Errorf("reports requires output_dir or email.to")
+		}
+	}
+	if cfg.Reports.Email.Enabled() && (cfg.Reports.Email.SMTPHost == "" || cfg.Reports.Email.From == "") {
+		return fmt.Errorf("reports.email requires smtp_host and from")
+	}
+	pluginNames := make(map[string]bool)
+	for i, plugin := range cfg.Plugins {
+		if plugin.Name == "" || plugin.Path == "" {
+			return fmt.Errorf("plugins[%d]: name and path are required", i)
+		}
+		if pluginNames[plugin.Name] {
+			return fmt.Errorf("plugins[%d]: duplicate plugin name %q", i, plugin.Name)
+		}
+		pluginNames[plugin.Name] = true
+	}
+	if cfg.Notifications.Slack.FalsePositiveButton && !cfg.Feedback.Enabled {
+		return fmt.Errorf("notifications.slack.false_positive_button requires feedback.enabled")
+	}
+	if slackCfg := cfg.Notifications.Slack; (slackCfg.AckButton || slackCfg.FalsePositiveButton) && slackCfg.SigningSecret == "" {
+		return fmt.Errorf("notifications.slack.signing_secret is required with ack_button or false_positive_button")
+	}
+	if _, err := time.LoadLocation(cfg.Notifications.Display.Timezone); err != nil {
+		return fmt.Errorf("notifications.display.timezone: %w", err)
+	}
+	if cfg.Notifications.Display.TimeFormat == "" {
+		return fmt.Errorf("notifications.display.time_format must not be empty")
+	}
+	if !isValidLanguage(cfg.Notifications.Display.Language) {
+		return fmt.Errorf("notifications.display.language %q is not supported (en, nl, de, fr)", cfg.Notifications.Display.Language)
+	}
+	if numbers := cfg.Notifications.Display.NumberFormat(); numbers.Decimal == "" || numbers.Decimal == numbers.Thousands {
+		return fmt.Errorf("notifications.display.decimal_separator must be set and differ from the thousands separator")
+	}
+	for i, channel := range cfg.Notifications.Display.ChannelLanguages {
+		if channel.WebhookURL == "" {
+			return fmt.Errorf("notifications.display.channel_languages[%d].webhook_url is required", i)
+		}
+		if !isValidLanguage(channel.Language) {
+			return fmt.Errorf("notifications.display.channel_languages[%d] has unsupported language %q (en, nl, de, fr)", i, channel.Language)
+		}
+	}
+	for i, alias := range cfg.Notifications.Display.QueueNames.Aliases {
+		if alias.Queue == "" || alias.Name == "" {
+			return fmt.Errorf("notifications.display.queue_names.aliases[%d] requires queue and name", i)
+		}
+		if _, err := path.Match(alias.Queue, ""); err != nil {
+			return fmt.Errorf("notifications.display.queue_names.aliases[%d] has invalid queue pattern %q", i, alias.Queue)
+		}
+	}
+	if maxLength := cfg.Notifications.Display.QueueNames.MaxLength; maxLength != 0 && maxLength < minQueueNameLength {
+		return fmt.Errorf("notifications.display.queue_names.max_length must be 0 or at least %d", minQueueNameLength)
+	}
+	if cfg.Enrichment.PeekMessages < 0 || cfg.Enrichment.PeekMessages > maxPeekMessages {
+		return fmt.Errorf("enrichment.peek_messages must be between 0 and %d", maxPeekMessages)
+	}
+	if ownership := cfg.Enrichment.Ownership; ownership.Enabled {
+		switch ownership.Source {
+		case "file":
+			if ownership.File == "" {
+				return fmt.Errorf("enrichment.ownership.file is required for the file source")
+			}
+		case "backstage":
+			if parsed, err := url.Parse(ownership.Backstage.URL); err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+				return fmt.Errorf("enrichment.ownership.backstage.url must be an absolute http(s) URL")
+			}
+			if ownership.Backstage.QueueAnnotation == "" {
+				return fmt.Errorf("enrichment.ownership.backstage.queue_annotation is required")
+			}
+		default:
+			return fmt.Errorf("enrichment.ownership.source must be file or backstage")
+		}
+		if ownership.Refresh < time.Minute {
+			return fmt.Errorf("enrichment.ownership.refresh must be at least 1m")
+		}
+	}
+	if cfg.Monitor.Detection.RedeliveryStormRatio < 0 || cfg.Monitor.Detection.RedeliveryStormRatio > 1 {
+		return fmt.Errorf("monitor.detection.redelivery_storm_ratio must be between 0 and 1")
+	}
+	if cfg.Monitor.Detection.MinHealthScore < 0 || cfg.Monitor.Detection.MinHealthScore > 100 {
+		return fmt.Errorf("monitor.detection.min_health_score must be between 0 and 100")
+	}
+	if cfg.Monitor.Detection.MaxExpireRate < 0 {
+		return fmt.Errorf("monitor.detection.max_expire_rate must not be negative")
+	}
+	protocols := make(map[string]bool)
+	for i, protocol := range cfg.Protocols {
+		if _, known := defaultQueuePatterns[protocol.Protocol]; !known {
+			return fmt.Errorf("protocols[%d].protocol must be mqtt or stomp", i)
+		}
+		if protocols[protocol.Protocol] {
+			return fmt.Errorf("protocols[%d] duplicates protocol %s", i, protocol.Protocol)
+		}
+		protocols[protocol.Protocol] = true
+		if protocol.MinConnections < 0 {
+			return fmt.Errorf("protocols[%d].min_connections must not be negative", i)
+		}
+		for _, pattern := range protocol.QueuePatterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("protocols[%d] has invalid queue pattern %q: %w", i, pattern, err)
+			}
+		}
+		if protocol.ThresholdChecks != nil && *protocol.ThresholdChecks < 1 {
+			return fmt.Errorf("protocols[%d].threshold_checks must be at least 1", i)
+		}
+	}
+	vhosts := make(map[string]bool)
+	for i, rule := range cfg.VHosts {
+		if rule.VHost == "" {
+			return fmt.Errorf("vhosts[%d].vhost is required", i)
+		}
+		if vhosts[rule.VHost] {
+			return fmt.Errorf("vhosts[%d] duplicates vhost %s", i, rule.VHost)
+		}
+		vhosts[rule.VHost] = true
+		if rule.MaxMessages < 0 || rule.MaxMessagesReady < 0 || rule.MaxPublishRate < 0 || rule.MinConsumeRate < 0 {
+			return fmt.Errorf("vhosts[%d] limits must not be negative", i)
+		}
+		if rule.MaxMessages == 0 && rule.MaxMessagesReady == 0 && rule.MaxPublishRate == 0 && rule.MinConsumeRate == 0 {
+			return fmt.Errorf("vhosts[%d] must set at least one of max_messages, max_messages_ready, max_publish_rate or min_consume_rate", i)
+		}
+		if rule.Priority != "" && !isValidPriority(rule.Priority) {
+			return fmt.Errorf("vhosts[%d] has invalid priority %q (critical, high, normal, low)", i, rule.Priority)
+		}
+		if rule.ThresholdChecks != nil && *rule.ThresholdChecks < 1 {
+			return fmt.Errorf("vhosts[%d].threshold_checks must be at least 1", i)
+		}
+	}
+	services := make(map[string]bool)
+	for i, service := range cfg.Services {
+		if service.Name == "" {
+			return fmt.Errorf("services[%d].name is required", i)
+		}
+		if services[service.Name] {
+			return fmt.Errorf("services[%d] duplicates service %s", i, service.Name)
+		}
+		services[service.Name] = true
+		if len(service.Queues) == 0 {
+			return fmt.Errorf("services[%d].queues must name at least one queue pattern", i)
+		}
+		for _, pattern := range service.Queues {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("services[%d] has invalid queue pattern %q: %w", i, pattern, err)
+			}
+		}
+		if service.MaxMessagesReady < 0 || service.MinConsumers < 0 || service.MinConsumeRate < 0 || service.MaxLag < 0 {
+			return fmt.Errorf("services[%d] limits must not be negative", i)
+		}
+		if service.MaxMessagesReady == 0 && service.MinConsumers == 0 && service.MinConsumeRate == 0 && service.MaxLag == 0 && service.OutlierFactor == 0 {
+			return fmt.Errorf("services[%d] must set at least one of max_messages_ready, min_consumers, min_consume_rate, max_lag or outlier_factor", i)
+		}
+		if service.OutlierFactor != 0 && service.OutlierFactor <= 1 {
+			return fmt.Errorf("services[%d].outlier_factor must be greater than 1", i)
+		}
+		if service.OutlierMinMessages != nil && *service.OutlierMinMessages < 0 {
+			return fmt.Errorf("services[%d].outlier_min_messages must not be negative", i)
+		}
+		if service.Priority != "" && !isValidPriority(service.Priority) {
+			return fmt.Errorf("services[%d] has invalid priority %q (critical, high, normal, low)", i, service.Priority)
+		}
+		if service.ThresholdChecks != nil && *service.ThresholdChecks < 1 {
+			return fmt.Errorf("services[%d].threshold_checks must be at least 1", i)
+		}
+	}
+	if cfg.Churn.Enabled {
+		if cfg.Churn.MaxCreated < 0 || cfg.Churn.MaxDeleted < 0 {
+			return fmt.Errorf("churn limits must not be negative")
+		}
+		if cfg.Churn.MaxCreated == 0 && cfg.Churn.MaxDeleted == 0 {
+			return fmt.Errorf("churn requires max_created_per_minute or max_deleted_per_minute")
+		}
+		if cfg.Churn.ThresholdChecks < 1 {
+			return fmt.Errorf("churn.threshold_checks must be at least 1")
+		}
+		if cfg.Churn.Priority != "" && !isValidPriority(cfg.Churn.Priority) {
+			return fmt.Errorf("churn has invalid priority %q (critical, high, normal, low)", cfg.Churn.Priority)
+		}
+	}
+	if cfg.Comparison.Enabled {
+		target := cfg.Comparison.TargetRabbitMQ(cfg.RabbitMQ)
+		if target.Host == cfg.RabbitMQ.Host && target.Port == cfg.RabbitMQ.Port && target.VHost == cfg.RabbitMQ.VHost {
+			return fmt.Errorf("comparison.target must name another vhost or broker")
+		}
+		if cfg.Comparison.Tolerance <= 0 || cfg.Comparison.Tolerance >= 1 {
+			return fmt.Errorf("comparison.tolerance must be between 0 and 1")
+		}
+		if cfg.Comparison.MinMessages < 0 || cfg.Comparison.MinRate < 0 {
+			return fmt.Errorf("comparison.min_messages and comparison.min_rate must not be negative")
+		}
+		if cfg.Comparison.ThresholdChecks < 1 {
+			return fmt.Errorf("comparison.threshold_checks must be at least 1")
+		}
+		if cfg.Comparison.Priority != "" && !isValidPriority(cfg.Comparison.Priority) {
+			return fmt.Errorf("comparison has invalid priority %q (critical, high, normal, low)", cfg.Comparison.Priority)
+		}
+		for _, pattern := range cfg.Comparison.Queues {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("comparison.queues has invalid pattern %q: %w", pattern, err)
+			}
+		}
+	}
+	if cfg.DeadLetters.Enabled {
+		if len(cfg.DeadLetters.Suffixes) == 0 {
+			return fmt.Errorf("dead_letters.suffixes must name at least one suffix")
+		}
+		for _, suffix := range cfg.DeadLetters.Suffixes {
+			if suffix == "" {
+				return fmt.Errorf("dead_letters.suffixes must not contain empty suffixes")
+			}
+		}
+		if cfg.DeadLetters.MinGrowth < 1 {
+			return fmt.Errorf("dead_letters.min_growth must be at least 1")
+		}
+		if cfg.DeadLetters.ThresholdChecks < 1 {
+			return fmt.Errorf("dead_letters.threshold_checks must be at least 1")
+		}
+		if cfg.DeadLetters.Priority != "" && !isValidPriority(cfg.DeadLetters.Priority) {
+			return fmt.Errorf("dead_letters has invalid priority %q (critical, high, normal, low)", cfg.DeadLetters.Priority)
+		}
+	}
+	if cfg.Streams.MaxOffsetLag < 0 {
+		return fmt.Errorf("streams.max_offset_lag must not be negative")
+	}
+	if cfg.Streams.LagGrowthChecks < 0 {
+		return fmt.Errorf("streams.lag_growth_checks must not be negative")
+	}
+	if cfg.Quarantine.Enabled {
+		if cfg.Monitor.Detection.RedeliveryStormRatio == 0 {
+			return fmt.Errorf("quarantine requires monitor.detection.redelivery_storm_ratio to detect redelivery storms")
+		}
+		if cfg.Quarantine.Queue == "" {
+			return fmt.Errorf("quarantine.queue is required when quarantine is enabled")
+		}
+		if cfg.Quarantine.MaxMessages < 1 || cfg.Quarantine.MaxMessages > maxQuarantineMessages {
+			return fmt.Errorf("quarantine.max_messages must be between 1 and %d", maxQuarantineMessages)
+		}
+		for _, queue := range cfg.Monitor.Queues {
+			if queue.Name == cfg.Quarantine.Queue {
+				return fmt.Errorf("quarantine.queue %s must not be a monitored queue", queue.Name)
+			}
+		}
+	}
+	if cfg.Sharding.Count < 1 {
+		return fmt.Errorf("sharding.count must be at least 1")
+	}
+	if cfg.Sharding.Index < 0 || cfg.Sharding.Index >= cfg.Sharding.Count {
+		return fmt.Errorf("sharding.index must be between 0 and sharding.count-1")
+	}
+	if cfg.Audit.Enabled && cfg.Audit.FilePath == "" {
+		return fmt.Errorf("audit.file_path is required when audit is enabled")
+	}
+	switch cfg.Logging.Format {
+	case "json", "text", "pretty":
+	default:
+		return fmt.Errorf("logging.format must be json, text or pretty")
+	}
+	if cfg.Logging.Sampling.Interval < 0 {
+		return fmt.Errorf("logging.sampling.interval must not be negative")
 	}
 
 	return nil
 }
 
+// validateEventSinks checks the settings of the enabled event sinks
+func validateEventSinks(sinks EventSinksConfig) error {
+	if sinks.Enabled() && sinks.Timeout <= 0 {
+		return fmt.Errorf("event_sinks.timeout must be positive")
+	}
+	if sinks.Kafka.Enabled {
+		if sinks.Kafka.RestProxyURL == "" {
+			return fmt.Errorf("event_sinks.kafka.rest_proxy_url is required when kafka is enabled")
+		}
+		if sinks.Kafka.Topic == "" {
+			return fmt.Errorf("event_sinks.kafka.topic is required when kafka is enabled")
+		}
+		if sinks.Kafka.Format != "json" && sinks.Kafka.Format != "avro" {
+			return fmt.Errorf("event_sinks.kafka.format must be json or avro")
+		}
+		if sinks.Kafka.Format == "avro" && sinks.CloudEvents.Enabled {
+			return fmt.Errorf("event_sinks.cloudevents requires event_sinks.kafka.format json")
+		}
+	}
+	if sinks.Webhook.Enabled && len(sinks.Webhook.URLs) == 0 {
+		return fmt.Errorf("event_sinks.webhook.urls is required when webhook is enabled")
+	}
+	if sinks.SNS.Enabled {
+		if parts := strings.Split(sinks.SNS.TopicARN, ":"); len(parts) != 6 || parts[0] != "arn" || parts[2] != "sns" || parts[3] == "" {
+			return fmt.Errorf("event_sinks.sns.topic_arn must be an SNS topic ARN")
+		}
+	}
+	if sinks.EventBridge.Enabled {
+		if sinks.EventBridge.EventBus == "" || sinks.EventBridge.Source == "" {
+			return fmt.Errorf("event_sinks.eventbridge requires event_bus and source")
+		}
+		if strings.HasPrefix(sinks.EventBridge.Source, "aws.") {
+			return fmt.Errorf("event_sinks.eventbridge.source must not start with aws.")
+		}
+	}
+	if sinks.CloudEvents.Enabled && (sinks.CloudEvents.Source == "" || sinks.CloudEvents.TypePrefix == "") {
+		return fmt.Errorf("event_sinks.cloudevents requires source and type_prefix")
+	}
+	if sinks.NATS.Enabled {
+		if sinks.NATS.URL == "" {
+			return fmt.Errorf("event_sinks.nats.url is required when nats is enabled")
+		}
+		if sinks.NATS.Subject == "" || strings.ContainsAny(sinks.NATS.Subject, " \t*>") {
+			return fmt.Errorf("event_sinks.nats.subject must be a subject without spaces or wildcards")
+		}
+	}
+	return nil
+}
+
+// tracksPriorities reports whether any queue has per-priority backlog tracking
+func (c *Config) tracksPriorities() bool {
+	for _, queue := range c.Monitor.Queues {
+		if queue.TracksPriorities() {
+			return true
+		}
+	}
+	return false
+}
+
+// detectsExpiry reports whether any queue alerts on messages expiring unprocessed
+func (c *Config) detectsExpiry() bool {
+	if c.Monitor.Detection.MaxExpireRate > 0 {
+		return true
+	}
+	for _, queue := range c.Monitor.Queues {
+		if queue.MaxExpireRate != nil && *queue.MaxExpireRate > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// EnabledFeatures returns the names of optional features enabled in the configuration
+func (c *Config) EnabledFeatures() []string {
+	features := make([]string, 0)
+	optional := []struct {
+		name    string
+		enabled bool
+	}{
+		{"slack", c.Notifications.Slack.Enabled},
+		{"google_chat", c.Notifications.GoogleChat.Enabled},
+		{"zulip", c.Notifications.Zulip.Enabled},
+		{"twilio", c.Notifications.Twilio.Enabled},
+		{"storm_suppression", c.Notifications.StormSuppression.Enabled},
+		{"quiet_hours", c.Notifications.QuietHours.Enabled},
+		{"acks", c.Notifications.Acks.Enabled},
+		{"reminders", c.Notifications.Reminders.Enabled},
+		{"self_test", c.Notifications.SelfTest.Enabled},
+		{"server", c.Server.Enabled},
+		{"debug", c.Server.Enabled && c.Server.Debug},
+		{"api_auth", c.Server.Enabled && len(c.Server.Auth.Tokens) > 0},
+		{"tls", c.Server.Enabled && c.Server.TLS.Enabled()},
+		{"mtls", c.Server.Enabled && c.Server.TLS.ClientCAFile != ""},
+		{"heartbeats", c.Heartbeats.Enabled},
+		{"backpressure", c.Backpressure.Enabled},
+		{"broker_events", c.BrokerEvents.Enabled},
+		{"history", c.History.Enabled},
+		{"textfile", c.Textfile.Enabled},
+		{"kafka", c.EventSinks.Kafka.Enabled},
+		{"nats", c.EventSinks.NATS.Enabled},
+		{"event_webhook", c.EventSinks.Webhook.Enabled},
+		{"sns", c.EventSinks.SNS.Enabled},
+		{"eventbridge", c.EventSinks.EventBridge.Enabled},
+		{"cloudevents", c.EventSinks.Enabled() && c.EventSinks.CloudEvents.Enabled},
+		{"feedback", c.Feedback.Enabled},
+		{"teams", len(c.Teams) > 0},
+		{"audit", c.Audit.Enabled},
+		{"consumer_details", c.Enrichment.ConsumerDetails},
+		{"message_peek", c.Enrichment.PeekMessages > 0},
+		{"ownership", c.Enrichment.Ownership.Enabled},
+		{"redelivery_storms", c.Monitor.Detection.RedeliveryStormRatio > 0},
+		{"health_score_alerts", c.Monitor.Detection.MinHealthScore > 0},
+		{"expiry_losses", c.detectsExpiry()},
+		{"quarantine", c.Quarantine.Enabled},
+		{"priority_depth", c.tracksPriorities()},
+		{"streams", c.Streams.Enabled},
+		{"protocols", len(c.Protocols) > 0},
+		{"vhost_rules", len(c.VHosts) > 0},
+		{"services", len(c.Services) > 0},
+		{"queue_churn", c.Churn.Enabled},
+		{"comparison", c.Comparison.Enabled},
+		{"dead_letters", c.DeadLetters.Enabled},
+		{"custom_rules", len(c.Monitor.Rules) > 0},
+		{"plugins", len(c.Plugins) > 0},
+		{"history_backfill", c.Monitor.BackfillHistory},
+		{"burst_sampling", c.Monitor.BurstInterval > 0},
+		{"idle_backoff", c.Monitor.IdleBackoff.Enabled},
+		{"slo", c.SLO.Enabled},
+		{"capacity", c.Capacity.Enabled},
+		{"scaling", c.Scaling.Enabled},
+		{"reports", c.Reports.Enabled},
+		{"silence_sync", c.SilenceSync.Enabled},
+		{"sharding", c.Sharding.Enabled()},
+		{"custom_templates", c.Notifications.Display.Templates.Alerting != "" || c.Notifications.Display.Templates.Recovery != ""},
+	}
+	for _, feature := range optional {
+		if feature.enabled {
+			features = append(features, feature.name)
+		}
+	}
+	return features
+}
+
 // GetRabbitMQURL returns the RabbitMQ management API URL
 func (c *RabbitMQConfig) GetRabbitMQURL() string {
 	scheme := "http"
@@ -182,3 +2489,22 @@ func (c *RabbitMQConfig) GetRabbitMQURL() string {
 	}
 	return fmt.Sprintf("%s://%s:%d", scheme, c.Host, c.Port)
 }
+
+// GetAMQPURL returns the AMQP URL of the broker and vhost, including the credentials
+func (c *RabbitMQConfig) GetAMQPURL() string {
+	scheme, port := "amqp", 5672
+	if c.UseTLS {
+		scheme, port = "amqps", 5671
+	}
+	if c.AMQPPort != 0 {
+		port = c.AMQPPort
+	}
+	amqpURL := url.URL{
+		Scheme:  scheme,
+		User:    url.UserPassword(c.Username, c.Password),
+		Host:    net.JoinHostPort(c.Host, strconv.Itoa(port)),
+		Path:    "/" + c.VHost,
+		RawPath: "/" + url.PathEscape(c.VHost),
+	}
+	return amqpURL.String()
+}
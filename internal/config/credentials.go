@@ -0,0 +1,46 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// HasCredentialFiles reports whether the username or password is read from a file
+func (c *RabbitMQConfig) HasCredentialFiles() bool {
+	return c.UsernameFile != "" || c.PasswordFile != ""
+}
+
+// LoadCredentials reads the username and password from their files, such as mounted Kubernetes
+// or Vault agent secrets, replacing the configured values; surrounding whitespace is trimmed
+// Called when the config is loaded, and again when the management API rejects the credentials
+func (c *RabbitMQConfig) LoadCredentials() error {
+	if c.UsernameFile != "" {
+		username, err := readSecretFile(c.UsernameFile)
+		if err != nil {
+			return fmt.Errorf("failed to read rabbitmq.username_file: %w", err)
+		}
+		c.Username = username
+	}
+	if c.PasswordFile != "" {
+		password, err := readSecretFile(c.PasswordFile)
+		if err != nil {
+			return fmt.Errorf("failed to read rabbitmq.password_file: %w", err)
+		}
+		c.Password = password
+	}
+	return nil
+}
+
+// readSecretFile returns the trimmed contents of a secret file, which must not be empty
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	secret := strings.TrimSpace(string(data))
+	if secret == "" {
+		return "", fmt.Errorf("%s is empty", path)
+	}
+	return secret, nil
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// instancePaths are the log and state locations kept apart per instance, unless set in the config file
+var instancePaths = []string{
+	"logging.file_path",
+	"history.file_path",
+	"feedback.file_path",
+	"slo.file_path",
+	"audit.file_path",
+	"reports.output_dir",
+}
+
+// instanceFiles are the locations kept apart per instance by a suffix on the file name instead,
+// because the directory is scanned by another program that does not look into subdirectories
+var instanceFiles = []string{
+	"textfile.file_path", // node_exporter's textfile collector
+}
+
+// LoadInstance reads the configuration of a named instance, one of several monitors running on a host
+// The name overrides the config file's instance; an empty name keeps it
+func LoadInstance(configPath, instance string) (*Config, error) {
+	v := viper.New()
+	setDefaults(v)
+	v.SetConfigFile(configPath)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	if instance != "" {
+		v.Set("instance", instance)
+	}
+	return decode(v)
+}
+
+// applyInstance moves the default log and state locations into a directory named after the instance,
+// e.g. /var/lib/rabbitmq-monitor/<instance>/history.jsonl, and suffixes the default textfile with the
+// instance name, so instances on one host do not share files
+// Locations set in the config file are kept as they are
+func applyInstance(v *viper.Viper) error {
+	instance := v.GetString("instance")
+	if instance == "" {
+		return nil
+	}
+	if err := ValidateInstanceName(instance); err != nil {
+		return err
+	}
+	for _, key := range instancePaths {
+		path := v.GetString(key)
+		if v.InConfig(key) || path == "" {
+			continue
+		}
+		v.Set(key, InstancePath(path, instance))
+	}
+	for _, key := range instanceFiles {
+		path := v.GetString(key)
+		if v.InConfig(key) || path == "" {
+			continue
+		}
+		v.Set(key, InstanceFile(path, instance))
+	}
+	return nil
+}
+
+// InstancePath returns the location of a file or directory for an instance: the same name
+// in a subdirectory named after the instance, or path unchanged without an instance
+func InstancePath(path, instance string) string {
+	if instance == "" {
+		return path
+	}
+	return filepath.Join(filepath.Dir(path), instance, filepath.Base(path))
+}
+
+// InstanceFile returns the location of a file for an instance: the instance name appended to
+// the file name before its extension, e.g. rmq_monitor-eu-west.prom, or path unchanged without an instance
+func InstanceFile(path, instance string) string {
+	if instance == "" {
+		return path
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + instance + ext
+}
+
+// ValidateInstanceName checks that an instance name is safe to use in file names:
+// letters, digits, '-', '_' and '.', not starting with '.' or '-'
+func ValidateInstanceName(name string) error {
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+		case (r == '-' || r == '.') && i > 0:
+		default:
+			return fmt.Errorf("invalid instance name %q: use letters, digits, '-', '_' and '.', starting with a letter or digit", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// QueueSettings renders queue configs keyed like the config file, for export through the config API
+// Unset and zero settings are left out and durations are rendered like "5m0s", so the result
+// can be read back by WithQueues
+func QueueSettings(queues []QueueConfig) []map[string]interface{} {
+	settings := make([]map[string]interface{}, 0, len(queues))
+	for _, queue := range queues {
+		settings = append(settings, settingsMap(reflect.ValueOf(queue)))
+	}
+	return settings
+}
+
+// settingsMap renders a config struct as a map keyed by its mapstructure tags
+func settingsMap(value reflect.Value) map[string]interface{} {
+	result := make(map[string]interface{})
+	for i := 0; i < value.NumField(); i++ {
+		name, _, _ := strings.Cut(value.Type().Field(i).Tag.Get("mapstructure"), ",")
+		field := value.Field(i)
+		if name == "" || name == "-" || field.IsZero() {
+			continue
+		}
+		result[name] = settingsValue(field)
+	}
+	return result
+}
+
+// settingsValue renders a config value, dereferencing pointers and formatting durations
+func settingsValue(value reflect.Value) interface{} {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if duration, ok := value.Interface().(time.Duration); ok {
+		return duration.String()
+	}
+	if value.Kind() == reflect.Struct {
+		return settingsMap(value)
+	}
+	return value.Interface()
+}
+
+// WithQueues returns a copy of cfg whose monitor.queues are replaced by the "queues" list of a
+// JSON document, decoded and validated like the config file
+func WithQueues(cfg *Config, document io.Reader) (*Config, error) {
+	v := viper.New()
+	v.SetConfigType("json")
+	if err := v.ReadConfig(document); err != nil {
+		return nil, fmt.Errorf("failed to read queues: %w", err)
+	}
+	if !v.IsSet("queues") {
+		return nil, fmt.Errorf("queues is required")
+	}
+
+	var queues []QueueConfig
+	if err := v.UnmarshalKey("queues", &queues); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal queues: %w", err)
+	}
+
+	updated := *cfg
+	updated.Monitor.Queues = queues
+	if err := validate(&updated); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return &updated, nil
+}
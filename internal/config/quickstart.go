@@ -0,0 +1,33 @@
+package config
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// quickstartConfig is layered over the defaults by Quickstart
+//
+//go:embed quickstart.yaml
+var quickstartConfig string
+
+// Quickstart returns the embedded evaluation config connecting with the given settings
+// No queues are configured; callers add the discovered ones
+func Quickstart(rabbitMQ RabbitMQConfig) (*Config, error) {
+	v := viper.New()
+	setDefaults(v)
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(strings.NewReader(quickstartConfig)); err != nil {
+		return nil, fmt.Errorf("failed to read embedded config: %w", err)
+	}
+
+	v.Set("rabbitmq.host", rabbitMQ.Host)
+	v.Set("rabbitmq.port", rabbitMQ.Port)
+	v.Set("rabbitmq.username", rabbitMQ.Username)
+	v.Set("rabbitmq.password", rabbitMQ.Password)
+	v.Set("rabbitmq.vhost", rabbitMQ.VHost)
+	v.Set("rabbitmq.use_tls", rabbitMQ.UseTLS)
+	return decode(v)
+}
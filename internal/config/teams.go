@@ -0,0 +1,162 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// TeamConfig is a config fragment owned by one team
+// Each team owns its queues, notification channels and silences
+type TeamConfig struct {
+	Name        string          `mapstructure:"team"`
+	WebhookURLs []string        `mapstructure:"webhook_urls"`
+	Queues      []QueueConfig   `mapstructure:"queues"`
+	Silences    []SilenceConfig `mapstructure:"silences"`
+}
+
+// SilenceConfig mutes notifications for a set of queues until a point in time
+type SilenceConfig struct {
+	Queues []string `mapstructure:"queues"`
+	EndsAt string   `mapstructure:"ends_at"` // RFC3339 timestamp
+	Reason string   `mapstructure:"reason"`
+}
+
+// SilenceSyncConfig mirrors silences and maintenance windows of external tools through inbound webhooks
+type SilenceSyncConfig struct {
+	Enabled           bool                `mapstructure:"enabled"`
+	QueueLabel        string              `mapstructure:"queue_label"`        // Alertmanager alert label naming the queues to silence
+	DefaultDuration   time.Duration       `mapstructure:"default_duration"`   // Silence length for firing alerts without an end time
+	PagerDutyServices map[string][]string `mapstructure:"pagerduty_services"` // PagerDuty service IDs (matched case-insensitively) and the queues they cover
+}
+
+// End returns the time the silence expires
+// Only valid after the config has been validated
+func (s SilenceConfig) End() time.Time {
+	end, _ := time.Parse(time.RFC3339, s.EndsAt)
+	return end
+}
+
+// GetTeam returns the fragment of the team owning a queue, if any
+func (c *Config) GetTeam(queueName string) (TeamConfig, bool) {
+	for _, team := range c.Teams {
+		for _, queue := range team.Queues {
+			if queue.Name == queueName {
+				return team, true
+			}
+		}
+	}
+	return TeamConfig{}, false
+}
+
+// loadTeams reads every YAML fragment in dir, ordered by file name
+// Teams without a name are named after their file
+func loadTeams(dir string) ([]TeamConfig, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read teams directory: %w", err)
+	}
+
+	files := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		files = append(files, entry.Name())
+	}
+	sort.Strings(files)
+
+	teams := make([]TeamConfig, 0, len(files))
+	for _, file := range files {
+		v := viper.New()
+		v.SetConfigFile(filepath.Join(dir, file))
+		v.SetConfigType("yaml")
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to read team config %s: %w", file, err)
+		}
+
+		var team TeamConfig
+		if err := v.Unmarshal(&team); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal team config %s: %w", file, err)
+		}
+		if team.Name == "" {
+			team.Name = strings.TrimSuffix(file, filepath.Ext(file))
+		}
+		teams = append(teams, team)
+	}
+
+	return teams, nil
+}
+
+// mergeTeams adds the queues of every team to the monitored queues
+// A queue may only be claimed once, either by the main config or by a single team
+func mergeTeams(cfg *Config) error {
+	owners := make(map[string]string)
+	for _, queue := range cfg.Monitor.Queues {
+		owners[queue.Name] = "the main config"
+	}
+
+	teamNames := make(map[string]bool)
+	for _, team := range cfg.Teams {
+		if teamNames[team.Name] {
+			return fmt.Errorf("team %s is defined more than once", team.Name)
+		}
+		teamNames[team.Name] = true
+
+		for _, queue := range team.Queues {
+			if owner, claimed := owners[queue.Name]; claimed {
+				return fmt.Errorf("queue %s is claimed by team %s but already owned by %s", queue.Name, team.Name, owner)
+			}
+			owners[queue.Name] = "team " + team.Name
+			cfg.Monitor.Queues = append(cfg.Monitor.Queues, queue)
+		}
+	}
+
+	return nil
+}
+
+// validateSilences checks silence timestamps and, for teams, that every
+// silenced queue is owned by the team
+func validateSilences(cfg *Config) error {
+	for i, silence := range cfg.Silences {
+		if err := validateSilence(silence); err != nil {
+			return fmt.Errorf("silences[%d]: %w", i, err)
+		}
+	}
+
+	for _, team := range cfg.Teams {
+		owned := make(map[string]bool)
+		for _, queue := range team.Queues {
+			owned[queue.Name] = true
+		}
+		for i, silence := range team.Silences {
+			if err := validateSilence(silence); err != nil {
+				return fmt.Errorf("team %s silences[%d]: %w", team.Name, i, err)
+			}
+			for _, queueName := range silence.Queues {
+				if !owned[queueName] {
+					return fmt.Errorf("team %s cannot silence queue %s it does not own", team.Name, queueName)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateSilence checks a single silence
+func validateSilence(silence SilenceConfig) error {
+	if len(silence.Queues) == 0 {
+		return fmt.Errorf("queues is required")
+	}
+	if _, err := time.Parse(time.RFC3339, silence.EndsAt); err != nil {
+		return fmt.Errorf("invalid ends_at %q, expected RFC3339 timestamp", silence.EndsAt)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package discovery
+
+import (
+	"fmt"
+	"path"
+	"sort"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/rabbitmq"
+)
+
+// Diff lists where the config and the live broker disagree
+type Diff struct {
+	Missing     []string // Configured queues that do not exist on the broker
+	Unmonitored []string // Live queues matching an interesting pattern but absent from the config
+	Unused      []string // Descriptions of overrides, profiles and priority classes that have no effect
+}
+
+// Empty reports whether the config matches the broker
+func (d Diff) Empty() bool {
+	return len(d.Missing) == 0 && len(d.Unmonitored) == 0 && len(d.Unused) == 0
+}
+
+// Compare checks the configured queues against the live queues
+// Unmonitored queues are only reported when the config lists queues explicitly: they
+// match one of the patterns or share a naming convention prefix with a configured queue
+func Compare(cfg *config.Config, live []rabbitmq.QueueInfo, patterns []string) Diff {
+	var diff Diff
+	exists := make(map[string]bool, len(live))
+	for _, queue := range live {
+		exists[queue.Name] = true
+	}
+
+	configured := make(map[string]bool, len(cfg.Monitor.Queues))
+	prefixes := make(map[string]bool)
+	for _, queue := range cfg.Monitor.Queues {
+		configured[queue.Name] = true
+		prefixes[GroupName(queue.Name)] = true
+		if !exists[queue.Name] {
+			diff.Missing = append(diff.Missing, queue.Name)
+		}
+	}
+
+	if len(cfg.Monitor.Queues) > 0 {
+		for _, queue := range live {
+			if configured[queue.Name] || cfg.Monitor.AutoExclude.Excludes(queue.Name, queue.Exclusive, queue.AutoDelete) {
+				continue
+			}
+			if prefixes[GroupName(queue.Name)] || matchesAny(patterns, queue.Name) {
+				diff.Unmonitored = append(diff.Unmonitored, queue.Name)
+			}
+		}
+	}
+
+	diff.Unused = unusedOverrides(cfg, exists)
+	sort.Strings(diff.Missing)
+	sort.Strings(diff.Unmonitored)
+	return diff
+}
+
+// unusedOverrides finds settings that change nothing: queue overrides equal to what the
+// queue inherits, profiles and priority classes no queue uses, and references to missing queues
+func unusedOverrides(cfg *config.Config, exists map[string]bool) []string {
+	var unused []string
+	monitor := &cfg.Monitor
+	usedProfiles := make(map[string]bool)
+	usedClasses := make(map[string]bool)
+
+	for i := range monitor.Queues {
+		queue := &monitor.Queues[i]
+		usedProfiles[queue.Profile] = true
+		usedClasses[queue.Priority] = true
+
+		inherited := monitor.GetQueueDetectionConfig(&config.QueueConfig{Priority: queue.Priority, Profile: queue.Profile})
+		if queue.ThresholdChecks != nil && *queue.ThresholdChecks == inherited.ThresholdChecks {
+			unused = append(unused, fmt.Sprintf("queue %s: threshold_checks %d is already inherited", queue.Name, *queue.ThresholdChecks))
+		}
+		if queue.MinMessageCount != nil && *queue.MinMessageCount == inherited.MinMessageCount {
+			unused = append(unused, fmt.Sprintf("queue %s: min_message_count %d is already inherited", queue.Name, *queue.MinMessageCount))
+		}
+		if queue.MinConsumeRate != nil && *queue.MinConsumeRate == inherited.MinConsumeRate {
+			unused = append(unused, fmt.Sprintf("queue %s: min_consume_rate %g is already inherited", queue.Name, *queue.MinConsumeRate))
+		}
+		if queue.MaxExpireRate != nil && *queue.MaxExpireRate == inherited.MaxExpireRate {
+			unused = append(unused, fmt.Sprintf("queue %s: max_expire_rate %g is already inherited", queue.Name, *queue.MaxExpireRate))
+		}
+		if queue.ExpiryTrackingQueue != "" && !exists[queue.ExpiryTrackingQueue] {
+			unused = append(unused, fmt.Sprintf("queue %s: expiry_tracking_queue %s does not exist on the broker", queue.Name, queue.ExpiryTrackingQueue))
+		}
+		if queue.CheckInterval != nil && *queue.CheckInterval == monitor.GetQueueCheckInterval(&config.QueueConfig{Profile: queue.Profile}) {
+			unused = append(unused, fmt.Sprintf("queue %s: check_interval %s is already inherited", queue.Name, *queue.CheckInterval))
+		}
+		for _, dependency := range queue.DependsOn {
+			if !exists[dependency] {
+				unused = append(unused, fmt.Sprintf("queue %s: depends_on %s, which does not exist on the broker", queue.Name, dependency))
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(monitor.Profiles) {
+		if !usedProfiles[name] {
+			unused = append(unused, fmt.Sprintf("profile %s is not used by any queue", name))
+		}
+	}
+	for _, name := range sortedKeys(monitor.PriorityClasses) {
+		if !usedClasses[name] {
+			unused = append(unused, fmt.Sprintf("priority class %s is not used by any queue", name))
+		}
+	}
+	for i, silence := range cfg.Silences {
+		for _, queueName := range silence.Queues {
+			if !exists[queueName] {
+				unused = append(unused, fmt.Sprintf("silences[%d]: queue %s does not exist on the broker", i, queueName))
+			}
+		}
+	}
+	return unused
+}
+
+// matchesAny reports whether a queue name matches any of the glob patterns
+func matchesAny(patterns []string, queueName string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, queueName); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
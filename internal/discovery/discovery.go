@@ -0,0 +1,148 @@
+package discovery
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/rabbitmq"
+)
+
+// separators split a queue name into its naming convention segments
+const separators = ".-_:"
+
+// deadLetterSuffixes mark queues that collect failed messages and usually have no consumers
+var deadLetterSuffixes = []string{"dlq", "dlx", "dead", "deadletter", "dead-letter", "error", "errors", "failed", "parking", "poison"}
+
+// Group is a set of queues sharing a naming convention prefix
+type Group struct {
+	Name        string
+	Suggestions []Suggestion
+}
+
+// Suggestion is a proposed queue block for a discovered queue
+type Suggestion struct {
+	Queue  rabbitmq.QueueInfo
+	Config config.QueueConfig
+	Notes  []string // Why settings were proposed, rendered as comments
+}
+
+// Result contains the grouped suggestions and the queues left out of them
+type Result struct {
+	Groups      []Group
+	ServerNamed []string // Auto-created queues left out by monitor.auto_exclude, such as RPC reply queues
+}
+
+// Discover groups queues by the first segment of their name and proposes a block per queue
+// Single queues whose prefix is shared by no other queue are collected in an "other" group
+func Discover(queues []rabbitmq.QueueInfo, detection config.DetectionConfig, exclude config.AutoExcludeConfig) Result {
+	var result Result
+	byPrefix := make(map[string][]rabbitmq.QueueInfo)
+	for _, queue := range queues {
+		if exclude.Excludes(queue.Name, queue.Exclusive, queue.AutoDelete) {
+			result.ServerNamed = append(result.ServerNamed, queue.Name)
+			continue
+		}
+		prefix := GroupName(queue.Name)
+		byPrefix[prefix] = append(byPrefix[prefix], queue)
+	}
+
+	var other []rabbitmq.QueueInfo
+	for prefix, members := range byPrefix {
+		if len(members) == 1 {
+			other = append(other, members[0])
+			continue
+		}
+		result.Groups = append(result.Groups, newGroup(prefix, members, detection))
+	}
+	sort.Slice(result.Groups, func(i, j int) bool {
+		return result.Groups[i].Name < result.Groups[j].Name
+	})
+	if len(other) > 0 {
+		result.Groups = append(result.Groups, newGroup("other", other, detection))
+	}
+	sort.Strings(result.ServerNamed)
+	return result
+}
+
+// GroupName returns the naming convention prefix of a queue, e.g. "orders" for "orders.created"
+func GroupName(queueName string) string {
+	if i := strings.IndexAny(queueName, separators); i > 0 {
+		return queueName[:i]
+	}
+	return queueName
+}
+
+// newGroup proposes blocks for the queues of a group, ordered by name
+func newGroup(name string, queues []rabbitmq.QueueInfo, detection config.DetectionConfig) Group {
+	sort.Slice(queues, func(i, j int) bool {
+		return queues[i].Name < queues[j].Name
+	})
+	group := Group{Name: name}
+	for _, queue := range queues {
+		group.Suggestions = append(group.Suggestions, suggest(queue, detection))
+	}
+	return group
+}
+
+// suggest proposes settings for a queue from its current state
+func suggest(queue rabbitmq.QueueInfo, detection config.DetectionConfig) Suggestion {
+	suggestion := Suggestion{
+		Queue:  queue,
+		Config: config.QueueConfig{Name: queue.Name},
+	}
+
+	if IsDeadLetter(queue.Name) {
+		suggestion.Config.ObserveOnly = true
+		suggestion.Notes = append(suggestion.Notes, "dead-letter queue, logged without notifications")
+		return suggestion
+	}
+
+	if queue.Consumers == 0 {
+		suggestion.Notes = append(suggestion.Notes, "no consumers attached at discovery")
+	} else {
+		consumers := queue.Consumers
+		suggestion.Config.ExpectedConsumers = &consumers
+		suggestion.Notes = append(suggestion.Notes, fmt.Sprintf("%d consumer(s) attached at discovery", queue.Consumers))
+	}
+
+	// A healthy queue already far above the threshold would alert as soon as its rate dips
+	if queue.Consumers > 0 && detection.MinMessageCount > 0 && queue.MessagesReady > detection.MinMessageCount*10 {
+		minMessages := roundUpNice(float64(queue.MessagesReady) * 2)
+		suggestion.Config.MinMessageCount = &minMessages
+		suggestion.Notes = append(suggestion.Notes, fmt.Sprintf("steady backlog of %d ready messages", queue.MessagesReady))
+	}
+	return suggestion
+}
+
+// IsDeadLetter reports whether a queue name ends in a dead-letter convention suffix
+func IsDeadLetter(queueName string) bool {
+	name := strings.ToLower(queueName)
+	for _, suffix := range deadLetterSuffixes {
+		if name == suffix {
+			return true
+		}
+		for _, separator := range separators {
+			if strings.HasSuffix(name, string(separator)+suffix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// roundUpNice rounds up to 1, 2 or 5 times a power of ten
+func roundUpNice(value float64) int {
+	if value <= 1 {
+		return 1
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(value)))
+	for _, step := range []float64{1, 2, 5, 10} {
+		if nice := step * magnitude; nice >= value {
+			return int(nice)
+		}
+	}
+	return int(10 * magnitude)
+}
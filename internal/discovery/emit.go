@@ -0,0 +1,127 @@
+package discovery
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"go-rmq-monitor/internal/config"
+)
+
+// maxListedServerNamed limits the skipped auto-created queues listed in comments
+const maxListedServerNamed = 10
+
+// WriteYAML emits a ready-to-edit config.yaml monitoring the discovered queues
+// The password is left empty; every other setting keeps its default
+func WriteYAML(w io.Writer, cfg *config.Config, result Result) error {
+	var b strings.Builder
+	b.WriteString("# Generated by `go-rmq-monitor discover --emit-config`\n")
+	b.WriteString("# Review the suggested queue blocks before use; see config.example.yaml for all settings\n\n")
+	b.WriteString("rabbitmq:\n")
+	fmt.Fprintf(&b, "  host: %q\n", cfg.RabbitMQ.Host)
+	fmt.Fprintf(&b, "  port: %d\n", cfg.RabbitMQ.Port)
+	fmt.Fprintf(&b, "  username: %q\n", cfg.RabbitMQ.Username)
+	b.WriteString("  password: \"\"   # fill in\n")
+	fmt.Fprintf(&b, "  vhost: %q\n", cfg.RabbitMQ.VHost)
+	fmt.Fprintf(&b, "  use_tls: %t\n\n", cfg.RabbitMQ.UseTLS)
+	b.WriteString("monitor:\n")
+	fmt.Fprintf(&b, "  interval: %s\n", cfg.Monitor.Interval)
+	b.WriteString("  queues:\n")
+	for i, group := range result.Groups {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "    # %s (%d queue(s))\n", group.Name, len(group.Suggestions))
+		for _, suggestion := range group.Suggestions {
+			writeYAMLQueue(&b, suggestion)
+		}
+	}
+	writeServerNamed(&b, result.ServerNamed, "    ")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeYAMLQueue renders one queue block with its notes as comments
+func writeYAMLQueue(b *strings.Builder, suggestion Suggestion) {
+	queue := suggestion.Config
+	fmt.Fprintf(b, "    - name: %q", queue.Name)
+	if len(suggestion.Notes) > 0 {
+		b.WriteString("   # " + strings.Join(suggestion.Notes, "; "))
+	}
+	b.WriteString("\n")
+	if queue.ObserveOnly {
+		b.WriteString("      observe_only: true\n")
+	}
+	if queue.ExpectedConsumers != nil {
+		fmt.Fprintf(b, "      expected_consumers: %d\n", *queue.ExpectedConsumers)
+	}
+	if queue.MinMessageCount != nil {
+		fmt.Fprintf(b, "      min_message_count: %d\n", *queue.MinMessageCount)
+	}
+}
+
+// WriteHCL emits the discovered queues as a Terraform locals block
+// Render it into the monitor config with yamlencode({ monitor = { queues = local.rabbitmq_monitor_queues } })
+func WriteHCL(w io.Writer, result Result) error {
+	var b strings.Builder
+	b.WriteString("# Generated by `go-rmq-monitor discover --emit-config --format hcl`\n")
+	b.WriteString("locals {\n")
+	b.WriteString("  rabbitmq_monitor_queues = [\n")
+	for i, group := range result.Groups {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "    # %s (%d queue(s))\n", group.Name, len(group.Suggestions))
+		for _, suggestion := range group.Suggestions {
+			writeHCLQueue(&b, suggestion)
+		}
+	}
+	b.WriteString("  ]\n")
+	writeServerNamed(&b, result.ServerNamed, "  ")
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeHCLQueue renders one queue as an HCL object with aligned attributes
+func writeHCLQueue(b *strings.Builder, suggestion Suggestion) {
+	queue := suggestion.Config
+	attributes := [][2]string{{"name", fmt.Sprintf("%q", queue.Name)}}
+	if queue.ObserveOnly {
+		attributes = append(attributes, [2]string{"observe_only", "true"})
+	}
+	if queue.ExpectedConsumers != nil {
+		attributes = append(attributes, [2]string{"expected_consumers", fmt.Sprintf("%d", *queue.ExpectedConsumers)})
+	}
+	if queue.MinMessageCount != nil {
+		attributes = append(attributes, [2]string{"min_message_count", fmt.Sprintf("%d", *queue.MinMessageCount)})
+	}
+
+	width := 0
+	for _, attribute := range attributes {
+		width = max(width, len(attribute[0]))
+	}
+	b.WriteString("    {")
+	if len(suggestion.Notes) > 0 {
+		b.WriteString(" # " + strings.Join(suggestion.Notes, "; "))
+	}
+	b.WriteString("\n")
+	for _, attribute := range attributes {
+		fmt.Fprintf(b, "      %-*s = %s\n", width, attribute[0], attribute[1])
+	}
+	b.WriteString("    },\n")
+}
+
+// writeServerNamed lists skipped auto-created queues as a comment
+func writeServerNamed(b *strings.Builder, names []string, indent string) {
+	if len(names) == 0 {
+		return
+	}
+	listed := names
+	if len(listed) > maxListedServerNamed {
+		listed = append(listed[:maxListedServerNamed:maxListedServerNamed], fmt.Sprintf("and %d more", len(names)-maxListedServerNamed))
+	}
+	fmt.Fprintf(b, "%s# Skipped %d auto-created queue(s): %s\n", indent, len(names), strings.Join(listed, ", "))
+}
@@ -0,0 +1,155 @@
+package events
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go-rmq-monitor/internal/rabbitmq"
+)
+
+// Event types detected from broker state changes
+const (
+	TypeMemoryAlarm   = "memory_alarm"
+	TypeDiskAlarm     = "disk_alarm"
+	TypeNodeRestarted = "node_restarted"
+	TypeNodeDown      = "node_down"
+	TypeNodeUp        = "node_up"
+	TypePolicyChanged = "policy_changed"
+)
+
+// Event represents a broker-side event detected between checks
+type Event struct {
+	Timestamp time.Time
+	Type      string
+	Source    string // Node or policy name
+	Message   string
+}
+
+// String returns a human-readable description of the event
+func (e Event) String() string {
+	return fmt.Sprintf("%s %s", e.Timestamp.UTC().Format("15:04:05 UTC"), e.Message)
+}
+
+// Tracker detects broker events by comparing successive node and policy snapshots
+type Tracker struct {
+	window     time.Duration
+	nodes      map[string]rabbitmq.NodeInfo
+	policies   map[string]rabbitmq.PolicyInfo
+	seenNodes  bool
+	seenPolicy bool
+	events     []Event
+	mu         sync.Mutex
+}
+
+// New creates a new broker event tracker
+// Events older than window are discarded
+func New(window time.Duration) *Tracker {
+	return &Tracker{
+		window:   window,
+		nodes:    make(map[string]rabbitmq.NodeInfo),
+		policies: make(map[string]rabbitmq.PolicyInfo),
+		events:   make([]Event, 0),
+	}
+}
+
+// ObserveNodes records node status and emits events for alarms, restarts and outages
+// The first observation only establishes a baseline
+func (t *Tracker) ObserveNodes(nodes []rabbitmq.NodeInfo, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, node := range nodes {
+		prev, known := t.nodes[node.Name]
+		t.nodes[node.Name] = node
+
+		if !t.seenNodes {
+			continue
+		}
+
+		if node.MemAlarm && (!known || !prev.MemAlarm) {
+			t.add(now, TypeMemoryAlarm, node.Name, fmt.Sprintf("memory alarm raised on %s", node.Name))
+		}
+		if node.DiskFreeAlarm && (!known || !prev.DiskFreeAlarm) {
+			t.add(now, TypeDiskAlarm, node.Name, fmt.Sprintf("disk free alarm raised on %s", node.Name))
+		}
+		if !known {
+			continue
+		}
+		if prev.Running && !node.Running {
+			t.add(now, TypeNodeDown, node.Name, fmt.Sprintf("node %s stopped running", node.Name))
+		} else if !prev.Running && node.Running {
+			t.add(now, TypeNodeUp, node.Name, fmt.Sprintf("node %s started running", node.Name))
+		} else if node.Running && node.Uptime < prev.Uptime {
+			t.add(now, TypeNodeRestarted, node.Name, fmt.Sprintf("node %s restarted", node.Name))
+		}
+	}
+
+	t.seenNodes = true
+	t.prune(now)
+}
+
+// ObservePolicies records policy definitions and emits events for added, removed or changed policies
+// The first observation only establishes a baseline
+func (t *Tracker) ObservePolicies(policies []rabbitmq.PolicyInfo, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	current := make(map[string]rabbitmq.PolicyInfo, len(policies))
+	for _, policy := range policies {
+		current[policy.Name] = policy
+	}
+
+	if t.seenPolicy {
+		for name, policy := range current {
+			prev, known := t.policies[name]
+			if !known {
+				t.add(now, TypePolicyChanged, name, fmt.Sprintf("policy %s added", name))
+			} else if prev != policy {
+				t.add(now, TypePolicyChanged, name, fmt.Sprintf("policy %s changed", name))
+			}
+		}
+		for name := range t.policies {
+			if _, exists := current[name]; !exists {
+				t.add(now, TypePolicyChanged, name, fmt.Sprintf("policy %s removed", name))
+			}
+		}
+	}
+
+	t.policies = current
+	t.seenPolicy = true
+	t.prune(now)
+}
+
+// Recent returns events that occurred within the correlation window
+func (t *Tracker) Recent(now time.Time) []Event {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.prune(now)
+	result := make([]Event, len(t.events))
+	copy(result, t.events)
+	return result
+}
+
+// add appends an event (caller must hold the lock)
+func (t *Tracker) add(now time.Time, eventType, source, message string) {
+	t.events = append(t.events, Event{
+		Timestamp: now,
+		Type:      eventType,
+		Source:    source,
+		Message:   message,
+	})
+}
+
+// prune drops events older than the correlation window (caller must hold the lock)
+func (t *Tracker) prune(now time.Time) {
+	cutoff := now.Add(-t.window)
+	kept := t.events[:0]
+	for _, event := range t.events {
+		if !event.Timestamp.Before(cutoff) {
+			kept = append(kept, event)
+		}
+	}
+	t.events = kept
+}
@@ -0,0 +1,207 @@
+package feedback
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go-rmq-monitor/internal/server"
+)
+
+// Sources a false positive mark can come from
+const (
+	SourceAPI   = "api"
+	SourceCLI   = "cli"
+	SourceSlack = "slack"
+)
+
+// ErrNoAlert is returned when marking a queue that has not alerted since the monitor started
+var ErrNoAlert = errors.New("queue has no alert to mark")
+
+// AlertContext describes the queue and detection settings at the time of marking
+type AlertContext struct {
+	Alerting         bool      `json:"alerting"`
+	StuckSince       time.Time `json:"stuck_since"`
+	ConsecutiveStuck int       `json:"consecutive_stuck"`
+	MessagesReady    int       `json:"messages_ready"`
+	Consumers        int       `json:"consumers"`
+	ConsumeRate      float64   `json:"consume_rate"`
+	AckRate          float64   `json:"ack_rate"`
+	ThresholdChecks  int       `json:"threshold_checks"`
+	MinMessageCount  int       `json:"min_message_count"`
+	MinConsumeRate   float64   `json:"min_consume_rate"`
+}
+
+// Mark records an alert an operator judged to be a false positive
+type Mark struct {
+	QueueName string       `json:"queue"`
+	By        string       `json:"by"`
+	Source    string       `json:"source"`
+	Comment   string       `json:"comment,omitempty"`
+	Identity  string       `json:"identity,omitempty"` // Authenticated API token name, if API auth is enabled
+	Timestamp time.Time    `json:"timestamp"`
+	Context   AlertContext `json:"context"`
+}
+
+// Store appends false positive marks to a JSON lines file
+// Marks are kept indefinitely and used by the analyze-config command
+type Store struct {
+	path     string
+	describe func(queueName string) (AlertContext, bool)
+	onMark   func(Mark)
+	file     *os.File
+	mu       sync.Mutex
+}
+
+// Open opens the feedback file for appending
+// describe returns the alert context for a queue, or false if it has not alerted
+// onMark, if not nil, is called after every recorded mark
+func Open(path string, describe func(queueName string) (AlertContext, bool), onMark func(Mark)) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create feedback directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open feedback file: %w", err)
+	}
+
+	return &Store{
+		path:     path,
+		describe: describe,
+		onMark:   onMark,
+		file:     file,
+	}, nil
+}
+
+// MarkFalsePositive records the queue's current or most recent alert as a false positive
+// identity is the authenticated API token name, empty when API auth is disabled
+func (s *Store) MarkFalsePositive(queueName, by, source, comment, identity string, now time.Time) (Mark, error) {
+	alertContext, exists := s.describe(queueName)
+	if !exists {
+		return Mark{}, ErrNoAlert
+	}
+
+	mark := Mark{
+		QueueName: queueName,
+		By:        by,
+		Source:    source,
+		Comment:   comment,
+		Identity:  identity,
+		Timestamp: now,
+		Context:   alertContext,
+	}
+
+	data, err := json.Marshal(mark)
+	if err != nil {
+		return Mark{}, fmt.Errorf("failed to encode mark: %w", err)
+	}
+
+	s.mu.Lock()
+	_, err = s.file.Write(append(data, '\n'))
+	s.mu.Unlock()
+	if err != nil {
+		return Mark{}, fmt.Errorf("failed to write mark: %w", err)
+	}
+
+	if s.onMark != nil {
+		s.onMark(mark)
+	}
+	return mark, nil
+}
+
+// Close closes the feedback file
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// markRequest is the JSON body accepted by the false positive endpoint
+type markRequest struct {
+	By      string `json:"by"`
+	Source  string `json:"source"`
+	Comment string `json:"comment"`
+}
+
+// HandleMark marks the alert of the queue in the request path as a false positive
+// Registered as POST /api/false-positives/{queue}
+func (s *Store) HandleMark(w http.ResponseWriter, r *http.Request) {
+	var req markRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	if req.By == "" {
+		http.Error(w, "\"by\" is required", http.StatusBadRequest)
+		return
+	}
+	if req.Source != SourceCLI {
+		req.Source = SourceAPI
+	}
+
+	mark, err := s.MarkFalsePositive(r.PathValue("queue"), req.By, req.Source, req.Comment, server.Identity(r), time.Now())
+	if errors.Is(err, ErrNoAlert) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(mark)
+}
+
+// HandleList returns all recorded marks grouped by queue as JSON
+// Registered as GET /api/false-positives
+func (s *Store) HandleList(w http.ResponseWriter, r *http.Request) {
+	marks, err := Load(s.path, time.Time{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(marks)
+}
+
+// Load reads all marks newer than since, grouped by queue in chronological order
+// A missing file is not an error and returns no marks
+func Load(path string, since time.Time) (map[string][]Mark, error) {
+	result := make(map[string][]Mark)
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open feedback file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var mark Mark
+		if err := json.Unmarshal(scanner.Bytes(), &mark); err != nil {
+			// Skip partially written or corrupt lines
+			continue
+		}
+		if mark.Timestamp.Before(since) {
+			continue
+		}
+		result[mark.QueueName] = append(result[mark.QueueName], mark)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read feedback file: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,124 @@
+package heartbeat
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Tracker records consumer heartbeats per queue
+// Only heartbeats of expected queues are accepted, so clients cannot grow it with arbitrary names
+type Tracker struct {
+	maxAge     time.Duration
+	expected   map[string]bool
+	heartbeats map[string]time.Time
+	mu         sync.RWMutex
+}
+
+// Status describes the heartbeat state of a queue's consumers
+type Status struct {
+	QueueName     string    `json:"queue"`
+	LastHeartbeat time.Time `json:"last_heartbeat,omitempty"`
+	Alive         bool      `json:"alive"`
+}
+
+// New creates a new heartbeat tracker accepting heartbeats for the expected queues
+// Heartbeats older than maxAge are considered missing
+func New(maxAge time.Duration, expected map[string]bool) *Tracker {
+	return &Tracker{
+		maxAge:     maxAge,
+		expected:   expected,
+		heartbeats: make(map[string]time.Time),
+	}
+}
+
+// SetExpected replaces the queues heartbeats are accepted for, forgetting those of other queues
+func (t *Tracker) SetExpected(expected map[string]bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.expected = expected
+	for queueName := range t.heartbeats {
+		if !expected[queueName] {
+			delete(t.heartbeats, queueName)
+		}
+	}
+}
+
+// Record stores a heartbeat for a queue's consumer
+// Returns false without storing it when the queue does not expect heartbeats
+func (t *Tracker) Record(queueName string, at time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.expected[queueName] {
+		return false
+	}
+	t.heartbeats[queueName] = at
+	return true
+}
+
+// GetStatus returns the heartbeat status for a queue
+func (t *Tracker) GetStatus(queueName string, now time.Time) Status {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	last, exists := t.heartbeats[queueName]
+	return Status{
+		QueueName:     queueName,
+		LastHeartbeat: last,
+		Alive:         exists && now.Sub(last) <= t.maxAge,
+	}
+}
+
+// Describe returns a human-readable summary of the heartbeat status
+// Used to enrich stuck queue reasons
+func (t *Tracker) Describe(queueName string, now time.Time) string {
+	status := t.GetStatus(queueName, now)
+	if status.LastHeartbeat.IsZero() {
+		return "no consumer heartbeat received"
+	}
+	age := now.Sub(status.LastHeartbeat).Round(time.Second)
+	if !status.Alive {
+		return fmt.Sprintf("consumer heartbeat missing for %s", age)
+	}
+	return fmt.Sprintf("consumer heartbeat alive (last %s ago)", age)
+}
+
+// HandleHeartbeat records a heartbeat for the queue in the request path
+// Registered as POST /heartbeats/{queue}
+func (t *Tracker) HandleHeartbeat(w http.ResponseWriter, r *http.Request) {
+	queueName := r.PathValue("queue")
+	if queueName == "" {
+		http.Error(w, "queue name is required", http.StatusBadRequest)
+		return
+	}
+	if !t.Record(queueName, time.Now()) {
+		http.Error(w, "queue is not configured with expect_heartbeat", http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleList returns the heartbeat status of all known queues as JSON
+// Registered as GET /heartbeats
+func (t *Tracker) HandleList(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+
+	t.mu.RLock()
+	names := make([]string, 0, len(t.heartbeats))
+	for name := range t.heartbeats {
+		names = append(names, name)
+	}
+	t.mu.RUnlock()
+	sort.Strings(names)
+
+	statuses := make([]Status, 0, len(names))
+	for _, name := range names {
+		statuses = append(statuses, t.GetStatus(name, now))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(statuses)
+}
@@ -0,0 +1,201 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go-rmq-monitor/internal/rabbitmq"
+)
+
+// Record is a single stored queue sample
+type Record struct {
+	Timestamp       time.Time `json:"timestamp"`
+	QueueName       string    `json:"queue"`
+	MessagesReady   int       `json:"messages_ready"`
+	MessagesUnacked int       `json:"messages_unacked,omitempty"`
+	Consumers       int       `json:"consumers"`
+	ConsumeRate     float64   `json:"consume_rate"`
+	AckRate         float64   `json:"ack_rate"`
+	PublishRate     float64   `json:"publish_rate"`
+	RedeliverRate   float64   `json:"redeliver_rate,omitempty"`
+	State           string    `json:"state,omitempty"`  // healthy, alerting or recovering after the check, empty in older files
+	Reason          string    `json:"reason,omitempty"` // Why the queue started alerting, on the check it did
+}
+
+// Queue states stored with samples
+const (
+	StateHealthy    = "healthy"
+	StateAlerting   = "alerting"
+	StateRecovering = "recovering"
+)
+
+// Status is a queue's analysis outcome, stored with its sample
+type Status struct {
+	State  string
+	Reason string
+}
+
+// compactInterval is how often a running store drops records older than its retention
+const compactInterval = time.Hour
+
+// Store appends queue samples to a JSON lines file
+type Store struct {
+	path        string
+	retention   time.Duration
+	file        *os.File
+	lastCompact time.Time
+	mu          sync.Mutex
+}
+
+// Open opens the history file for appending
+// Records older than retention are dropped when the file is opened and hourly while appending
+func Open(path string, retention time.Duration) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	if err := compact(path, time.Now().Add(-retention)); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history file: %w", err)
+	}
+
+	return &Store{
+		path:        path,
+		retention:   retention,
+		file:        file,
+		lastCompact: time.Now(),
+	}, nil
+}
+
+// Append stores a sample for each queue, with its status if statuses has one
+func (s *Store) Append(queues []rabbitmq.QueueInfo, statuses map[string]Status, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writer := bufio.NewWriter(s.file)
+	encoder := json.NewEncoder(writer)
+	for _, queue := range queues {
+		record := Record{
+			Timestamp:       at,
+			QueueName:       queue.Name,
+			MessagesReady:   queue.MessagesReady,
+			MessagesUnacked: queue.MessagesUnacked,
+			Consumers:       queue.Consumers,
+			ConsumeRate:     queue.ConsumeRate,
+			AckRate:         queue.AckRate,
+			PublishRate:     queue.PublishRate,
+			RedeliverRate:   queue.RedeliverRate,
+			State:           statuses[queue.Name].State,
+			Reason:          statuses[queue.Name].Reason,
+		}
+		if err := encoder.Encode(record); err != nil {
+			return fmt.Errorf("failed to encode history record: %w", err)
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+
+	if at.Sub(s.lastCompact) >= compactInterval {
+		return s.compactLocked(at)
+	}
+	return nil
+}
+
+// compactLocked drops records older than the retention and reopens the file; the caller holds mu
+func (s *Store) compactLocked(now time.Time) error {
+	s.lastCompact = now
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("failed to close history file: %w", err)
+	}
+	compactErr := compact(s.path, now.Add(-s.retention))
+
+	// Keep appending even if compaction failed
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to reopen history file: %w", err)
+	}
+	s.file = file
+	return compactErr
+}
+
+// Close closes the history file
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// Load reads all records newer than since, grouped by queue in chronological order
+func Load(path string, since time.Time) (map[string][]Record, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer file.Close()
+
+	result := make(map[string][]Record)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			// Skip partially written or corrupt lines
+			continue
+		}
+		if record.Timestamp.Before(since) {
+			continue
+		}
+		result[record.QueueName] = append(result[record.QueueName], record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+
+	return result, nil
+}
+
+// compact rewrites the history file without records older than cutoff
+func compact(path string, cutoff time.Time) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+
+	records, err := Load(path, cutoff)
+	if err != nil {
+		return err
+	}
+
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to compact history: %w", err)
+	}
+
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for _, queueRecords := range records {
+		for _, record := range queueRecords {
+			encoder.Encode(record)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to compact history: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to compact history: %w", err)
+	}
+
+	return os.Rename(tmpPath, path)
+}
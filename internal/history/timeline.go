@@ -0,0 +1,94 @@
+package history
+
+import (
+	"time"
+)
+
+// StateUnknown marks periods without samples carrying a state, such as samples stored by older versions
+const StateUnknown = "unknown"
+
+// Interval is a period a queue spent in one state
+type Interval struct {
+	State string    `json:"state"` // healthy, alerting, recovering or unknown
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Event is a change of a queue's alerting state
+type Event struct {
+	Timestamp time.Time `json:"timestamp"`
+	Type      string    `json:"type"` // alerting, recovering or recovered
+	Reason    string    `json:"reason,omitempty"`
+}
+
+// Timeline is a queue's state history over a time range
+type Timeline struct {
+	Queue     string     `json:"queue"`
+	From      time.Time  `json:"from"`
+	To        time.Time  `json:"to"`
+	Intervals []Interval `json:"intervals"`
+	Events    []Event    `json:"events"`
+}
+
+// BuildTimeline turns a queue's chronological samples into state intervals and alert events within [from, to)
+// Each sample's state holds until the next sample; the last one holds until to
+func BuildTimeline(queue string, records []Record, from, to time.Time) Timeline {
+	timeline := Timeline{
+		Queue:     queue,
+		From:      from,
+		To:        to,
+		Intervals: make([]Interval, 0),
+		Events:    make([]Event, 0),
+	}
+
+	previous := ""
+	for i, record := range records {
+		if !record.Timestamp.Before(to) {
+			break
+		}
+		state := record.State
+		if state == "" {
+			state = StateUnknown
+		}
+		if event, changed := stateEvent(previous, state); changed && !record.Timestamp.Before(from) {
+			timeline.Events = append(timeline.Events, Event{Timestamp: record.Timestamp, Type: event, Reason: record.Reason})
+		}
+		previous = state
+
+		end := to
+		if i+1 < len(records) && records[i+1].Timestamp.Before(to) {
+			end = records[i+1].Timestamp
+		}
+		if !end.After(from) {
+			continue
+		}
+		start := record.Timestamp
+		if start.Before(from) {
+			start = from
+		}
+
+		last := len(timeline.Intervals) - 1
+		if last >= 0 && timeline.Intervals[last].State == state && timeline.Intervals[last].End.Equal(start) {
+			timeline.Intervals[last].End = end
+			continue
+		}
+		timeline.Intervals = append(timeline.Intervals, Interval{State: state, Start: start, End: end})
+	}
+	return timeline
+}
+
+// stateEvent returns the alert event of a state change, if it is one
+// Changes from the first or an unknown state are not events, the change itself is not known
+func stateEvent(from, to string) (string, bool) {
+	switch {
+	case from == to || from == "" || from == StateUnknown || to == StateUnknown:
+		return "", false
+	case to == StateAlerting && from != StateRecovering:
+		return StateAlerting, true
+	case to == StateRecovering:
+		return StateRecovering, true
+	case to == StateHealthy && (from == StateAlerting || from == StateRecovering):
+		return "recovered", true
+	}
+	return "", false
+}
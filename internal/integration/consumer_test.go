@@ -0,0 +1,116 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// consumer is how a scenario's consumer behaves, played by a real AMQP consumer on the scenario's queue
+type consumer struct {
+	prefetch    int
+	processing  time.Duration // Time spent on each message before acknowledging it
+	stall       bool          // Takes deliveries but never acknowledges them
+	attachAfter time.Duration // When the consumer attaches, 0 for right away
+	crashAfter  time.Duration // When the consumer's socket drops after attaching, 0 for never
+}
+
+// healthy acknowledges every message as soon as it is delivered
+func healthy() consumer {
+	return consumer{prefetch: 50}
+}
+
+// stalled takes a prefetch worth of messages and never acknowledges them,
+// like a consumer blocked on a downstream call; the broker delivers it nothing more
+func stalled() consumer {
+	return consumer{prefetch: 10, stall: true}
+}
+
+// slow processes one message at a time at a fixed rate per second
+func slow(perSecond int) consumer {
+	return consumer{prefetch: 1, processing: time.Second / time.Duration(perSecond)}
+}
+
+// crashing keeps up until its socket drops after the given time, without closing the connection,
+// so the broker requeues its unacknowledged messages and the queue is left without a consumer
+func crashing(after time.Duration) consumer {
+	return consumer{prefetch: 50, crashAfter: after}
+}
+
+// restarting is absent until it attaches after the given time and drains the backlog
+func restarting(after time.Duration) consumer {
+	return consumer{prefetch: 50, attachAfter: after}
+}
+
+// run plays the consumer on a queue until ctx is done or the consumer crashes
+func (c consumer) run(ctx context.Context, amqpURL, queue string) error {
+	if c.attachAfter > 0 {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(c.attachAfter):
+		}
+	}
+
+	// The socket is kept to drop it without the AMQP close handshake
+	var socket net.Conn
+	conn, err := amqp.DialConfig(amqpURL, amqp.Config{
+		Properties: amqp.Table{"connection_name": "go-rmq-monitor integration consumer"},
+		Dial: func(network, addr string) (net.Conn, error) {
+			var err error
+			socket, err = net.DialTimeout(network, addr, 10*time.Second)
+			return socket, err
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to connect consumer: %w", err)
+	}
+	defer conn.Close()
+	channel, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("failed to open consumer channel: %w", err)
+	}
+	if err := channel.Qos(c.prefetch, 0, false); err != nil {
+		return fmt.Errorf("failed to set prefetch: %w", err)
+	}
+	deliveries, err := channel.Consume(queue, "", false, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to consume from %s: %w", queue, err)
+	}
+
+	var crash <-chan time.Time
+	if c.crashAfter > 0 {
+		crash = time.After(c.crashAfter)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-crash:
+			socket.Close()
+			return nil
+		case delivery, ok := <-deliveries:
+			if !ok {
+				return fmt.Errorf("consumer on %s closed by the broker", queue)
+			}
+			if c.stall {
+				continue
+			}
+			if c.processing > 0 {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-time.After(c.processing):
+				}
+			}
+			if err := delivery.Ack(false); err != nil {
+				return fmt.Errorf("failed to acknowledge: %w", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,140 @@
+//go:build integration
+
+// Package integration checks detection end to end against a RabbitMQ container started with Docker
+// Run with go test -tags integration ./...
+package integration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"go-rmq-monitor/internal/analyzer"
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/rabbitmq"
+)
+
+// testBroker is the container shared by all scenarios, each on a queue of its own
+var testBroker *broker
+
+func TestMain(m *testing.M) {
+	image := os.Getenv("RMQ_MONITOR_IMAGE")
+	if image == "" {
+		image = defaultImage
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	b, err := startBroker(ctx, image)
+	cancel()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "integration: %v\n", err)
+		os.Exit(1)
+	}
+	testBroker = b
+	code := m.Run()
+	b.stop()
+	os.Exit(code)
+}
+
+// scenario publishes to a fresh queue while a consumer behaves in a controlled way
+type scenario struct {
+	name             string
+	publishPerSecond int
+	consumer         consumer
+	expect           []string      // Expected sequence of states entered, empty for none
+	within           time.Duration // How long to run before giving up on the expectation
+}
+
+// scenarios are the end-to-end detection cases
+var scenarios = []scenario{
+	{name: "healthy", publishPerSecond: 3, consumer: healthy(), within: 40 * time.Second},
+	{name: "stalled", publishPerSecond: 3, consumer: stalled(), expect: []string{"alerting"}, within: 60 * time.Second},
+	{name: "slow", publishPerSecond: 6, consumer: slow(1), expect: []string{"alerting"}, within: 60 * time.Second},
+	{name: "crash", publishPerSecond: 3, consumer: crashing(15 * time.Second), expect: []string{"alerting"}, within: 75 * time.Second},
+	{name: "recovery", publishPerSecond: 3, consumer: restarting(40 * time.Second), expect: []string{"alerting", "not_alerting"}, within: 90 * time.Second},
+}
+
+// checkInterval is how often scenarios check their queue, often enough to finish within a minute or two
+const checkInterval = 5 * time.Second
+
+// detection holds the settings scenarios are analyzed with
+var detection = config.DetectionConfig{
+	ThresholdChecks: 3,
+	MinMessageCount: 5,
+	MinConsumeRate:  0.1,
+}
+
+func TestScenarios(t *testing.T) {
+	for _, sc := range scenarios {
+		t.Run(sc.name, func(t *testing.T) {
+			t.Parallel()
+			entered := runScenario(t, sc)
+			if strings.Join(entered, ",") != strings.Join(sc.expect, ",") {
+				t.Errorf("entered %v, expected %v", entered, sc.expect)
+			}
+		})
+	}
+}
+
+// runScenario plays a scenario against the broker, feeding the real client and analyzer,
+// and returns the states entered in order
+// Messages are published over AMQP once a second while the scenario's consumer runs alongside
+func runScenario(t *testing.T, sc scenario) []string {
+	queue := "integration." + sc.name
+	testBroker.declareQueue(t, queue)
+	cfg := testBroker.config
+
+	client, err := rabbitmq.NewClient(&cfg)
+	if err != nil {
+		t.Fatalf("failed to create RabbitMQ client: %v", err)
+	}
+	publisher := testBroker.newPublisher(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	consumerErr := make(chan error, 1)
+	go func() {
+		if err := sc.consumer.run(ctx, testBroker.amqpURL, queue); err != nil {
+			consumerErr <- err
+		}
+	}()
+	defer cancel()
+
+	detector := analyzer.New(&detection)
+	var entered []string
+	start := time.Now()
+	lastCheck := start
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for time.Since(start) < sc.within {
+		for i := 0; i < sc.publishPerSecond; i++ {
+			if err := publisher.publish(queue); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if time.Since(lastCheck) >= checkInterval {
+			lastCheck = time.Now()
+			info, err := client.GetQueue(queue)
+			if err != nil {
+				t.Fatalf("failed to fetch queue: %v", err)
+			}
+			for _, transition := range detector.Analyze([]rabbitmq.QueueInfo{*info}).Transitions {
+				entered = append(entered, transition.ToState)
+				t.Logf("%s after %s: %s", transition.ToState, time.Since(start).Round(time.Second), transition.Reason)
+			}
+			// Scenarios without expected transitions run their full duration
+			if len(sc.expect) > 0 && len(entered) >= len(sc.expect) {
+				break
+			}
+		}
+
+		select {
+		case err := <-consumerErr:
+			t.Fatal(err)
+		case <-ticker.C:
+		}
+	}
+	return entered
+}
@@ -24,10 +24,14 @@ const (
 
 // Logger handles application logging
 type Logger struct {
-	file   *os.File
-	mu     sync.Mutex
-	level  Level
-	format string
+	file    *os.File
+	mu      sync.Mutex
+	level   Level
+	format  string
+	color   bool // Colorize pretty console output
+	sampler *sampler
+	scope   map[string]interface{} // Fields added to every entry, such as the running check's ID
+	parent  *Logger                // Logger entries are written through, nil for a root logger
 }
 
 // LogEntry represents a structured log entry
@@ -40,7 +44,13 @@ type LogEntry struct {
 }
 
 // New creates a new logger instance
+// Without a file path entries are only written to stdout
 func New(cfg config.LoggingConfig) (*Logger, error) {
+	color := cfg.Format == FormatPretty && colorSupported(os.Stdout)
+	if cfg.FilePath == "" {
+		return &Logger{level: parseLevel(cfg.Level), format: cfg.Format, color: color, sampler: newSampler(cfg.Sampling)}, nil
+	}
+
 	// Create log directory if it doesn't exist
 	logDir := filepath.Dir(cfg.FilePath)
 	if err := os.MkdirAll(logDir, 0755); err != nil {
@@ -57,9 +67,11 @@ func New(cfg config.LoggingConfig) (*Logger, error) {
 	level := parseLevel(cfg.Level)
 
 	return &Logger{
-		file:   file,
-		level:  level,
-		format: cfg.Format,
+		file:    file,
+		level:   level,
+		format:  cfg.Format,
+		color:   color,
+		sampler: newSampler(cfg.Sampling),
 	}, nil
 }
 
@@ -97,6 +109,10 @@ func levelToString(level Level) string {
 
 // log writes a log entry
 func (l *Logger) log(level Level, message string, err error, fields map[string]interface{}) {
+	if l.parent != nil {
+		l.parent.log(level, message, err, l.scoped(fields))
+		return
+	}
 	if level < l.level {
 		return
 	}
@@ -104,8 +120,27 @@ func (l *Logger) log(level Level, message string, err error, fields map[string]i
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
+	now := time.Now()
+	allowed, dropped := l.sampler.allow(level, message, fields, now)
+	if !allowed {
+		return
+	}
+	if dropped > 0 || len(l.scope) > 0 {
+		merged := make(map[string]interface{}, len(fields)+len(l.scope)+1)
+		for key, value := range l.scope {
+			merged[key] = value
+		}
+		for key, value := range fields {
+			merged[key] = value
+		}
+		if dropped > 0 {
+			merged["suppressed"] = dropped
+		}
+		fields = merged
+	}
+
 	entry := LogEntry{
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Timestamp: now.UTC().Format(time.RFC3339),
 		Level:     levelToString(level),
 		Message:   message,
 		Fields:    fields,
@@ -115,8 +150,9 @@ func (l *Logger) log(level Level, message string, err error, fields map[string]i
 		entry.Error = err.Error()
 	}
 
+	// Pretty output is for the console only, files keep JSON
 	var output string
-	if l.format == "json" {
+	if l.format == "json" || l.format == FormatPretty {
 		jsonBytes, _ := json.Marshal(entry)
 		output = string(jsonBytes) + "\n"
 	} else {
@@ -125,9 +161,14 @@ func (l *Logger) log(level Level, message string, err error, fields map[string]i
 	}
 
 	// Write to file
-	io.WriteString(l.file, output)
+	if l.file != nil {
+		io.WriteString(l.file, output)
+	}
 	
 	// Also write to stdout for visibility
+	if l.format == FormatPretty {
+		output = l.formatPretty(entry, now)
+	}
 	io.WriteString(os.Stdout, output)
 }
 
@@ -167,6 +208,37 @@ func (l *Logger) Error(message string, err error, fields map[string]interface{})
 	l.log(LevelError, message, err, fields)
 }
 
+// With returns a logger writing through l that adds fields to its entries
+// Scope set on it applies to its own entries only, not to those logged through l
+func (l *Logger) With(fields map[string]interface{}) *Logger {
+	return &Logger{parent: l, scope: fields}
+}
+
+// SetScope sets fields added to every following entry, or clears them when fields is nil
+// Fields passed with an entry take precedence over scope fields of the same name
+func (l *Logger) SetScope(fields map[string]interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.scope = fields
+}
+
+// scoped returns fields with a child logger's scope added
+func (l *Logger) scoped(fields map[string]interface{}) map[string]interface{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.scope) == 0 {
+		return fields
+	}
+	merged := make(map[string]interface{}, len(fields)+len(l.scope))
+	for key, value := range l.scope {
+		merged[key] = value
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+	return merged
+}
+
 // Close closes the log file
 func (l *Logger) Close() error {
 	l.mu.Lock()
@@ -0,0 +1,117 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormatPretty writes colored, aligned lines to the console and JSON to the log file
+const FormatPretty = "pretty"
+
+// prettyMessageWidth is the column fields start at when the message is shorter
+const prettyMessageWidth = 36
+
+// ANSI escape sequences used by pretty output
+const (
+	ansiReset  = "\033[0m"
+	ansiBold   = "\033[1m"
+	ansiRed    = "\033[31m"
+	ansiYellow = "\033[33m"
+	ansiCyan   = "\033[36m"
+	ansiGray   = "\033[90m"
+)
+
+// levelColors colors the level label of each log level
+var levelColors = map[string]string{
+	"debug": ansiGray,
+	"info":  ansiCyan,
+	"warn":  ansiYellow,
+	"error": ansiBold + ansiRed,
+}
+
+// colorSupported reports whether f is a terminal that should receive colors
+// Colors are disabled by the NO_COLOR environment variable or TERM=dumb
+func colorSupported(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// formatPretty formats a log entry as one aligned line in local time
+// Fields are sorted by name and rendered as key=value pairs
+func (l *Logger) formatPretty(entry LogEntry, now time.Time) string {
+	var b strings.Builder
+	b.WriteString(l.paint(ansiGray, now.Format("15:04:05")))
+	b.WriteString(" ")
+	b.WriteString(l.paint(levelColors[entry.Level], fmt.Sprintf("%-5s", strings.ToUpper(entry.Level))))
+	b.WriteString(" ")
+
+	message := entry.Message
+	if len(entry.Fields) > 0 || entry.Error != "" {
+		message = fmt.Sprintf("%-*s", prettyMessageWidth, message)
+	}
+	if entry.Level == "warn" || entry.Level == "error" {
+		message = l.paint(ansiBold, message)
+	}
+	b.WriteString(message)
+
+	keys := make([]string, 0, len(entry.Fields))
+	for key := range entry.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		b.WriteString(" ")
+		b.WriteString(l.paint(ansiGray, key+"="))
+		b.WriteString(prettyValue(entry.Fields[key]))
+	}
+	if entry.Error != "" {
+		b.WriteString(" ")
+		b.WriteString(l.paint(ansiRed, "error="+strconv.Quote(entry.Error)))
+	}
+	return b.String() + "\n"
+}
+
+// paint wraps text in an ANSI color when colors are enabled
+func (l *Logger) paint(color, text string) string {
+	if !l.color || color == "" {
+		return text
+	}
+	return color + text + ansiReset
+}
+
+// prettyValue renders a field value compactly, quoting strings that contain spaces
+func prettyValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "-"
+	case string:
+		if v == "" || strings.ContainsAny(v, " \t\"=") {
+			return strconv.Quote(v)
+		}
+		return v
+	case int, int64, uint64, bool:
+		return fmt.Sprint(v)
+	case float64:
+		return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+	case time.Time:
+		return v.Local().Format(time.RFC3339)
+	case error:
+		return strconv.Quote(v.Error())
+	case fmt.Stringer:
+		return prettyValue(v.String())
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"fmt"
+	"time"
+
+	"go-rmq-monitor/internal/config"
+)
+
+// sampler drops repeats of configured messages written within the sampling interval
+// Repeats are counted per message and queue, so every queue still logs once per interval
+type sampler struct {
+	interval time.Duration
+	messages map[string]bool
+	last     map[string]time.Time
+	dropped  map[string]int
+}
+
+// newSampler creates a sampler, or returns nil when sampling is disabled
+func newSampler(cfg config.LogSamplingConfig) *sampler {
+	if cfg.Interval <= 0 || len(cfg.Messages) == 0 {
+		return nil
+	}
+	messages := make(map[string]bool, len(cfg.Messages))
+	for _, message := range cfg.Messages {
+		messages[message] = true
+	}
+	return &sampler{
+		interval: cfg.Interval,
+		messages: messages,
+		last:     make(map[string]time.Time),
+		dropped:  make(map[string]int),
+	}
+}
+
+// allow reports whether an entry should be written, and how many repeats were dropped since the last one
+func (s *sampler) allow(level Level, message string, fields map[string]interface{}, now time.Time) (bool, int) {
+	if s == nil || level >= LevelWarn || !s.messages[message] {
+		return true, 0
+	}
+	key := message
+	if queue, ok := fields["queue"]; ok {
+		key += "\x00" + fmt.Sprint(queue)
+	}
+	if last, seen := s.last[key]; seen && now.Sub(last) < s.interval {
+		s.dropped[key]++
+		return false, 0
+	}
+	dropped := s.dropped[key]
+	s.last[key] = now
+	delete(s.dropped, key)
+	return true, dropped
+}
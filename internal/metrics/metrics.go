@@ -0,0 +1,341 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metric kinds in the Prometheus text exposition format
+const (
+	kindCounter   = "counter"
+	kindGauge     = "gauge"
+	kindHistogram = "histogram"
+)
+
+// DefaultLatencyBuckets are histogram buckets in seconds suited for HTTP calls
+var DefaultLatencyBuckets = []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Registry holds metric families and renders them in Prometheus text format
+type Registry struct {
+	families []*family
+	mu       sync.Mutex
+}
+
+// family is a named metric with a fixed set of label names
+type family struct {
+	name       string
+	help       string
+	kind       string
+	labelNames []string
+	buckets    []float64
+	series     map[string]*series
+}
+
+// series is a single labelled time series of a family
+type series struct {
+	labelValues  []string
+	value        float64
+	bucketCounts []uint64
+	exemplars    []*exemplar // Latest exemplar per bucket, the last one for +Inf
+	sum          float64
+	count        uint64
+}
+
+// exemplar links an observation to the labels identifying where it came from, e.g. an incident ID
+// Exemplars are only exposed in the OpenMetrics format
+type exemplar struct {
+	labels    map[string]string
+	value     float64
+	timestamp time.Time
+}
+
+// Counter is a monotonically increasing metric
+type Counter struct {
+	registry *Registry
+	family   *family
+}
+
+// Gauge is a metric that can go up and down
+type Gauge struct {
+	registry *Registry
+	family   *family
+}
+
+// Histogram samples observations into buckets
+type Histogram struct {
+	registry *Registry
+	family   *family
+}
+
+// NewRegistry creates an empty metrics registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// register adds a metric family to the registry
+func (r *Registry) register(name, help, kind string, buckets []float64, labelNames []string) *family {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f := &family{
+		name:       name,
+		help:       help,
+		kind:       kind,
+		labelNames: labelNames,
+		buckets:    buckets,
+		series:     make(map[string]*series),
+	}
+	r.families = append(r.families, f)
+	return f
+}
+
+// NewCounter registers a new counter
+func (r *Registry) NewCounter(name, help string, labelNames ...string) *Counter {
+	return &Counter{registry: r, family: r.register(name, help, kindCounter, nil, labelNames)}
+}
+
+// NewGauge registers a new gauge
+func (r *Registry) NewGauge(name, help string, labelNames ...string) *Gauge {
+	return &Gauge{registry: r, family: r.register(name, help, kindGauge, nil, labelNames)}
+}
+
+// NewHistogram registers a new histogram with the given upper bucket bounds
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labelNames ...string) *Histogram {
+	return &Histogram{registry: r, family: r.register(name, help, kindHistogram, buckets, labelNames)}
+}
+
+// getSeries returns the series for the label values, creating it if needed (caller must hold the lock)
+func (f *family) getSeries(labelValues []string) *series {
+	if len(labelValues) != len(f.labelNames) {
+		panic(fmt.Sprintf("metric %s expects %d label values, got %d", f.name, len(f.labelNames), len(labelValues)))
+	}
+
+	key := strings.Join(labelValues, "\xff")
+	s, exists := f.series[key]
+	if !exists {
+		s = &series{labelValues: append([]string(nil), labelValues...)}
+		if f.kind == kindHistogram {
+			s.bucketCounts = make([]uint64, len(f.buckets))
+			s.exemplars = make([]*exemplar, len(f.buckets)+1)
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+// Inc increments the counter by one
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add increments the counter by the given non-negative value
+func (c *Counter) Add(value float64, labelValues ...string) {
+	if value < 0 {
+		return
+	}
+	c.registry.mu.Lock()
+	defer c.registry.mu.Unlock()
+	c.family.getSeries(labelValues).value += value
+}
+
+// Set sets the gauge to the given value
+func (g *Gauge) Set(value float64, labelValues ...string) {
+	g.registry.mu.Lock()
+	defer g.registry.mu.Unlock()
+	g.family.getSeries(labelValues).value = value
+}
+
+// Reset removes all series of the gauge
+// Used before re-populating gauges whose label sets change over time
+func (g *Gauge) Reset() {
+	g.registry.mu.Lock()
+	defer g.registry.mu.Unlock()
+	g.family.series = make(map[string]*series)
+}
+
+// Observe records a value in the histogram
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	h.registry.mu.Lock()
+	defer h.registry.mu.Unlock()
+
+	s := h.family.getSeries(labelValues)
+	for i, bound := range h.family.buckets {
+		if value <= bound {
+			s.bucketCounts[i]++
+		}
+	}
+	s.sum += value
+	s.count++
+}
+
+// ObserveWithExemplar records a value in the histogram and attaches the exemplar labels to its bucket
+func (h *Histogram) ObserveWithExemplar(value float64, exemplarLabels map[string]string, labelValues ...string) {
+	h.Observe(value, labelValues...)
+
+	h.registry.mu.Lock()
+	defer h.registry.mu.Unlock()
+
+	s := h.family.getSeries(labelValues)
+	bucket := sort.SearchFloat64s(h.family.buckets, value)
+	s.exemplars[bucket] = &exemplar{labels: exemplarLabels, value: value, timestamp: time.Now()}
+}
+
+// WriteText writes all metrics in the Prometheus text exposition format
+func (r *Registry) WriteText(w io.Writer) error {
+	return r.write(w, false, nil)
+}
+
+// WriteOpenMetrics writes all metrics in the OpenMetrics text format, including histogram exemplars
+func (r *Registry) WriteOpenMetrics(w io.Writer) error {
+	return r.write(w, true, nil)
+}
+
+// write renders all metrics in the Prometheus text format, or in OpenMetrics when openMetrics is set
+// OpenMetrics names counter families without their _total suffix and ends with an EOF marker
+// constLabels are added to every sample
+func (r *Registry) write(w io.Writer, openMetrics bool, constLabels map[string]string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	constNames := make([]string, 0, len(constLabels))
+	for name := range constLabels {
+		constNames = append(constNames, name)
+	}
+	sort.Strings(constNames)
+	constValues := make([]string, 0, len(constNames))
+	for _, name := range constNames {
+		constValues = append(constValues, constLabels[name])
+	}
+
+	var b strings.Builder
+	for _, f := range r.families {
+		labelNames := append(append([]string(nil), constNames...), f.labelNames...)
+		name := f.name
+		if openMetrics && f.kind == kindCounter {
+			name = strings.TrimSuffix(name, "_total")
+		}
+		fmt.Fprintf(&b, "# HELP %s %s\n", name, f.help)
+		fmt.Fprintf(&b, "# TYPE %s %s\n", name, f.kind)
+
+		keys := make([]string, 0, len(f.series))
+		for key := range f.series {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			s := f.series[key]
+			labelValues := append(append([]string(nil), constValues...), s.labelValues...)
+			if f.kind != kindHistogram {
+				fmt.Fprintf(&b, "%s%s %s\n", f.name, formatLabels(labelNames, labelValues, "", ""), formatValue(s.value))
+				continue
+			}
+			for i, bound := range f.buckets {
+				fmt.Fprintf(&b, "%s_bucket%s %d%s\n", f.name, formatLabels(labelNames, labelValues, "le", formatValue(bound)), s.bucketCounts[i], formatExemplar(s.exemplars[i], openMetrics))
+			}
+			fmt.Fprintf(&b, "%s_bucket%s %d%s\n", f.name, formatLabels(labelNames, labelValues, "le", "+Inf"), s.count, formatExemplar(s.exemplars[len(f.buckets)], openMetrics))
+			fmt.Fprintf(&b, "%s_sum%s %s\n", f.name, formatLabels(labelNames, labelValues, "", ""), formatValue(s.sum))
+			fmt.Fprintf(&b, "%s_count%s %d\n", f.name, formatLabels(labelNames, labelValues, "", ""), s.count)
+		}
+	}
+	if openMetrics {
+		b.WriteString("# EOF\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteFile writes all metrics to a file in the Prometheus text exposition format, with constLabels on every sample
+// The file is replaced atomically so readers such as the node_exporter textfile collector never see a partial write
+func (r *Registry) WriteFile(path string, constLabels map[string]string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary metrics file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := r.write(tmp, false, constLabels); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write metrics: %w", err)
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to set metrics file permissions: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close metrics file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace metrics file: %w", err)
+	}
+	return nil
+}
+
+// Handler returns an HTTP handler serving the metrics
+// Scrapers that accept OpenMetrics, such as Prometheus with exemplar storage enabled, receive exemplars
+func (r *Registry) Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if strings.Contains(req.Header.Get("Accept"), "application/openmetrics-text") {
+			w.Header().Set("Content-Type", "application/openmetrics-text; version=1.0.0; charset=utf-8")
+			r.WriteOpenMetrics(w)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		r.WriteText(w)
+	}
+}
+
+// formatLabels renders a label set, optionally with an extra label appended
+func formatLabels(names, values []string, extraName, extraValue string) string {
+	pairs := make([]string, 0, len(names)+1)
+	for i, name := range names {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", name, strconv.Quote(values[i])))
+	}
+	if extraName != "" {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", extraName, strconv.Quote(extraValue)))
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// formatExemplar renders the exemplar suffix of a bucket sample, or nothing outside OpenMetrics
+func formatExemplar(e *exemplar, openMetrics bool) string {
+	if !openMetrics || e == nil {
+		return ""
+	}
+	names := make([]string, 0, len(e.labels))
+	for name := range e.labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	values := make([]string, len(names))
+	for i, name := range names {
+		values[i] = e.labels[name]
+	}
+	labels := formatLabels(names, values, "", "")
+	if labels == "" {
+		labels = "{}"
+	}
+	timestamp := strconv.FormatFloat(float64(e.timestamp.UnixMilli())/1000, 'f', 3, 64)
+	return fmt.Sprintf(" # %s %s %s", labels, formatValue(e.value), timestamp)
+}
+
+// formatValue renders a sample value
+func formatValue(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,186 @@
+package mockserver
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Script describes the metric timelines served for each fake queue, e.g.
+//
+//	vhost: /
+//	loop: true
+//	queues:
+//	  - name: payments
+//	    timeline:
+//	      - {for: 1m, ready: 5, consumers: 2, publish_rate: 10, consume_rate: 10}
+//	      - {for: 3m, ready: 20, ready_to: 400, consumers: 2, publish_rate: 10}
+//	      - {for: 1m, ready: 400, ready_to: 0, consumers: 2, publish_rate: 10, consume_rate: 17}
+type Script struct {
+	VHost  string        `mapstructure:"vhost"`
+	Loop   bool          `mapstructure:"loop"` // Restart timelines after their last point instead of holding it
+	Queues []QueueScript `mapstructure:"queues"`
+}
+
+// QueueScript is the timeline of one fake queue
+type QueueScript struct {
+	Name     string  `mapstructure:"name"`
+	Type     string  `mapstructure:"type"`
+	Timeline []Point `mapstructure:"timeline"`
+}
+
+// Point holds queue metrics for a stretch of the timeline
+type Point struct {
+	For           time.Duration `mapstructure:"for"`
+	Ready         int           `mapstructure:"ready"`
+	ReadyTo       *int          `mapstructure:"ready_to"` // Ramp the ready count linearly to this value
+	Unacked       int           `mapstructure:"unacked"`
+	Consumers     int           `mapstructure:"consumers"`
+	PublishRate   float64       `mapstructure:"publish_rate"`
+	ConsumeRate   float64       `mapstructure:"consume_rate"`
+	AckRate       *float64      `mapstructure:"ack_rate"` // Defaults to the consume rate
+	RedeliverRate float64       `mapstructure:"redeliver_rate"`
+}
+
+// DemoScript is served when no script is given: a healthy queue, a queue that gets
+// stuck and recovers, and a queue whose consumers disappear for a while
+const DemoScript = `
+vhost: /
+loop: true
+queues:
+  - name: orders
+    timeline:
+      - {for: 1m, ready: 3, consumers: 2, publish_rate: 10, consume_rate: 10}
+  - name: payments
+    timeline:
+      - {for: 1m, ready: 5, consumers: 2, publish_rate: 8, consume_rate: 8}
+      - {for: 3m, ready: 20, ready_to: 400, consumers: 2, publish_rate: 8}
+      - {for: 1m, ready: 400, ready_to: 0, consumers: 2, publish_rate: 8, consume_rate: 15}
+  - name: emails
+    timeline:
+      - {for: 2m, ready: 0, consumers: 1, publish_rate: 2, consume_rate: 2}
+      - {for: 3m, ready: 0, ready_to: 360, consumers: 0, publish_rate: 2}
+`
+
+// LoadScript reads a script file
+func LoadScript(path string) (*Script, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read script: %w", err)
+	}
+	return decodeScript(v)
+}
+
+// ParseScript reads a script from YAML
+func ParseScript(r io.Reader) (*Script, error) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(r); err != nil {
+		return nil, fmt.Errorf("failed to parse script: %w", err)
+	}
+	return decodeScript(v)
+}
+
+// Demo returns the parsed DemoScript
+func Demo() *Script {
+	script, err := ParseScript(bytes.NewBufferString(DemoScript))
+	if err != nil {
+		panic(err)
+	}
+	return script
+}
+
+// decodeScript unmarshals and validates a script, filling in defaults
+func decodeScript(v *viper.Viper) (*Script, error) {
+	v.SetDefault("vhost", "/")
+	var script Script
+	if err := v.Unmarshal(&script); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal script: %w", err)
+	}
+
+	if len(script.Queues) == 0 {
+		return nil, fmt.Errorf("script must define at least one queue")
+	}
+	seen := make(map[string]bool, len(script.Queues))
+	for i := range script.Queues {
+		queue := &script.Queues[i]
+		if queue.Name == "" {
+			return nil, fmt.Errorf("queues[%d]: name is required", i)
+		}
+		if seen[queue.Name] {
+			return nil, fmt.Errorf("queues[%d]: duplicate queue %s", i, queue.Name)
+		}
+		seen[queue.Name] = true
+		if queue.Type == "" {
+			queue.Type = "classic"
+		}
+		if len(queue.Timeline) == 0 {
+			return nil, fmt.Errorf("queue %s: timeline must have at least one point", queue.Name)
+		}
+		for j, point := range queue.Timeline {
+			if point.For <= 0 {
+				return nil, fmt.Errorf("queue %s: timeline[%d].for must be positive", queue.Name, j)
+			}
+			if point.Ready < 0 || point.Unacked < 0 || point.Consumers < 0 || (point.ReadyTo != nil && *point.ReadyTo < 0) {
+				return nil, fmt.Errorf("queue %s: timeline[%d] message and consumer counts must be non-negative", queue.Name, j)
+			}
+		}
+	}
+	return &script, nil
+}
+
+// Sample is the state of a fake queue at one moment
+type Sample struct {
+	Ready         int
+	Unacked       int
+	Consumers     int
+	PublishRate   float64
+	ConsumeRate   float64
+	AckRate       float64
+	RedeliverRate float64
+}
+
+// At returns the queue metrics a given time after the script started
+// Without looping the last point holds once the timeline has played
+func (q *QueueScript) At(elapsed time.Duration, loop bool) Sample {
+	var total time.Duration
+	for _, point := range q.Timeline {
+		total += point.For
+	}
+	if loop {
+		elapsed %= total
+	}
+
+	point := q.Timeline[len(q.Timeline)-1]
+	offset := point.For
+	for _, candidate := range q.Timeline {
+		if elapsed < candidate.For {
+			point, offset = candidate, elapsed
+			break
+		}
+		elapsed -= candidate.For
+	}
+
+	sample := Sample{
+		Ready:         point.Ready,
+		Unacked:       point.Unacked,
+		Consumers:     point.Consumers,
+		PublishRate:   point.PublishRate,
+		ConsumeRate:   point.ConsumeRate,
+		AckRate:       point.ConsumeRate,
+		RedeliverRate: point.RedeliverRate,
+	}
+	if point.ReadyTo != nil {
+		progress := float64(offset) / float64(point.For)
+		sample.Ready = point.Ready + int(float64(*point.ReadyTo-point.Ready)*progress)
+	}
+	if point.AckRate != nil {
+		sample.AckRate = *point.AckRate
+	}
+	return sample
+}
@@ -0,0 +1,187 @@
+package mockserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Server serves a fake management API replaying a script
+// Only the read endpoints the monitor uses are implemented; credentials are not checked
+type Server struct {
+	script *Script
+	start  time.Time
+}
+
+// New creates a server whose timelines start now
+func New(script *Script) *Server {
+	return &Server{script: script, start: time.Now()}
+}
+
+// Handler returns the management API routes
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/overview", s.handleOverview)
+	mux.HandleFunc("GET /api/nodes", s.handleNodes)
+	mux.HandleFunc("GET /api/queues", s.handleQueues)
+	// Vhost names like / arrive escaped as %2F, which path patterns cannot match
+	mux.HandleFunc("GET /api/queues/", s.handleQueues)
+	mux.HandleFunc("GET /api/policies/", s.handleEmpty)
+	mux.HandleFunc("GET /api/consumers/", s.handleEmpty)
+	mux.HandleFunc("GET /api/connections", s.handleEmpty)
+	mux.HandleFunc("/", s.handleNotFound)
+	return mux
+}
+
+// elapsed is how far into the script the server is
+func (s *Server) elapsed() time.Duration {
+	return time.Since(s.start)
+}
+
+func (s *Server) handleOverview(w http.ResponseWriter, r *http.Request) {
+	var ready, unacked, consumers int
+	var publish, deliver, ack float64
+	for i := range s.script.Queues {
+		sample := s.script.Queues[i].At(s.elapsed(), s.script.Loop)
+		ready += sample.Ready
+		unacked += sample.Unacked
+		consumers += sample.Consumers
+		publish += sample.PublishRate
+		deliver += sample.ConsumeRate
+		ack += sample.AckRate
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"management_version": "mock",
+		"rabbitmq_version":   "mock",
+		"cluster_name":       "mock@localhost",
+		"node":               "rabbit@mock",
+		"object_totals": map[string]int{
+			"queues":      len(s.script.Queues),
+			"consumers":   consumers,
+			"connections": consumers,
+			"channels":    consumers,
+			"exchanges":   7,
+		},
+		"queue_totals": map[string]int{
+			"messages":                ready + unacked,
+			"messages_ready":          ready,
+			"messages_unacknowledged": unacked,
+		},
+		"message_stats": map[string]interface{}{
+			"publish_details":     rate(publish),
+			"deliver_get_details": rate(deliver),
+			"ack_details":         rate(ack),
+		},
+	})
+}
+
+func (s *Server) handleNodes(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, []map[string]interface{}{{
+		"name":            "rabbit@mock",
+		"type":            "disc",
+		"running":         true,
+		"mem_alarm":       false,
+		"disk_free_alarm": false,
+		"uptime":          s.elapsed().Milliseconds(),
+		"proc_used":       400,
+		"proc_total":      1048576,
+	}})
+}
+
+func (s *Server) handleQueues(w http.ResponseWriter, r *http.Request) {
+	segments := pathSegments(r, "/api/queues")
+	switch {
+	case len(segments) == 2:
+		s.handleQueue(w, r, segments[0], segments[1])
+		return
+	case len(segments) > 2:
+		s.handleNotFound(w, r)
+		return
+	case len(segments) == 1 && segments[0] != s.script.VHost:
+		writeJSON(w, http.StatusOK, []interface{}{})
+		return
+	}
+	queues := make([]map[string]interface{}, 0, len(s.script.Queues))
+	for i := range s.script.Queues {
+		queues = append(queues, s.queueJSON(&s.script.Queues[i]))
+	}
+	writeJSON(w, http.StatusOK, queues)
+}
+
+func (s *Server) handleQueue(w http.ResponseWriter, r *http.Request, vhost, name string) {
+	if vhost == s.script.VHost {
+		for i := range s.script.Queues {
+			if s.script.Queues[i].Name == name {
+				writeJSON(w, http.StatusOK, s.queueJSON(&s.script.Queues[i]))
+				return
+			}
+		}
+	}
+	s.handleNotFound(w, r)
+}
+
+// handleEmpty serves endpoints the script has nothing to say about
+func (s *Server) handleEmpty(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, []interface{}{})
+}
+
+func (s *Server) handleNotFound(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusNotFound, map[string]string{"error": "Object Not Found", "reason": "Not Found"})
+}
+
+// queueJSON renders a queue the way the management API does
+func (s *Server) queueJSON(queue *QueueScript) map[string]interface{} {
+	sample := queue.At(s.elapsed(), s.script.Loop)
+	info := map[string]interface{}{
+		"name":                    queue.Name,
+		"vhost":                   s.script.VHost,
+		"type":                    queue.Type,
+		"durable":                 true,
+		"auto_delete":             false,
+		"arguments":               map[string]interface{}{},
+		"node":                    "rabbit@mock",
+		"state":                   "running",
+		"consumers":               sample.Consumers,
+		"messages":                sample.Ready + sample.Unacked,
+		"messages_ready":          sample.Ready,
+		"messages_unacknowledged": sample.Unacked,
+		"message_stats": map[string]interface{}{
+			"publish_details":     rate(sample.PublishRate),
+			"deliver_get_details": rate(sample.ConsumeRate),
+			"ack_details":         rate(sample.AckRate),
+			"redeliver_details":   rate(sample.RedeliverRate),
+		},
+	}
+	if sample.Consumers > 0 {
+		info["consumer_utilisation"] = 1.0
+	}
+	return info
+}
+
+// pathSegments splits the escaped path after prefix into unescaped segments
+func pathSegments(r *http.Request, prefix string) []string {
+	rest := strings.Trim(strings.TrimPrefix(r.URL.EscapedPath(), prefix), "/")
+	if rest == "" {
+		return nil
+	}
+	segments := strings.Split(rest, "/")
+	for i, segment := range segments {
+		if unescaped, err := url.PathUnescape(segment); err == nil {
+			segments[i] = unescaped
+		}
+	}
+	return segments
+}
+
+// rate renders a rate details object
+func rate(value float64) map[string]float64 {
+	return map[string]float64{"rate": value}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
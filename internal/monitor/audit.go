@@ -0,0 +1,60 @@
+package monitor
+
+import (
+	"time"
+
+	"go-rmq-monitor/internal/audit"
+	"go-rmq-monitor/internal/logger"
+	"go-rmq-monitor/internal/silence"
+)
+
+// recordAudit appends an operator action to the audit log if one is configured
+// Failures are logged but never block the action itself
+func recordAudit(auditLog *audit.Log, log *logger.Logger, entry audit.Entry) {
+	if auditLog == nil {
+		return
+	}
+	if err := auditLog.Record(entry); err != nil {
+		log.Error("Failed to record audit entry", err, map[string]interface{}{
+			"action": entry.Action,
+			"actor":  entry.Actor,
+		})
+	}
+}
+
+// silenceAuditEntry describes a silence change for the audit log
+func silenceAuditEntry(event string, s silence.Silence, actor, identity string) audit.Entry {
+	action := audit.ActionSilence
+	if event == silence.EventExpired {
+		action = audit.ActionSilenceExpire
+	}
+
+	details := map[string]string{
+		"silence_id": s.ID,
+		"ends_at":    s.EndsAt.Format(time.RFC3339),
+	}
+	if s.Reason != "" {
+		details["reason"] = s.Reason
+	}
+	if s.Team != "" {
+		details["team"] = s.Team
+	}
+
+	return audit.Entry{
+		Timestamp: time.Now(),
+		Action:    action,
+		Actor:     actor,
+		Identity:  identity,
+		Source:    audit.SourceAPI,
+		Queues:    s.Queues,
+		Details:   details,
+	}
+}
+
+// commentDetails returns audit details holding an optional comment
+func commentDetails(comment string) map[string]string {
+	if comment == "" {
+		return nil
+	}
+	return map[string]string{"comment": comment}
+}
@@ -0,0 +1,78 @@
+package monitor
+
+import (
+	"time"
+
+	"go-rmq-monitor/internal/analyzer"
+	"go-rmq-monitor/internal/rabbitmq"
+)
+
+// backfillHistory seeds the analyzer with each monitored queue's recent samples from the management API
+// Failures are logged and leave the queue to build its history from live checks
+func (s *Service) backfillHistory() {
+	apiStart := time.Now()
+	queues, err := s.client.GetQueues()
+	s.metrics.observeAPICall("queues", apiStart, err)
+	if err != nil {
+		s.logger.Warn("Failed to fetch queues for history backfill", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	now := time.Now()
+	backfilled := 0
+	for _, queue := range rabbitmq.FilterQueues(queues, s.config.Monitor.Queues, s.config.Monitor.AutoExclude) {
+		if queue.Type == queueTypeStream {
+			continue
+		}
+		interval, exists := s.queueIntervals[queue.Name]
+		if !exists {
+			interval = s.config.Monitor.Interval
+		}
+		thresholdChecks := s.analyzer.GetQueueConfig(queue.Name).ThresholdChecks
+
+		apiStart := time.Now()
+		samples, err := s.client.GetQueueSamples(queue.Name, interval*time.Duration(thresholdChecks), interval)
+		s.metrics.observeAPICall("queue_samples", apiStart, err)
+		if err != nil {
+			s.logger.Warn("Failed to backfill queue history", map[string]interface{}{
+				"queue": queue.Name,
+				"error": err.Error(),
+			})
+			continue
+		}
+
+		snapshots := make([]analyzer.QueueSnapshot, 0, len(samples))
+		for _, sample := range samples {
+			// The first live check stands in for the most recent interval
+			if now.Sub(sample.Timestamp) < interval/2 {
+				continue
+			}
+			snapshots = append(snapshots, analyzer.QueueSnapshot{
+				Timestamp:           sample.Timestamp,
+				MessagesReady:       sample.MessagesReady,
+				MessagesUnacked:     sample.MessagesUnacked,
+				ConsumeRate:         sample.ConsumeRate,
+				AckRate:             sample.AckRate,
+				PublishRate:         sample.PublishRate,
+				Consumers:           queue.Consumers, // Not sampled, assume unchanged
+				ConsumerUtilisation: -1,
+				HighPriorityDepth:   -1,
+			})
+		}
+		if len(snapshots) == 0 {
+			continue
+		}
+		s.analyzer.Backfill(queue.Name, snapshots)
+		backfilled++
+		s.logger.Debug("Backfilled queue history", map[string]interface{}{
+			"queue":   queue.Name,
+			"samples": len(snapshots),
+		})
+	}
+
+	s.logger.Info("Queue history backfilled", map[string]interface{}{
+		"queues": backfilled,
+	})
+}
@@ -0,0 +1,46 @@
+package monitor
+
+import (
+	"time"
+
+	"go-rmq-monitor/internal/rabbitmq"
+)
+
+// isIdle reports whether a queue shows no activity at all
+func isIdle(queue rabbitmq.QueueInfo) bool {
+	return queue.MessagesReady == 0 && queue.MessagesUnacked == 0 && queue.PublishRate == 0 && queue.ConsumeRate == 0
+}
+
+// backoffInterval returns the check interval for a queue, lengthened while it stays idle
+// The interval doubles for every idle_backoff.after the queue has been idle, up to max_interval
+// woke is true when a backed-off queue shows activity again and should be checked right away
+// Must be called with scheduleMu held
+func (s *Service) backoffInterval(queue rabbitmq.QueueInfo, base time.Duration, now time.Time) (interval time.Duration, woke bool) {
+	cfg := s.config.Monitor.IdleBackoff
+	if !cfg.Enabled {
+		return base, false
+	}
+
+	state := s.analyzer.GetQueueState(queue.Name)
+	if !isIdle(queue) || (state != nil && state.LastKnownState == "alerting") {
+		_, backedOff := s.backoffs[queue.Name]
+		delete(s.idleSince, queue.Name)
+		delete(s.backoffs, queue.Name)
+		return base, backedOff
+	}
+
+	since, exists := s.idleSince[queue.Name]
+	if !exists {
+		s.idleSince[queue.Name] = now
+		return base, false
+	}
+
+	interval = base
+	for idle := now.Sub(since); idle >= cfg.After && interval*2 <= cfg.MaxInterval; idle -= cfg.After {
+		interval *= 2
+	}
+	if interval > base {
+		s.backoffs[queue.Name] = interval
+	}
+	return interval, false
+}
@@ -0,0 +1,57 @@
+package monitor
+
+import (
+	"time"
+
+	"go-rmq-monitor/internal/backpressure"
+	"go-rmq-monitor/internal/rabbitmq"
+)
+
+// updateBackpressure signals producers of checked queues that are stuck or over the backlog limit
+// Signals of queues no longer monitored are dropped; their Redis keys expire on their own
+func (s *Service) updateBackpressure(checked, monitored []rabbitmq.QueueInfo, alerting []string, now time.Time) {
+	stuck := make(map[string]bool, len(alerting))
+	for _, name := range alerting {
+		stuck[name] = true
+	}
+
+	signals := make([]backpressure.Signal, 0, len(checked))
+	for _, queue := range checked {
+		signal := s.backpressure.Evaluate(queue.Name, queue.MessagesReady, stuck[queue.Name], now)
+		signals = append(signals, signal)
+
+		if previous, exists := s.backpressure.Get(queue.Name); signal.Backpressure != (exists && previous.Backpressure) {
+			fields := map[string]interface{}{
+				"queue":          queue.Name,
+				"messages_ready": queue.MessagesReady,
+			}
+			if signal.Backpressure {
+				fields["reason"] = signal.Reason
+				s.logger.Info("Signalling backpressure to producers", fields)
+			} else {
+				s.logger.Info("Backpressure lifted", fields)
+			}
+		}
+		if signal.Backpressure {
+			s.metrics.backpressure.Set(1, queue.Name)
+		} else {
+			s.metrics.backpressure.Set(0, queue.Name)
+		}
+	}
+	s.backpressure.Update(signals)
+
+	keep := make(map[string]bool, len(monitored))
+	for _, queue := range monitored {
+		keep[queue.Name] = true
+	}
+	s.backpressure.Forget(keep)
+
+	if s.redisSignals != nil {
+		if err := s.redisSignals.Write(signals); err != nil {
+			s.logger.Warn("Failed to write backpressure signals to Redis", map[string]interface{}{
+				"error":  err.Error(),
+				"queues": len(signals),
+			})
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package monitor
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/rabbitmq"
+)
+
+// canaryQueuePrefix names canary queues, followed by a random suffix
+const canaryQueuePrefix = "rmq-monitor.canary."
+
+// canaryQueueExpiry is how long the broker keeps a canary queue left behind by an interrupted test
+const canaryQueueExpiry = 10 * time.Minute
+
+// canaryPollInterval is how often the management API is polled for the canary message
+const canaryPollInterval = 500 * time.Millisecond
+
+// canaryConsumerTag identifies the canary consumer in the management UI
+const canaryConsumerTag = "rmq-monitor.canary"
+
+// CanaryStep is one check of an end-to-end canary test
+type CanaryStep struct {
+	Name     string
+	Passed   bool
+	Skipped  bool   // Not run, because an earlier step failed or it is not configured
+	Detail   string // What was observed, or why the step failed or was skipped
+	Duration time.Duration
+}
+
+// CanaryReport is the outcome of an end-to-end canary test
+type CanaryReport struct {
+	Queue string
+	Steps []CanaryStep
+}
+
+// Passed reports whether no step failed; skipped steps do not fail the test
+func (r CanaryReport) Passed() bool {
+	for _, step := range r.Steps {
+		if !step.Passed && !step.Skipped {
+			return false
+		}
+	}
+	return true
+}
+
+// RunCanary validates a deployment end to end with a temporary canary queue
+// It declares the queue, publishes a message to it over AMQP, waits up to timeout for the management API
+// to report it, consumes it over AMQP, deletes the queue and sends a self-test notification to webhookURLs
+func RunCanary(cfg *config.Config, webhookURLs []string, timeout time.Duration) CanaryReport {
+	buf := make([]byte, 4)
+	rand.Read(buf)
+	report := CanaryReport{Queue: canaryQueuePrefix + hex.EncodeToString(buf)}
+	payload := fmt.Sprintf("go-rmq-monitor canary %s", time.Now().UTC().Format(time.RFC3339Nano))
+
+	failed := ""
+	run := func(name string, check func() (string, error)) {
+		if failed != "" {
+			report.Steps = append(report.Steps, CanaryStep{Name: name, Skipped: true, Detail: "skipped, " + failed + " failed"})
+			return
+		}
+		start := time.Now()
+		detail, err := check()
+		step := CanaryStep{Name: name, Passed: err == nil, Detail: detail, Duration: time.Since(start)}
+		if err != nil {
+			step.Detail = err.Error()
+			failed = name
+		}
+		report.Steps = append(report.Steps, step)
+	}
+
+	client, err := rabbitmq.NewClient(&cfg.RabbitMQ)
+	run("management API", func() (string, error) {
+		if err != nil {
+			return "", err
+		}
+		overview, err := client.GetClusterOverview()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d of %d node(s) running", overview.RunningNodes, overview.Nodes), nil
+	})
+
+	declared := false
+	run("declare queue", func() (string, error) {
+		if err := client.DeclareCanaryQueue(report.Queue, canaryQueueExpiry); err != nil {
+			return "", err
+		}
+		declared = true
+		return fmt.Sprintf("expires after %s unused", canaryQueueExpiry), nil
+	})
+	var session *rabbitmq.AMQPSession
+	run("AMQP connection", func() (string, error) {
+		session, err = rabbitmq.DialAMQP(&cfg.RabbitMQ, "go-rmq-monitor canary")
+		if err != nil {
+			return "", err
+		}
+		return "channel in confirm mode", nil
+	})
+	if session != nil {
+		defer session.Close()
+	}
+	run("publish", func() (string, error) {
+		if err := session.Publish(report.Queue, payload, timeout); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d bytes through the default exchange, confirmed", len(payload)), nil
+	})
+	run("management stats", func() (string, error) {
+		start := time.Now()
+		for {
+			queue, err := client.GetQueue(report.Queue)
+			if err != nil {
+				return "", err
+			}
+			if queue.MessagesReady > 0 {
+				return fmt.Sprintf("message reported after %s", time.Since(start).Round(100*time.Millisecond)), nil
+			}
+			if time.Since(start) >= timeout {
+				return "", fmt.Errorf("message not reported within %s; check the management stats collection interval", timeout)
+			}
+			time.Sleep(canaryPollInterval)
+		}
+	})
+	run("consume", func() (string, error) {
+		received, err := session.Consume(report.Queue, canaryConsumerTag, timeout)
+		if err != nil {
+			return "", err
+		}
+		if received != payload {
+			return "", fmt.Errorf("received a different message: %q", received)
+		}
+		return "payload matches, acknowledged", nil
+	})
+
+	// The queue is removed even if an earlier step failed
+	if declared {
+		failed = ""
+		run("delete queue", func() (string, error) {
+			return "", client.DeleteQueue(report.Queue)
+		})
+	}
+
+	if len(webhookURLs) == 0 {
+		report.Steps = append(report.Steps, CanaryStep{Name: "notification", Skipped: true, Detail: "skipped, no test channel configured"})
+		return report
+	}
+	failed = ""
+	run("notification", func() (string, error) {
+		selfTest := RunSelfTest(cfg, webhookURLs)
+		return fmt.Sprintf("%d notification(s) sent to %d webhook(s)", selfTest.Sent, len(webhookURLs)), selfTest.Err
+	})
+	return report
+}
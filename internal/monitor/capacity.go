@@ -0,0 +1,122 @@
+package monitor
+
+import (
+	"time"
+
+	"go-rmq-monitor/internal/capacity"
+	"go-rmq-monitor/internal/rabbitmq"
+	"go-rmq-monitor/internal/slack"
+)
+
+// refreshCapacity recomputes capacity estimates from history once they are older than the refresh interval
+// A failed refresh keeps the previous estimates
+func (s *Service) refreshCapacity(now time.Time) {
+	cfg := s.config.Capacity
+	if !s.capacityAt.IsZero() && now.Sub(s.capacityAt) < cfg.Refresh {
+		return
+	}
+	s.capacityAt = now
+
+	estimates, err := capacity.Load(s.config.History.FilePath, now.Add(-cfg.Window), cfg.MinSamples)
+	if err != nil {
+		s.logger.Warn("Failed to estimate queue capacity from history", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+	s.capacities = estimates
+	s.logger.Debug("Estimated queue capacity from history", map[string]interface{}{
+		"queues": len(estimates),
+		"window": cfg.Window.String(),
+	})
+}
+
+// checkCapacity exports the utilisation of checked queues with a capacity estimate and alerts on sustained high utilisation
+// A queue alerts once it is above the alert utilisation for the threshold checks, and recovers on the first check below it
+func (s *Service) checkCapacity(queues []rabbitmq.QueueInfo, now time.Time) {
+	s.refreshCapacity(now)
+
+	cfg := s.config.Capacity
+	for _, queue := range queues {
+		estimate, known := s.capacities[queue.Name]
+		if !known {
+			continue
+		}
+		utilisation := estimate.Utilisation(queue.PublishRate)
+		s.metrics.capacityRate.Set(estimate.Capacity, queue.Name)
+		s.metrics.utilisation.Set(utilisation, queue.Name)
+		if cfg.AlertUtilisation <= 0 {
+			continue
+		}
+
+		state, exists := s.capacityStates[queue.Name]
+		if !exists {
+			state = &breachState{}
+			s.capacityStates[queue.Name] = state
+		}
+		alerting := !state.since.IsZero()
+		high := utilisation > cfg.AlertUtilisation
+		if high {
+			state.breaches++
+		} else {
+			state.breaches = 0
+		}
+
+		fields := map[string]interface{}{
+			"queue":        queue.Name,
+			"publish_rate": queue.PublishRate,
+			"capacity":     estimate.Capacity,
+			"utilisation":  utilisation,
+		}
+		switch {
+		case !alerting && state.breaches >= cfg.ThresholdChecks:
+			state.since = now
+			s.logger.Warn("QUEUE NEARING CAPACITY", fields)
+			s.notifyCapacity(queue, estimate, utilisation, false, now)
+		case alerting && !high:
+			state.since = time.Time{}
+			s.logger.Info("Queue back within capacity", fields)
+			s.notifyCapacity(queue, estimate, utilisation, true, now)
+		case high && !alerting:
+			fields["breaches"] = state.breaches
+			s.logger.Debug("Queue utilisation high", fields)
+		}
+	}
+}
+
+// notifyCapacity sends a capacity utilisation alert to the queue's Slack channels
+func (s *Service) notifyCapacity(queue rabbitmq.QueueInfo, estimate capacity.Estimate, utilisation float64, resolved bool, now time.Time) {
+	if s.slackClient == nil || s.observeOnly[queue.Name] {
+		return
+	}
+	if _, silenced := s.silences.Active(queue.Name, now); silenced {
+		return
+	}
+	if resolved && !s.config.Notifications.Slack.SendRecovery {
+		return
+	}
+
+	alert := slack.CapacityAlert{
+		Resolved:    resolved,
+		QueueName:   queue.Name,
+		Priority:    s.priorities[queue.Name],
+		PublishRate: queue.PublishRate,
+		Capacity:    estimate.Capacity,
+		Utilisation: utilisation,
+		Threshold:   s.config.Capacity.AlertUtilisation,
+		Timestamp:   now,
+	}
+
+	webhookURLs := s.alerts.Route(queue.Name, alert.Priority, now).WebhookURLs
+	if len(webhookURLs) == 0 {
+		return
+	}
+
+	err := s.slackClient.SendCapacityAlert(alert, webhookURLs)
+	s.metrics.observeNotification("slack", err)
+	if err != nil {
+		s.logger.Error("Failed to send capacity Slack notification", err, map[string]interface{}{
+			"queue": queue.Name,
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package monitor
+
+import (
+	"time"
+
+	"go-rmq-monitor/internal/slack"
+)
+
+// checkChurn compares the cluster-wide queue churn with its limits
+// Alerts once churn exceeds a limit for the configured threshold checks, and recovers on the first check within both
+func (s *Service) checkChurn(now time.Time) {
+	cfg := s.config.Churn
+
+	if !s.permitted("queue_churn", now) {
+		return
+	}
+
+	apiStart := time.Now()
+	churn, err := s.client.GetQueueChurn()
+	s.metrics.observeAPICall("overview", apiStart, err)
+	if s.lacksPermission("queue_churn", "overview", err, now) {
+		return
+	}
+	if err != nil {
+		s.logger.Warn("Failed to fetch queue churn", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	created, deleted := churn.CreatedRate*60, churn.DeletedRate*60
+	s.metrics.queueChurn.Set(churn.DeclaredRate*60, "declared")
+	s.metrics.queueChurn.Set(created, "created")
+	s.metrics.queueChurn.Set(deleted, "deleted")
+
+	high := (cfg.MaxCreated > 0 && created > cfg.MaxCreated) || (cfg.MaxDeleted > 0 && deleted > cfg.MaxDeleted)
+	alerting := !s.churn.since.IsZero()
+	if high {
+		s.churn.breaches++
+	} else {
+		s.churn.breaches = 0
+	}
+
+	fields := map[string]interface{}{
+		"created_per_minute": created,
+		"deleted_per_minute": deleted,
+	}
+	alert := slack.ChurnAlert{
+		CreatedRate: created,
+		DeletedRate: deleted,
+		MaxCreated:  cfg.MaxCreated,
+		MaxDeleted:  cfg.MaxDeleted,
+		Timestamp:   now,
+	}
+
+	switch {
+	case !alerting && s.churn.breaches >= cfg.ThresholdChecks:
+		s.churn.since = now
+		s.logger.Warn("HIGH QUEUE CHURN DETECTED", fields)
+		s.notifyChurn(alert, now)
+	case alerting && !high:
+		alert.Resolved = true
+		alert.AlertDuration = now.Sub(s.churn.since)
+		s.churn.since = time.Time{}
+		fields["duration"] = alert.AlertDuration.String()
+		s.logger.Info("Queue churn back to normal", fields)
+		s.notifyChurn(alert, now)
+	case high && !alerting:
+		fields["breaches"] = s.churn.breaches
+		s.logger.Debug("Queue churn over limits", fields)
+	}
+}
+
+// notifyChurn sends a queue churn alert to Slack
+func (s *Service) notifyChurn(alert slack.ChurnAlert, now time.Time) {
+	if s.slackClient == nil {
+		return
+	}
+
+	// Churn is not owned by a team, so the churn alert type settings route it
+	webhookURLs := s.alertWebhooks("churn", "", s.config.Churn.Priority, !alert.Resolved, now)
+	if len(webhookURLs) == 0 {
+		return
+	}
+
+	err := s.slackClient.SendChurnAlert(alert, webhookURLs)
+	s.metrics.observeNotification("slack", err)
+	if err != nil {
+		s.logger.Error("Failed to send queue churn Slack notification", err, nil)
+	} else if !alert.Resolved {
+		s.alerts.SentAlert("churn", "", now)
+	}
+}
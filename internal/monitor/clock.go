@@ -0,0 +1,65 @@
+package monitor
+
+import (
+	"time"
+
+	"go-rmq-monitor/internal/rabbitmq"
+)
+
+// checkClockSkew compares the broker clock and the queues' idle_since timestamps with the local clock
+// Warns once when they drift apart by more than the configured maximum, and logs when they agree again
+func (s *Service) checkClockSkew(queues []rabbitmq.QueueInfo, now time.Time) {
+	maxSkew := s.config.Monitor.ClockSkew.MaxSkew
+	fields := map[string]interface{}{
+		"max_skew": maxSkew.String(),
+	}
+	skewed := false
+
+	apiStart := time.Now()
+	var reading *rabbitmq.ClockReading
+	err := errSubsystemDisabled
+	if s.permitted("clock_skew", now) {
+		reading, err = s.client.GetClockReading()
+		s.metrics.observeAPICall("clock", apiStart, err)
+		s.lacksPermission("clock_skew", "clock", err, now)
+	}
+	if err != nil {
+		s.logger.Debug("Failed to read broker clock", map[string]interface{}{
+			"error": err.Error(),
+		})
+	} else {
+		s.metrics.clockSkew.Set(reading.Skew.Seconds())
+		fields["skew"] = reading.Skew.Round(time.Second).String()
+		fields["broker_time"] = reading.BrokerTime.UTC().Format(time.RFC3339)
+		skewed = reading.Skew.Abs()-reading.Uncertainty > maxSkew
+	}
+
+	// Queues cannot have gone idle in the future, unless the broker clock runs ahead
+	future, ahead := 0, time.Duration(0)
+	for _, queue := range queues {
+		if offset := queue.IdleSince.Sub(now); !queue.IdleSince.IsZero() && offset > maxSkew {
+			future++
+			if offset > ahead {
+				ahead = offset
+				fields["queue"] = queue.Name
+			}
+		}
+	}
+	if future > 0 {
+		fields["future_idle_since"] = future
+		fields["idle_since_ahead"] = ahead.Round(time.Second).String()
+		skewed = true
+	}
+
+	// Without a clock reading, a previously reported skew cannot be confirmed as resolved
+	if err != nil && !skewed {
+		return
+	}
+	if skewed && !s.clockSkewed {
+		fields["local_time"] = now.UTC().Format(time.RFC3339)
+		s.logger.Warn("Clock skew detected between broker and monitor, rate windows and stuck durations may be distorted", fields)
+	} else if !skewed && s.clockSkewed {
+		s.logger.Info("Clock skew resolved", fields)
+	}
+	s.clockSkewed = skewed
+}
@@ -0,0 +1,177 @@
+package monitor
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/rabbitmq"
+	"go-rmq-monitor/internal/slack"
+)
+
+// checkComparison compares monitored queues with their namesakes on the compared side
+// A queue alerts once it diverges for the threshold checks, and recovers on the first check in line
+func (s *Service) checkComparison(queues []rabbitmq.QueueInfo, now time.Time) {
+	cfg := s.config.Comparison
+
+	apiStart := time.Now()
+	targetQueues, err := s.compareClient.GetQueues()
+	s.metrics.observeAPICall("comparison_queues", apiStart, err)
+	if err != nil {
+		s.logger.Warn("Failed to fetch compared queues", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+	targets := make(map[string]rabbitmq.QueueInfo, len(targetQueues))
+	for _, queue := range targetQueues {
+		targets[queue.Name] = queue
+	}
+
+	target := cfg.TargetRabbitMQ(s.config.RabbitMQ)
+	sourceLabel, targetLabel := comparisonLabel(s.config.RabbitMQ, target), comparisonLabel(target, s.config.RabbitMQ)
+	seen := make(map[string]bool)
+	for _, queue := range queues {
+		if !cfg.Compares(queue.Name) {
+			continue
+		}
+		seen[queue.Name] = true
+
+		state, exists := s.comparisons[queue.Name]
+		if !exists {
+			state = &breachState{}
+			s.comparisons[queue.Name] = state
+		}
+
+		other, found := targets[queue.Name]
+		diverged := map[string]bool{"missing": !found}
+		if found {
+			diverged = queueDivergence(cfg, queue, other)
+		}
+		alerting := !state.since.IsZero()
+		if len(diverged) > 0 {
+			state.breaches++
+		} else {
+			state.breaches = 0
+		}
+
+		fields := map[string]interface{}{
+			"queue":  queue.Name,
+			"source": sourceLabel,
+			"target": targetLabel,
+		}
+		alert := slack.ComparisonAlert{
+			QueueName:   queue.Name,
+			Source:      sourceLabel,
+			Target:      targetLabel,
+			Missing:     !found,
+			SourceStats: comparisonStats(queue),
+			TargetStats: comparisonStats(other),
+			Diverged:    diverged,
+			Tolerance:   cfg.Tolerance,
+			Timestamp:   now,
+		}
+
+		switch {
+		case !alerting && state.breaches >= cfg.ThresholdChecks:
+			state.since = now
+			fields["diverged"] = divergedNames(diverged)
+			s.logger.Warn("QUEUE DIVERGES FROM COMPARED SIDE", fields)
+			s.notifyComparison(alert, now)
+		case alerting && len(diverged) == 0:
+			alert.Resolved = true
+			alert.AlertDuration = now.Sub(state.since)
+			state.since = time.Time{}
+			fields["duration"] = alert.AlertDuration.String()
+			s.logger.Info("Queue back in line with compared side", fields)
+			s.notifyComparison(alert, now)
+		case len(diverged) > 0 && !alerting:
+			fields["diverged"] = divergedNames(diverged)
+			fields["breaches"] = state.breaches
+			s.logger.Debug("Queue diverges from compared side", fields)
+		}
+	}
+
+	// Queues no longer monitored or compared are forgotten
+	for name := range s.comparisons {
+		if !seen[name] {
+			delete(s.comparisons, name)
+		}
+	}
+}
+
+// queueDivergence returns the values of a queue that differ from its namesake beyond the tolerance, by name
+// Differences below the configured minimums are ignored, so idle queues never diverge
+func queueDivergence(cfg config.ComparisonConfig, source, target rabbitmq.QueueInfo) map[string]bool {
+	diverged := make(map[string]bool)
+	if diverges(float64(source.MessagesReady), float64(target.MessagesReady), cfg.Tolerance, float64(cfg.MinMessages)) {
+		diverged["messages_ready"] = true
+	}
+	if diverges(source.PublishRate, target.PublishRate, cfg.Tolerance, cfg.MinRate) {
+		diverged["publish_rate"] = true
+	}
+	if diverges(source.ConsumeRate, target.ConsumeRate, cfg.Tolerance, cfg.MinRate) {
+		diverged["consume_rate"] = true
+	}
+	return diverged
+}
+
+// diverges reports whether two values differ by more than the tolerance relative to the larger one
+func diverges(a, b, tolerance, minimum float64) bool {
+	difference := math.Abs(a - b)
+	return difference >= minimum && difference > tolerance*math.Max(a, b)
+}
+
+// divergedNames lists diverging values in a stable order for logging
+func divergedNames(diverged map[string]bool) []string {
+	names := make([]string, 0, len(diverged))
+	for name := range diverged {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// comparisonStats returns the compared values of a queue
+func comparisonStats(queue rabbitmq.QueueInfo) slack.ComparisonStats {
+	return slack.ComparisonStats{
+		MessagesReady: queue.MessagesReady,
+		PublishRate:   queue.PublishRate,
+		ConsumeRate:   queue.ConsumeRate,
+	}
+}
+
+// comparisonLabel names one side of the comparison by what sets it apart from the other
+func comparisonLabel(side, other config.RabbitMQConfig) string {
+	switch {
+	case side.Host == other.Host && side.Port == other.Port:
+		return side.VHost
+	case side.VHost == other.VHost:
+		return side.Host
+	}
+	return side.Host + " " + side.VHost
+}
+
+// notifyComparison sends a queue comparison alert to Slack
+func (s *Service) notifyComparison(alert slack.ComparisonAlert, now time.Time) {
+	if s.slackClient == nil {
+		return
+	}
+
+	// Comparisons span two environments, so the comparison alert type settings route them
+	webhookURLs := s.alertWebhooks("comparison", alert.QueueName, s.config.Comparison.Priority, !alert.Resolved, now)
+	if len(webhookURLs) == 0 {
+		return
+	}
+
+	err := s.slackClient.SendComparisonAlert(alert, webhookURLs)
+	s.metrics.observeNotification("slack", err)
+	if err != nil {
+		s.logger.Error("Failed to send queue comparison Slack notification", err, map[string]interface{}{
+			"queue": alert.QueueName,
+		})
+	} else if !alert.Resolved {
+		s.alerts.SentAlert("comparison", alert.QueueName, now)
+	}
+}
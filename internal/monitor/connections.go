@@ -0,0 +1,62 @@
+package monitor
+
+import (
+	"fmt"
+	"time"
+
+	"go-rmq-monitor/internal/rabbitmq"
+)
+
+// describeSharedConnection explains a stuck queue whose consumers all sit on one idle connection
+// Returns an empty string when consumers are spread out, the connection carries traffic,
+// or the broker could not be queried
+func (s *Service) describeSharedConnection(queue rabbitmq.QueueInfo) string {
+	if queue.Consumers == 0 {
+		return ""
+	}
+
+	apiStart := time.Now()
+	consumers, err := s.client.GetConsumers(queue.Name)
+	s.metrics.observeAPICall("consumers", apiStart, err)
+	if err != nil || len(consumers) == 0 {
+		return ""
+	}
+	first := consumers[0]
+	sameChannel := true
+	for _, consumer := range consumers[1:] {
+		if consumer.ConnectionName != first.ConnectionName {
+			return ""
+		}
+		if consumer.ChannelNumber != first.ChannelNumber {
+			sameChannel = false
+		}
+	}
+
+	apiStart = time.Now()
+	if !s.permitted("connection_activity", apiStart) {
+		return ""
+	}
+	activity, err := s.client.GetConnectionActivity(first.ConnectionName)
+	s.metrics.observeAPICall("connection", apiStart, err)
+	if s.lacksPermission("connection_activity", "connection", err, apiStart) {
+		return ""
+	}
+	if err != nil {
+		s.logger.Debug("Failed to fetch consumer connection activity", map[string]interface{}{
+			"queue":      queue.Name,
+			"connection": first.ConnectionName,
+			"error":      err.Error(),
+		})
+		return ""
+	}
+	if activity.RecvRate > 0 || activity.SendRate > 0 {
+		return ""
+	}
+
+	// Connection names and hosts are client addresses, masked like consumer details
+	shown := s.redactor.Consumers(consumers[:1])[0]
+	if sameChannel {
+		return fmt.Sprintf("all %d consumer(s) on channel %d of idle connection %s from host %s", len(consumers), shown.ChannelNumber, shown.ConnectionName, shown.PeerHost)
+	}
+	return fmt.Sprintf("all %d consumer(s) on idle connection %s from host %s", len(consumers), shown.ConnectionName, shown.PeerHost)
+}
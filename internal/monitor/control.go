@@ -0,0 +1,181 @@
+package monitor
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"go-rmq-monitor/internal/audit"
+
+	"go-rmq-monitor/internal/analyzer"
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/rabbitmq"
+	"go-rmq-monitor/internal/server"
+)
+
+// checkResponse is the JSON document served by POST /api/v1/check
+type checkResponse struct {
+	CheckID   string             `json:"check_id"`
+	Timestamp time.Time          `json:"timestamp"`
+	Duration  string             `json:"duration"`
+	Queues    []checkQueueResult `json:"queues"`
+}
+
+// checkQueueResult is the analysis of one queue by an on-demand check
+type checkQueueResult struct {
+	Queue            string     `json:"queue"`
+	State            string     `json:"state"` // alerting or not_alerting
+	Recovering       bool       `json:"recovering"`
+	HealthScore      int        `json:"health_score"`
+	ConsecutiveStuck int        `json:"consecutive_stuck"`
+	StuckSince       *time.Time `json:"stuck_since,omitempty"`
+	MessagesReady    int        `json:"messages_ready"`
+	MessagesUnacked  int        `json:"messages_unacked"`
+	Consumers        int        `json:"consumers"`
+	ConsumeRate      float64    `json:"consume_rate"`
+	PublishRate      float64    `json:"publish_rate"`
+}
+
+// registerControlHandlers exposes the status and control API on the embedded server
+// Each endpoint requires the listed role; without API tokens only read_only endpoints are served
+func (s *Service) registerControlHandlers() {
+	s.server.HandleAPI("GET /api/status", config.RoleReadOnly, s.handleStatus)
+	s.server.HandleAPI("GET /api/silences", config.RoleReadOnly, s.silences.HandleList)
+	s.server.HandleAPI("POST /api/silences", config.RoleSilencer, s.silences.HandleCreate)
+	s.server.HandleAPI("DELETE /api/silences/{id}", config.RoleSilencer, s.silences.HandleExpire)
+	if sync := s.config.SilenceSync; sync.Enabled {
+		s.server.HandleAPI("POST /api/silences/alertmanager", config.RoleSilencer, s.silences.HandleAlertmanager(sync.QueueLabel, sync.DefaultDuration))
+		s.server.HandleAPI("POST /api/silences/pagerduty", config.RoleSilencer, s.silences.HandlePagerDuty(sync.PagerDutyServices))
+	}
+	// Deprecated in favor of /api/v1/check, kept with its scheduling behavior for existing clients
+	s.server.HandleAPI("POST /api/check", config.RoleAdmin, s.handleCheck)
+	s.server.HandleAPI("POST /api/v1/check", config.RoleAdmin, s.handleCheckNow)
+	s.server.HandleAPI("GET /api/v1/config", config.RoleReadOnly, s.handleGetConfig)
+	s.server.HandleAPI("PUT /api/v1/config", config.RoleAdmin, s.handlePutConfig)
+	if s.history != nil {
+		s.server.HandleAPI("GET /api/v1/queues/{name}/timeline", config.RoleReadOnly, s.handleTimeline)
+	}
+	s.server.HandleAPI("GET /api/v1/tail", config.RoleReadOnly, s.tail.HandleStream)
+}
+
+// handleCheck schedules an immediate check of all queues
+// The optional "by" query parameter names who requested it
+// Requests made while a manual check is already pending are coalesced
+func (s *Service) handleCheck(w http.ResponseWriter, r *http.Request) {
+	select {
+	case s.checkNow <- struct{}{}:
+	default:
+	}
+
+	actor := r.URL.Query().Get("by")
+	identity := server.Identity(r)
+	s.baseLogger.Info("Manual check requested", map[string]interface{}{
+		"by":       actor,
+		"identity": identity,
+	})
+	recordAudit(s.audit, s.baseLogger, audit.Entry{
+		Timestamp: time.Now(),
+		Action:    audit.ActionManualCheck,
+		Actor:     actor,
+		Identity:  identity,
+		Source:    audit.SourceAPI,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]string{"status": "scheduled"})
+}
+
+// handleCheckNow runs an out-of-band check and responds with its analysis once it finishes
+// The optional "queue" query parameter limits the check to one monitored queue, and "by"
+// names who requested it; a check already in progress is waited for first
+func (s *Service) handleCheckNow(w http.ResponseWriter, r *http.Request) {
+	queue := r.URL.Query().Get("queue")
+	actor := r.URL.Query().Get("by")
+	identity := server.Identity(r)
+	s.baseLogger.Info("On-demand check requested", map[string]interface{}{
+		"queue":    queue,
+		"by":       actor,
+		"identity": identity,
+	})
+	entry := audit.Entry{
+		Timestamp: time.Now(),
+		Action:    audit.ActionManualCheck,
+		Actor:     actor,
+		Identity:  identity,
+		Source:    audit.SourceAPI,
+	}
+	if queue != "" {
+		entry.Queues = []string{queue}
+	}
+	recordAudit(s.audit, s.baseLogger, entry)
+
+	start := time.Now()
+	s.checkMu.Lock()
+	err := s.checkCycle(true, queue)
+	checkID := s.checkID
+	s.checkMu.Unlock()
+	if err != nil {
+		http.Error(w, "check failed: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	response := checkResponse{
+		CheckID:   checkID,
+		Timestamp: start,
+		Duration:  time.Since(start).Round(time.Millisecond).String(),
+		Queues:    make([]checkQueueResult, 0),
+	}
+	for _, state := range s.analyzer.Snapshot() {
+		if (queue == "" || state.QueueName == queue) && checkedSince(state, start) {
+			response.Queues = append(response.Queues, newCheckQueueResult(state))
+		}
+	}
+	if queue != "" && len(response.Queues) == 0 {
+		http.Error(w, "queue is not monitored: "+queue, http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// checkedSince reports whether a queue was analyzed at or after the given time
+func checkedSince(state analyzer.QueueState, since time.Time) bool {
+	return len(state.History) > 0 && !state.History[len(state.History)-1].Timestamp.Before(since)
+}
+
+// newCheckQueueResult describes a queue's state after its latest check
+func newCheckQueueResult(state analyzer.QueueState) checkQueueResult {
+	latest := state.History[len(state.History)-1]
+	result := checkQueueResult{
+		Queue:            state.QueueName,
+		State:            state.LastKnownState,
+		Recovering:       state.IsRecovering(),
+		HealthScore:      state.HealthScore,
+		ConsecutiveStuck: state.ConsecutiveStuck,
+		MessagesReady:    latest.MessagesReady,
+		MessagesUnacked:  latest.MessagesUnacked,
+		Consumers:        latest.Consumers,
+		ConsumeRate:      latest.ConsumeRate,
+		PublishRate:      latest.PublishRate,
+	}
+	if result.State == "" {
+		result.State = "not_alerting"
+	}
+	if state.LastKnownState == "alerting" {
+		stuckSince := state.StuckSince
+		result.StuckSince = &stuckSince
+	}
+	return result
+}
+
+// scopeQueues returns the queue with the given name, or none if it is not monitored
+func scopeQueues(queues []rabbitmq.QueueInfo, name string) []rabbitmq.QueueInfo {
+	for _, queue := range queues {
+		if queue.Name == name {
+			return []rabbitmq.QueueInfo{queue}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package monitor
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"go-rmq-monitor/internal/slack"
+)
+
+// newCheckID returns a random check cycle ID, e.g. chk-9a8b7c6d
+func newCheckID() string {
+	buf := make([]byte, 4)
+	rand.Read(buf)
+	return "chk-" + hex.EncodeToString(buf)
+}
+
+// correlate tags a notification with its incident and the running check cycle
+func (s *Service) correlate(alert *slack.QueueAlert, incidentID string) {
+	alert.IncidentID = incidentID
+	alert.CheckID = s.checkID
+}
+
+// exemplar returns the metric exemplar labels linking an observation to its incident and check cycle
+func (s *Service) exemplar(incidentID string) map[string]string {
+	return map[string]string{"incident_id": incidentID, "check_id": s.checkID}
+}
@@ -0,0 +1,119 @@
+package monitor
+
+import (
+	"errors"
+	"time"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/rabbitmq"
+	"go-rmq-monitor/internal/slack"
+)
+
+// checkCredentials handles a check the management API rejected with 401 Unauthorized, as after a password rotation
+// The credentials are reloaded from their secret files and the check is retried once with them; when that does not
+// help, a single auth failure alert is sent instead of a generic check error every interval
+// The alert resolves on the first check the management API accepts again
+func (s *Service) checkCredentials(err error, retry func() error, now time.Time) error {
+	if !rabbitmq.IsUnauthorized(err) {
+		if err == nil && !s.authFailedAt.IsZero() {
+			s.resolveAuthFailure(now)
+		}
+		return err
+	}
+
+	reloadErr := s.reloadCredentials()
+	if reloadErr == nil {
+		s.logger.Info("Reloaded RabbitMQ credentials after the management API rejected them, retrying check", map[string]interface{}{
+			"username": s.config.RabbitMQ.Username,
+		})
+		err = retry()
+		if !rabbitmq.IsUnauthorized(err) {
+			if err == nil && !s.authFailedAt.IsZero() {
+				s.resolveAuthFailure(now)
+			}
+			return err
+		}
+		reloadErr = errors.New("reloaded credentials were rejected too")
+	}
+
+	fields := map[string]interface{}{
+		"broker":   s.config.RabbitMQ.GetRabbitMQURL(),
+		"username": s.config.RabbitMQ.Username,
+		"reload":   reloadErr.Error(),
+	}
+	if !s.authFailedAt.IsZero() {
+		s.logger.Debug("RabbitMQ credentials still rejected", fields)
+		return err
+	}
+	s.authFailedAt = now
+	s.logger.Warn("RABBITMQ AUTHENTICATION FAILED", fields)
+	s.notifyAuth(slack.AuthAlert{
+		Broker:    s.config.RabbitMQ.GetRabbitMQURL(),
+		Username:  s.config.RabbitMQ.Username,
+		Reload:    reloadErr.Error(),
+		Timestamp: now,
+	}, now)
+	return err
+}
+
+// reloadCredentials re-reads the credential files and switches the client to them
+// Fails when no files are configured or they still hold the rejected credentials
+func (s *Service) reloadCredentials() error {
+	current := s.config.RabbitMQ
+	if !current.HasCredentialFiles() {
+		return errors.New("no rabbitmq.username_file or rabbitmq.password_file to reload from")
+	}
+	reloaded := current
+	if err := reloaded.LoadCredentials(); err != nil {
+		return err
+	}
+	if reloaded.Username == current.Username && reloaded.Password == current.Password {
+		return errors.New("credential files unchanged")
+	}
+
+	// Checks are serialized, so no request is in flight between two of them
+	s.client.SetCredentials(reloaded.Username, reloaded.Password)
+	s.config.RabbitMQ.Username = reloaded.Username
+	s.config.RabbitMQ.Password = reloaded.Password
+	return nil
+}
+
+// resolveAuthFailure notes that the management API accepts the credentials again
+func (s *Service) resolveAuthFailure(now time.Time) {
+	duration := now.Sub(s.authFailedAt)
+	s.authFailedAt = time.Time{}
+	s.logger.Info("RabbitMQ authentication restored", map[string]interface{}{
+		"username": s.config.RabbitMQ.Username,
+		"duration": duration.String(),
+	})
+	s.notifyAuth(slack.AuthAlert{
+		Resolved:      true,
+		Broker:        s.config.RabbitMQ.GetRabbitMQURL(),
+		Username:      s.config.RabbitMQ.Username,
+		Timestamp:     now,
+		AlertDuration: duration,
+	}, now)
+}
+
+// notifyAuth sends an authentication failure alert to Slack
+func (s *Service) notifyAuth(alert slack.AuthAlert, now time.Time) {
+	if s.slackClient == nil {
+		return
+	}
+
+	// Without working credentials nothing is monitored, so the alert is critical unless routed otherwise
+	webhookURLs := s.alertWebhooks("auth", "", config.PriorityCritical, !alert.Resolved, now)
+	if len(webhookURLs) == 0 {
+		return
+	}
+
+	err := s.slackClient.SendAuthAlert(alert, webhookURLs)
+	s.metrics.observeNotification("slack", err)
+	if err != nil {
+		s.logger.Error("Failed to send authentication failure Slack notification", err, map[string]interface{}{
+			"username": alert.Username,
+		})
+	} else if !alert.Resolved {
+		s.alerts.SentAlert("auth", "", now)
+	}
+}
@@ -0,0 +1,137 @@
+package monitor
+
+import (
+	"time"
+
+	"go-rmq-monitor/internal/rabbitmq"
+	"go-rmq-monitor/internal/slack"
+)
+
+// dlqPair is a monitored queue paired with its dead-letter queue by naming convention
+type dlqPair struct {
+	breachState
+	queue    rabbitmq.QueueInfo // Dead-letter queue as last fetched
+	previous int                // Messages in the dead-letter queue at the previous check
+	start    int                // Messages in the dead-letter queue when growth began
+}
+
+// deadLetterQueueFor returns the dead-letter queue paired with a queue, trying the configured suffixes in order
+func (s *Service) deadLetterQueueFor(queueName string, brokerQueues map[string]rabbitmq.QueueInfo) (rabbitmq.QueueInfo, bool) {
+	for _, suffix := range s.config.DeadLetters.Suffixes {
+		if queue, exists := brokerQueues[queueName+suffix]; exists {
+			return queue, true
+		}
+	}
+	return rabbitmq.QueueInfo{}, false
+}
+
+// checkDeadLetters pairs monitored queues with their dead-letter queues and alerts on growing dead-letter queues
+// A dead-letter queue alerts once it grew for the threshold checks, and recovers on the first check without growth
+func (s *Service) checkDeadLetters(queues, brokerQueues []rabbitmq.QueueInfo, now time.Time) {
+	cfg := s.config.DeadLetters
+
+	byName := make(map[string]rabbitmq.QueueInfo, len(brokerQueues))
+	for _, queue := range brokerQueues {
+		byName[queue.Name] = queue
+	}
+
+	seen := make(map[string]bool)
+	for _, queue := range queues {
+		dlq, paired := s.deadLetterQueueFor(queue.Name, byName)
+		if !paired {
+			continue
+		}
+		seen[queue.Name] = true
+
+		pair, exists := s.deadLetters[queue.Name]
+		if !exists || pair.queue.Name != dlq.Name {
+			// First sight of the pair: there is nothing to compare the depth with yet
+			s.deadLetters[queue.Name] = &dlqPair{queue: dlq, previous: dlq.Messages}
+			continue
+		}
+		pair.queue = dlq
+
+		alerting := !pair.since.IsZero()
+		growing := dlq.Messages-pair.previous >= cfg.MinGrowth
+		if growing {
+			if pair.breaches == 0 {
+				pair.start = pair.previous
+			}
+			pair.breaches++
+		} else {
+			pair.breaches = 0
+		}
+		pair.previous = dlq.Messages
+
+		fields := map[string]interface{}{
+			"queue":             queue.Name,
+			"dead_letter_queue": dlq.Name,
+			"messages":          dlq.Messages,
+		}
+		alert := slack.DeadLetterAlert{
+			QueueName:       queue.Name,
+			DeadLetterQueue: dlq.Name,
+			Messages:        dlq.Messages,
+			Growth:          dlq.Messages - pair.start,
+			Timestamp:       now,
+		}
+
+		switch {
+		case !alerting && pair.breaches >= cfg.ThresholdChecks:
+			pair.since = now
+			fields["growth"] = alert.Growth
+			s.logger.Warn("DEAD-LETTER QUEUE GROWING", fields)
+			s.notifyDeadLetter(alert, now)
+		case alerting && !growing:
+			alert.Resolved = true
+			alert.Growth = 0
+			alert.AlertDuration = now.Sub(pair.since)
+			pair.since = time.Time{}
+			fields["duration"] = alert.AlertDuration.String()
+			s.logger.Info("Dead-letter queue stopped growing", fields)
+			s.notifyDeadLetter(alert, now)
+		case growing && !alerting:
+			fields["breaches"] = pair.breaches
+			s.logger.Debug("Dead-letter queue growing", fields)
+		}
+	}
+
+	// Queues no longer monitored or without a dead-letter queue are forgotten
+	for name := range s.deadLetters {
+		if !seen[name] {
+			delete(s.deadLetters, name)
+		}
+	}
+}
+
+// addDeadLetters adds the depth of a queue's paired dead-letter queue to its alert
+func (s *Service) addDeadLetters(alert *slack.QueueAlert) {
+	if pair, paired := s.deadLetters[alert.QueueName]; paired {
+		alert.DeadLetterQueue = pair.queue.Name
+		alert.DeadLetterMessages = pair.queue.Messages
+	}
+}
+
+// notifyDeadLetter sends a dead-letter queue growth alert to Slack
+func (s *Service) notifyDeadLetter(alert slack.DeadLetterAlert, now time.Time) {
+	if s.slackClient == nil {
+		return
+	}
+
+	// Growth alerts are keyed by the originating queue, which owns the dead-lettered messages
+	webhookURLs := s.alertWebhooks("dead_letter", alert.QueueName, s.config.DeadLetters.Priority, !alert.Resolved, now)
+	if len(webhookURLs) == 0 {
+		return
+	}
+
+	err := s.slackClient.SendDeadLetterAlert(alert, webhookURLs)
+	s.metrics.observeNotification("slack", err)
+	if err != nil {
+		s.logger.Error("Failed to send dead-letter queue Slack notification", err, map[string]interface{}{
+			"queue":             alert.QueueName,
+			"dead_letter_queue": alert.DeadLetterQueue,
+		})
+	} else if !alert.Resolved {
+		s.alerts.SentAlert("dead_letter", alert.QueueName, now)
+	}
+}
@@ -0,0 +1,80 @@
+package monitor
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/pprof"
+	"sort"
+	"time"
+
+	"go-rmq-monitor/internal/analyzer"
+	"go-rmq-monitor/internal/config"
+)
+
+// debugState is the JSON document served by /debug/state
+type debugState struct {
+	Timestamp time.Time             `json:"timestamp"`
+	StartTime time.Time             `json:"start_time"`
+	Schedule  []debugScheduleEntry  `json:"schedule"`
+	States    []analyzer.QueueState `json:"states"`
+}
+
+// debugScheduleEntry describes when a queue was and will next be checked
+type debugScheduleEntry struct {
+	QueueName     string    `json:"queue"`
+	CheckInterval string    `json:"check_interval"`
+	LastCheck     time.Time `json:"last_check"`
+	NextCheck     time.Time `json:"next_check"`
+}
+
+// registerDebugHandlers exposes pprof and the state dump on the embedded server
+// Debug endpoints require the admin role and are unavailable without API tokens
+func (s *Service) registerDebugHandlers() {
+	s.server.HandleAPI("/debug/pprof/", config.RoleAdmin, pprof.Index)
+	s.server.HandleAPI("/debug/pprof/cmdline", config.RoleAdmin, pprof.Cmdline)
+	s.server.HandleAPI("/debug/pprof/profile", config.RoleAdmin, pprof.Profile)
+	s.server.HandleAPI("/debug/pprof/symbol", config.RoleAdmin, pprof.Symbol)
+	s.server.HandleAPI("/debug/pprof/trace", config.RoleAdmin, pprof.Trace)
+	s.server.HandleAPI("GET /debug/state", config.RoleAdmin, s.handleDebugState)
+}
+
+// handleDebugState dumps analyzer states and the check schedule as JSON
+func (s *Service) handleDebugState(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+
+	s.scheduleMu.Lock()
+	schedule := make([]debugScheduleEntry, 0, len(s.lastCheckTimes))
+	for name, lastCheck := range s.lastCheckTimes {
+		interval, exists := s.queueIntervals[name]
+		if !exists {
+			interval = s.config.Monitor.Interval
+		}
+		if backoff, backedOff := s.backoffs[name]; backedOff {
+			interval = backoff
+		}
+		intervalsSinceStart := int(now.Sub(s.startTime)/interval) + 1
+		schedule = append(schedule, debugScheduleEntry{
+			QueueName:     name,
+			CheckInterval: interval.String(),
+			LastCheck:     lastCheck,
+			NextCheck:     s.startTime.Add(time.Duration(intervalsSinceStart) * interval),
+		})
+	}
+	s.scheduleMu.Unlock()
+
+	sort.Slice(schedule, func(i, j int) bool {
+		return schedule[i].QueueName < schedule[j].QueueName
+	})
+
+	state := debugState{
+		Timestamp: now,
+		StartTime: s.startTime,
+		Schedule:  schedule,
+		States:    s.analyzer.Snapshot(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(state)
+}
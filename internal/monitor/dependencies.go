@@ -0,0 +1,61 @@
+package monitor
+
+import (
+	"sort"
+
+	"go-rmq-monitor/internal/config"
+)
+
+// dependencyGraph maps a queue to the queues its consumers depend on
+// When a dependency is stuck, the dependent queue is usually stuck as a consequence
+type dependencyGraph map[string][]string
+
+// newDependencyGraph builds the dependency graph from queue configs
+func newDependencyGraph(queues []config.QueueConfig) dependencyGraph {
+	graph := make(dependencyGraph)
+	for _, queue := range queues {
+		if len(queue.DependsOn) > 0 {
+			graph[queue.Name] = queue.DependsOn
+		}
+	}
+	return graph
+}
+
+// rootCauses returns the alerting dependencies of a queue that have no alerting
+// dependencies themselves, i.e. the most likely root causes of the queue being stuck
+// Returns nil if none of the queue's dependencies are alerting
+func (g dependencyGraph) rootCauses(queueName string, isAlerting func(string) bool) []string {
+	roots := make(map[string]bool)
+	visited := map[string]bool{queueName: true}
+
+	var visit func(name string) bool
+	visit = func(name string) bool {
+		foundAlertingDependency := false
+		for _, dependency := range g[name] {
+			if visited[dependency] {
+				continue
+			}
+			visited[dependency] = true
+			if !isAlerting(dependency) {
+				continue
+			}
+			foundAlertingDependency = true
+			if !visit(dependency) {
+				roots[dependency] = true
+			}
+		}
+		return foundAlertingDependency
+	}
+	visit(queueName)
+
+	if len(roots) == 0 {
+		return nil
+	}
+
+	result := make([]string, 0, len(roots))
+	for root := range roots {
+		result = append(result, root)
+	}
+	sort.Strings(result)
+	return result
+}
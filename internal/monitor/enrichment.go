@@ -0,0 +1,43 @@
+package monitor
+
+import (
+	"time"
+
+	"go-rmq-monitor/internal/redact"
+	"go-rmq-monitor/internal/slack"
+)
+
+// enrichAlert adds redacted consumer and head message details to an alerting notification
+// Enrichment failures are logged and the alert is sent without the details
+func (s *Service) enrichAlert(alert *slack.QueueAlert) {
+	enrichment := s.config.Enrichment
+
+	if apiStart := time.Now(); enrichment.ConsumerDetails && s.permitted("consumer_details", apiStart) {
+		consumers, err := s.client.GetConsumers(alert.QueueName)
+		s.metrics.observeAPICall("consumers", apiStart, err)
+		if denied := s.lacksPermission("consumer_details", "consumers", err, apiStart); err != nil && !denied {
+			s.logger.Error("Failed to fetch consumer details", err, map[string]interface{}{
+				"queue": alert.QueueName,
+			})
+		} else {
+			for _, consumer := range s.redactor.Consumers(consumers) {
+				alert.ConsumerDetails = append(alert.ConsumerDetails, redact.DescribeConsumer(consumer))
+			}
+		}
+	}
+
+	if enrichment.PeekMessages > 0 {
+		apiStart := time.Now()
+		messages, err := s.client.PeekMessages(alert.QueueName, enrichment.PeekMessages)
+		s.metrics.observeAPICall("get_messages", apiStart, err)
+		if err != nil {
+			s.logger.Error("Failed to peek head messages", err, map[string]interface{}{
+				"queue": alert.QueueName,
+			})
+		} else {
+			for _, message := range s.redactor.Messages(messages) {
+				alert.HeadMessages = append(alert.HeadMessages, redact.DescribeMessage(message))
+			}
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package monitor
+
+import (
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/rabbitmq"
+)
+
+// expiryTrackingQueues maps each queue to the queue its expired messages are dead-lettered to
+func expiryTrackingQueues(queues []config.QueueConfig) map[string]string {
+	tracking := make(map[string]string)
+	for _, queue := range queues {
+		if queue.ExpiryTrackingQueue != "" {
+			tracking[queue.Name] = queue.ExpiryTrackingQueue
+		}
+	}
+	return tracking
+}
+
+// applyExpiryTracking sets the measured expiry rate of checked queues with a tracking queue
+// The tracking queue's publish rate is the rate its source queue's messages expire at
+// Queues whose tracking queue does not exist are left to estimation
+func (s *Service) applyExpiryTracking(queuesToCheck, brokerQueues []rabbitmq.QueueInfo) {
+	publishRates := make(map[string]float64, len(brokerQueues))
+	for _, queue := range brokerQueues {
+		publishRates[queue.Name] = queue.PublishRate
+	}
+	for i := range queuesToCheck {
+		trackingQueue, tracked := s.expiryTracking[queuesToCheck[i].Name]
+		if !tracked {
+			continue
+		}
+		rate, exists := publishRates[trackingQueue]
+		if !exists {
+			s.logger.Warn("Expiry tracking queue not found", map[string]interface{}{
+				"queue":          queuesToCheck[i].Name,
+				"tracking_queue": trackingQueue,
+			})
+			continue
+		}
+		queuesToCheck[i].ExpireRate = rate
+		queuesToCheck[i].ExpiryTracked = true
+	}
+}
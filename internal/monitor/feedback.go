@@ -0,0 +1,33 @@
+package monitor
+
+import (
+	"go-rmq-monitor/internal/analyzer"
+	"go-rmq-monitor/internal/feedback"
+)
+
+// describeAlert returns the context of a queue's current or most recent alert
+// Returns false if the queue has not alerted since the monitor started
+func describeAlert(queueAnalyzer *analyzer.Analyzer, queueName string) (feedback.AlertContext, bool) {
+	state, exists := queueAnalyzer.SnapshotQueue(queueName)
+	if !exists || state.StuckSince.IsZero() {
+		return feedback.AlertContext{}, false
+	}
+
+	detectionCfg := queueAnalyzer.GetQueueConfig(queueName)
+	alertContext := feedback.AlertContext{
+		Alerting:         state.LastKnownState == "alerting",
+		StuckSince:       state.StuckSince,
+		ConsecutiveStuck: state.ConsecutiveStuck,
+		ThresholdChecks:  detectionCfg.ThresholdChecks,
+		MinMessageCount:  detectionCfg.MinMessageCount,
+		MinConsumeRate:   detectionCfg.MinConsumeRate,
+	}
+	if len(state.History) > 0 {
+		latest := state.History[len(state.History)-1]
+		alertContext.MessagesReady = latest.MessagesReady
+		alertContext.Consumers = latest.Consumers
+		alertContext.ConsumeRate = latest.ConsumeRate
+		alertContext.AckRate = latest.AckRate
+	}
+	return alertContext, true
+}
@@ -0,0 +1,35 @@
+package monitor
+
+import "time"
+
+// discardAfterGap drops the analyzer history of a queue whose last check is more than
+// monitor.max_missed_checks intervals ago, as after an API outage or a suspended host,
+// so its next checks are not compared with metrics from before the gap
+// Must be called before the queue's last check time is updated
+func (s *Service) discardAfterGap(queueName string, interval time.Duration, now time.Time) {
+	maxMissed := s.config.Monitor.MaxMissedChecks
+	lastCheck, checked := s.lastCheckTimes[queueName]
+	if maxMissed == 0 || !checked {
+		return
+	}
+	// Idle queues are checked less often on purpose
+	if backoff, backedOff := s.backoffs[queueName]; backedOff {
+		interval = backoff
+	}
+	gap := now.Sub(lastCheck)
+	if gap <= interval*time.Duration(maxMissed+1) {
+		return
+	}
+	state, tracked := s.analyzer.SnapshotQueue(queueName)
+	if !tracked || len(state.History) == 0 {
+		return
+	}
+	s.analyzer.DiscardHistory(queueName)
+	s.logger.Info("Discarded queue history after missed checks", map[string]interface{}{
+		"queue":     queueName,
+		"gap":       gap.Round(time.Second).String(),
+		"interval":  interval.String(),
+		"snapshots": len(state.History),
+		"alerting":  state.LastKnownState == "alerting",
+	})
+}
@@ -0,0 +1,68 @@
+package monitor
+
+import (
+	"time"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/rabbitmq"
+)
+
+// verifyConsumerIdentities records on each queue with an identity rule the consumers the rule does not allow
+// With require_all, listed applications and users without a consumer are recorded as well
+// Failures are logged and leave the queues unverified for this check
+func (s *Service) verifyConsumerIdentities(queues []rabbitmq.QueueInfo) {
+	rules := make(map[string]*config.ConsumerIdentityConfig)
+	for _, queueCfg := range s.config.Monitor.Queues {
+		if queueCfg.ConsumerIdentities != nil {
+			rules[queueCfg.Name] = queueCfg.ConsumerIdentities
+		}
+	}
+	due := false
+	for _, queue := range queues {
+		if rules[queue.Name] != nil {
+			due = true
+			break
+		}
+	}
+	if !due {
+		return
+	}
+
+	apiStart := time.Now()
+	if !s.permitted("consumer_identities", apiStart) {
+		return
+	}
+	identities, err := s.client.GetConsumerIdentities()
+	s.metrics.observeAPICall("consumers", apiStart, err)
+	if s.lacksPermission("consumer_identities", "consumers", err, apiStart) {
+		return
+	}
+	if err != nil {
+		s.logger.Warn("Failed to fetch consumer identities", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	for i := range queues {
+		rule := rules[queues[i].Name]
+		if rule == nil {
+			continue
+		}
+
+		var violations, applications, users []string
+		for _, identity := range identities[queues[i].Name] {
+			if !rule.Allows(identity.Application, identity.User) {
+				violations = append(violations, "unexpected consumer "+identity.String())
+			}
+			applications = append(applications, identity.Application)
+			users = append(users, identity.User)
+		}
+		if rule.RequireAll {
+			for _, missing := range rule.Missing(applications, users) {
+				violations = append(violations, "no consumer from "+missing)
+			}
+		}
+		queues[i].IdentityViolations = violations
+	}
+}
@@ -0,0 +1,117 @@
+package monitor
+
+import (
+	"time"
+
+	"go-rmq-monitor/internal/ack"
+	"go-rmq-monitor/internal/alerting"
+	"go-rmq-monitor/internal/analyzer"
+	"go-rmq-monitor/internal/history"
+	"go-rmq-monitor/internal/slack"
+)
+
+// recordResponseTimes measures how long a resolved incident took to be acknowledged and to recover
+// Both are counted from the alert; a is nil when the incident was never acknowledged
+func (s *Service) recordResponseTimes(transition analyzer.StateTransition, incident alerting.Incident, a *ack.Ack) {
+	timeToRecover := max(transition.Timestamp.Sub(incident.Since), 0)
+	var timeToAck time.Duration
+	if a != nil {
+		timeToAck = max(a.Timestamp.Sub(incident.Since), 0)
+		s.metrics.timeToAck.ObserveWithExemplar(timeToAck.Seconds(), s.exemplar(incident.ID), transition.QueueName)
+	}
+	s.metrics.timeToRecover.ObserveWithExemplar(timeToRecover.Seconds(), s.exemplar(incident.ID), transition.QueueName)
+
+	if s.slo != nil && s.config.SLO.Tracks(transition.QueueName) {
+		s.slo.RecordIncident(transition.QueueName, timeToAck, a != nil, timeToRecover)
+	}
+	s.logger.Debug("Incident response times recorded", map[string]interface{}{
+		"queue":           transition.QueueName,
+		"incident_id":     incident.ID,
+		"acknowledged":    a != nil,
+		"time_to_ack":     timeToAck.String(),
+		"time_to_recover": timeToRecover.String(),
+	})
+}
+
+// incidentHistory loads the stored history covering every incident that recovered in a check cycle
+// History is read once per cycle, from the start of the longest incident; nil when there is nothing to summarize
+func (s *Service) incidentHistory(transitions []analyzer.StateTransition) map[string][]history.Record {
+	if s.history == nil {
+		return nil
+	}
+	var since time.Time
+	for _, transition := range transitions {
+		if transition.ToState != "not_alerting" || transition.StuckDuration <= 0 {
+			continue
+		}
+		if start := transition.Timestamp.Add(-transition.StuckDuration); since.IsZero() || start.Before(since) {
+			since = start
+		}
+	}
+	if since.IsZero() {
+		return nil
+	}
+	records, err := history.Load(s.config.History.FilePath, since)
+	if err != nil {
+		s.logger.Warn("Failed to load history for incident statistics", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil
+	}
+	return records
+}
+
+// incidentStats summarizes a recovered queue's incident from the history loaded for the cycle
+// Returns nil when the history holds no records for the incident
+func incidentStats(transition analyzer.StateTransition, records map[string][]history.Record) *slack.IncidentStats {
+	if transition.StuckDuration <= 0 {
+		return nil
+	}
+	since := transition.Timestamp.Add(-transition.StuckDuration)
+	var queueRecords []history.Record
+	for _, record := range records[transition.QueueName] {
+		if !record.Timestamp.Before(since) {
+			queueRecords = append(queueRecords, record)
+		}
+	}
+	if len(queueRecords) == 0 {
+		return nil
+	}
+	return summarizeIncident(queueRecords, transition.QueueInfo.MessagesReady, transition.Timestamp)
+}
+
+// summarizeIncident finds the backlog peak and measures the drain from it until recovery
+// Processed messages integrate the ack rate, or the consume rate for auto-ack consumers
+func summarizeIncident(records []history.Record, recoveredBacklog int, recoveredAt time.Time) *slack.IncidentStats {
+	peak := 0
+	for i, record := range records {
+		if record.MessagesReady > records[peak].MessagesReady {
+			peak = i
+		}
+	}
+
+	stats := &slack.IncidentStats{
+		PeakBacklog: records[peak].MessagesReady,
+		PeakAt:      records[peak].Timestamp,
+	}
+
+	processed := 0.0
+	for i := peak + 1; i < len(records); i++ {
+		elapsed := records[i].Timestamp.Sub(records[i-1].Timestamp).Seconds()
+		processed += (processedRate(records[i-1]) + processedRate(records[i])) / 2 * elapsed
+	}
+	stats.Processed = int(processed)
+
+	if elapsed := recoveredAt.Sub(stats.PeakAt).Seconds(); elapsed > 0 && stats.PeakBacklog > recoveredBacklog {
+		stats.DrainRate = float64(stats.PeakBacklog-recoveredBacklog) / elapsed
+	}
+	return stats
+}
+
+// processedRate returns the rate messages were finished at in a record
+func processedRate(record history.Record) float64 {
+	if record.AckRate > 0 {
+		return record.AckRate
+	}
+	return record.ConsumeRate
+}
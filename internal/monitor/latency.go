@@ -0,0 +1,89 @@
+package monitor
+
+import (
+	"time"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/slack"
+)
+
+// latencyBuckets are histogram buckets in seconds for notification delivery, from instant to five minutes
+var latencyBuckets = []float64{0.5, 1, 2.5, 5, 10, 30, 60, 120, 300}
+
+// observeDelivery records a queue notification attempt on a channel and, once delivered,
+// its latency from the detection of the state change in alert.Timestamp
+// Returns the latency, zero when the notification failed
+func (s *Service) observeDelivery(channel string, alert slack.QueueAlert, err error) time.Duration {
+	s.metrics.observeNotification(channel, err)
+	if err != nil {
+		return 0
+	}
+	now := time.Now()
+	latency := now.Sub(alert.Timestamp)
+	s.metrics.deliveryLatency.Observe(latency.Seconds(), channel)
+	s.checkDeliveryLatency(channel, alert.QueueName, latency, now)
+	return latency
+}
+
+// checkDeliveryLatency alerts once when a channel delivers slower than notifications.max_delivery_latency,
+// and resolves when a later notification on it is delivered in time again
+func (s *Service) checkDeliveryLatency(channel, queue string, latency time.Duration, now time.Time) {
+	maxLatency := s.config.Notifications.MaxDeliveryLatency
+	if maxLatency == 0 {
+		return
+	}
+	since, slow := s.slowDelivery[channel]
+	isSlow := latency > maxLatency
+	if isSlow == slow {
+		return
+	}
+
+	fields := map[string]interface{}{
+		"channel":     channel,
+		"queue":       queue,
+		"latency":     latency.Round(time.Millisecond).String(),
+		"max_latency": maxLatency.String(),
+	}
+	alert := slack.LatencyAlert{
+		Resolved:   !isSlow,
+		Channel:    channel,
+		Latency:    latency,
+		MaxLatency: maxLatency,
+		QueueName:  queue,
+		Timestamp:  now,
+	}
+	if isSlow {
+		s.slowDelivery[channel] = now
+		s.logger.Warn("SLOW NOTIFICATION DELIVERY DETECTED", fields)
+	} else {
+		delete(s.slowDelivery, channel)
+		alert.AlertDuration = now.Sub(since)
+		fields["duration"] = alert.AlertDuration.String()
+		s.logger.Info("Notification delivery latency back to normal", fields)
+	}
+	s.notifyLatency(alert, now)
+}
+
+// notifyLatency sends a slow delivery alert to Slack
+// Its own delivery is not measured, so a slow Slack webhook cannot keep re-alerting about itself
+func (s *Service) notifyLatency(alert slack.LatencyAlert, now time.Time) {
+	if s.slackClient == nil {
+		return
+	}
+
+	// Late alerts delay every response, so slow delivery is high priority unless routed otherwise
+	webhookURLs := s.alertWebhooks("latency", alert.Channel, config.PriorityHigh, !alert.Resolved, now)
+	if len(webhookURLs) == 0 {
+		return
+	}
+
+	err := s.slackClient.SendLatencyAlert(alert, webhookURLs)
+	s.metrics.observeNotification("slack", err)
+	if err != nil {
+		s.logger.Error("Failed to send delivery latency Slack notification", err, map[string]interface{}{
+			"channel": alert.Channel,
+		})
+	} else if !alert.Resolved {
+		s.alerts.SentAlert("latency", alert.Channel, now)
+	}
+}
@@ -0,0 +1,60 @@
+package monitor
+
+import (
+	"fmt"
+	"time"
+
+	"go-rmq-monitor/internal/rabbitmq"
+)
+
+// leaderChangeWindow is how long after a queue moved to another node its alerts mention the move
+const leaderChangeWindow = time.Hour
+
+// queueNode is the node a queue lives on and its last move
+type queueNode struct {
+	node     string
+	previous string    // Node the queue moved from, empty until it moves
+	movedAt  time.Time // When the move was first seen
+}
+
+// trackQueueNodes records the home node of every monitored queue and logs queues that moved
+// A moved leader means a failover or rebalance, after which consumers often need to reconnect
+func (s *Service) trackQueueNodes(queues []rabbitmq.QueueInfo, now time.Time) {
+	seen := make(map[string]bool, len(queues))
+	for _, queue := range queues {
+		if queue.Node == "" {
+			continue
+		}
+		seen[queue.Name] = true
+		current, tracked := s.queueNodes[queue.Name]
+		if !tracked {
+			s.queueNodes[queue.Name] = &queueNode{node: queue.Node}
+			continue
+		}
+		if current.node == queue.Node {
+			continue
+		}
+		s.logger.Info("Queue leader moved", map[string]interface{}{
+			"queue": queue.Name,
+			"from":  current.node,
+			"to":    queue.Node,
+		})
+		s.metrics.leaderChanges.Inc(queue.Name)
+		current.previous, current.node, current.movedAt = current.node, queue.Node, now
+	}
+	for name := range s.queueNodes {
+		if !seen[name] {
+			delete(s.queueNodes, name)
+		}
+	}
+}
+
+// describeLeaderChange describes a queue's move to another node within the leader change window
+// Returns an empty string if the queue has not moved recently
+func (s *Service) describeLeaderChange(queueName string, now time.Time) string {
+	tracked, exists := s.queueNodes[queueName]
+	if !exists || tracked.previous == "" || now.Sub(tracked.movedAt) > leaderChangeWindow {
+		return ""
+	}
+	return fmt.Sprintf("leader moved from %s to %s %s ago", tracked.previous, tracked.node, now.Sub(tracked.movedAt).Round(time.Second))
+}
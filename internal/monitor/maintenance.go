@@ -0,0 +1,69 @@
+package monitor
+
+import (
+	"time"
+
+	"go-rmq-monitor/internal/config"
+)
+
+// checkNodeMaintenance updates which nodes are in maintenance mode, logging nodes that enter or leave it
+// Nodes stay tracked for the grace period after leaving maintenance, while consumers reconnect
+func (s *Service) checkNodeMaintenance(now time.Time) {
+	if !s.permitted("node_maintenance", now) {
+		return
+	}
+
+	apiStart := time.Now()
+	draining, err := s.client.GetDrainingNodes()
+	s.metrics.observeAPICall("nodes", apiStart, err)
+	if s.lacksPermission("node_maintenance", "nodes", err, now) {
+		return
+	}
+	if err != nil {
+		s.logger.Debug("Failed to get node maintenance status", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	for node := range draining {
+		if left, tracked := s.maintenance[node]; !tracked || !left.IsZero() {
+			s.logger.Warn("Node entered maintenance mode, alerts for its queues are "+s.maintenanceHandling(), map[string]interface{}{
+				"node": node,
+			})
+		}
+		s.maintenance[node] = time.Time{}
+	}
+	grace := s.config.Monitor.NodeMaintenance.Grace
+	for node, left := range s.maintenance {
+		if draining[node] {
+			continue
+		}
+		if left.IsZero() {
+			s.logger.Info("Node left maintenance mode", map[string]interface{}{
+				"node":  node,
+				"grace": grace.String(),
+			})
+			s.maintenance[node] = now
+		} else if now.Sub(left) >= grace {
+			delete(s.maintenance, node)
+		}
+	}
+}
+
+// inMaintenance reports whether a node is in maintenance mode or left it within the grace period
+func (s *Service) inMaintenance(node string, now time.Time) bool {
+	left, tracked := s.maintenance[node]
+	if !tracked || node == "" {
+		return false
+	}
+	return left.IsZero() || now.Sub(left) < s.config.Monitor.NodeMaintenance.Grace
+}
+
+// maintenanceHandling describes what happens to alerts of queues on a node in maintenance mode
+func (s *Service) maintenanceHandling() string {
+	if s.config.Monitor.NodeMaintenance.Action == config.MaintenanceSuppress {
+		return "suppressed"
+	}
+	return "annotated"
+}
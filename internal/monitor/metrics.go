@@ -0,0 +1,141 @@
+package monitor
+
+import (
+	"time"
+
+	"go-rmq-monitor/internal/metrics"
+)
+
+// serviceMetrics holds the metrics describing the monitor's own behavior
+type serviceMetrics struct {
+	registry      *metrics.Registry
+	checks        *metrics.Counter
+	checkFailures *metrics.Counter
+	checkDuration *metrics.Gauge
+	lastCheck     *metrics.Gauge
+	skippedTicks  *metrics.Counter
+	apiLatency    *metrics.Histogram
+	apiErrors     *metrics.Counter
+	notifications *metrics.Counter
+	stuckQueues   *metrics.Gauge
+	recovering    *metrics.Gauge
+	acksActive    *metrics.Gauge
+	silences      *metrics.Gauge
+	healthScores  *metrics.Gauge
+	queueAlerting *metrics.Gauge
+	selfTests     *metrics.Counter
+	lastSelfTest  *metrics.Gauge
+	timeToAck     *metrics.Histogram
+	timeToRecover *metrics.Histogram
+	clockSkew     *metrics.Gauge
+	queueChurn    *metrics.Gauge
+	leaderChanges *metrics.Counter
+
+	serviceReady     *metrics.Gauge
+	serviceConsumers *metrics.Gauge
+	serviceRates     *metrics.Gauge
+	serviceLag       *metrics.Gauge
+
+	capacityRate *metrics.Gauge
+	utilisation  *metrics.Gauge
+
+	backpressure *metrics.Gauge
+
+	desiredConsumers *metrics.Gauge
+
+	degraded *metrics.Gauge
+
+	deliveryLatency *metrics.Histogram
+}
+
+// incidentBuckets are histogram buckets in seconds for incident response times, from a minute to a day
+var incidentBuckets = []float64{60, 300, 900, 1800, 3600, 7200, 14400, 43200, 86400}
+
+// newServiceMetrics registers the monitor's self-metrics
+func newServiceMetrics() *serviceMetrics {
+	registry := metrics.NewRegistry()
+
+	return &serviceMetrics{
+		registry:      registry,
+		checks:        registry.NewCounter("rmq_monitor_checks_total", "Number of check cycles performed"),
+		checkFailures: registry.NewCounter("rmq_monitor_check_failures_total", "Number of check cycles that failed"),
+		checkDuration: registry.NewGauge("rmq_monitor_last_check_duration_seconds", "Duration of the most recent check cycle"),
+		lastCheck:     registry.NewGauge("rmq_monitor_last_check_timestamp_seconds", "Unix time the most recent check cycle finished"),
+		skippedTicks:  registry.NewCounter("rmq_monitor_skipped_ticks_total", "Number of scheduled checks skipped because a check was still running"),
+		apiLatency:    registry.NewHistogram("rmq_monitor_api_request_duration_seconds", "Latency of RabbitMQ management API requests", metrics.DefaultLatencyBuckets, "endpoint"),
+		apiErrors:     registry.NewCounter("rmq_monitor_api_errors_total", "Number of failed RabbitMQ management API requests", "endpoint"),
+		notifications: registry.NewCounter("rmq_monitor_notifications_total", "Number of notifications by channel and result", "channel", "result"),
+		stuckQueues:   registry.NewGauge("rmq_monitor_stuck_queues", "Number of queues currently alerting"),
+		recovering:    registry.NewGauge("rmq_monitor_recovering_queues", "Number of alerting queues whose backlog is decreasing"),
+		acksActive:    registry.NewGauge("rmq_monitor_acks_active", "Number of alerting queues currently acknowledged"),
+		silences:      registry.NewGauge("rmq_monitor_silences_active", "Number of silences currently in effect"),
+		healthScores:  registry.NewGauge("rmq_monitor_queue_health_score", "Composite queue health score from 0 (stuck) to 100 (healthy)", "queue"),
+		queueAlerting: registry.NewGauge("rmq_monitor_queue_alerting", "Whether a queue is currently alerting (1) or not (0)", "queue"),
+		selfTests:     registry.NewCounter("rmq_monitor_self_tests_total", "Number of alert pipeline self-tests by result", "result"),
+		lastSelfTest:  registry.NewGauge("rmq_monitor_last_self_test_success_timestamp_seconds", "Unix time the most recent self-test passed"),
+		timeToAck:     registry.NewHistogram("rmq_monitor_time_to_acknowledge_seconds", "Time from a queue alert to its acknowledgment", incidentBuckets, "queue"),
+		timeToRecover: registry.NewHistogram("rmq_monitor_time_to_recover_seconds", "Time from a queue alert to its recovery", incidentBuckets, "queue"),
+		clockSkew:     registry.NewGauge("rmq_monitor_clock_skew_seconds", "Broker clock minus local clock, from the management API Date header"),
+		queueChurn:    registry.NewGauge("rmq_monitor_queue_churn_per_minute", "Cluster-wide queues declared, created or deleted per minute", "event"),
+		leaderChanges: registry.NewCounter("rmq_monitor_queue_leader_changes_total", "Number of times a queue was seen on another node than in the previous check", "queue"),
+
+		serviceReady:     registry.NewGauge("rmq_monitor_service_messages_ready", "Ready messages summed across the queues of a service", "service"),
+		serviceConsumers: registry.NewGauge("rmq_monitor_service_consumers", "Consumers summed across the queues of a service", "service"),
+		serviceRates:     registry.NewGauge("rmq_monitor_service_rate", "Publish, consume or ack rate summed across the queues of a service", "service", "rate"),
+		serviceLag:       registry.NewGauge("rmq_monitor_service_lag_seconds", "Time for a service to drain its backlog at its consume rate, +Inf while nothing is consumed", "service"),
+
+		capacityRate: registry.NewGauge("rmq_monitor_queue_capacity_rate", "Sustainable consume rate of a queue estimated from history, msg/s", "queue"),
+		utilisation:  registry.NewGauge("rmq_monitor_queue_capacity_utilisation", "Publish rate of a queue relative to its estimated capacity", "queue"),
+
+		backpressure: registry.NewGauge("rmq_monitor_queue_backpressure", "Whether a queue signals producers to slow down (1) or not (0)", "queue"),
+
+		desiredConsumers: registry.NewGauge("rmq_monitor_scaling_desired_consumers", "Consumers a scaling target needs to keep up with publishing and drain its backlog in time", "target"),
+
+		degraded: registry.NewGauge("rmq_monitor_subsystem_disabled", "Whether a subsystem is off because the RabbitMQ user lacks permission for its endpoint (1) or not (0)", "subsystem"),
+
+		deliveryLatency: registry.NewHistogram("rmq_monitor_notification_latency_seconds", "Time from detecting a queue state change to delivering its notification", latencyBuckets, "channel"),
+	}
+}
+
+// observeAPICall records the latency and outcome of a management API request
+func (m *serviceMetrics) observeAPICall(endpoint string, start time.Time, err error) {
+	m.apiLatency.Observe(time.Since(start).Seconds(), endpoint)
+	if err != nil {
+		m.apiErrors.Inc(endpoint)
+	}
+}
+
+// observeHealthScores publishes the latest health score of every tracked queue
+func (m *serviceMetrics) observeHealthScores(scores map[string]int) {
+	for queue, score := range scores {
+		m.healthScores.Set(float64(score), queue)
+	}
+}
+
+// observeAlertStates publishes whether each tracked queue is currently alerting
+func (m *serviceMetrics) observeAlertStates(tracked map[string]int, alerting []string) {
+	for queue := range tracked {
+		m.queueAlerting.Set(0, queue)
+	}
+	for _, queue := range alerting {
+		m.queueAlerting.Set(1, queue)
+	}
+}
+
+// observeNotification records a notification attempt on a channel
+func (m *serviceMetrics) observeNotification(channel string, err error) {
+	result := "sent"
+	if err != nil {
+		result = "failed"
+	}
+	m.notifications.Inc(channel, result)
+}
+
+// textfileLabels labels the textfile samples with the instance name, so node_exporter can
+// collect the files of several instances on one host without duplicate series
+func textfileLabels(instance string) map[string]string {
+	if instance == "" {
+		return nil
+	}
+	return map[string]string{"monitor_instance": instance}
+}
@@ -0,0 +1,252 @@
+package monitor
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go-rmq-monitor/internal/alerting"
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/logger"
+	"go-rmq-monitor/internal/notify"
+	"go-rmq-monitor/internal/plugins"
+	"go-rmq-monitor/internal/slack"
+)
+
+// slackWebhookOptions returns the channel and mention settings of structured Slack webhooks
+func slackWebhookOptions(cfg config.SlackConfig) map[string]slack.WebhookOptions {
+	options := make(map[string]slack.WebhookOptions)
+	for _, webhook := range cfg.Webhooks {
+		if webhook.Channel == "" && webhook.Mention == "" {
+			continue
+		}
+		options[webhook.URL] = slack.WebhookOptions{
+			Channel: webhook.Channel,
+			Mention: slack.FormatMention(webhook.Mention),
+		}
+	}
+	return options
+}
+
+// newSlackConfig returns the Slack client settings, with templates loaded from the display config
+func newSlackConfig(cfg *config.Config, templates *slack.Templates) slack.Config {
+	return slack.Config{
+		Enabled:          cfg.Notifications.Slack.Enabled,
+		WebhookURLs:      cfg.Notifications.Slack.WebhookURLs,
+		AlertCooldown:    cfg.Notifications.Slack.AlertCooldown,
+		SendRecovery:     cfg.Notifications.Slack.SendRecovery,
+		RecoveryCooldown: cfg.Notifications.Slack.RecoveryCooldown,
+		Timeout:          cfg.Notifications.Slack.Timeout,
+		Display: slack.Display{
+			Location:   cfg.Notifications.Display.Location(),
+			TimeFormat: cfg.Notifications.Display.TimeFormat,
+			Language:   cfg.Notifications.Display.Language,
+			Templates:  templates,
+			Numbers:    cfg.Notifications.Display.NumberOverride(),
+			QueueNames: cfg.Notifications.Display.QueueNamer(),
+		},
+		WebhookLanguages: cfg.Notifications.Display.WebhookLanguages(),
+		Webhooks:         slackWebhookOptions(cfg.Notifications.Slack),
+	}
+}
+
+// notifierDisplay returns the display settings of non-Slack notifiers
+// Custom templates only apply to Slack messages
+func notifierDisplay(cfg *config.Config) slack.Display {
+	return slack.Display{
+		Location:   cfg.Notifications.Display.Location(),
+		TimeFormat: cfg.Notifications.Display.TimeFormat,
+		Language:   cfg.Notifications.Display.Language,
+		Numbers:    cfg.Notifications.Display.NumberOverride(),
+		QueueNames: cfg.Notifications.Display.QueueNamer(),
+	}
+}
+
+// newNotifiers creates the configured chat notifiers besides Slack
+func newNotifiers(cfg *config.Config) []notify.Notifier {
+	display := notifierDisplay(cfg)
+	notifiers := make([]notify.Notifier, 0)
+	if googleChat := cfg.Notifications.GoogleChat; googleChat.Enabled {
+		notifiers = append(notifiers, notify.NewGoogleChat(googleChat.WebhookURLs, display, googleChat.Timeout))
+	}
+	if zulip := cfg.Notifications.Zulip; zulip.Enabled {
+		notifiers = append(notifiers, notify.NewZulip(notify.ZulipConfig{
+			Site:     zulip.Site,
+			BotEmail: zulip.BotEmail,
+			APIKey:   zulip.APIKey,
+			Stream:   zulip.Stream,
+			Topic:    zulip.Topic,
+		}, display, zulip.Timeout))
+	}
+	return notifiers
+}
+
+// notifyChats sends a queue alert to every chat notifier
+// Returns the number of notifiers that delivered it, failures are logged and counted
+func (s *Service) notifyChats(alert slack.QueueAlert) int {
+	delivered := 0
+	for _, notifier := range s.notifiers {
+		err := notifier.SendAlert(alert)
+		latency := s.observeDelivery(notifier.Name(), alert, err)
+		if err != nil {
+			s.logger.Error("Failed to send notification", err, map[string]interface{}{
+				"notifier":   notifier.Name(),
+				"queue":      alert.QueueName,
+				"alert_type": string(alert.Type),
+			})
+			continue
+		}
+		s.logger.Info("Sent chat notification", map[string]interface{}{
+			"notifier":   notifier.Name(),
+			"queue":      alert.QueueName,
+			"alert_type": string(alert.Type),
+			"latency":    latency.Round(time.Millisecond).String(),
+		})
+		delivered++
+	}
+	return delivered
+}
+
+// alertWebhooks returns the webhooks a non-queue alert transition is sent to, nil if it is not notified
+// Cooldowns, severity and routing come from the alert type's notification settings
+func (s *Service) alertWebhooks(alertType, key, priority string, firing bool, now time.Time) []string {
+	decision := s.alerts.DecideAlert(alertType, key, priority, firing, now)
+	switch decision.Skip {
+	case "", alerting.SkipRecoveryDisabled:
+	default:
+		s.logger.Debug("Skipping Slack notification ("+decision.Skip+")", map[string]interface{}{
+			"alert_type":      alertType,
+			"key":             key,
+			"resolved":        !firing,
+			"cooldown":        decision.Cooldown.String(),
+			"time_since_last": decision.Since.String(),
+		})
+	}
+	if !decision.Send {
+		return nil
+	}
+	return decision.Route.WebhookURLs
+}
+
+// newSMSNotifier creates the Twilio SMS notifier if enabled
+func newSMSNotifier(cfg *config.Config) *notify.Twilio {
+	twilio := cfg.Notifications.Twilio
+	if !twilio.Enabled {
+		return nil
+	}
+	return notify.NewTwilio(notify.TwilioConfig{
+		AccountSID: twilio.AccountSID,
+		AuthToken:  twilio.AuthToken,
+		From:       twilio.From,
+		To:         twilio.To,
+		MaxPerHour: twilio.MaxPerHour,
+	}, notifierDisplay(cfg), twilio.Timeout)
+}
+
+// sendSMS texts a critical queue alert, reporting whether it was delivered
+// Messages over the hourly cap are dropped with a warning
+func (s *Service) sendSMS(alert slack.QueueAlert) bool {
+	err := s.sms.SendAlert(alert)
+	if errors.Is(err, notify.ErrRateLimited) {
+		s.logger.Warn("Dropping SMS notification (rate limit reached)", map[string]interface{}{
+			"queue":        alert.QueueName,
+			"max_per_hour": s.config.Notifications.Twilio.MaxPerHour,
+		})
+		return false
+	}
+	latency := s.observeDelivery(s.sms.Name(), alert, err)
+	if err != nil {
+		s.logger.Error("Failed to send SMS notification", err, map[string]interface{}{
+			"queue": alert.QueueName,
+		})
+		return false
+	}
+	s.logger.Info("Sent SMS notification", map[string]interface{}{
+		"queue":      alert.QueueName,
+		"recipients": len(s.config.Notifications.Twilio.To),
+		"latency":    latency.Round(time.Millisecond).String(),
+	})
+	return true
+}
+
+// ChannelCheck is the outcome of pinging one notification channel
+type ChannelCheck struct {
+	Channel string // e.g. "slack" or "zulip"
+	Target  string // Which webhook or account, without secrets
+	Err     error
+}
+
+// CheckNotificationChannels pings every enabled notification channel
+// Nothing is posted: webhooks receive empty payloads and APIs are only read
+func CheckNotificationChannels(cfg *config.Config) []ChannelCheck {
+	checks := make([]ChannelCheck, 0)
+	if cfg.Notifications.Slack.Enabled {
+		client := slack.New(slack.Config{Timeout: cfg.Notifications.Slack.Timeout})
+		for i, webhookURL := range cfg.SlackWebhookURLs() {
+			checks = append(checks, ChannelCheck{
+				Channel: "slack",
+				Target:  webhookTarget(i, webhookURL),
+				Err:     client.PingWebhook(webhookURL),
+			})
+		}
+	}
+	for _, notifier := range newNotifiers(cfg) {
+		checks = append(checks, ChannelCheck{Channel: notifier.Name(), Err: notifier.Ping()})
+	}
+	if len(cfg.Plugins) > 0 {
+		loaded, err := plugins.Load(cfg.Plugins)
+		if err != nil {
+			checks = append(checks, ChannelCheck{Channel: "plugins", Err: err})
+		}
+		for _, plugin := range loaded {
+			if plugin.Notifier != nil {
+				checks = append(checks, ChannelCheck{Channel: plugin.Name, Target: plugin.Path, Err: plugins.NewNotifier(plugin).Ping()})
+			}
+		}
+	}
+	if sms := newSMSNotifier(cfg); sms != nil {
+		checks = append(checks, ChannelCheck{Channel: sms.Name(), Target: cfg.Notifications.Twilio.From, Err: sms.Ping()})
+	}
+	return checks
+}
+
+// webhookTarget names a webhook by position and host, keeping its secret path out of logs
+func webhookTarget(index int, webhookURL string) string {
+	host := "invalid URL"
+	if parsed, err := url.Parse(webhookURL); err == nil {
+		host = parsed.Host
+	}
+	return fmt.Sprintf("webhook %d (%s)", index+1, host)
+}
+
+// checkChannelsAtStartup pings the notification channels as configured by startup_check
+// Failures are logged, and fail startup in "fail" mode
+func checkChannelsAtStartup(cfg *config.Config, log *logger.Logger) error {
+	mode := cfg.Notifications.StartupCheck
+	if mode == "off" {
+		return nil
+	}
+
+	failed := 0
+	checks := CheckNotificationChannels(cfg)
+	for _, check := range checks {
+		if check.Err == nil {
+			continue
+		}
+		failed++
+		log.Warn("Notification channel check failed", map[string]interface{}{
+			"channel": check.Channel,
+			"target":  check.Target,
+			"error":   check.Err.Error(),
+		})
+	}
+	if failed > 0 && mode == "fail" {
+		return fmt.Errorf("%d of %d notification channel checks failed", failed, len(checks))
+	}
+	log.Info("Notification channels checked", map[string]interface{}{
+		"checked": len(checks),
+		"failed":  failed,
+	})
+	return nil
+}
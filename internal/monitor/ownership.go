@@ -0,0 +1,70 @@
+package monitor
+
+import (
+	"time"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/logger"
+	"go-rmq-monitor/internal/ownership"
+	"go-rmq-monitor/internal/slack"
+)
+
+// newOwnershipDirectory creates the queue owner directory if enabled and fetches it once
+// A failed first fetch is logged and retried when the next alert needs an owner
+func newOwnershipDirectory(cfg config.OwnershipConfig, log *logger.Logger) *ownership.Directory {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	var source ownership.Source
+	switch cfg.Source {
+	case "backstage":
+		source = ownership.NewBackstage(ownership.BackstageConfig{
+			URL:                  cfg.Backstage.URL,
+			Token:                cfg.Backstage.Token,
+			QueueAnnotation:      cfg.Backstage.QueueAnnotation,
+			SlackAnnotation:      cfg.Backstage.SlackAnnotation,
+			EscalationAnnotation: cfg.Backstage.EscalationAnnotation,
+		}, cfg.Timeout)
+	default:
+		source = ownership.NewFile(cfg.File)
+	}
+
+	directory := ownership.NewDirectory(source, cfg.Refresh)
+	if err := directory.Refresh(time.Now()); err != nil {
+		log.Warn("Failed to load queue ownership", map[string]interface{}{
+			"source": source.Name(),
+			"error":  err.Error(),
+		})
+	}
+	log.Info("Queue ownership enabled", map[string]interface{}{
+		"source":  source.Name(),
+		"entries": directory.Len(),
+		"refresh": cfg.Refresh.String(),
+	})
+	return directory
+}
+
+// queueOwner resolves the owning team of a queue, refreshing the directory when due
+// Returns nil when ownership is disabled or no entry matches
+func (s *Service) queueOwner(queueName string, now time.Time) *slack.QueueOwner {
+	if s.owners == nil {
+		return nil
+	}
+	if err := s.owners.Refresh(now); err != nil {
+		s.logger.Warn("Failed to refresh queue ownership, using previous entries", map[string]interface{}{
+			"source": s.owners.Source(),
+			"error":  err.Error(),
+		})
+	}
+
+	owner, found := s.owners.Lookup(queueName)
+	if !found {
+		return nil
+	}
+	return &slack.QueueOwner{
+		Team:       owner.Team,
+		SlackGroup: owner.SlackGroup,
+		Escalation: owner.Escalation,
+	}
+}
@@ -0,0 +1,50 @@
+package monitor
+
+import (
+	"errors"
+	"time"
+
+	"go-rmq-monitor/internal/rabbitmq"
+)
+
+// permissionRetry is how long a subsystem stays off after the management API denied it access, before it is
+// tried again in case the user was granted the permission meanwhile
+const permissionRetry = time.Hour
+
+// errSubsystemDisabled stands in for a management API call skipped while its subsystem is off
+var errSubsystemDisabled = errors.New("disabled after the management API denied permission")
+
+// permitted reports whether a subsystem may call the management API, false while it is off after a permission error
+func (s *Service) permitted(subsystem string, now time.Time) bool {
+	deniedAt, denied := s.denied[subsystem]
+	return !denied || now.Sub(deniedAt) >= permissionRetry
+}
+
+// lacksPermission switches a subsystem off when the management API denies the user access to its endpoint,
+// so queue monitoring continues without it and without an error every check; returns whether access was denied
+// A successful call turns a switched off subsystem back on
+func (s *Service) lacksPermission(subsystem, endpoint string, err error, now time.Time) bool {
+	_, wasDenied := s.denied[subsystem]
+	if !rabbitmq.IsPermissionDenied(err) {
+		if err == nil && wasDenied {
+			delete(s.denied, subsystem)
+			s.metrics.degraded.Set(0, subsystem)
+			s.logger.Info("RabbitMQ user was granted permission, re-enabling "+subsystem, map[string]interface{}{
+				"endpoint": endpoint,
+			})
+		}
+		return false
+	}
+
+	s.denied[subsystem] = now
+	s.metrics.degraded.Set(1, subsystem)
+	if !wasDenied {
+		s.logger.Warn("RabbitMQ user lacks permission, disabling "+subsystem+" and continuing queue monitoring", map[string]interface{}{
+			"endpoint": endpoint,
+			"user":     s.config.RabbitMQ.Username,
+			"error":    err.Error(),
+			"retry_in": permissionRetry.String(),
+		})
+	}
+	return true
+}
@@ -0,0 +1,35 @@
+package monitor
+
+import (
+	"go-rmq-monitor/internal/analyzer"
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/logger"
+	"go-rmq-monitor/internal/notify"
+	"go-rmq-monitor/internal/plugins"
+)
+
+// loadPlugins loads the configured plugins, returning their detection checks and notifiers
+func loadPlugins(cfg *config.Config, log *logger.Logger) ([]analyzer.PluginDetector, []notify.Notifier, error) {
+	loaded, err := plugins.Load(cfg.Plugins)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	detectors := make([]analyzer.PluginDetector, 0)
+	notifiers := make([]notify.Notifier, 0)
+	for _, plugin := range loaded {
+		if plugin.Detector != nil {
+			detectors = append(detectors, analyzer.PluginDetector{Name: plugin.Name, Detector: plugin.Detector})
+		}
+		if plugin.Notifier != nil {
+			notifiers = append(notifiers, plugins.NewNotifier(plugin))
+		}
+		log.Info("Plugin loaded", map[string]interface{}{
+			"plugin":   plugin.Name,
+			"path":     plugin.Path,
+			"detector": plugin.Detector != nil,
+			"notifier": plugin.Notifier != nil,
+		})
+	}
+	return detectors, notifiers, nil
+}
@@ -0,0 +1,140 @@
+package monitor
+
+import (
+	"strings"
+	"time"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/rabbitmq"
+	"go-rmq-monitor/internal/slack"
+)
+
+// addProtocolQueues adds queues created by the MQTT and STOMP plugins to the monitored queues
+// Matching queues use the detection settings of their protocol rule unless configured explicitly
+func (s *Service) addProtocolQueues(allQueues, monitored []rabbitmq.QueueInfo) []rabbitmq.QueueInfo {
+	included := make(map[string]bool, len(monitored))
+	for _, queue := range monitored {
+		included[queue.Name] = true
+	}
+	configured := make(map[string]bool, len(s.config.Monitor.Queues))
+	for _, queueCfg := range s.config.Monitor.Queues {
+		configured[queueCfg.Name] = true
+	}
+
+	for _, queue := range allQueues {
+		if configured[queue.Name] {
+			continue
+		}
+		for _, protocol := range s.config.Protocols {
+			if !protocol.Matches(queue.Name) {
+				continue
+			}
+			if !s.protocolQueues[queue.Name] {
+				s.protocolQueues[queue.Name] = true
+				s.analyzer.SetQueueConfig(queue.Name, s.config.Monitor.GetProtocolDetectionConfig(protocol))
+				if protocol.Priority != "" {
+					s.priorities[queue.Name] = protocol.Priority
+				}
+				s.logger.Debug("Monitoring protocol queue", map[string]interface{}{
+					"queue":    queue.Name,
+					"protocol": protocol.Protocol,
+				})
+			}
+			if !included[queue.Name] {
+				included[queue.Name] = true
+				monitored = append(monitored, queue)
+			}
+			break
+		}
+	}
+	return monitored
+}
+
+// checkProtocolConnections alerts when fewer MQTT or STOMP connections are open than expected
+func (s *Service) checkProtocolConnections(now time.Time) {
+	watched := false
+	for _, protocol := range s.config.Protocols {
+		if protocol.MinConnections > 0 {
+			watched = true
+		}
+	}
+	if !watched {
+		return
+	}
+
+	if !s.permitted("protocol_connections", now) {
+		return
+	}
+
+	apiStart := time.Now()
+	counts, err := s.client.GetConnectionCounts()
+	s.metrics.observeAPICall("connections", apiStart, err)
+	if s.lacksPermission("protocol_connections", "connections", err, now) {
+		return
+	}
+	if err != nil {
+		s.logger.Warn("Failed to fetch connections", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	for _, protocol := range s.config.Protocols {
+		if protocol.MinConnections == 0 {
+			continue
+		}
+		connections := counts[protocol.Protocol]
+		since, low := s.lowConnections[protocol.Protocol]
+		isLow := connections < protocol.MinConnections
+		if isLow == low {
+			continue
+		}
+
+		fields := map[string]interface{}{
+			"protocol":        protocol.Protocol,
+			"connections":     connections,
+			"min_connections": protocol.MinConnections,
+		}
+		alert := slack.ConnectionAlert{
+			Resolved:       !isLow,
+			Protocol:       strings.ToUpper(protocol.Protocol),
+			Connections:    connections,
+			MinConnections: protocol.MinConnections,
+			VHost:          s.config.RabbitMQ.VHost,
+			Timestamp:      now,
+		}
+		if isLow {
+			s.lowConnections[protocol.Protocol] = now
+			s.logger.Warn("LOW PROTOCOL CONNECTIONS DETECTED", fields)
+		} else {
+			delete(s.lowConnections, protocol.Protocol)
+			alert.AlertDuration = now.Sub(since)
+			fields["duration"] = alert.AlertDuration.String()
+			s.logger.Info("Protocol connections recovered", fields)
+		}
+		s.notifyConnections(alert, protocol, now)
+	}
+}
+
+// notifyConnections sends a protocol connection alert to Slack
+func (s *Service) notifyConnections(alert slack.ConnectionAlert, protocol config.ProtocolConfig, now time.Time) {
+	if s.slackClient == nil {
+		return
+	}
+
+	// Connections are not owned by a team, so the connections alert type settings route them
+	webhookURLs := s.alertWebhooks("connections", protocol.Protocol, protocol.Priority, !alert.Resolved, now)
+	if len(webhookURLs) == 0 {
+		return
+	}
+
+	err := s.slackClient.SendConnectionAlert(alert, webhookURLs)
+	s.metrics.observeNotification("slack", err)
+	if err != nil {
+		s.logger.Error("Failed to send connection Slack notification", err, map[string]interface{}{
+			"protocol": protocol.Protocol,
+		})
+	} else if !alert.Resolved {
+		s.alerts.SentAlert("connections", protocol.Protocol, now)
+	}
+}
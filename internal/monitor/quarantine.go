@@ -0,0 +1,68 @@
+package monitor
+
+import (
+	"strconv"
+	"time"
+
+	"go-rmq-monitor/internal/audit"
+	"go-rmq-monitor/internal/rabbitmq"
+	"go-rmq-monitor/internal/redact"
+	"go-rmq-monitor/internal/slack"
+)
+
+// quarantineHead moves the head messages of a queue in a redelivery storm to the quarantine queue
+// In dry-run mode the messages are only peeked; the result is attached to the alert either way
+func (s *Service) quarantineHead(queueName string, now time.Time) *slack.Quarantine {
+	cfg := s.config.Quarantine
+	result := &slack.Quarantine{Queue: cfg.Queue, DryRun: cfg.DryRun}
+
+	var messages []rabbitmq.MessageInfo
+	var err error
+	apiStart := time.Now()
+	if cfg.DryRun {
+		messages, err = s.client.PeekMessages(queueName, cfg.MaxMessages)
+		s.metrics.observeAPICall("get_messages", apiStart, err)
+	} else {
+		messages, err = s.client.MoveMessages(queueName, cfg.Queue, cfg.MaxMessages)
+		s.metrics.observeAPICall("move_messages", apiStart, err)
+	}
+	for _, message := range s.redactor.Messages(messages) {
+		result.Messages = append(result.Messages, redact.DescribeMessage(message))
+	}
+
+	fields := map[string]interface{}{
+		"queue":            queueName,
+		"quarantine_queue": cfg.Queue,
+		"messages":         len(messages),
+		"dry_run":          cfg.DryRun,
+	}
+	if err != nil {
+		result.Error = err.Error()
+		s.logger.Error("Failed to quarantine poison messages", err, fields)
+	} else if cfg.DryRun {
+		s.logger.Info("Would quarantine poison messages (dry run)", fields)
+	} else {
+		s.logger.Warn("Quarantined poison messages", fields)
+	}
+
+	// Only real moves change broker state, so dry runs are not audited
+	if !cfg.DryRun && len(messages) > 0 {
+		details := map[string]string{
+			"quarantine_queue": cfg.Queue,
+			"messages":         strconv.Itoa(len(messages)),
+		}
+		if err != nil {
+			details["error"] = err.Error()
+		}
+		recordAudit(s.audit, s.logger, audit.Entry{
+			Timestamp: now,
+			Action:    audit.ActionQuarantine,
+			Actor:     audit.SourceMonitor,
+			Source:    audit.SourceMonitor,
+			Queues:    []string{queueName},
+			Details:   details,
+		})
+	}
+
+	return result
+}
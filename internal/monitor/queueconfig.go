@@ -0,0 +1,227 @@
+package monitor
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"time"
+
+	"go-rmq-monitor/internal/audit"
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/logger"
+	"go-rmq-monitor/internal/server"
+)
+
+// queueSettings holds the per-queue settings the service derives from monitor.queues
+type queueSettings struct {
+	detection     map[string]config.DetectionConfig
+	intervals     map[string]time.Duration
+	observeOnly   map[string]bool
+	priorities    map[string]string
+	expectBeats   map[string]bool
+	priorityBands map[string]bool
+}
+
+// newQueueSettings resolves the settings of every enabled queue in monitor.queues and logs them
+func newQueueSettings(cfg *config.Config, log *logger.Logger, verbosity int) queueSettings {
+	settings := queueSettings{
+		detection:     make(map[string]config.DetectionConfig),
+		intervals:     make(map[string]time.Duration),
+		observeOnly:   make(map[string]bool),
+		priorities:    make(map[string]string),
+		expectBeats:   make(map[string]bool),
+		priorityBands: make(map[string]bool),
+	}
+
+	// Log monitored queues at startup if verbosity >= 2
+	if verbosity >= 2 {
+		log.Info("Configured queue monitoring", map[string]interface{}{
+			"total_queues": len(cfg.Monitor.Queues),
+		})
+	}
+
+	for _, queueCfg := range cfg.Monitor.Queues {
+		// Disabled queues stay in config but are not monitored at all
+		if !queueCfg.IsEnabled() {
+			log.Info("Queue monitoring disabled", map[string]interface{}{
+				"queue": queueCfg.Name,
+			})
+			continue
+		}
+
+		detectionCfg := cfg.Monitor.GetQueueDetectionConfig(&queueCfg)
+		settings.detection[queueCfg.Name] = detectionCfg
+
+		checkInterval := cfg.Monitor.GetQueueCheckInterval(&queueCfg)
+		settings.intervals[queueCfg.Name] = checkInterval
+
+		if queueCfg.ObserveOnly {
+			settings.observeOnly[queueCfg.Name] = true
+		}
+		if queueCfg.Priority != "" {
+			settings.priorities[queueCfg.Name] = queueCfg.Priority
+		}
+		if queueCfg.ExpectHeartbeat {
+			settings.expectBeats[queueCfg.Name] = true
+		}
+		if queueCfg.TracksPriorities() {
+			settings.priorityBands[queueCfg.Name] = true
+		}
+
+		fields := map[string]interface{}{
+			"queue":             queueCfg.Name,
+			"check_interval":    checkInterval.String(),
+			"threshold_checks":  detectionCfg.ThresholdChecks,
+			"min_message_count": detectionCfg.MinMessageCount,
+			"min_consume_rate":  detectionCfg.MinConsumeRate,
+			"observe_only":      queueCfg.ObserveOnly,
+			"priority":          queueCfg.Priority,
+			"profile":           queueCfg.Profile,
+		}
+		// Log queue configuration if verbosity >= 2
+		if verbosity >= 2 {
+			log.Info("Queue configuration", fields)
+		} else {
+			log.Debug("Configured queue monitoring", fields)
+		}
+	}
+	return settings
+}
+
+// configDocument is the JSON document served by GET /api/v1/config
+type configDocument struct {
+	Queues []map[string]interface{} `json:"queues"`
+}
+
+// configUpdateResult is the JSON response of PUT /api/v1/config
+type configUpdateResult struct {
+	DryRun  bool     `json:"dry_run"`
+	Applied bool     `json:"applied"`
+	Queues  int      `json:"queues"`
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+	Changed []string `json:"changed"`
+}
+
+// handleGetConfig exports the queue monitoring settings in effect
+func (s *Service) handleGetConfig(w http.ResponseWriter, r *http.Request) {
+	s.scheduleMu.Lock()
+	queues := s.config.Monitor.Queues
+	s.scheduleMu.Unlock()
+	document := configDocument{Queues: config.QueueSettings(queues)}
+
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(document)
+}
+
+// handlePutConfig replaces the queue monitoring settings with the "queues" list of the request body
+// The settings are validated like the config file; with dry_run=true the changes are only reported
+// The optional "by" query parameter names who made the change
+func (s *Service) handlePutConfig(w http.ResponseWriter, r *http.Request) {
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+
+	s.checkMu.Lock()
+	defer s.checkMu.Unlock()
+	updated, err := config.WithQueues(s.config, r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result := diffQueueConfigs(s.config.Monitor.Queues, updated.Monitor.Queues)
+	result.DryRun = dryRun
+	result.Queues = len(updated.Monitor.Queues)
+	if !dryRun {
+		s.applyQueues(updated.Monitor.Queues)
+		result.Applied = true
+
+		actor := r.URL.Query().Get("by")
+		identity := server.Identity(r)
+		s.baseLogger.Info("Queue settings updated through the config API", map[string]interface{}{
+			"by":       actor,
+			"identity": identity,
+			"added":    result.Added,
+			"removed":  result.Removed,
+			"changed":  result.Changed,
+		})
+		recordAudit(s.audit, s.baseLogger, audit.Entry{
+			Timestamp: time.Now(),
+			Action:    audit.ActionConfigUpdate,
+			Actor:     actor,
+			Identity:  identity,
+			Source:    audit.SourceAPI,
+			Queues:    append(append(append([]string{}, result.Added...), result.Removed...), result.Changed...),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// diffQueueConfigs lists the queues added, removed and changed between two queue lists
+func diffQueueConfigs(current, updated []config.QueueConfig) configUpdateResult {
+	before := make(map[string]config.QueueConfig, len(current))
+	for _, queue := range current {
+		before[queue.Name] = queue
+	}
+	result := configUpdateResult{Added: make([]string, 0), Removed: make([]string, 0), Changed: make([]string, 0)}
+	seen := make(map[string]bool, len(updated))
+	for _, queue := range updated {
+		seen[queue.Name] = true
+		previous, exists := before[queue.Name]
+		switch {
+		case !exists:
+			result.Added = append(result.Added, queue.Name)
+		case !reflect.DeepEqual(previous, queue):
+			result.Changed = append(result.Changed, queue.Name)
+		}
+	}
+	for _, queue := range current {
+		if !seen[queue.Name] {
+			result.Removed = append(result.Removed, queue.Name)
+		}
+	}
+	return result
+}
+
+// applyQueues switches the running service to new queue settings; the caller holds checkMu
+// Queues no longer monitored lose their state and open incidents without notifications
+// Changes are not written to the config file and are lost on restart
+func (s *Service) applyQueues(queues []config.QueueConfig) {
+	s.scheduleMu.Lock()
+	s.config.Monitor.Queues = queues
+	s.scheduleMu.Unlock()
+	settings := newQueueSettings(s.config, s.baseLogger, s.verbosity)
+
+	s.analyzer.ReplaceQueueConfigs(settings.detection)
+	s.scheduleMu.Lock()
+	s.queueIntervals = settings.intervals
+	s.scheduleMu.Unlock()
+	s.observeOnly = settings.observeOnly
+	s.priorities = settings.priorities
+	s.expectBeats = settings.expectBeats
+	if s.heartbeats != nil {
+		s.heartbeats.SetExpected(settings.expectBeats)
+	}
+	s.priorityBands = settings.priorityBands
+	s.expiryTracking = expiryTrackingQueues(queues)
+	s.dependencies = newDependencyGraph(queues)
+
+	// With an empty list every queue is monitored, so none is dropped
+	if len(queues) == 0 {
+		return
+	}
+	// Walk the tracked states rather than the previous list, which is empty when every queue was monitored
+	for _, state := range s.analyzer.Snapshot() {
+		if _, monitored := settings.intervals[state.QueueName]; monitored {
+			continue
+		}
+		s.analyzer.Forget(state.QueueName)
+		s.alerts.Resolve(state.QueueName)
+		s.baseLogger.Info("Queue no longer monitored", map[string]interface{}{
+			"queue": state.QueueName,
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package monitor
+
+import (
+	"fmt"
+	"time"
+
+	"go-rmq-monitor/internal/queueerrors"
+	"go-rmq-monitor/internal/rabbitmq"
+	"go-rmq-monitor/internal/slack"
+)
+
+// trackQueueErrors records configured queues missing from the broker and data for the monitored queues,
+// logging when a queue's data returns after a gap
+func (s *Service) trackQueueErrors(brokerQueues, monitored []rabbitmq.QueueInfo, now time.Time) {
+	names := make([]string, 0, len(monitored))
+	keep := make(map[string]bool)
+	for _, queue := range monitored {
+		names = append(names, queue.Name)
+		keep[queue.Name] = true
+	}
+	for queue, gap := range s.queueErrors.Observed(names, now) {
+		s.logger.Info("Queue data resumed after a gap", map[string]interface{}{
+			"queue": queue,
+			"gap":   gap.Round(time.Second).String(),
+		})
+	}
+
+	// Queues of other shards are not missing, so with sharding only the instance owning cluster alerts reports them
+	if !s.config.Sharding.OwnsClusterAlerts() {
+		s.queueErrors.Forget(keep)
+		return
+	}
+	onBroker := make(map[string]bool, len(brokerQueues))
+	for _, queue := range brokerQueues {
+		onBroker[queue.Name] = true
+	}
+	var missing []string
+	for _, qCfg := range s.config.Monitor.Queues {
+		keep[qCfg.Name] = true
+		if qCfg.IsEnabled() && !onBroker[qCfg.Name] {
+			missing = append(missing, qCfg.Name)
+		}
+	}
+	for _, queue := range s.queueErrors.Failed(missing, queueerrors.MissingQueue, now) {
+		s.logger.Warn("Configured queue not found on the broker", map[string]interface{}{
+			"queue": queue,
+		})
+	}
+	s.queueErrors.Forget(keep)
+}
+
+// enrichWithDataGap appends the length of a data gap to the reason of an alert that fires
+// within threshold_checks + 1 intervals after the gap, as the gap may hide how the queue got stuck
+func (s *Service) enrichWithDataGap(queueName, reason string, now time.Time) string {
+	interval, exists := s.queueIntervals[queueName]
+	if !exists {
+		interval = s.config.Monitor.Interval
+	}
+	window := interval * time.Duration(s.config.Monitor.Detection.ThresholdChecks+1)
+	gap, recent := s.queueErrors.RecentGap(queueName, window, now)
+	if !recent {
+		return reason
+	}
+	return fmt.Sprintf("%s (after a data gap of %s)", reason, slack.FormatDuration(gap, "en"))
+}
@@ -0,0 +1,71 @@
+package monitor
+
+import (
+	"time"
+
+	"go-rmq-monitor/internal/analyzer"
+	"go-rmq-monitor/internal/slack"
+)
+
+// logRecovering reports alerting queues whose backlog started decreasing
+func (s *Service) logRecovering(transitions []analyzer.StateTransition) {
+	for _, transition := range transitions {
+		s.logger.Info("Queue recovering", map[string]interface{}{
+			"queue":          transition.QueueName,
+			"messages_ready": transition.QueueInfo.MessagesReady,
+			"alerting_for":   transition.StuckDuration.Round(time.Second).String(),
+			"reason":         transition.Reason,
+			"incident_id":    s.alerts.IncidentID(transition.QueueName),
+		})
+	}
+}
+
+// sendRecovering sends informational notifications for queues that started recovering
+// They are held back like reminders: during storms, silences and quiet hours, and for
+// observe-only queues and queues covered by a root cause alert
+func (s *Service) sendRecovering(transitions []analyzer.StateTransition, now time.Time) {
+	for _, transition := range transitions {
+		queue := transition.QueueName
+		if s.stormActive || s.observeOnly[queue] || s.alerts.IsSuppressed(queue) {
+			continue
+		}
+		if _, silenced := s.silences.Active(queue, now); silenced {
+			continue
+		}
+
+		priority := s.priorities[queue]
+		route := s.alerts.Route(queue, priority, now)
+		if route.Quiet && len(route.WebhookURLs) == 0 {
+			continue
+		}
+
+		alert := slack.QueueAlert{
+			Type:          slack.AlertTypeRecovering,
+			QueueName:     queue,
+			Priority:      priority,
+			VHost:         transition.QueueInfo.VHost,
+			MessagesReady: transition.QueueInfo.MessagesReady,
+			Consumers:     transition.QueueInfo.Consumers,
+			ConsumeRate:   transition.QueueInfo.ConsumeRate,
+			AckRate:       transition.QueueInfo.AckRate,
+			PublishRate:   transition.QueueInfo.PublishRate,
+			Reason:        transition.Reason,
+			Timestamp:     transition.Timestamp,
+			StuckDuration: transition.StuckDuration,
+			DrainRate:     transition.DrainRate,
+		}
+		s.correlate(&alert, s.alerts.IncidentID(queue))
+		if s.slackClient != nil && len(route.WebhookURLs) > 0 {
+			err := s.slackClient.SendAlertTo(alert, route.WebhookURLs)
+			s.observeDelivery("slack", alert, err)
+			if err != nil {
+				s.logger.Error("Failed to send recovering notification", err, map[string]interface{}{
+					"queue": queue,
+				})
+			}
+		}
+		if !route.Quiet {
+			s.notifyChats(alert)
+		}
+	}
+}
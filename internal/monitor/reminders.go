@@ -0,0 +1,91 @@
+package monitor
+
+import (
+	"time"
+
+	"go-rmq-monitor/internal/rabbitmq"
+	"go-rmq-monitor/internal/slack"
+)
+
+// reminder tracks a notified incident for periodic reminders
+type reminder struct {
+	depth    int       // Ready messages when the alert was sent
+	lastSent time.Time // When the alert or the latest reminder was sent
+}
+
+// trackReminder starts reminding about a queue whose alert was delivered
+func (s *Service) trackReminder(queue rabbitmq.QueueInfo, now time.Time) {
+	if !s.config.Notifications.Reminders.Enabled {
+		return
+	}
+	s.reminders[queue.Name] = &reminder{depth: queue.MessagesReady, lastSent: now}
+}
+
+// sendReminders notifies again about checked queues that are still alerting after the reminder interval
+// Reminders are held back like alerts: during storms, silences and quiet hours, and once acknowledged
+func (s *Service) sendReminders(queues []rabbitmq.QueueInfo, now time.Time) {
+	interval := s.config.Notifications.Reminders.Interval
+	for _, queue := range queues {
+		r, tracked := s.reminders[queue.Name]
+		if !tracked {
+			continue
+		}
+		state := s.analyzer.GetQueueState(queue.Name)
+		if state == nil || state.LastKnownState != "alerting" {
+			delete(s.reminders, queue.Name)
+			continue
+		}
+		if now.Sub(r.lastSent) < interval || s.stormActive {
+			continue
+		}
+		if _, silenced := s.silences.Active(queue.Name, now); silenced {
+			continue
+		}
+		if s.acks != nil {
+			if _, acked := s.acks.Get(queue.Name); acked {
+				continue
+			}
+		}
+
+		priority := s.priorities[queue.Name]
+		route := s.alerts.Route(queue.Name, priority, now)
+		if route.Quiet && len(route.WebhookURLs) == 0 {
+			continue
+		}
+		r.lastSent = now
+
+		alert := slack.QueueAlert{
+			Type:            slack.AlertTypeReminder,
+			QueueName:       queue.Name,
+			Priority:        priority,
+			VHost:           queue.VHost,
+			MessagesReady:   queue.MessagesReady,
+			Consumers:       queue.Consumers,
+			ConsumeRate:     queue.ConsumeRate,
+			AckRate:         queue.AckRate,
+			PublishRate:     queue.PublishRate,
+			Timestamp:       now,
+			StuckDuration:   now.Sub(state.StuckSince),
+			InitialMessages: r.depth,
+		}
+		s.correlate(&alert, s.alerts.IncidentID(queue.Name))
+		if s.slackClient != nil && len(route.WebhookURLs) > 0 {
+			err := s.slackClient.SendAlertTo(alert, route.WebhookURLs)
+			s.observeDelivery("slack", alert, err)
+			if err != nil {
+				s.logger.Error("Failed to send reminder", err, map[string]interface{}{
+					"queue": queue.Name,
+				})
+			}
+		}
+		if !route.Quiet {
+			s.notifyChats(alert)
+		}
+		s.logger.Info("Sent reminder for long-stuck queue", map[string]interface{}{
+			"queue":          queue.Name,
+			"alerting_for":   alert.StuckDuration.String(),
+			"messages_ready": queue.MessagesReady,
+			"initial_depth":  r.depth,
+		})
+	}
+}
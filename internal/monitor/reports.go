@@ -0,0 +1,59 @@
+package monitor
+
+import (
+	"path/filepath"
+	"time"
+
+	"go-rmq-monitor/internal/report"
+)
+
+// checkReportSchedule generates the weekly report on the first check after its scheduled time
+// Reports due while the monitor was not running are skipped
+func (s *Service) checkReportSchedule(now time.Time) {
+	scheduled := s.config.Reports.Previous(now, s.config.Notifications.Display.Location())
+	if !scheduled.After(s.lastReport) {
+		return
+	}
+	s.lastReport = scheduled
+	s.generateReport(scheduled)
+}
+
+// generateReport renders the report of the window ending at to and writes or emails it
+func (s *Service) generateReport(to time.Time) {
+	cfg := s.config.Reports
+	display := s.config.Notifications.Display
+	fields := map[string]interface{}{
+		"from": to.Add(-cfg.Window).Format(time.RFC3339),
+		"to":   to.Format(time.RFC3339),
+	}
+
+	built, err := report.Generate(s.config.History.FilePath, cfg.Window, to, cfg.BaselineWeeks)
+	if err != nil {
+		s.logger.Error("Failed to generate report", err, fields)
+		return
+	}
+	page, err := built.HTML(display.Location(), display.NumberFormat())
+	if err != nil {
+		s.logger.Error("Failed to render report", err, fields)
+		return
+	}
+
+	delivery := report.Delivery{
+		PDFCommand: cfg.PDFCommand,
+		Email:      cfg.Email,
+		Subject:    report.Subject(built, display.Location()),
+	}
+	if cfg.OutputDir != "" {
+		delivery.HTMLPath = filepath.Join(cfg.OutputDir, "report-"+to.In(display.Location()).Format("2006-01-02")+".html")
+	}
+	files, err := report.Deliver(page, delivery)
+	fields["queues"] = len(built.Queues)
+	fields["incidents"] = built.Incidents
+	fields["files"] = files
+	fields["emailed"] = len(cfg.Email.To)
+	if err != nil {
+		s.logger.Error("Failed to deliver report", err, fields)
+		return
+	}
+	s.logger.Info("Generated weekly report", fields)
+}
@@ -0,0 +1,54 @@
+package monitor
+
+import (
+	"time"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/rabbitmq"
+	"go-rmq-monitor/internal/scaling"
+)
+
+// checkScaling computes the desired consumers of every scaling target from the queues it consumes from
+func (s *Service) checkScaling(queues []rabbitmq.QueueInfo, now time.Time) {
+	hints := make([]scaling.Hint, 0, len(s.config.Scaling.Targets))
+	for _, targetCfg := range s.config.Scaling.Targets {
+		hint := scaling.Compute(scalingTarget(s.config.Scaling, targetCfg), sumScalingLoad(targetCfg, queues), now)
+		hints = append(hints, hint)
+		s.metrics.desiredConsumers.Set(float64(hint.DesiredConsumers), hint.Target)
+		s.logger.Debug("Computed scaling hint", map[string]interface{}{
+			"target":            hint.Target,
+			"queues":            hint.Queues,
+			"messages_ready":    hint.MessagesReady,
+			"publish_rate":      hint.PublishRate,
+			"consumers":         hint.Consumers,
+			"desired_consumers": hint.DesiredConsumers,
+		})
+	}
+	s.scaling.Update(hints)
+}
+
+// scalingTarget resolves the settings of a scaling target
+func scalingTarget(cfg config.ScalingConfig, target config.ScalingTargetConfig) scaling.Target {
+	return scaling.Target{
+		Name:         target.Name,
+		ConsumerRate: target.ConsumerRate,
+		DrainTime:    cfg.GetDrainTime(target),
+		MinConsumers: target.MinConsumers,
+		MaxConsumers: target.MaxConsumers,
+	}
+}
+
+// sumScalingLoad sums the load of the queues a scaling target consumes from
+func sumScalingLoad(target config.ScalingTargetConfig, queues []rabbitmq.QueueInfo) scaling.Load {
+	var load scaling.Load
+	for _, queue := range queues {
+		if !target.Includes(queue.Name) {
+			continue
+		}
+		load.Queues++
+		load.MessagesReady += queue.MessagesReady
+		load.PublishRate += queue.PublishRate
+		load.Consumers += queue.Consumers
+	}
+	return load
+}
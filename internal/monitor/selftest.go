@@ -0,0 +1,135 @@
+package monitor
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"go-rmq-monitor/internal/analyzer"
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/rabbitmq"
+	"go-rmq-monitor/internal/slack"
+)
+
+// selfTestPrefix marks self-test notifications so nobody acts on them
+const selfTestPrefix = "[self-test] "
+
+// SelfTestReport is the outcome of one self-test
+type SelfTestReport struct {
+	Transitions []string // States the synthetic queue entered, in order
+	Sent        int      // Notifications delivered to the test channel
+	Err         error
+}
+
+// RunSelfTest plays a synthetic stuck and recovery sequence through a fresh analyzer
+// and sends the resulting alerts through the Slack formatter to the given webhooks
+// No broker is contacted and the monitor's own queue state is left untouched
+func RunSelfTest(cfg *config.Config, webhookURLs []string) SelfTestReport {
+	var report SelfTestReport
+	transitions, err := selfTestTransitions(cfg)
+	for _, transition := range transitions {
+		report.Transitions = append(report.Transitions, transition.ToState)
+	}
+	if err != nil {
+		report.Err = err
+		return report
+	}
+
+	templates, err := slack.LoadTemplates(cfg.Notifications.Display.Templates.Alerting, cfg.Notifications.Display.Templates.Recovery)
+	if err != nil {
+		report.Err = fmt.Errorf("failed to load notification templates: %w", err)
+		return report
+	}
+	slackConfig := newSlackConfig(cfg, templates)
+	slackConfig.Enabled = true
+	slackConfig.WebhookURLs = webhookURLs
+	client := slack.New(slackConfig)
+
+	for _, transition := range transitions {
+		alert := slack.QueueAlert{
+			Type:          slack.AlertTypeAlerting,
+			QueueName:     transition.QueueName,
+			VHost:         transition.QueueInfo.VHost,
+			MessagesReady: transition.QueueInfo.MessagesReady,
+			Consumers:     transition.QueueInfo.Consumers,
+			ConsumeRate:   transition.QueueInfo.ConsumeRate,
+			AckRate:       transition.QueueInfo.AckRate,
+			PublishRate:   transition.QueueInfo.PublishRate,
+			Reason:        selfTestPrefix + transition.Reason,
+			Timestamp:     transition.Timestamp,
+			StuckDuration: transition.StuckDuration,
+		}
+		if transition.ToState == "not_alerting" {
+			alert.Type = slack.AlertTypeNotAlerting
+			alert.Reason = selfTestPrefix + "synthetic queue recovered"
+		}
+		if err := client.SendAlertTo(alert, webhookURLs); err != nil {
+			report.Err = fmt.Errorf("failed to send %s notification: %w", alert.Type, err)
+			return report
+		}
+		report.Sent++
+	}
+	return report
+}
+
+// selfTestTransitions feeds a synthetic queue through the analyzer with the global detection
+// settings: a growing backlog without consumers until it alerts, then a drained queue until it recovers
+// Backlog growth and rates are randomized so successive runs exercise different message content
+func selfTestTransitions(cfg *config.Config) ([]analyzer.StateTransition, error) {
+	detection := cfg.Monitor.Detection
+	// The health score lags behind the metrics and would hold the recovery back
+	detection.MinHealthScore = 0
+	detector := analyzer.New(&detection)
+	maxChecks := 3 * max(detection.ThresholdChecks, 1)
+
+	queue := rabbitmq.QueueInfo{
+		Name:  cfg.Notifications.SelfTest.QueueName,
+		VHost: cfg.RabbitMQ.VHost,
+		Type:  "classic",
+	}
+	growth := 1 + rand.Intn(500)
+	queue.MessagesReady = detection.MinMessageCount
+	queue.PublishRate = 1 + rand.Float64()*50
+	var transitions []analyzer.StateTransition
+	for check := 1; check <= maxChecks && len(transitions) == 0; check++ {
+		queue.MessagesReady += growth
+		transitions = append(transitions, detector.Analyze([]rabbitmq.QueueInfo{queue}).Transitions...)
+	}
+	if len(transitions) == 0 {
+		return nil, fmt.Errorf("analyzer raised no alert for the synthetic stuck queue after %d checks", maxChecks)
+	}
+
+	queue.MessagesReady = 0
+	queue.Consumers = 1
+	queue.ConsumeRate = queue.PublishRate
+	queue.AckRate = queue.PublishRate
+	for check := 1; check <= maxChecks && len(transitions) == 1; check++ {
+		transitions = append(transitions, detector.Analyze([]rabbitmq.QueueInfo{queue}).Transitions...)
+	}
+	if len(transitions) == 1 {
+		return transitions, fmt.Errorf("analyzer did not recover the synthetic queue after %d checks", maxChecks)
+	}
+	return transitions, nil
+}
+
+// runSelfTest runs a scheduled self-test and records its outcome
+func (s *Service) runSelfTest() {
+	selfTest := s.config.Notifications.SelfTest
+	report := RunSelfTest(s.config, selfTest.WebhookURLs)
+	if report.Err != nil {
+		s.metrics.selfTests.Inc("failed")
+		s.logger.Error("Self-test failed", report.Err, map[string]interface{}{
+			"queue":       selfTest.QueueName,
+			"transitions": report.Transitions,
+			"sent":        report.Sent,
+		})
+		return
+	}
+	s.metrics.selfTests.Inc("passed")
+	s.metrics.lastSelfTest.Set(float64(time.Now().Unix()))
+	s.logger.Info("Self-test passed", map[string]interface{}{
+		"queue":       selfTest.QueueName,
+		"transitions": report.Transitions,
+		"sent":        report.Sent,
+	})
+}
@@ -1,29 +1,105 @@
 package monitor
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
 
+	"go-rmq-monitor/internal/ack"
+	"go-rmq-monitor/internal/alerting"
 	"go-rmq-monitor/internal/analyzer"
+	"go-rmq-monitor/internal/audit"
+	"go-rmq-monitor/internal/backpressure"
+	"go-rmq-monitor/internal/capacity"
 	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/events"
+	"go-rmq-monitor/internal/heartbeat"
+	"go-rmq-monitor/internal/feedback"
+	"go-rmq-monitor/internal/history"
 	"go-rmq-monitor/internal/logger"
+	"go-rmq-monitor/internal/notify"
+	"go-rmq-monitor/internal/ownership"
+	"go-rmq-monitor/internal/queueerrors"
 	"go-rmq-monitor/internal/rabbitmq"
+	"go-rmq-monitor/internal/redact"
+	"go-rmq-monitor/internal/scaling"
+	"go-rmq-monitor/internal/server"
+	"go-rmq-monitor/internal/silence"
 	"go-rmq-monitor/internal/slack"
+	"go-rmq-monitor/internal/slo"
+	"go-rmq-monitor/internal/streams"
+	"go-rmq-monitor/internal/tail"
 )
 
 // Service manages the monitoring process
 type Service struct {
 	config         *config.Config
-	logger         *logger.Logger
+	logger         *logger.Logger           // Stamped with the running check's ID during check cycles
+	baseLogger     *logger.Logger           // Never scoped, for HTTP handlers and shutdown, which run outside check cycles
 	client         *rabbitmq.Client
 	analyzer       *analyzer.Analyzer
 	slackClient    *slack.Client
+	notifiers      []notify.Notifier // Chat platforms besides Slack
+	sms            *notify.Twilio    // Last-resort SMS for critical alerts
+	alerts         *alerting.Manager
+	server         *server.Server
+	heartbeats     *heartbeat.Tracker
+	streams        *streams.Tracker
+	backpressure   *backpressure.Board // Flags producers poll, nil when disabled
+	redisSignals   *backpressure.Redis // Mirrors backpressure flags to Redis, nil when not configured
+	scaling        *scaling.Board      // Desired consumers of scaling targets, nil when disabled
+	compareClient  *rabbitmq.Client // Vhost or broker queues are compared with, nil when disabled
+	slo            *slo.Tracker
+	brokerEvents   *events.Tracker
+	acks           *ack.Store
+	feedback       *feedback.Store
+	audit          *audit.Log
+	redactor       *redact.Redactor
+	owners         *ownership.Directory // Queue owners from a service catalog
+	silences       *silence.Store
+	metrics        *serviceMetrics
+	history        *history.Store
+	capacities     map[string]capacity.Estimate // Consume capacity estimated from history, by queue
+	sinks          []*sinkQueue
+	tail           *tail.Hub                // Live event streams of the server, nil when it is disabled
 	queueIntervals map[string]time.Duration // Per-queue check intervals
 	lastCheckTimes map[string]time.Time     // Track last check time per queue
+	idleSince      map[string]time.Time     // When each idle queue was first seen idle
+	backoffs       map[string]time.Duration // Lengthened check intervals of idle queues
+	scheduleMu     sync.Mutex               // Guards lastCheckTimes, backoffs and the queue list for the debug and config endpoints
+	observeOnly    map[string]bool          // Queues that are logged but never notified
+	priorities     map[string]string        // Priority class per queue
+	expectBeats    map[string]bool          // Queues whose consumers send heartbeats
+	priorityBands  map[string]bool          // Priority queues whose per-priority backlog is fetched
+	expiryTracking map[string]string        // Queues whose expired messages are dead-lettered to a tracking queue
+	protocolQueues map[string]bool          // MQTT/STOMP queues configured from protocol rules
+	lowConnections map[string]time.Time     // Protocols below their minimum connections, since when
+	vhostStates    map[string]*breachState  // Vhost rule breaches, by vhost
+	serviceStates  map[string]*breachState  // Service limit breaches, by service
+	shardStates    map[string]*breachState  // Shards falling behind their siblings, by service and queue
+	capacityAt     time.Time                // When capacity was last estimated
+	capacityStates map[string]*breachState  // Sustained high utilisation, by queue
+	comparisons    map[string]*breachState  // Divergence from the compared side, by queue
+	deadLetters    map[string]*dlqPair      // Dead-letter queues paired by naming convention, by queue
+	churn          breachState              // Queue churn limit breaches
+	reminders      map[string]*reminder     // Notified incidents awaiting reminders
+	dependencies   dependencyGraph          // Declared queue dependencies
+	stormActive    bool                     // Per-queue notifications suppressed by a cluster-wide alert
+	clockSkewed    bool                     // Clock skew was reported and has not been resolved
+	authFailedAt   time.Time                // When the management API started rejecting the credentials, zero while accepted
+	denied         map[string]time.Time     // Subsystems off for lack of permission, and when access was last denied
+	slowDelivery   map[string]time.Time     // Channels delivering notifications too slowly, and since when
+	queueErrors    *queueerrors.Tracker     // Last check error per queue and the data gaps errors left
+	lastReport     time.Time                // Scheduled time of the last weekly report
+	maintenance    map[string]time.Time     // Nodes in maintenance mode, and when they left it (zero while draining)
+	queueNodes     map[string]*queueNode    // Home node of each monitored queue and its last move
 	startTime      time.Time                 // Service start time for synchronized checks
 	verbosity      int                       // Verbosity level (1=info, 2=+healthy, 3=+each check)
 	stopChan       chan struct{}
+	checkNow       chan struct{}            // Manual check requests from the control API
+	checkMu        sync.Mutex               // Held for the duration of a check cycle
+	checkID        string                   // ID of the running check cycle, attached to its logs, notifications and events
 	wg             sync.WaitGroup
 	running        bool
 	mu             sync.Mutex
@@ -37,79 +113,419 @@ func New(cfg *config.Config, log *logger.Logger, verbosity int) (*Service, error
 		return nil, fmt.Errorf("failed to create RabbitMQ client: %w", err)
 	}
 
-	// Create analyzer with global defaults
+	// Create analyzer with global defaults and user-defined rules
+	rules, err := analyzer.CompileRules(cfg.Monitor.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile detection rules: %w", err)
+	}
+	detectors, pluginNotifiers, err := loadPlugins(cfg, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load plugins: %w", err)
+	}
 	analyzer := analyzer.New(&cfg.Monitor.Detection)
 
 	// Configure per-queue settings and intervals
-	queueIntervals := make(map[string]time.Duration)
 	lastCheckTimes := make(map[string]time.Time)
-	
-	// Log monitored queues at startup if verbosity >= 2
-	if verbosity >= 2 {
-		log.Info("Configured queue monitoring", map[string]interface{}{
-			"total_queues": len(cfg.Monitor.Queues),
-		})
-	}
-	
-	for _, queueCfg := range cfg.Monitor.Queues {
-		detectionCfg := queueCfg.GetDetectionConfig(cfg.Monitor.Detection)
-		analyzer.SetQueueConfig(queueCfg.Name, detectionCfg)
-		
-		checkInterval := queueCfg.GetCheckInterval(cfg.Monitor.Interval)
-		queueIntervals[queueCfg.Name] = checkInterval
-		
-		// Log queue configuration if verbosity >= 2
-		if verbosity >= 2 {
-			log.Info("Queue configuration", map[string]interface{}{
-				"queue":             queueCfg.Name,
-				"check_interval":    checkInterval.String(),
-				"threshold_checks":  detectionCfg.ThresholdChecks,
-				"min_message_count": detectionCfg.MinMessageCount,
-				"min_consume_rate":  detectionCfg.MinConsumeRate,
-			})
-		} else {
-			log.Debug("Configured queue monitoring", map[string]interface{}{
-				"queue":             queueCfg.Name,
-				"check_interval":    checkInterval.String(),
-				"threshold_checks":  detectionCfg.ThresholdChecks,
-				"min_message_count": detectionCfg.MinMessageCount,
-				"min_consume_rate":  detectionCfg.MinConsumeRate,
-			})
-		}
+	queues := newQueueSettings(cfg, log, verbosity)
+	for name, detectionCfg := range queues.detection {
+		analyzer.SetQueueConfig(name, detectionCfg)
 	}
+	analyzer.SetRules(rules)
+	analyzer.SetPluginDetectors(detectors)
 
 	// Create Slack client if enabled
 	var slackClient *slack.Client
 	if cfg.Notifications.Slack.Enabled {
-		slackConfig := slack.Config{
-			Enabled:          cfg.Notifications.Slack.Enabled,
-			WebhookURLs:      cfg.Notifications.Slack.WebhookURLs,
-			AlertCooldown:    cfg.Notifications.Slack.AlertCooldown,
-			SendRecovery:     cfg.Notifications.Slack.SendRecovery,
-			RecoveryCooldown: cfg.Notifications.Slack.RecoveryCooldown,
-			Timeout:          cfg.Notifications.Slack.Timeout,
+		templates, err := slack.LoadTemplates(cfg.Notifications.Display.Templates.Alerting, cfg.Notifications.Display.Templates.Recovery)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load notification templates: %w", err)
 		}
+		slackConfig := newSlackConfig(cfg, templates)
 		slackClient = slack.New(slackConfig)
 		log.Info("Slack notifications enabled", map[string]interface{}{
 			"webhook_count":     len(slackConfig.WebhookURLs),
 			"alert_cooldown":    slackConfig.AlertCooldown.String(),
 			"send_recovery":     slackConfig.SendRecovery,
 			"recovery_cooldown": slackConfig.RecoveryCooldown.String(),
+			"language":          slackConfig.Display.Language,
+			"custom_templates":  templates != nil,
+		})
+	}
+
+	// Create Google Chat, Zulip and plugin notifiers if enabled
+	notifiers := append(newNotifiers(cfg), pluginNotifiers...)
+	for _, notifier := range notifiers {
+		log.Info("Chat notifier enabled", map[string]interface{}{
+			"notifier": notifier.Name(),
+		})
+	}
+	sms := newSMSNotifier(cfg)
+	if sms != nil {
+		log.Info("SMS notifications enabled for critical alerts", map[string]interface{}{
+			"recipients":   len(cfg.Notifications.Twilio.To),
+			"max_per_hour": cfg.Notifications.Twilio.MaxPerHour,
+		})
+	}
+
+	// Ping notification channels so dead webhooks are found before the first incident
+	if err := checkChannelsAtStartup(cfg, log); err != nil {
+		return nil, err
+	}
+
+	// Self-metrics are always collected and exposed when the server is enabled
+	serviceMetrics := newServiceMetrics()
+
+	// Create embedded HTTP server if enabled
+	var httpServer *server.Server
+	if cfg.Server.Enabled {
+		httpServer, err = server.New(cfg.Server)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create HTTP server: %w", err)
+		}
+		metricsRole := ""
+		if cfg.Server.Auth.ProtectMetrics {
+			metricsRole = config.RoleReadOnly
+		}
+		httpServer.HandleAPI("GET /metrics", metricsRole, serviceMetrics.registry.Handler())
+		if cfg.Server.TLS.Enabled() {
+			log.Info("HTTP server TLS enabled", map[string]interface{}{
+				"client_certificates": cfg.Server.TLS.ClientCAFile != "",
+			})
+		}
+	}
+
+	if cfg.Textfile.Enabled {
+		log.Info("Metrics textfile export enabled", map[string]interface{}{
+			"file_path": cfg.Textfile.FilePath,
+		})
+	}
+
+	// Create heartbeat tracker and expose its endpoint if enabled
+	var heartbeats *heartbeat.Tracker
+	if cfg.Heartbeats.Enabled {
+		heartbeats = heartbeat.New(cfg.Heartbeats.MaxAge, queues.expectBeats)
+		httpServer.HandleAPI("POST /heartbeats/{queue}", config.RoleReadOnly, heartbeats.HandleHeartbeat)
+		httpServer.HandleAPI("GET /heartbeats", config.RoleReadOnly, heartbeats.HandleList)
+		log.Info("Consumer heartbeats enabled", map[string]interface{}{
+			"max_age": cfg.Heartbeats.MaxAge.String(),
+		})
+	}
+
+	// Create stream consumer lag tracker if enabled
+	var streamTracker *streams.Tracker
+	if cfg.Streams.Enabled {
+		streamTracker = streams.New(streams.Config{
+			MaxOffsetLag:    cfg.Streams.MaxOffsetLag,
+			LagGrowthChecks: cfg.Streams.LagGrowthChecks,
+			AlertOnDetach:   cfg.Streams.AlertOnDetach,
+		})
+		if httpServer != nil {
+			httpServer.HandleAPI("GET /api/streams", config.RoleReadOnly, streamTracker.HandleList)
+		}
+		log.Info("Stream monitoring enabled", map[string]interface{}{
+			"streams":           cfg.Streams.Names,
+			"max_offset_lag":    cfg.Streams.MaxOffsetLag,
+			"lag_growth_checks": cfg.Streams.LagGrowthChecks,
+		})
+	}
+
+	// Create the backpressure board producers poll and its Redis mirror if enabled
+	var backpressureBoard *backpressure.Board
+	var redisSignals *backpressure.Redis
+	if cfg.Backpressure.Enabled {
+		backpressureBoard = backpressure.New(cfg.Backpressure.MaxMessagesReady)
+		if httpServer != nil {
+			httpServer.HandleAPI("GET /api/backpressure/{queue}", config.RoleReadOnly, backpressureBoard.HandleGet)
+			httpServer.HandleAPI("GET /api/backpressure", config.RoleReadOnly, backpressureBoard.HandleList)
+		}
+		if redisCfg := cfg.Backpressure.Redis; redisCfg.URL != "" {
+			redisSignals, err = backpressure.NewRedis(redisCfg.URL, redisCfg.KeyPrefix, redisCfg.TTL, redisCfg.Timeout)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create backpressure redis writer: %w", err)
+			}
+		}
+		log.Info("Backpressure signals enabled", map[string]interface{}{
+			"max_messages_ready": cfg.Backpressure.MaxMessagesReady,
+			"redis":              redisSignals != nil,
+		})
+	}
+
+	// Expose scaling hints to autoscalers if enabled
+	var scalingBoard *scaling.Board
+	if cfg.Scaling.Enabled {
+		scalingBoard = scaling.New()
+		if httpServer != nil {
+			httpServer.HandleAPI("GET /api/scaling/{target}", config.RoleReadOnly, scalingBoard.HandleGet)
+			httpServer.HandleAPI("GET /api/scaling", config.RoleReadOnly, scalingBoard.HandleList)
+		}
+		log.Info("Scaling hints enabled", map[string]interface{}{
+			"targets":    len(cfg.Scaling.Targets),
+			"drain_time": cfg.Scaling.DrainTime.String(),
 		})
 	}
 
-	return &Service{
+	// Connect to the vhost or broker queues are compared with if enabled
+	var compareClient *rabbitmq.Client
+	if cfg.Comparison.Enabled {
+		target := cfg.Comparison.TargetRabbitMQ(cfg.RabbitMQ)
+		compareClient, err = rabbitmq.NewClient(&target)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to comparison target: %w", err)
+		}
+		log.Info("Queue comparison enabled", map[string]interface{}{
+			"target_host":  target.Host,
+			"target_vhost": target.VHost,
+			"tolerance":    cfg.Comparison.Tolerance,
+		})
+	}
+
+	// Open availability objective tracker if enabled
+	var sloTracker *slo.Tracker
+	if cfg.SLO.Enabled {
+		sloTracker, err = slo.Open(cfg.SLO.FilePath, slo.Config{
+			Target:         cfg.SLO.Target,
+			BurnRateWindow: cfg.SLO.BurnRateWindow,
+			BurnRateAlert:  cfg.SLO.BurnRateAlert,
+			MaxGap:         3 * cfg.Monitor.Interval,
+			Location:       cfg.Notifications.Display.Location(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to open SLO state: %w", err)
+		}
+		if httpServer != nil {
+			httpServer.HandleAPI("GET /api/slo", config.RoleReadOnly, sloTracker.HandleStatus)
+		}
+		log.Info("SLO tracking enabled", map[string]interface{}{
+			"target":          cfg.SLO.Target,
+			"burn_rate_alert": cfg.SLO.BurnRateAlert,
+			"file_path":       cfg.SLO.FilePath,
+		})
+	}
+
+	// Create broker event tracker if enabled
+	var brokerEvents *events.Tracker
+	if cfg.BrokerEvents.Enabled {
+		brokerEvents = events.New(cfg.BrokerEvents.Window)
+		log.Info("Broker event correlation enabled", map[string]interface{}{
+			"window": cfg.BrokerEvents.Window.String(),
+		})
+	}
+
+	// Open the operator action audit log if enabled
+	var auditLog *audit.Log
+	if cfg.Audit.Enabled {
+		auditLog, err = audit.Open(cfg.Audit.FilePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open audit log: %w", err)
+		}
+		log.Info("Audit log enabled", map[string]interface{}{
+			"file_path": cfg.Audit.FilePath,
+		})
+	}
+
+	// Create acknowledgment store and expose its endpoints if enabled
+	var acks *ack.Store
+	if cfg.Notifications.Acks.Enabled {
+		isAlerting := func(queueName string) bool {
+			state := analyzer.GetQueueState(queueName)
+			return state != nil && state.LastKnownState == "alerting"
+		}
+		onAck := func(a ack.Ack) {
+			log.Info("Alert acknowledged", map[string]interface{}{
+				"queue":   a.QueueName,
+				"by":      a.By,
+				"source":  a.Source,
+				"comment": a.Comment,
+			})
+			recordAudit(auditLog, log, audit.Entry{
+				Timestamp: a.Timestamp,
+				Action:    audit.ActionAck,
+				Actor:     a.By,
+				Identity:  a.Identity,
+				Source:    a.Source,
+				Queues:    []string{a.QueueName},
+				Details:   commentDetails(a.Comment),
+			})
+		}
+		acks = ack.New(isAlerting, onAck)
+		httpServer.HandleAPI("POST /api/acks/{queue}", config.RoleSilencer, acks.HandleAcknowledge)
+		httpServer.HandleAPI("GET /api/acks", config.RoleReadOnly, acks.HandleList)
+	}
+
+	// Open false positive feedback store and expose its endpoints if enabled
+	var feedbackStore *feedback.Store
+	if cfg.Feedback.Enabled {
+		describe := func(queueName string) (feedback.AlertContext, bool) {
+			return describeAlert(analyzer, queueName)
+		}
+		onMark := func(m feedback.Mark) {
+			log.Info("Alert marked as false positive", map[string]interface{}{
+				"queue":             m.QueueName,
+				"by":                m.By,
+				"source":            m.Source,
+				"comment":           m.Comment,
+				"messages_ready":    m.Context.MessagesReady,
+				"consume_rate":      m.Context.ConsumeRate,
+				"threshold_checks":  m.Context.ThresholdChecks,
+				"min_message_count": m.Context.MinMessageCount,
+			})
+			recordAudit(auditLog, log, audit.Entry{
+				Timestamp: m.Timestamp,
+				Action:    audit.ActionFalsePositive,
+				Actor:     m.By,
+				Identity:  m.Identity,
+				Source:    m.Source,
+				Queues:    []string{m.QueueName},
+				Details:   commentDetails(m.Comment),
+			})
+		}
+		feedbackStore, err = feedback.Open(cfg.Feedback.FilePath, describe, onMark)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open feedback store: %w", err)
+		}
+		httpServer.HandleAPI("POST /api/false-positives/{queue}", config.RoleSilencer, feedbackStore.HandleMark)
+		httpServer.HandleAPI("GET /api/false-positives", config.RoleReadOnly, feedbackStore.HandleList)
+	}
+
+	// Handle Slack button clicks if any buttons are enabled
+	slackActions := make(map[string]slack.ActionFunc)
+	if cfg.Notifications.Slack.AckButton {
+		slackActions[slack.ActionAcknowledge] = func(queueName, user string) error {
+			_, err := acks.Acknowledge(queueName, user, ack.SourceSlack, "", "", time.Now())
+			return err
+		}
+	}
+	if cfg.Notifications.Slack.FalsePositiveButton {
+		slackActions[slack.ActionFalsePositive] = func(queueName, user string) error {
+			_, err := feedbackStore.MarkFalsePositive(queueName, user, feedback.SourceSlack, "", "", time.Now())
+			return err
+		}
+	}
+	if len(slackActions) > 0 {
+		httpServer.HandleFunc("POST /slack/actions", slack.NewActionHandler(cfg.Notifications.Slack.SigningSecret, slackActions))
+	}
+
+	// Open history store if enabled
+	var historyStore *history.Store
+	if cfg.History.Enabled {
+		historyStore, err = history.Open(cfg.History.FilePath, cfg.History.Retention)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open history: %w", err)
+		}
+		log.Info("Queue history recording enabled", map[string]interface{}{
+			"file_path": cfg.History.FilePath,
+			"retention": cfg.History.Retention.String(),
+		})
+	}
+
+	// Create event sinks for the data platform if enabled
+	eventSinks, err := newEventSinks(cfg.EventSinks)
+	if err != nil {
+		return nil, err
+	}
+	for _, eventSink := range eventSinks {
+		log.Info("Event sink enabled", map[string]interface{}{
+			"sink":         eventSink.Name(),
+			"check_events": cfg.EventSinks.CheckEvents,
+		})
+	}
+
+	// Record silences created or expired through the API
+	onSilenceChange := func(event string, s silence.Silence, actor, identity string) {
+		log.Info("Silence "+event, map[string]interface{}{
+			"silence_id": s.ID,
+			"queues":     s.Queues,
+			"by":         actor,
+			"ends_at":    s.EndsAt,
+		})
+		recordAudit(auditLog, log, silenceAuditEntry(event, s, actor, identity))
+	}
+
+	service := &Service{
 		config:         cfg,
-		logger:         log,
+		logger:         log.With(nil),
+		baseLogger:     log,
 		client:         client,
 		analyzer:       analyzer,
 		slackClient:    slackClient,
-		queueIntervals: queueIntervals,
+		notifiers:      notifiers,
+		sms:            sms,
+		alerts:         alerting.New(cfg),
+		server:         httpServer,
+		heartbeats:     heartbeats,
+		streams:        streamTracker,
+		backpressure:   backpressureBoard,
+		redisSignals:   redisSignals,
+		scaling:        scalingBoard,
+		compareClient:  compareClient,
+		slo:            sloTracker,
+		brokerEvents:   brokerEvents,
+		acks:           acks,
+		feedback:       feedbackStore,
+		audit:          auditLog,
+		redactor:       redact.New(cfg.Privacy),
+		owners:         newOwnershipDirectory(cfg.Enrichment.Ownership, log),
+		silences:       silence.New(configuredSilences(cfg), onSilenceChange),
+		metrics:        serviceMetrics,
+		history:        historyStore,
+		sinks:          newSinkQueues(eventSinks),
+		queueIntervals: queues.intervals,
 		lastCheckTimes: lastCheckTimes,
+		idleSince:      make(map[string]time.Time),
+		backoffs:       make(map[string]time.Duration),
+		observeOnly:    queues.observeOnly,
+		priorities:     queues.priorities,
+		expectBeats:    queues.expectBeats,
+		priorityBands:  queues.priorityBands,
+		expiryTracking: expiryTrackingQueues(cfg.Monitor.Queues),
+		protocolQueues: make(map[string]bool),
+		lowConnections: make(map[string]time.Time),
+		vhostStates:    make(map[string]*breachState),
+		serviceStates:  make(map[string]*breachState),
+		shardStates:    make(map[string]*breachState),
+		capacityStates: make(map[string]*breachState),
+		comparisons:    make(map[string]*breachState),
+		deadLetters:    make(map[string]*dlqPair),
+		denied:         make(map[string]time.Time),
+		slowDelivery:   make(map[string]time.Time),
+		queueErrors:    queueerrors.New(),
+		reminders:      make(map[string]*reminder),
+		maintenance:    make(map[string]time.Time),
+		lastReport:     cfg.Reports.Previous(time.Now(), cfg.Notifications.Display.Location()),
+		queueNodes:     make(map[string]*queueNode),
+		dependencies:   newDependencyGraph(cfg.Monitor.Queues),
 		startTime:      time.Now(), // Record start time for synchronized checks
 		verbosity:      verbosity,
 		stopChan:       make(chan struct{}),
-	}, nil
+		checkNow:       make(chan struct{}, 1),
+	}
+
+	if cfg.Server.Enabled {
+		service.tail = tail.NewHub()
+		service.registerControlHandlers()
+		if len(cfg.Server.Auth.Tokens) > 0 {
+			log.Info("Control API authentication enabled", map[string]interface{}{
+				"tokens": len(cfg.Server.Auth.Tokens),
+			})
+		}
+	}
+
+	// Expose profiling and state dump endpoints if enabled
+	if cfg.Server.Enabled && cfg.Server.Debug {
+		service.registerDebugHandlers()
+		log.Warn("Debug endpoints enabled", map[string]interface{}{
+			"address": cfg.Server.ListenAddress,
+		})
+	}
+
+	if cfg.Sharding.Enabled() {
+		log.Info("Sharding enabled", map[string]interface{}{
+			"shard_index": cfg.Sharding.Index,
+			"shard_count": cfg.Sharding.Count,
+		})
+	}
+
+	return service, nil
 }
 
 // Start begins the monitoring process
@@ -123,6 +539,21 @@ func (s *Service) Start() error {
 	s.mu.Unlock()
 
 	s.logger.Info("Monitor service started", nil)
+	s.startSinks()
+
+	// Start embedded HTTP server in the background
+	if s.server != nil {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.logger.Info("HTTP server listening", map[string]interface{}{
+				"address": s.server.Addr(),
+			})
+			if err := s.server.Start(); err != nil {
+				s.baseLogger.Error("HTTP server stopped", err, nil)
+			}
+		}()
+	}
 
 	// Determine the shortest check interval (base ticker frequency)
 	tickerInterval := s.config.Monitor.Interval
@@ -140,18 +571,70 @@ func (s *Service) Start() error {
 	ticker := time.NewTicker(tickerInterval)
 	defer ticker.Stop()
 
+	// Queues that look stuck are checked more often until the verdict is confirmed or cleared
+	var burstTicks <-chan time.Time
+	if s.config.Monitor.BurstInterval > 0 {
+		burstTicker := time.NewTicker(s.config.Monitor.BurstInterval)
+		defer burstTicker.Stop()
+		burstTicks = burstTicker.C
+		s.logger.Info("Burst sampling enabled", map[string]interface{}{
+			"interval": s.config.Monitor.BurstInterval.String(),
+		})
+	}
+
+	// Synthetic alerts to a test channel prove the notification pipeline still delivers
+	var selfTestTicks <-chan time.Time
+	if s.config.Notifications.SelfTest.Enabled {
+		selfTestTicker := time.NewTicker(s.config.Notifications.SelfTest.Interval)
+		defer selfTestTicker.Stop()
+		selfTestTicks = selfTestTicker.C
+		s.logger.Info("Alert pipeline self-test enabled", map[string]interface{}{
+			"interval": s.config.Notifications.SelfTest.Interval.String(),
+			"queue":    s.config.Notifications.SelfTest.QueueName,
+		})
+	}
+
+	// Seed detection history so the first checks can already alert
+	if s.config.Monitor.BackfillHistory {
+		s.backfillHistory()
+	}
+
 	// Run first check immediately
-	if err := s.performCheck(); err != nil {
+	if err := s.performCheck(false); err != nil {
 		s.logger.Error("Initial check failed", err, nil)
 	}
+	lastCheckEnd := time.Now()
 
 	// Main monitoring loop
 	for {
 		select {
-		case <-ticker.C:
-			if err := s.performCheck(); err != nil {
+		case tick := <-ticker.C:
+			// A tick buffered while a slow check ran is stale; it was counted as skipped
+			// and the queues it would have covered are caught up on the next tick
+			if tick.Before(lastCheckEnd) {
+				continue
+			}
+			start := time.Now()
+			if err := s.performCheck(false); err != nil {
 				s.logger.Error("Check failed", err, nil)
 			}
+			lastCheckEnd = time.Now()
+			s.accountSkippedTicks(lastCheckEnd.Sub(start), tickerInterval)
+		case tick := <-burstTicks:
+			if tick.Before(lastCheckEnd) || len(s.analyzer.Suspicious()) == 0 {
+				continue
+			}
+			if err := s.performCheck(false); err != nil {
+				s.logger.Error("Burst check failed", err, nil)
+			}
+			lastCheckEnd = time.Now()
+		case <-selfTestTicks:
+			s.runSelfTest()
+		case <-s.checkNow:
+			if err := s.performCheck(true); err != nil {
+				s.logger.Error("Manual check failed", err, nil)
+			}
+			lastCheckEnd = time.Now()
 		case <-s.stopChan:
 			s.logger.Info("Stopping monitor service", nil)
 			return nil
@@ -170,34 +653,213 @@ func (s *Service) Stop() {
 
 	s.running = false
 	close(s.stopChan)
+
+	if s.server != nil {
+		// Live streams never end on their own and would hold up the shutdown
+		s.tail.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := s.server.Shutdown(ctx); err != nil {
+			s.baseLogger.Error("Failed to shut down HTTP server", err, nil)
+		}
+	}
+
 	s.wg.Wait()
+
+	if s.history != nil {
+		if err := s.history.Close(); err != nil {
+			s.baseLogger.Error("Failed to close history", err, nil)
+		}
+	}
+
+	if s.feedback != nil {
+		if err := s.feedback.Close(); err != nil {
+			s.baseLogger.Error("Failed to close feedback store", err, nil)
+		}
+	}
+
+	if s.audit != nil {
+		if err := s.audit.Close(); err != nil {
+			s.baseLogger.Error("Failed to close audit log", err, nil)
+		}
+	}
+
+	if s.redisSignals != nil {
+		s.redisSignals.Close()
+	}
+
+	s.stopSinks()
 }
 
-// performCheck performs a single monitoring check
-func (s *Service) performCheck() error {
+// performCheck performs a single monitoring check and records its outcome
+// A forced check covers all queues regardless of their schedule
+func (s *Service) performCheck(force bool) error {
+	// Never run two checks at once, they would mutate the same queue state
+	if !s.checkMu.TryLock() {
+		s.metrics.skippedTicks.Inc()
+		s.logger.Warn("Skipping check, previous check still running", nil)
+		return nil
+	}
+	defer s.checkMu.Unlock()
+	err := s.checkCycle(force, "")
+
+	// Rejected credentials were reported once by checkCredentials instead of failing every interval
+	if rabbitmq.IsUnauthorized(err) {
+		return nil
+	}
+	return err
+}
+
+// checkCycle runs one check cycle and exports its outcome; the caller holds checkMu
+// A non-empty queue limits the cycle to that queue, which is checked even if not due
+func (s *Service) checkCycle(force bool, queue string) error {
+	// Every log line of the cycle carries its ID
+	s.checkID = newCheckID()
+	s.logger.SetScope(map[string]interface{}{"check_id": s.checkID})
+	defer s.logger.SetScope(nil)
+
+	start := time.Now()
+	s.metrics.checks.Inc()
+	err := s.runCheck(force, queue)
+	err = s.checkCredentials(err, func() error { return s.runCheck(force, queue) }, start)
+	duration := time.Since(start)
+	s.metrics.checkDuration.Set(duration.Seconds())
+	s.metrics.lastCheck.Set(float64(time.Now().Unix()))
+	if err != nil {
+		s.metrics.checkFailures.Inc()
+	}
+	if s.config.EventSinks.CheckEvents || s.tail != nil {
+		s.publishCheck(start, duration, err)
+	}
+
+	// Export the state for the node_exporter textfile collector, also after failed checks
+	if s.config.Textfile.Enabled {
+		if err := s.metrics.registry.WriteFile(s.config.Textfile.FilePath, textfileLabels(s.config.Instance)); err != nil {
+			s.logger.Error("Failed to write metrics textfile", err, nil)
+		}
+	}
+	return err
+}
+
+// accountSkippedTicks records the ticks that passed while a check ran
+// Warns that checks are falling behind when a check outlasts the ticker interval
+func (s *Service) accountSkippedTicks(duration, tickerInterval time.Duration) {
+	skipped := int(duration / tickerInterval)
+	if skipped == 0 {
+		return
+	}
+	s.metrics.skippedTicks.Add(float64(skipped))
+	s.logger.Warn("Checks falling behind", map[string]interface{}{
+		"check_duration":  duration.String(),
+		"ticker_interval": tickerInterval.String(),
+		"skipped_ticks":   skipped,
+	})
+}
+
+// runCheck fetches, analyzes and notifies for all queues due for checking
+// A non-empty only limits the check to that queue
+func (s *Service) runCheck(force bool, only string) error {
 	now := time.Now()
 
 	// Fetch queue information
+	apiStart := time.Now()
 	allQueues, err := s.client.GetQueues()
+	s.metrics.observeAPICall("queues", apiStart, err)
 	if err != nil {
-		return fmt.Errorf("failed to fetch queues: %w", err)
+		err = fmt.Errorf("failed to fetch queues: %w", err)
+		s.queueErrors.Failed(s.queueErrors.Known(), err.Error(), now)
+		return err
 	}
 
 	s.logger.Debug("Fetched queues", map[string]interface{}{
 		"count": len(allQueues),
 	})
 
+	// Skew distorts rate windows and stuck durations, which mix broker and local timestamps
+	if s.config.Monitor.ClockSkew.Enabled {
+		s.checkClockSkew(allQueues, now)
+	}
+
+	// Other instances monitor the queues of other shards
+	brokerQueues := allQueues
+	if s.config.Sharding.Enabled() {
+		allQueues = s.ownedQueues(allQueues)
+	}
+
+	// Track broker events for correlation with stuck queues
+	if s.brokerEvents != nil {
+		s.pollBrokerEvents(now)
+	}
+
+	// Streams are checked by consumer offset lag instead of depth
+	if s.streams != nil {
+		allQueues = s.checkStreams(allQueues, now)
+	}
+
 	// Filter queues if specific queues are configured
-	allQueuesToMonitor := rabbitmq.FilterQueues(allQueues, s.config.Monitor.Queues)
+	allQueuesToMonitor := rabbitmq.FilterQueues(allQueues, s.config.Monitor.Queues, s.config.Monitor.AutoExclude)
+
+	// Include queues auto-created by the MQTT and STOMP plugins
+	if len(s.config.Protocols) > 0 {
+		allQueuesToMonitor = s.addProtocolQueues(allQueues, allQueuesToMonitor)
+		if s.config.Sharding.OwnsClusterAlerts() {
+			s.checkProtocolConnections(now)
+		}
+	}
+
+	// Vhost totals and churn cover queues of every shard, so only one instance checks them
+	if len(s.config.VHosts) > 0 && s.config.Sharding.OwnsClusterAlerts() {
+		s.checkVHosts(now)
+	}
+	if s.config.Churn.Enabled && s.config.Sharding.OwnsClusterAlerts() {
+		s.checkChurn(now)
+	}
+
+	// Services group queues that may be spread over shards, so they are summed from every broker queue
+	if len(s.config.Services) > 0 && s.config.Sharding.OwnsClusterAlerts() {
+		s.checkServices(brokerQueues, now)
+	}
+
+	// Scaling targets may consume from queues of every shard, so they are summed from every broker queue
+	if s.scaling != nil {
+		s.checkScaling(brokerQueues, now)
+	}
+
+	// Compare with the other side of a blue/green migration
+	if s.compareClient != nil {
+		s.checkComparison(allQueuesToMonitor, now)
+	}
+
+	// Pair queues with their dead-letter queues, which may not be monitored themselves
+	if s.config.DeadLetters.Enabled {
+		s.checkDeadLetters(allQueuesToMonitor, brokerQueues, now)
+	}
+
+	// Note queues whose leader moved, which often leaves consumers to reconnect
+	s.trackQueueNodes(allQueuesToMonitor, now)
+
+	// Remember configured queues the broker no longer reports and end the data gaps of the others
+	s.trackQueueErrors(allQueues, allQueuesToMonitor, now)
 
 	// Filter based on per-queue check intervals
 	queuesToCheck := make([]rabbitmq.QueueInfo, 0)
+	suspicious := make(map[string]bool)
+	if s.config.Monitor.BurstInterval > 0 {
+		suspicious = s.analyzer.Suspicious()
+	}
+	if only != "" {
+		allQueuesToMonitor = scopeQueues(allQueuesToMonitor, only)
+		force = true
+	}
+	s.scheduleMu.Lock()
 	for _, queue := range allQueuesToMonitor {
 		// Get the check interval for this queue (or use global default)
 		checkInterval, exists := s.queueIntervals[queue.Name]
 		if !exists {
 			checkInterval = s.config.Monitor.Interval
 		}
+		s.discardAfterGap(queue.Name, checkInterval, now)
+		checkInterval, woke := s.backoffInterval(queue, checkInterval, now)
 
 		// Check if this queue is due for checking
 		// Option B: Synchronized checking - check if elapsed time from start is a multiple of interval
@@ -209,8 +871,11 @@ func (s *Service) performCheck() error {
 		lastCheck, hasBeenChecked := s.lastCheckTimes[queue.Name]
 		shouldCheck := false
 		
-		if !hasBeenChecked {
-			// First check - always check
+		if !hasBeenChecked || force || woke {
+			// First check, manual check or idle queue with new activity - always check
+			shouldCheck = true
+		} else if suspicious[queue.Name] && now.Sub(lastCheck) >= s.config.Monitor.BurstInterval/2 {
+			// Burst sampling - due on every burst tick while the queue looks stuck
 			shouldCheck = true
 		} else {
 			// Check if we've passed the next scheduled check time and haven't checked since
@@ -243,6 +908,7 @@ func (s *Service) performCheck() error {
 			}
 		}
 	}
+	s.scheduleMu.Unlock()
 	if len(queuesToCheck) == 0 {
 		s.logger.Debug("No queues due for checking", nil)
 		return nil
@@ -252,23 +918,288 @@ func (s *Service) performCheck() error {
 		"count": len(queuesToCheck),
 	})
 
+	// Fetch per-priority backlog for tracked priority queues
+	for i := range queuesToCheck {
+		if s.priorityBands[queuesToCheck[i].Name] {
+			queuesToCheck[i].PriorityLengths = s.fetchPriorityLengths(queuesToCheck[i].Name, now)
+		}
+	}
+
+	// Verify who consumes from queues with consumer identity rules
+	s.verifyConsumerIdentities(queuesToCheck)
+
+	// Measure expiry losses from the queues expired messages are dead-lettered to
+	if len(s.expiryTracking) > 0 {
+		s.applyExpiryTracking(queuesToCheck, brokerQueues)
+	}
+
 	// Analyze queues for stuck status
 	result := s.analyzer.Analyze(queuesToCheck)
 
-	// Log any stuck queue alerts
+	// Record samples for offline analysis
+	if s.history != nil {
+		if err := s.history.Append(queuesToCheck, s.historyStatuses(queuesToCheck, result), now); err != nil {
+			s.logger.Error("Failed to record queue history", err, nil)
+		}
+	}
+
+	// Compare demand with the consume rate queues sustained in the past
+	if s.config.Capacity.Enabled {
+		s.checkCapacity(queuesToCheck, now)
+	}
+
+	// Point out alerts that fire right after a gap in the queue's data
+	for i := range result.StuckAlerts {
+		result.StuckAlerts[i].Reason = s.enrichWithDataGap(result.StuckAlerts[i].QueueName, result.StuckAlerts[i].Reason, now)
+	}
+	for i := range result.Transitions {
+		if result.Transitions[i].ToState == "alerting" {
+			result.Transitions[i].Reason = s.enrichWithDataGap(result.Transitions[i].QueueName, result.Transitions[i].Reason, now)
+		}
+	}
+
+	// Enrich stuck reasons with consumer heartbeat status
+	if s.heartbeats != nil {
+		for i := range result.StuckAlerts {
+			result.StuckAlerts[i].Reason = s.enrichWithHeartbeat(result.StuckAlerts[i].QueueName, result.StuckAlerts[i].Reason, now)
+		}
+		for i := range result.Transitions {
+			if result.Transitions[i].ToState == "alerting" {
+				result.Transitions[i].Reason = s.enrichWithHeartbeat(result.Transitions[i].QueueName, result.Transitions[i].Reason, now)
+			}
+		}
+	}
+
+	// Point out consumers that all hang on a single idle connection
+	if s.config.Enrichment.IdleConnections {
+		for i := range result.Transitions {
+			if result.Transitions[i].ToState != "alerting" {
+				continue
+			}
+			if shared := s.describeSharedConnection(result.Transitions[i].QueueInfo); shared != "" {
+				result.Transitions[i].Reason = fmt.Sprintf("%s (%s)", result.Transitions[i].Reason, shared)
+			}
+		}
+	}
+
+	// A queue stuck right after a leader failover points at consumers that did not follow
+	for i := range result.Transitions {
+		if result.Transitions[i].ToState != "alerting" {
+			continue
+		}
+		if moved := s.describeLeaderChange(result.Transitions[i].QueueName, now); moved != "" {
+			result.Transitions[i].Reason = fmt.Sprintf("%s (%s)", result.Transitions[i].Reason, moved)
+		}
+	}
+
+	// Queues homed on a drained node look stuck while a rolling upgrade moves their consumers
+	if s.config.Monitor.NodeMaintenance.Enabled {
+		s.checkNodeMaintenance(now)
+		for i := range result.Transitions {
+			if result.Transitions[i].ToState != "alerting" {
+				continue
+			}
+			if node := result.Transitions[i].QueueInfo.Node; s.inMaintenance(node, now) {
+				result.Transitions[i].Reason = fmt.Sprintf("%s (home node %s in maintenance mode)", result.Transitions[i].Reason, node)
+			}
+		}
+	}
+
+	// Log any stuck queue alerts, at most once per interval per queue
 	for _, alert := range result.StuckAlerts {
-		s.logStuckQueue(alert)
+		if s.alerts.ShouldLogStuck(alert.QueueName, now) {
+			s.logStuckQueue(alert)
+		}
+	}
+
+	// Open incidents for queues that became alerting and close those that recovered
+	resolved := make(map[string]alerting.Incident)
+	incidentIDs := make(map[string]string)
+	for _, transition := range result.Transitions {
+		if transition.ToState == "alerting" {
+			s.alerts.Open(transition.QueueName, now)
+			incidentIDs[transition.QueueName] = s.alerts.IncidentID(transition.QueueName)
+		} else if incident, exists := s.alerts.Resolve(transition.QueueName); exists {
+			resolved[transition.QueueName] = incident
+			incidentIDs[transition.QueueName] = incident.ID
+		}
+	}
+
+	// Publish every state change to the event sinks, independent of notification routing
+	for _, transition := range result.Transitions {
+		s.publishTransition(transition, incidentIDs[transition.QueueName])
+	}
+
+	// Alerting queues whose backlog started decreasing are reported before they recover
+	s.logRecovering(result.Recovering)
+
+	// Group stuck dependent queues under their root cause to avoid alert storms
+	downstream := s.groupByRootCause(result.Transitions, now)
+
+	// Collapse mass alerts into a single cluster-wide alert
+	if s.config.Notifications.StormSuppression.Enabled {
+		s.evaluateStorm(now)
+	}
+
+	// Acknowledgments end when the queue recovers
+	recoveredAcks := make(map[string]ack.Ack)
+	if s.acks != nil {
+		for _, transition := range result.Transitions {
+			if transition.ToState != "not_alerting" {
+				continue
+			}
+			if a, acked := s.acks.Clear(transition.QueueName); acked {
+				recoveredAcks[transition.QueueName] = a
+			}
+		}
+	}
+
+	// Record acknowledgment and recovery times of resolved incidents
+	for _, transition := range result.Transitions {
+		incident, exists := resolved[transition.QueueName]
+		if !exists {
+			continue
+		}
+		var acknowledged *ack.Ack
+		if a, acked := recoveredAcks[transition.QueueName]; acked {
+			acknowledged = &a
+		}
+		s.recordResponseTimes(transition, incident, acknowledged)
 	}
 
-	// Handle state transitions and send Slack notifications
-	if s.slackClient != nil {
+	// Move poison messages out of queues caught in a redelivery storm
+	quarantined := make(map[string]*slack.Quarantine)
+	if s.config.Quarantine.Enabled {
 		for _, transition := range result.Transitions {
-			if err := s.handleStateTransition(transition, now); err != nil {
+			if transition.ToState != "alerting" || !analyzer.IsRedeliveryStorm(transition.Reason) || s.observeOnly[transition.QueueName] {
+				continue
+			}
+			quarantined[transition.QueueName] = s.quarantineHead(transition.QueueName, now)
+		}
+	}
+
+	// Handle state transitions and send Slack and chat notifications
+	if s.slackClient != nil || len(s.notifiers) > 0 || s.sms != nil {
+		incidentRecords := s.incidentHistory(result.Transitions)
+		for _, transition := range result.Transitions {
+			// Per-queue notifications are suppressed during a cluster-wide problem
+			if s.stormActive {
+				// Track suppressed alerts so their recovery is not notified either
+				if transition.ToState == "alerting" {
+					s.alerts.Suppress(transition.QueueName, now)
+				}
+				s.logger.Debug("Skipping Slack notification (cluster-wide alert active)", map[string]interface{}{
+					"queue":    transition.QueueName,
+					"to_state": transition.ToState,
+				})
+				continue
+			}
+			// Observe-only queues are logged but never notified
+			if s.observeOnly[transition.QueueName] {
+				s.logger.Debug("Skipping Slack notification (observe-only queue)", map[string]interface{}{
+					"queue":    transition.QueueName,
+					"to_state": transition.ToState,
+				})
+				continue
+			}
+			// Silenced alerts are logged but not notified, and neither is their recovery
+			if active, silenced := s.silences.Active(transition.QueueName, now); silenced && transition.ToState == "alerting" {
+				s.alerts.Suppress(transition.QueueName, now)
+				s.logger.Debug("Skipping Slack notification (queue silenced)", map[string]interface{}{
+					"queue":    transition.QueueName,
+					"to_state": transition.ToState,
+					"team":     active.Team,
+					"reason":   active.Reason,
+					"ends_at":  active.EndsAt,
+				})
+				continue
+			}
+			// Alerts of queues on a node in maintenance mode are held like silenced ones
+			if node := transition.QueueInfo.Node; transition.ToState == "alerting" && s.config.Monitor.NodeMaintenance.Action == config.MaintenanceSuppress && s.inMaintenance(node, now) {
+				s.alerts.Suppress(transition.QueueName, now)
+				s.logger.Debug("Skipping Slack notification (home node in maintenance mode)", map[string]interface{}{
+					"queue":    transition.QueueName,
+					"to_state": transition.ToState,
+					"node":     node,
+				})
+				continue
+			}
+			// Dependent queues are reported as part of their root cause alert
+			if s.alerts.IsSuppressed(transition.QueueName) || resolved[transition.QueueName].Suppressed {
+				s.logger.Debug("Skipping Slack notification (covered by root cause alert)", map[string]interface{}{
+					"queue":    transition.QueueName,
+					"to_state": transition.ToState,
+				})
+				continue
+			}
+			notification := transitionContext{
+				downstream: downstream[transition.QueueName],
+				quarantine: quarantined[transition.QueueName],
+				incidentID: incidentIDs[transition.QueueName],
+			}
+			if transition.ToState == "not_alerting" {
+				notification.incident = incidentStats(transition, incidentRecords)
+			}
+			if a, acked := recoveredAcks[transition.QueueName]; acked {
+				notification.ack = &a
+			}
+			if err := s.handleStateTransition(transition, notification, now); err != nil {
 				s.logger.Error("Failed to send Slack notification", err, map[string]interface{}{
 					"queue": transition.QueueName,
 				})
 			}
 		}
+		if s.config.Notifications.Reminders.Enabled {
+			s.sendReminders(queuesToCheck, now)
+		}
+		if s.config.Notifications.Slack.SendRecovering {
+			s.sendRecovering(result.Recovering, now)
+		}
+	}
+
+	// Update gauges describing the current alerting state
+	alerting, _ := s.analyzer.GetAlertingQueues()
+	s.metrics.stuckQueues.Set(float64(len(alerting)))
+	s.metrics.recovering.Set(float64(len(s.analyzer.GetRecoveringQueues())))
+	if s.acks != nil {
+		s.metrics.acksActive.Set(float64(len(s.acks.List())))
+	}
+	activeSilences := 0
+	for _, entry := range s.silences.List(now) {
+		if entry.IsActive(now) {
+			activeSilences++
+		}
+	}
+	s.metrics.silences.Set(float64(activeSilences))
+	scores := s.analyzer.HealthScores()
+	s.metrics.observeHealthScores(scores)
+	s.metrics.observeAlertStates(scores, alerting)
+
+	// Tell producers which queues to slow down publishing to
+	if s.backpressure != nil {
+		s.updateBackpressure(queuesToCheck, allQueuesToMonitor, alerting, now)
+	}
+
+	// Account availability against the queues' objectives
+	if s.slo != nil {
+		s.trackSLO(now)
+	}
+
+	// Render the weekly report from history once its scheduled time has passed
+	if s.config.Reports.Enabled {
+		s.checkReportSchedule(now)
+	}
+
+	// Pretty console output summarizes each check on one line
+	if s.config.Logging.Format == logger.FormatPretty {
+		s.logger.Info("Check complete", map[string]interface{}{
+			"checked":     len(queuesToCheck),
+			"alerting":    len(alerting),
+			"recovering":  len(s.analyzer.GetRecoveringQueues()),
+			"transitions": len(result.Transitions),
+			"took":        time.Since(now).Round(time.Millisecond),
+		})
+		return nil
 	}
 
 	// Log results based on verbosity
@@ -295,9 +1226,226 @@ func (s *Service) performCheck() error {
 	return nil
 }
 
+// evaluateStorm enters or leaves storm mode based on the share of alerting queues
+// Entering sends one cluster-wide alert; leaving sends a resolution notice
+func (s *Service) evaluateStorm(now time.Time) {
+	cfg := s.config.Notifications.StormSuppression
+	alerting, total := s.analyzer.GetAlertingQueues()
+	if total == 0 {
+		return
+	}
+
+	percent := float64(len(alerting)) / float64(total) * 100
+	inStorm := len(alerting) >= cfg.MinQueues && percent >= cfg.ThresholdPercent
+	if inStorm == s.stormActive {
+		return
+	}
+	s.stormActive = inStorm
+
+	fields := map[string]interface{}{
+		"alerting_queues":   len(alerting),
+		"total_queues":      total,
+		"alerting_percent":  percent,
+		"threshold_percent": cfg.ThresholdPercent,
+	}
+	overview := s.clusterOverview()
+	if overview != nil {
+		fields["connections"] = overview.Connections
+		fields["channels"] = overview.Channels
+		fields["publish_rate"] = overview.PublishRate
+		fields["deliver_rate"] = overview.DeliverRate
+		fields["erlang_processes"] = overview.ErlangProcesses
+	}
+	if inStorm {
+		fields["queues"] = alerting
+		s.logger.Warn("CLUSTER-WIDE PROBLEM DETECTED", fields)
+	} else {
+		s.logger.Info("Cluster-wide problem resolved", fields)
+	}
+
+	// Every shard suppresses its own queues, but only one notifies
+	if s.slackClient == nil || !s.config.Sharding.OwnsClusterAlerts() {
+		return
+	}
+
+	clusterAlert := slack.ClusterAlert{
+		Resolved:         !inStorm,
+		AlertingQueues:   alerting,
+		TotalQueues:      total,
+		ThresholdPercent: cfg.ThresholdPercent,
+		Timestamp:        now,
+		Overview:         overview,
+	}
+	webhookURLs := s.alertWebhooks("cluster", "", config.PriorityCritical, inStorm, now)
+	if len(webhookURLs) == 0 {
+		return
+	}
+	err := s.slackClient.SendClusterAlert(clusterAlert, webhookURLs)
+	s.metrics.observeNotification("slack", err)
+	if err != nil {
+		s.logger.Error("Failed to send cluster-wide Slack notification", err, nil)
+	} else if inStorm {
+		s.alerts.SentAlert("cluster", "", now)
+	}
+}
+
+// clusterOverview fetches broker-wide load for cluster alerts
+// Returns nil if the overview is unavailable so the alert is still sent
+func (s *Service) clusterOverview() *slack.ClusterOverview {
+	apiStart := time.Now()
+	if !s.permitted("cluster_overview", apiStart) {
+		return nil
+	}
+	overview, err := s.client.GetClusterOverview()
+	s.metrics.observeAPICall("overview", apiStart, err)
+	if s.lacksPermission("cluster_overview", "overview", err, apiStart) {
+		return nil
+	}
+	if err != nil {
+		s.logger.Warn("Failed to fetch cluster overview", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil
+	}
+
+	return &slack.ClusterOverview{
+		Connections:        overview.Connections,
+		Channels:           overview.Channels,
+		Queues:             overview.Queues,
+		Consumers:          overview.Consumers,
+		Messages:           overview.Messages,
+		MessagesUnacked:    overview.MessagesUnacked,
+		PublishRate:        overview.PublishRate,
+		DeliverRate:        overview.DeliverRate,
+		AckRate:            overview.AckRate,
+		ErlangProcesses:    overview.ErlangProcesses,
+		ErlangProcessLimit: overview.ErlangProcessLimit,
+		Nodes:              overview.Nodes,
+		RunningNodes:       overview.RunningNodes,
+	}
+}
+
+// groupByRootCause finds queues that became stuck because a dependency is stuck
+// Returns the dependent queues per root cause queue and marks them as suppressed
+func (s *Service) groupByRootCause(transitions []analyzer.StateTransition, now time.Time) map[string][]string {
+	downstream := make(map[string][]string)
+	if len(s.dependencies) == 0 {
+		return downstream
+	}
+
+	isAlerting := func(queueName string) bool {
+		state := s.analyzer.GetQueueState(queueName)
+		return state != nil && state.LastKnownState == "alerting"
+	}
+
+	for _, transition := range transitions {
+		if transition.ToState != "alerting" {
+			continue
+		}
+
+		roots := s.dependencies.rootCauses(transition.QueueName, isAlerting)
+		if len(roots) == 0 {
+			continue
+		}
+
+		s.alerts.Suppress(transition.QueueName, now)
+		for _, root := range roots {
+			downstream[root] = append(downstream[root], transition.QueueName)
+		}
+
+		s.logger.Info("Queue stuck downstream of stuck dependency", map[string]interface{}{
+			"queue":       transition.QueueName,
+			"root_causes": roots,
+		})
+	}
+
+	return downstream
+}
+
+// pollBrokerEvents fetches node and policy state and records any changes as events
+// Failures are logged but never fail the check
+func (s *Service) pollBrokerEvents(now time.Time) {
+	if !s.permitted("broker_events", now) {
+		return
+	}
+
+	apiStart := time.Now()
+	nodes, err := s.client.GetNodes()
+	s.metrics.observeAPICall("nodes", apiStart, err)
+	if s.lacksPermission("broker_events", "nodes", err, now) {
+		return
+	}
+	if err != nil {
+		s.logger.Warn("Failed to fetch nodes for broker events", map[string]interface{}{
+			"error": err.Error(),
+		})
+	} else {
+		s.brokerEvents.ObserveNodes(nodes, now)
+	}
+
+	apiStart = time.Now()
+	policies, err := s.client.GetPolicies()
+	s.metrics.observeAPICall("policies", apiStart, err)
+	if s.lacksPermission("broker_events", "policies", err, now) {
+		return
+	}
+	if err != nil {
+		s.logger.Warn("Failed to fetch policies for broker events", map[string]interface{}{
+			"error": err.Error(),
+		})
+	} else {
+		s.brokerEvents.ObservePolicies(policies, now)
+	}
+}
+
+// recentBrokerEvents returns descriptions of broker events within the correlation window
+func (s *Service) recentBrokerEvents(now time.Time) []string {
+	if s.brokerEvents == nil {
+		return nil
+	}
+
+	recent := s.brokerEvents.Recent(now)
+	descriptions := make([]string, 0, len(recent))
+	for _, event := range recent {
+		descriptions = append(descriptions, event.String())
+	}
+	return descriptions
+}
+
+// fetchPriorityLengths returns the per-priority backlog of a queue
+// Failures are logged and the queue is analyzed without priority bands
+func (s *Service) fetchPriorityLengths(queueName string, now time.Time) map[int]int {
+	apiStart := time.Now()
+	lengths, err := s.client.GetPriorityLengths(queueName)
+	s.metrics.observeAPICall("queue_details", apiStart, err)
+	if err != nil {
+		s.logger.Warn("Failed to fetch priority backlog", map[string]interface{}{
+			"queue": queueName,
+			"error": err.Error(),
+		})
+		s.queueErrors.Degraded(queueName, fmt.Sprintf("failed to fetch priority backlog: %v", err), now)
+		return nil
+	}
+	if lengths == nil {
+		s.logger.Debug("Queue reports no priority bands", map[string]interface{}{
+			"queue": queueName,
+		})
+	}
+	return lengths
+}
+
+// enrichWithHeartbeat appends the consumer heartbeat status to a stuck reason
+// Only applies to queues configured to expect heartbeats
+func (s *Service) enrichWithHeartbeat(queueName, reason string, now time.Time) string {
+	if !s.expectBeats[queueName] {
+		return reason
+	}
+	return fmt.Sprintf("%s (%s)", reason, s.heartbeats.Describe(queueName, now))
+}
+
 // logStuckQueue logs a stuck queue alert
 func (s *Service) logStuckQueue(alert analyzer.StuckQueueAlert) {
-	s.logger.Warn("STUCK QUEUE DETECTED", map[string]interface{}{
+	fields := map[string]interface{}{
 		"queue":             alert.QueueName,
 		"messages_ready":    alert.MessagesReady,
 		"consumers":         alert.Consumers,
@@ -310,47 +1458,61 @@ func (s *Service) logStuckQueue(alert analyzer.StuckQueueAlert) {
 		"threshold_checks":  alert.ThresholdChecks,
 		"min_message_count": alert.MinMessageCount,
 		"min_consume_rate":  alert.MinConsumeRate,
-	})
+	}
+	if brokerEvents := s.recentBrokerEvents(alert.Timestamp); len(brokerEvents) > 0 {
+		fields["broker_events"] = brokerEvents
+	}
+	if incidentID := s.alerts.IncidentID(alert.QueueName); incidentID != "" {
+		fields["incident_id"] = incidentID
+	}
+	s.logger.Warn("STUCK QUEUE DETECTED", fields)
+}
+
+// transitionContext carries per-cycle details attached to a transition notification
+type transitionContext struct {
+	downstream []string             // Dependent queues stuck in the same cycle, listed on the root cause alert
+	ack        *ack.Ack             // Acknowledgment of the incident, for recovery notifications
+	quarantine *slack.Quarantine    // Poison messages moved out of the queue
+	incidentID string               // Incident opened or resolved by the transition
+	incident   *slack.IncidentStats // Backlog peak and drain of the resolved incident, from history
 }
 
 // handleStateTransition handles queue state changes and sends Slack notifications
-func (s *Service) handleStateTransition(transition analyzer.StateTransition, now time.Time) error {
+func (s *Service) handleStateTransition(transition analyzer.StateTransition, notification transitionContext, now time.Time) error {
 	state := s.analyzer.GetQueueState(transition.QueueName)
 	if state == nil {
 		return fmt.Errorf("queue state not found: %s", transition.QueueName)
 	}
 
-	// Determine cooldown based on transition type
-	var cooldown time.Duration
 	var alertType slack.AlertType
-	
-	if transition.ToState == "alerting" {
-		// Queue became alerting
-		cooldown = s.config.Notifications.Slack.AlertCooldown
+	switch transition.ToState {
+	case "alerting":
 		alertType = slack.AlertTypeAlerting
-	} else if transition.ToState == "not_alerting" {
-		// Queue recovered
-		if !s.config.Notifications.Slack.SendRecovery {
-			// Recovery notifications are disabled
-			s.logger.Debug("Skipping recovery notification (disabled)", map[string]interface{}{
-				"queue": transition.QueueName,
-			})
-			return nil
-		}
-		cooldown = s.config.Notifications.Slack.RecoveryCooldown
+	case "not_alerting":
 		alertType = slack.AlertTypeNotAlerting
-	} else {
+	default:
 		// Unknown transition, skip
 		return nil
 	}
 
-	// Check cooldown
-	if !state.LastSlackAlert.IsZero() && now.Sub(state.LastSlackAlert) < cooldown {
-		s.logger.Debug("Skipping Slack notification (cooldown active)", map[string]interface{}{
-			"queue":            transition.QueueName,
-			"alert_type":       string(alertType),
-			"cooldown":         cooldown.String(),
-			"time_since_last":  now.Sub(state.LastSlackAlert).String(),
+	// The alert manager applies cooldowns and routes to the team, priority class and quiet hours channels
+	priority := s.priorities[transition.QueueName]
+	decision := s.alerts.Decide(transition.QueueName, priority, alertType == slack.AlertTypeAlerting, now)
+	switch decision.Skip {
+	case "":
+	case alerting.SkipQuietHours:
+		s.logger.Info("Skipping Slack notification (quiet hours)", map[string]interface{}{
+			"queue":      transition.QueueName,
+			"alert_type": string(alertType),
+			"severity":   decision.Route.Severity,
+		})
+		return nil
+	default:
+		s.logger.Debug("Skipping Slack notification ("+decision.Skip+")", map[string]interface{}{
+			"queue":           transition.QueueName,
+			"alert_type":      string(alertType),
+			"cooldown":        decision.Cooldown.String(),
+			"time_since_last": decision.Since.String(),
 		})
 		return nil
 	}
@@ -359,7 +1521,9 @@ func (s *Service) handleStateTransition(transition analyzer.StateTransition, now
 	slackAlert := slack.QueueAlert{
 		Type:             alertType,
 		QueueName:        transition.QueueName,
+		Priority:         priority,
 		VHost:            transition.QueueInfo.VHost,
+		Node:             transition.QueueInfo.Node,
 		MessagesReady:    transition.QueueInfo.MessagesReady,
 		Consumers:        transition.QueueInfo.Consumers,
 		ConsumeRate:      transition.QueueInfo.ConsumeRate,
@@ -369,20 +1533,60 @@ func (s *Service) handleStateTransition(transition analyzer.StateTransition, now
 		Reason:           transition.Reason,
 		Timestamp:        transition.Timestamp,
 		StuckDuration:    transition.StuckDuration,
+		PriorityLengths:  transition.QueueInfo.PriorityLengths,
 	}
-
-	// Send notification
-	if err := s.slackClient.SendAlert(slackAlert); err != nil {
-		return err
+	s.addDeadLetters(&slackAlert)
+	if alertType == slack.AlertTypeAlerting {
+		slackAlert.BrokerEvents = s.recentBrokerEvents(transition.Timestamp)
+		slackAlert.DownstreamQueues = notification.downstream
+		slackAlert.AckButton = s.config.Notifications.Slack.AckButton
+		slackAlert.FalsePositiveButton = s.config.Notifications.Slack.FalsePositiveButton
+		slackAlert.Quarantine = notification.quarantine
+	}
+	if notification.ack != nil {
+		slackAlert.AcknowledgedBy = notification.ack.By
+	}
+	if alertType == slack.AlertTypeNotAlerting {
+		slackAlert.Incident = notification.incident
 	}
 
-	// Update last alert time
-	state.LastSlackAlert = now
+	if alertType == slack.AlertTypeAlerting {
+		s.enrichAlert(&slackAlert)
+		slackAlert.Owner = s.queueOwner(transition.QueueName, now)
+	}
+	s.correlate(&slackAlert, notification.incidentID)
 
-	s.logger.Info("Sent Slack notification", map[string]interface{}{
-		"queue":      transition.QueueName,
-		"alert_type": string(alertType),
-	})
+	// Chat notifiers share the cooldowns but only Slack has always-notify channels for quiet hours
+	delivered := false
+	var slackErr error
+	if s.slackClient != nil && len(decision.Route.WebhookURLs) > 0 {
+		slackErr = s.slackClient.SendAlertTo(slackAlert, decision.Route.WebhookURLs)
+		latency := s.observeDelivery("slack", slackAlert, slackErr)
+		if slackErr == nil {
+			delivered = true
+			s.logger.Info("Sent Slack notification", map[string]interface{}{
+				"queue":       transition.QueueName,
+				"latency":     latency.Round(time.Millisecond).String(),
+				"alert_type":  string(alertType),
+				"priority":    priority,
+				"team":        decision.Route.Team,
+				"incident_id": notification.incidentID,
+			})
+		}
+	}
+	if !decision.Route.Quiet && s.notifyChats(slackAlert) > 0 {
+		delivered = true
+	}
+	// SMS is a last resort for critical stuck queues only
+	if s.sms != nil && alertType == slack.AlertTypeAlerting && decision.Route.Severity == config.SeverityCritical && s.sendSMS(slackAlert) {
+		delivered = true
+	}
+	if delivered {
+		s.alerts.Sent(transition.QueueName, now)
+		if alertType == slack.AlertTypeAlerting {
+			s.trackReminder(transition.QueueInfo, now)
+		}
+	}
 
-	return nil
+	return slackErr
 }
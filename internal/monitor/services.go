@@ -0,0 +1,341 @@
+package monitor
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/rabbitmq"
+	"go-rmq-monitor/internal/slack"
+)
+
+// serviceTotals are the metrics of a service summed across its queues
+type serviceTotals struct {
+	Queues          int
+	MessagesReady   int
+	MessagesUnacked int
+	Consumers       int
+	PublishRate     float64
+	ConsumeRate     float64
+	AckRate         float64
+}
+
+// lag returns how long the backlog takes to drain at the current consume rate
+// Returns false when messages are ready but none are consumed, as the backlog then never drains
+func (t serviceTotals) lag() (time.Duration, bool) {
+	if t.MessagesReady == 0 {
+		return 0, true
+	}
+	if t.ConsumeRate <= 0 {
+		return 0, false
+	}
+	return time.Duration(float64(t.MessagesReady) / t.ConsumeRate * float64(time.Second)), true
+}
+
+// sumServiceQueues sums the metrics of the queues belonging to a service
+func sumServiceQueues(service config.ServiceConfig, queues []rabbitmq.QueueInfo) serviceTotals {
+	var totals serviceTotals
+	for _, queue := range queues {
+		if !service.Includes(queue.Name) {
+			continue
+		}
+		totals.Queues++
+		totals.MessagesReady += queue.MessagesReady
+		totals.MessagesUnacked += queue.MessagesUnacked
+		totals.Consumers += queue.Consumers
+		totals.PublishRate += queue.PublishRate
+		totals.ConsumeRate += queue.ConsumeRate
+		totals.AckRate += queue.AckRate
+	}
+	return totals
+}
+
+// checkServices compares the totals of every service with its limits
+// Services are judged as a whole: one idle shard does not alert while its siblings keep up
+// A service alerts once it breaches a limit for its threshold checks, and recovers on the first check within all limits
+func (s *Service) checkServices(queues []rabbitmq.QueueInfo, now time.Time) {
+	seenShards := make(map[string]bool)
+	for _, service := range s.config.Services {
+		totals := sumServiceQueues(service, queues)
+		lag, draining := totals.lag()
+		s.observeService(service.Name, totals, lag, draining)
+
+		state, exists := s.serviceStates[service.Name]
+		if !exists {
+			state = &breachState{}
+			s.serviceStates[service.Name] = state
+		}
+
+		breached := serviceBreaches(service, totals, lag, draining)
+		alerting := !state.since.IsZero()
+		if len(breached) > 0 {
+			state.breaches++
+		} else {
+			state.breaches = 0
+		}
+
+		fields := map[string]interface{}{
+			"service":        service.Name,
+			"queues":         totals.Queues,
+			"messages_ready": totals.MessagesReady,
+			"consumers":      totals.Consumers,
+			"consume_rate":   totals.ConsumeRate,
+		}
+		if draining {
+			fields["lag"] = lag.String()
+		}
+		alert := slack.ServiceAlert{
+			Service:         service.Name,
+			Queues:          totals.Queues,
+			MessagesReady:   totals.MessagesReady,
+			MessagesUnacked: totals.MessagesUnacked,
+			Consumers:       totals.Consumers,
+			PublishRate:     totals.PublishRate,
+			ConsumeRate:     totals.ConsumeRate,
+			Lag:             lag,
+			Stalled:         !draining,
+			Limits: slack.ServiceLimits{
+				MaxMessagesReady: service.MaxMessagesReady,
+				MinConsumers:     service.MinConsumers,
+				MinConsumeRate:   service.MinConsumeRate,
+				MaxLag:           service.MaxLag,
+			},
+			Breached:  breached,
+			Timestamp: now,
+		}
+
+		switch {
+		case !alerting && state.breaches >= s.config.Monitor.GetServiceThresholdChecks(service):
+			state.since = now
+			fields["breached"] = serviceBreachedNames(breached)
+			s.logger.Warn("SERVICE LIMITS EXCEEDED", fields)
+			s.notifyService(alert, service, now)
+		case alerting && len(breached) == 0:
+			alert.Resolved = true
+			alert.AlertDuration = now.Sub(state.since)
+			state.since = time.Time{}
+			fields["duration"] = alert.AlertDuration.String()
+			s.logger.Info("Service back within limits", fields)
+			s.notifyService(alert, service, now)
+		case len(breached) > 0 && !alerting:
+			fields["breached"] = serviceBreachedNames(breached)
+			fields["breaches"] = state.breaches
+			s.logger.Debug("Service over limits", fields)
+		}
+
+		if service.OutlierFactor > 0 {
+			s.checkShards(service, queues, seenShards, now)
+		}
+	}
+
+	// Shards no longer part of a service are forgotten
+	for key := range s.shardStates {
+		if !seenShards[key] {
+			delete(s.shardStates, key)
+		}
+	}
+}
+
+// minShards is the fewest queues a service needs for outlier detection, so a shard always has siblings to compare with
+const minShards = 3
+
+// checkShards flags the queues of a service that fall behind their siblings
+// A shard is an outlier when its backlog exceeds the outlier factor times the median of the other shards,
+// or when it consumes slower than that median divided by the factor; shards below the minimum backlog never are
+// A shard alerts once it is an outlier for the service's threshold checks, and recovers on the first check in line
+func (s *Service) checkShards(service config.ServiceConfig, queues []rabbitmq.QueueInfo, seen map[string]bool, now time.Time) {
+	var shards []rabbitmq.QueueInfo
+	for _, queue := range queues {
+		if service.Includes(queue.Name) {
+			shards = append(shards, queue)
+		}
+	}
+	if len(shards) < minShards {
+		return
+	}
+
+	minMessages := s.config.Monitor.GetServiceOutlierMinMessages(service)
+	for i, shard := range shards {
+		key := service.Name + "/" + shard.Name
+		seen[key] = true
+
+		state, exists := s.shardStates[key]
+		if !exists {
+			state = &breachState{}
+			s.shardStates[key] = state
+		}
+
+		siblingReady := make([]float64, 0, len(shards)-1)
+		siblingRates := make([]float64, 0, len(shards)-1)
+		for j, sibling := range shards {
+			if j != i {
+				siblingReady = append(siblingReady, float64(sibling.MessagesReady))
+				siblingRates = append(siblingRates, sibling.ConsumeRate)
+			}
+		}
+		medianReady, medianRate := median(siblingReady), median(siblingRates)
+
+		outlying := make(map[string]bool)
+		if shard.MessagesReady >= minMessages && shard.MessagesReady > 0 {
+			if float64(shard.MessagesReady) > service.OutlierFactor*medianReady {
+				outlying["messages_ready"] = true
+			}
+			if medianRate > 0 && shard.ConsumeRate < medianRate/service.OutlierFactor {
+				outlying["consume_rate"] = true
+			}
+		}
+		alerting := !state.since.IsZero()
+		if len(outlying) > 0 {
+			state.breaches++
+		} else {
+			state.breaches = 0
+		}
+
+		fields := map[string]interface{}{
+			"service":        service.Name,
+			"queue":          shard.Name,
+			"messages_ready": shard.MessagesReady,
+			"consume_rate":   shard.ConsumeRate,
+			"sibling_ready":  medianReady,
+			"sibling_rate":   medianRate,
+		}
+		alert := slack.ShardAlert{
+			Service:            service.Name,
+			QueueName:          shard.Name,
+			Shards:             len(shards),
+			MessagesReady:      shard.MessagesReady,
+			Consumers:          shard.Consumers,
+			ConsumeRate:        shard.ConsumeRate,
+			SiblingMessages:    medianReady,
+			SiblingConsumeRate: medianRate,
+			Outlying:           outlying,
+			Timestamp:          now,
+		}
+
+		switch {
+		case !alerting && state.breaches >= s.config.Monitor.GetServiceThresholdChecks(service):
+			state.since = now
+			fields["outlying"] = divergedNames(outlying)
+			s.logger.Warn("SHARD FALLING BEHIND SIBLINGS", fields)
+			s.notifyShard(alert, service, now)
+		case alerting && len(outlying) == 0:
+			alert.Resolved = true
+			alert.AlertDuration = now.Sub(state.since)
+			state.since = time.Time{}
+			fields["duration"] = alert.AlertDuration.String()
+			s.logger.Info("Shard back in line with siblings", fields)
+			s.notifyShard(alert, service, now)
+		case len(outlying) > 0 && !alerting:
+			fields["outlying"] = divergedNames(outlying)
+			fields["breaches"] = state.breaches
+			s.logger.Debug("Shard behind siblings", fields)
+		}
+	}
+}
+
+// median returns the middle of a list of values, or the mean of the two middle values
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+// serviceBreaches returns the limits of a service its totals exceed, by name
+// A low consume rate and lag only count while messages are waiting for consumers
+func serviceBreaches(service config.ServiceConfig, totals serviceTotals, lag time.Duration, draining bool) map[string]bool {
+	breached := make(map[string]bool)
+	if service.MaxMessagesReady > 0 && totals.MessagesReady > service.MaxMessagesReady {
+		breached["messages_ready"] = true
+	}
+	if service.MinConsumers > 0 && totals.Consumers < service.MinConsumers {
+		breached["consumers"] = true
+	}
+	if service.MinConsumeRate > 0 && totals.MessagesReady > 0 && totals.ConsumeRate < service.MinConsumeRate {
+		breached["consume_rate"] = true
+	}
+	if service.MaxLag > 0 && (!draining || lag > service.MaxLag) {
+		breached["lag"] = true
+	}
+	return breached
+}
+
+// serviceBreachedNames lists breached service limits in a stable order for logging
+func serviceBreachedNames(breached map[string]bool) []string {
+	var names []string
+	for _, name := range []string{"messages_ready", "consumers", "consume_rate", "lag"} {
+		if breached[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// observeService exports the totals of a service, with an infinite lag while its backlog does not drain
+func (s *Service) observeService(name string, totals serviceTotals, lag time.Duration, draining bool) {
+	s.metrics.serviceReady.Set(float64(totals.MessagesReady), name)
+	s.metrics.serviceConsumers.Set(float64(totals.Consumers), name)
+	s.metrics.serviceRates.Set(totals.PublishRate, name, "publish")
+	s.metrics.serviceRates.Set(totals.ConsumeRate, name, "consume")
+	s.metrics.serviceRates.Set(totals.AckRate, name, "ack")
+	if draining {
+		s.metrics.serviceLag.Set(lag.Seconds(), name)
+	} else {
+		s.metrics.serviceLag.Set(math.Inf(1), name)
+	}
+}
+
+// notifyService sends a service limit alert to Slack
+func (s *Service) notifyService(alert slack.ServiceAlert, service config.ServiceConfig, now time.Time) {
+	if s.slackClient == nil {
+		return
+	}
+
+	// Services span queues that may belong to several teams, so the services alert type settings route them
+	webhookURLs := s.alertWebhooks("services", service.Name, service.Priority, !alert.Resolved, now)
+	if len(webhookURLs) == 0 {
+		return
+	}
+
+	err := s.slackClient.SendServiceAlert(alert, webhookURLs)
+	s.metrics.observeNotification("slack", err)
+	if err != nil {
+		s.logger.Error("Failed to send service Slack notification", err, map[string]interface{}{
+			"service": service.Name,
+		})
+	} else if !alert.Resolved {
+		s.alerts.SentAlert("services", service.Name, now)
+	}
+}
+
+// notifyShard sends a shard outlier alert to Slack
+func (s *Service) notifyShard(alert slack.ShardAlert, service config.ServiceConfig, now time.Time) {
+	if s.slackClient == nil {
+		return
+	}
+
+	// Shards are routed like their service, each shard with its own cooldown
+	key := service.Name + "/" + alert.QueueName
+	webhookURLs := s.alertWebhooks("services", key, service.Priority, !alert.Resolved, now)
+	if len(webhookURLs) == 0 {
+		return
+	}
+
+	err := s.slackClient.SendShardAlert(alert, webhookURLs)
+	s.metrics.observeNotification("slack", err)
+	if err != nil {
+		s.logger.Error("Failed to send shard outlier Slack notification", err, map[string]interface{}{
+			"service": service.Name,
+			"queue":   alert.QueueName,
+		})
+	} else if !alert.Resolved {
+		s.alerts.SentAlert("services", key, now)
+	}
+}
@@ -0,0 +1,22 @@
+package monitor
+
+import (
+	"go-rmq-monitor/internal/rabbitmq"
+)
+
+// ownedQueues returns the queues assigned to this instance's shard
+func (s *Service) ownedQueues(queues []rabbitmq.QueueInfo) []rabbitmq.QueueInfo {
+	owned := make([]rabbitmq.QueueInfo, 0, len(queues)/s.config.Sharding.Count+1)
+	for _, queue := range queues {
+		if s.config.Sharding.Owns(queue.Name) {
+			owned = append(owned, queue)
+		}
+	}
+
+	s.logger.Debug("Filtered queues by shard", map[string]interface{}{
+		"shard_index": s.config.Sharding.Index,
+		"owned":       len(owned),
+		"total":       len(queues),
+	})
+	return owned
+}
@@ -0,0 +1,203 @@
+package monitor
+
+import (
+	"fmt"
+	"time"
+
+	"go-rmq-monitor/internal/analyzer"
+	"go-rmq-monitor/internal/awsauth"
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/sink"
+)
+
+// eventQueueSize is how many events a sink buffers while it is slow or unreachable
+const eventQueueSize = 1000
+
+// sinkDrainTimeout bounds how long shutdown waits for a sink to publish its queued events
+const sinkDrainTimeout = 10 * time.Second
+
+// sinkQueue feeds a sink from its own goroutine, so a slow sink never holds up a check
+type sinkQueue struct {
+	sink   sink.Sink
+	events chan sink.Event
+	done   chan struct{}
+}
+
+// newSinkQueues gives each sink a bounded event queue
+func newSinkQueues(sinks []sink.Sink) []*sinkQueue {
+	queues := make([]*sinkQueue, 0, len(sinks))
+	for _, eventSink := range sinks {
+		queues = append(queues, &sinkQueue{
+			sink:   eventSink,
+			events: make(chan sink.Event, eventQueueSize),
+			done:   make(chan struct{}),
+		})
+	}
+	return queues
+}
+
+// newEventSinks creates the configured event sinks
+func newEventSinks(cfg config.EventSinksConfig) ([]sink.Sink, error) {
+	envelope := sink.Envelope{
+		CloudEvents: cfg.CloudEvents.Enabled,
+		Source:      cfg.CloudEvents.Source,
+		TypePrefix:  cfg.CloudEvents.TypePrefix,
+	}
+
+	sinks := make([]sink.Sink, 0)
+	if cfg.Kafka.Enabled {
+		sinks = append(sinks, sink.NewKafka(cfg.Kafka.RestProxyURL, cfg.Kafka.Topic, cfg.Kafka.Format, envelope, cfg.Timeout))
+	}
+	if cfg.Webhook.Enabled {
+		sinks = append(sinks, sink.NewWebhook(cfg.Webhook.URLs, envelope, cfg.Webhook.SigningSecret, cfg.Timeout))
+	}
+	if cfg.SNS.Enabled || cfg.EventBridge.Enabled {
+		credentials, err := awsauth.NewProvider(cfg.AWS.Profile, cfg.AWS.Region)
+		if err != nil {
+			return nil, err
+		}
+		region := credentials.Region()
+		if cfg.SNS.Enabled {
+			sns, err := sink.NewSNS(cfg.SNS.TopicARN, credentials, envelope, cfg.Timeout)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create sns sink: %w", err)
+			}
+			sinks = append(sinks, sns)
+		}
+		if cfg.EventBridge.Enabled {
+			if region == "" {
+				return nil, fmt.Errorf("eventbridge sink requires event_sinks.aws.region, $AWS_REGION or a profile region")
+			}
+			sinks = append(sinks, sink.NewEventBridge(cfg.EventBridge.EventBus, cfg.EventBridge.Source, region, credentials, cfg.Timeout))
+		}
+	}
+	if cfg.NATS.Enabled {
+		nats, err := sink.NewNATS(cfg.NATS.URL, cfg.NATS.Subject, cfg.NATS.Token, envelope, cfg.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create nats sink: %w", err)
+		}
+		sinks = append(sinks, nats)
+	}
+	return sinks, nil
+}
+
+// publishEvent queues an event for every sink and sends it to the live streams
+// Events are dropped when a sink's queue is full; failures never fail the check
+// Check events only go to the sinks with event_sinks.check_events
+func (s *Service) publishEvent(event sink.Event) {
+	event.SchemaVersion = sink.SchemaVersion
+	event.Cluster = s.config.RabbitMQ.Host
+	if event.VHost == "" {
+		event.VHost = s.config.RabbitMQ.VHost
+	}
+	s.tail.Publish(event)
+	if event.Type == sink.TypeCheck && !s.config.EventSinks.CheckEvents {
+		return
+	}
+	for _, queue := range s.sinks {
+		select {
+		case queue.events <- event:
+		default:
+			s.metrics.notifications.Inc(queue.sink.Name(), "dropped")
+			s.logger.Warn("Dropping event, sink queue full", map[string]interface{}{
+				"sink":  queue.sink.Name(),
+				"type":  event.Type,
+				"queue": event.Queue,
+			})
+		}
+	}
+}
+
+// startSinks publishes queued events to each sink in the background until stopSinks
+func (s *Service) startSinks() {
+	for _, queue := range s.sinks {
+		go s.runSink(queue)
+	}
+}
+
+// runSink publishes the events of one sink queue in order, logging and counting failures
+func (s *Service) runSink(queue *sinkQueue) {
+	defer close(queue.done)
+	for event := range queue.events {
+		err := queue.sink.Publish(event)
+		s.metrics.observeNotification(queue.sink.Name(), err)
+		if err != nil {
+			s.baseLogger.Error("Failed to publish event", err, map[string]interface{}{
+				"sink":     queue.sink.Name(),
+				"type":     event.Type,
+				"queue":    event.Queue,
+				"check_id": event.CheckID,
+			})
+		}
+	}
+}
+
+// stopSinks waits for the queued events to be published, up to sinkDrainTimeout, and closes the sinks
+func (s *Service) stopSinks() {
+	// Events are only published during checks, so later checks find no sinks to queue to
+	s.checkMu.Lock()
+	queues := s.sinks
+	s.sinks = nil
+	s.checkMu.Unlock()
+
+	for _, queue := range queues {
+		close(queue.events)
+	}
+	deadline := time.Now().Add(sinkDrainTimeout)
+	for _, queue := range queues {
+		select {
+		case <-queue.done:
+		case <-time.After(time.Until(deadline)):
+			s.baseLogger.Warn("Event sink did not publish its queued events before shutdown", map[string]interface{}{
+				"sink":    queue.sink.Name(),
+				"pending": len(queue.events),
+			})
+		}
+		if err := queue.sink.Close(); err != nil {
+			s.baseLogger.Error("Failed to close event sink", err, map[string]interface{}{
+				"sink": queue.sink.Name(),
+			})
+		}
+	}
+}
+
+// publishTransition publishes a queue becoming stuck or recovering
+// Every transition is published, including those not notified because of silences or cooldowns
+func (s *Service) publishTransition(transition analyzer.StateTransition, incidentID string) {
+	event := sink.Event{
+		Type:          sink.TypeQueueStuck,
+		Timestamp:     transition.Timestamp,
+		VHost:         transition.QueueInfo.VHost,
+		CheckID:       s.checkID,
+		Queue:         transition.QueueName,
+		IncidentID:    incidentID,
+		Priority:      s.priorities[transition.QueueName],
+		Reason:        transition.Reason,
+		MessagesReady: transition.QueueInfo.MessagesReady,
+		Consumers:     transition.QueueInfo.Consumers,
+		ConsumeRate:   transition.QueueInfo.ConsumeRate,
+		PublishRate:   transition.QueueInfo.PublishRate,
+	}
+	if transition.ToState != "alerting" {
+		event.Type = sink.TypeQueueRecovered
+		event.StuckDurationSeconds = transition.StuckDuration.Seconds()
+	}
+	s.publishEvent(event)
+}
+
+// publishCheck publishes the outcome of a check cycle
+func (s *Service) publishCheck(start time.Time, duration time.Duration, checkErr error) {
+	alerting, tracked := s.analyzer.GetAlertingQueues()
+	event := sink.Event{
+		Type:            sink.TypeCheck,
+		Timestamp:       start,
+		CheckID:         s.checkID,
+		TrackedQueues:   tracked,
+		AlertingQueues:  len(alerting),
+		DurationSeconds: duration.Seconds(),
+	}
+	if checkErr != nil {
+		event.Error = checkErr.Error()
+	}
+	s.publishEvent(event)
+}
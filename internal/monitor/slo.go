@@ -0,0 +1,119 @@
+package monitor
+
+import (
+	"time"
+
+	"go-rmq-monitor/internal/slack"
+	"go-rmq-monitor/internal/slo"
+)
+
+// trackSLO accounts the checked interval against each queue's availability objective
+// Sends burn rate alerts and, on the first check of a month, last month's report
+func (s *Service) trackSLO(now time.Time) {
+	stuck := make(map[string]bool)
+	for _, state := range s.analyzer.Snapshot() {
+		if s.config.SLO.Tracks(state.QueueName) {
+			stuck[state.QueueName] = state.LastKnownState == "alerting"
+		}
+	}
+
+	transitions, report, err := s.slo.Observe(stuck, now)
+	if err != nil {
+		s.logger.Error("Failed to save SLO state", err, nil)
+	}
+	for _, transition := range transitions {
+		s.notifyBurnRate(transition, now)
+	}
+	if report != nil {
+		s.sendSLOReport(*report)
+	}
+}
+
+// notifyBurnRate logs an error budget burn rate change and sends it to Slack
+func (s *Service) notifyBurnRate(transition slo.Transition, now time.Time) {
+	fields := map[string]interface{}{
+		"queue":            transition.Queue,
+		"burn_rate":        transition.BurnRate,
+		"budget_remaining": transition.BudgetRemainingPercent,
+	}
+	if transition.Burning {
+		s.logger.Warn("ERROR BUDGET BURNING", fields)
+	} else {
+		s.logger.Info("Error budget burn rate recovered", fields)
+	}
+
+	if s.slackClient == nil || s.observeOnly[transition.Queue] {
+		return
+	}
+	if _, silenced := s.silences.Active(transition.Queue, now); silenced {
+		return
+	}
+	if !transition.Burning && !s.config.Notifications.Slack.SendRecovery {
+		return
+	}
+
+	alert := slack.SLOBurnAlert{
+		Resolved:        !transition.Burning,
+		QueueName:       transition.Queue,
+		Priority:        s.priorities[transition.Queue],
+		BurnRate:        transition.BurnRate,
+		Threshold:       s.config.SLO.BurnRateAlert,
+		Window:          s.config.SLO.BurnRateWindow,
+		BudgetRemaining: transition.BudgetRemainingPercent,
+		Target:          s.config.SLO.Target,
+		Timestamp:       transition.Timestamp,
+	}
+
+	webhookURLs := s.alerts.Route(transition.Queue, alert.Priority, now).WebhookURLs
+	if len(webhookURLs) == 0 {
+		return
+	}
+
+	err := s.slackClient.SendSLOBurnAlert(alert, webhookURLs)
+	s.metrics.observeNotification("slack", err)
+	if err != nil {
+		s.logger.Error("Failed to send burn rate Slack notification", err, map[string]interface{}{
+			"queue": transition.Queue,
+		})
+	}
+}
+
+// sendSLOReport logs a finished month's availability and sends the report to the global webhooks
+func (s *Service) sendSLOReport(report slo.Report) {
+	missed := make([]string, 0)
+	queues := make([]slack.SLOQueue, 0, len(report.Queues))
+	for _, queue := range report.Queues {
+		if queue.AvailabilityPercent < report.Target {
+			missed = append(missed, queue.Queue)
+		}
+		queues = append(queues, slack.SLOQueue{
+			QueueName:       queue.Queue,
+			Availability:    queue.AvailabilityPercent,
+			BudgetRemaining: queue.BudgetRemainingPercent,
+			Incidents:       queue.Incidents,
+			MTTA:            time.Duration(queue.MTTASeconds * float64(time.Second)),
+			MTTR:            time.Duration(queue.MTTRSeconds * float64(time.Second)),
+		})
+	}
+	s.logger.Info("Monthly SLO report", map[string]interface{}{
+		"month":         report.Month,
+		"target":        report.Target,
+		"queues":        len(report.Queues),
+		"missed_queues": missed,
+	})
+
+	if s.slackClient == nil || !s.config.SLO.MonthlyReport {
+		return
+	}
+	err := s.slackClient.SendSLOReport(slack.SLOReport{
+		Month:  report.Month,
+		Target: report.Target,
+		Queues: queues,
+	}, s.config.Notifications.Slack.WebhookURLs)
+	s.metrics.observeNotification("slack", err)
+	if err != nil {
+		s.logger.Error("Failed to send SLO report", err, map[string]interface{}{
+			"month": report.Month,
+		})
+	}
+}
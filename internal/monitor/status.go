@@ -0,0 +1,68 @@
+package monitor
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"go-rmq-monitor/internal/buildinfo"
+)
+
+// statusResponse is the JSON document served by /api/status
+type statusResponse struct {
+	Build          buildinfo.Info `json:"build"`
+	StartTime      time.Time      `json:"start_time"`
+	Uptime         string         `json:"uptime"`
+	TrackedQueues  int            `json:"tracked_queues"`
+	AlertingQueues []string       `json:"alerting_queues"`
+	// Alerting queues whose backlog is decreasing, also listed in alerting_queues
+	RecoveringQueues []string       `json:"recovering_queues"`
+	HealthScores     map[string]int `json:"health_scores"`
+	// Last check error per queue, most recent first
+	QueueErrors []queueErrorStatus `json:"queue_errors"`
+}
+
+// queueErrorStatus is the last check error affecting a queue
+type queueErrorStatus struct {
+	Queue      string    `json:"queue"`
+	Error      string    `json:"error"`
+	At         time.Time `json:"at"`
+	NoData     bool      `json:"no_data"`     // No data for the queue since the error
+	GapSeconds float64   `json:"gap_seconds"` // Length of the ongoing or latest data gap, 0 if the error left none
+}
+
+// handleStatus reports build information and a summary of the monitoring state
+func (s *Service) handleStatus(w http.ResponseWriter, r *http.Request) {
+	build := buildinfo.Get()
+	build.Features = s.config.EnabledFeatures()
+
+	alerting, tracked := s.analyzer.GetAlertingQueues()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(statusResponse{
+		Build:            build,
+		StartTime:        s.startTime,
+		Uptime:           time.Since(s.startTime).Round(time.Second).String(),
+		TrackedQueues:    tracked,
+		AlertingQueues:   alerting,
+		RecoveringQueues: s.analyzer.GetRecoveringQueues(),
+		HealthScores:     s.analyzer.HealthScores(),
+		QueueErrors:      s.queueErrorStatuses(time.Now()),
+	})
+}
+
+// queueErrorStatuses returns the last error of every queue that had one
+func (s *Service) queueErrorStatuses(now time.Time) []queueErrorStatus {
+	entries := s.queueErrors.Entries()
+	statuses := make([]queueErrorStatus, 0, len(entries))
+	for _, entry := range entries {
+		statuses = append(statuses, queueErrorStatus{
+			Queue:      entry.Queue,
+			Error:      entry.Error,
+			At:         entry.At,
+			NoData:     entry.NoData,
+			GapSeconds: entry.Gap(now).Seconds(),
+		})
+	}
+	return statuses
+}
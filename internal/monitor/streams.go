@@ -0,0 +1,112 @@
+package monitor
+
+import (
+	"time"
+
+	"go-rmq-monitor/internal/rabbitmq"
+	"go-rmq-monitor/internal/slack"
+	"go-rmq-monitor/internal/streams"
+)
+
+// queueTypeStream is the management API type of stream queues
+const queueTypeStream = "stream"
+
+// checkStreams tracks consumer group lag of the monitored streams and notifies state changes
+// Returns the remaining queues, since depth-based detection does not apply to streams
+func (s *Service) checkStreams(queues []rabbitmq.QueueInfo, now time.Time) []rabbitmq.QueueInfo {
+	remaining := make([]rabbitmq.QueueInfo, 0, len(queues))
+	monitored := make(map[string]rabbitmq.QueueInfo)
+	names := make([]string, 0)
+	for _, queue := range queues {
+		if queue.Type != queueTypeStream {
+			remaining = append(remaining, queue)
+			continue
+		}
+		if s.config.Streams.Monitors(queue.Name) {
+			monitored[queue.Name] = queue
+			names = append(names, queue.Name)
+		}
+	}
+	if len(names) == 0 {
+		return remaining
+	}
+
+	if !s.permitted("stream_consumers", now) {
+		return remaining
+	}
+
+	apiStart := time.Now()
+	consumers, err := s.client.GetStreamConsumers()
+	s.metrics.observeAPICall("stream_consumers", apiStart, err)
+	if s.lacksPermission("stream_consumers", "stream_consumers", err, now) {
+		return remaining
+	}
+	if err != nil {
+		s.logger.Warn("Failed to fetch stream consumers", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return remaining
+	}
+
+	for _, transition := range s.streams.Observe(names, consumers, now) {
+		s.notifyStream(transition, monitored[transition.Stream], now)
+	}
+	return remaining
+}
+
+// notifyStream logs a stream consumer group state change and sends it to Slack
+func (s *Service) notifyStream(transition streams.Transition, queue rabbitmq.QueueInfo, now time.Time) {
+	fields := map[string]interface{}{
+		"stream":     transition.Stream,
+		"group":      transition.Group,
+		"offset_lag": transition.OffsetLag,
+	}
+	if transition.Alerting {
+		fields["reason"] = transition.Reason
+		s.logger.Warn("STREAM CONSUMER PROBLEM DETECTED", fields)
+	} else {
+		fields["duration"] = transition.Duration.String()
+		s.logger.Info("Stream consumer group recovered", fields)
+	}
+
+	if s.slackClient == nil || s.observeOnly[transition.Stream] {
+		return
+	}
+	if _, silenced := s.silences.Active(transition.Stream, now); silenced {
+		return
+	}
+	if !transition.Alerting && !s.config.Notifications.Slack.SendRecovery {
+		return
+	}
+
+	alert := slack.QueueAlert{
+		Type:          slack.AlertTypeAlerting,
+		QueueName:     transition.Stream,
+		Priority:      s.priorities[transition.Stream],
+		VHost:         queue.VHost,
+		MessagesReady: queue.Messages,
+		Consumers:     queue.Consumers,
+		ConsumeRate:   queue.ConsumeRate,
+		PublishRate:   queue.PublishRate,
+		Reason:        transition.Reason,
+		Timestamp:     transition.Timestamp,
+		StuckDuration: transition.Duration,
+	}
+	if !transition.Alerting {
+		alert.Type = slack.AlertTypeNotAlerting
+	}
+
+	webhookURLs := s.alerts.Route(transition.Stream, alert.Priority, now).WebhookURLs
+	if len(webhookURLs) == 0 {
+		return
+	}
+
+	err := s.slackClient.SendAlertTo(alert, webhookURLs)
+	s.metrics.observeNotification("slack", err)
+	if err != nil {
+		s.logger.Error("Failed to send stream Slack notification", err, map[string]interface{}{
+			"stream": transition.Stream,
+			"group":  transition.Group,
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package monitor
+
+import (
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/silence"
+)
+
+// configuredSilences converts platform and team silences from config
+func configuredSilences(cfg *config.Config) []silence.Silence {
+	silences := make([]silence.Silence, 0, len(cfg.Silences))
+	for _, silenceCfg := range cfg.Silences {
+		silences = append(silences, silence.Silence{
+			Queues: silenceCfg.Queues,
+			Reason: silenceCfg.Reason,
+			EndsAt: silenceCfg.End(),
+		})
+	}
+	for _, team := range cfg.Teams {
+		for _, silenceCfg := range team.Silences {
+			silences = append(silences, silence.Silence{
+				Queues: silenceCfg.Queues,
+				Team:   team.Name,
+				Reason: silenceCfg.Reason,
+				EndsAt: silenceCfg.End(),
+			})
+		}
+	}
+	return silences
+}
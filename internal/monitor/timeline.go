@@ -0,0 +1,84 @@
+package monitor
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"go-rmq-monitor/internal/analyzer"
+	"go-rmq-monitor/internal/history"
+	"go-rmq-monitor/internal/rabbitmq"
+)
+
+// defaultTimelineRange is the time range of a timeline request without "from"
+const defaultTimelineRange = 24 * time.Hour
+
+// timelineLookback is how far before the range samples are read, so the range starts in a known state
+const timelineLookback = time.Hour
+
+// historyStatuses returns the state of the checked queues after a check, for the history file
+// Queues that started alerting carry the detector's reason
+func (s *Service) historyStatuses(queues []rabbitmq.QueueInfo, result analyzer.AnalysisResult) map[string]history.Status {
+	statuses := make(map[string]history.Status, len(queues))
+	for _, queue := range queues {
+		statuses[queue.Name] = history.Status{State: history.StateHealthy}
+	}
+	alerting, _ := s.analyzer.GetAlertingQueues()
+	for _, name := range alerting {
+		statuses[name] = history.Status{State: history.StateAlerting}
+	}
+	for _, name := range s.analyzer.GetRecoveringQueues() {
+		statuses[name] = history.Status{State: history.StateRecovering}
+	}
+	for _, transition := range result.Transitions {
+		if transition.ToState == "alerting" {
+			statuses[transition.QueueName] = history.Status{State: history.StateAlerting, Reason: transition.Reason}
+		}
+	}
+	return statuses
+}
+
+// handleTimeline serves a queue's healthy, alerting and recovering intervals and alert events from the history file
+// The optional "from" and "to" query parameters are RFC 3339 times; the range defaults to the last 24 hours
+func (s *Service) handleTimeline(w http.ResponseWriter, r *http.Request) {
+	queue := r.PathValue("name")
+	to := time.Now()
+	if value := r.URL.Query().Get("to"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			http.Error(w, "invalid to: expected an RFC 3339 time", http.StatusBadRequest)
+			return
+		}
+		to = parsed
+	}
+	from := to.Add(-defaultTimelineRange)
+	if value := r.URL.Query().Get("from"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			http.Error(w, "invalid from: expected an RFC 3339 time", http.StatusBadRequest)
+			return
+		}
+		from = parsed
+	}
+	if !from.Before(to) {
+		http.Error(w, "from must be before to", http.StatusBadRequest)
+		return
+	}
+
+	records, err := history.Load(s.config.History.FilePath, from.Add(-timelineLookback))
+	if err != nil {
+		s.baseLogger.Error("Failed to load history for timeline", err, map[string]interface{}{
+			"queue": queue,
+		})
+		http.Error(w, "failed to load history", http.StatusInternalServerError)
+		return
+	}
+	queueRecords, exists := records[queue]
+	if !exists {
+		http.Error(w, "no history for queue: "+queue, http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history.BuildTimeline(queue, queueRecords, from, to))
+}
@@ -0,0 +1,151 @@
+package monitor
+
+import (
+	"time"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/rabbitmq"
+	"go-rmq-monitor/internal/slack"
+)
+
+// breachState tracks a limit rule such as a vhost rule between checks
+type breachState struct {
+	breaches int       // Consecutive checks over a limit
+	since    time.Time // When the alert fired, zero while not alerting
+}
+
+// checkVHosts compares the totals of every vhost rule with its limits
+// A vhost alerts once it breaches a limit for the rule's threshold checks, and recovers on the first check within all limits
+func (s *Service) checkVHosts(now time.Time) {
+	for _, rule := range s.config.VHosts {
+		// Permissions are per vhost, so each rule is switched off on its own
+		subsystem := "vhost_limits/" + rule.VHost
+		if !s.permitted(subsystem, now) {
+			continue
+		}
+
+		apiStart := time.Now()
+		totals, err := s.client.GetVHostTotals(rule.VHost)
+		s.metrics.observeAPICall("vhost", apiStart, err)
+		if s.lacksPermission(subsystem, "vhost", err, now) {
+			continue
+		}
+		if err != nil {
+			s.logger.Warn("Failed to fetch vhost totals", map[string]interface{}{
+				"vhost": rule.VHost,
+				"error": err.Error(),
+			})
+			continue
+		}
+
+		state, exists := s.vhostStates[rule.VHost]
+		if !exists {
+			state = &breachState{}
+			s.vhostStates[rule.VHost] = state
+		}
+
+		breached := vhostBreaches(rule, totals)
+		alerting := !state.since.IsZero()
+		if len(breached) > 0 {
+			state.breaches++
+		} else {
+			state.breaches = 0
+		}
+
+		fields := map[string]interface{}{
+			"vhost":          rule.VHost,
+			"messages":       totals.Messages,
+			"messages_ready": totals.MessagesReady,
+			"publish_rate":   totals.PublishRate,
+			"consume_rate":   totals.ConsumeRate,
+		}
+		alert := slack.VHostAlert{
+			VHost:           rule.VHost,
+			Messages:        totals.Messages,
+			MessagesReady:   totals.MessagesReady,
+			MessagesUnacked: totals.MessagesUnacked,
+			PublishRate:     totals.PublishRate,
+			ConsumeRate:     totals.ConsumeRate,
+			Limits: slack.VHostLimits{
+				MaxMessages:      rule.MaxMessages,
+				MaxMessagesReady: rule.MaxMessagesReady,
+				MaxPublishRate:   rule.MaxPublishRate,
+				MinConsumeRate:   rule.MinConsumeRate,
+			},
+			Breached:  breached,
+			Timestamp: now,
+		}
+
+		switch {
+		case !alerting && state.breaches >= s.config.Monitor.GetVHostThresholdChecks(rule):
+			state.since = now
+			fields["breached"] = breachedNames(breached)
+			s.logger.Warn("VHOST LIMITS EXCEEDED", fields)
+			s.notifyVHost(alert, rule, now)
+		case alerting && len(breached) == 0:
+			alert.Resolved = true
+			alert.AlertDuration = now.Sub(state.since)
+			state.since = time.Time{}
+			fields["duration"] = alert.AlertDuration.String()
+			s.logger.Info("VHost back within limits", fields)
+			s.notifyVHost(alert, rule, now)
+		case len(breached) > 0 && !alerting:
+			fields["breached"] = breachedNames(breached)
+			fields["breaches"] = state.breaches
+			s.logger.Debug("VHost over limits", fields)
+		}
+	}
+}
+
+// vhostBreaches returns the limits of a rule the vhost totals exceed, by name
+// A low consume rate only counts while messages are waiting for consumers
+func vhostBreaches(rule config.VHostRuleConfig, totals *rabbitmq.VHostTotals) map[string]bool {
+	breached := make(map[string]bool)
+	if rule.MaxMessages > 0 && totals.Messages > rule.MaxMessages {
+		breached["messages"] = true
+	}
+	if rule.MaxMessagesReady > 0 && totals.MessagesReady > rule.MaxMessagesReady {
+		breached["messages_ready"] = true
+	}
+	if rule.MaxPublishRate > 0 && totals.PublishRate > rule.MaxPublishRate {
+		breached["publish_rate"] = true
+	}
+	if rule.MinConsumeRate > 0 && totals.MessagesReady > 0 && totals.ConsumeRate < rule.MinConsumeRate {
+		breached["consume_rate"] = true
+	}
+	return breached
+}
+
+// breachedNames lists breached limits in a stable order for logging
+func breachedNames(breached map[string]bool) []string {
+	var names []string
+	for _, name := range []string{"messages", "messages_ready", "publish_rate", "consume_rate"} {
+		if breached[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// notifyVHost sends a vhost limit alert to Slack
+func (s *Service) notifyVHost(alert slack.VHostAlert, rule config.VHostRuleConfig, now time.Time) {
+	if s.slackClient == nil {
+		return
+	}
+
+	// Vhosts are not owned by a team, so the vhosts alert type settings route them
+	webhookURLs := s.alertWebhooks("vhosts", rule.VHost, rule.Priority, !alert.Resolved, now)
+	if len(webhookURLs) == 0 {
+		return
+	}
+
+	err := s.slackClient.SendVHostAlert(alert, webhookURLs)
+	s.metrics.observeNotification("slack", err)
+	if err != nil {
+		s.logger.Error("Failed to send vhost Slack notification", err, map[string]interface{}{
+			"vhost": rule.VHost,
+		})
+	} else if !alert.Resolved {
+		s.alerts.SentAlert("vhosts", rule.VHost, now)
+	}
+}
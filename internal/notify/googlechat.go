@@ -0,0 +1,161 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+	"time"
+
+	"go-rmq-monitor/internal/slack"
+)
+
+// GoogleChat posts cards v2 messages to Google Chat incoming webhooks
+type GoogleChat struct {
+	webhookURLs []string
+	display     slack.Display
+	httpClient  *http.Client
+}
+
+// NewGoogleChat creates a notifier posting to every webhook
+func NewGoogleChat(webhookURLs []string, display slack.Display, timeout time.Duration) *GoogleChat {
+	return &GoogleChat{
+		webhookURLs: webhookURLs,
+		display:     display,
+		httpClient:  &http.Client{Timeout: timeout},
+	}
+}
+
+// chatMessage is a Google Chat message with a single card
+type chatMessage struct {
+	Text    string     `json:"text"`
+	CardsV2 []chatCard `json:"cardsV2"`
+}
+
+type chatCard struct {
+	CardID string   `json:"cardId"`
+	Card   cardBody `json:"card"`
+}
+
+type cardBody struct {
+	Header   cardHeader    `json:"header"`
+	Sections []cardSection `json:"sections"`
+}
+
+type cardHeader struct {
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle,omitempty"`
+}
+
+type cardSection struct {
+	Header  string       `json:"header,omitempty"`
+	Widgets []cardWidget `json:"widgets"`
+}
+
+type cardWidget struct {
+	DecoratedText *decoratedText `json:"decoratedText,omitempty"`
+	TextParagraph *textParagraph `json:"textParagraph,omitempty"`
+}
+
+type decoratedText struct {
+	TopLabel string `json:"topLabel"`
+	Text     string `json:"text"`
+}
+
+type textParagraph struct {
+	Text string `json:"text"`
+}
+
+// Name identifies the notifier in logs and metrics
+func (g *GoogleChat) Name() string {
+	return "google_chat"
+}
+
+// SendAlert posts the alert card to every webhook
+// Succeeds if at least one webhook accepted it
+func (g *GoogleChat) SendAlert(alert slack.QueueAlert) error {
+	body, err := json.Marshal(formatChatCard(slack.Summarize(alert, g.display), alert, g.display))
+	if err != nil {
+		return fmt.Errorf("failed to marshal google chat message: %w", err)
+	}
+
+	var lastError error
+	delivered := false
+	for i, url := range g.webhookURLs {
+		resp, err := g.httpClient.Post(url, "application/json; charset=UTF-8", bytes.NewReader(body))
+		if err != nil {
+			lastError = fmt.Errorf("google chat webhook %d failed: %w", i+1, err)
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			lastError = fmt.Errorf("google chat webhook %d returned status %d", i+1, resp.StatusCode)
+			continue
+		}
+		delivered = true
+	}
+
+	if !delivered {
+		return lastError
+	}
+	return nil
+}
+
+// Ping checks every webhook with an empty message, which Google Chat rejects
+// with status 400 for live webhooks and 401, 403 or 404 for revoked ones
+func (g *GoogleChat) Ping() error {
+	var errs []error
+	for i, url := range g.webhookURLs {
+		resp, err := g.httpClient.Post(url, "application/json; charset=UTF-8", strings.NewReader("{}"))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("google chat webhook %d failed: %w", i+1, err))
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusBadRequest {
+			errs = append(errs, fmt.Errorf("google chat webhook %d returned status %d", i+1, resp.StatusCode))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// formatChatCard lays out a summary as a card, one widget per field
+// Card text supports basic HTML, so values are escaped
+func formatChatCard(summary slack.Summary, alert slack.QueueAlert, display slack.Display) chatMessage {
+	fields := cardSection{Widgets: make([]cardWidget, 0, len(summary.Fields))}
+	for _, field := range summary.Fields {
+		fields.Widgets = append(fields.Widgets, cardWidget{DecoratedText: &decoratedText{
+			TopLabel: field.Label,
+			Text:     html.EscapeString(field.Value),
+		}})
+	}
+
+	sections := []cardSection{fields}
+	for _, section := range summary.Sections {
+		lines := make([]string, 0, len(section.Lines))
+		for _, line := range section.Lines {
+			lines = append(lines, html.EscapeString(line))
+		}
+		sections = append(sections, cardSection{
+			Header:  html.EscapeString(section.Label),
+			Widgets: []cardWidget{{TextParagraph: &textParagraph{Text: strings.Join(lines, "<br>")}}},
+		})
+	}
+	sections = append(sections, cardSection{
+		Widgets: []cardWidget{{TextParagraph: &textParagraph{Text: html.EscapeString(summary.Footer)}}},
+	})
+
+	return chatMessage{
+		Text: summary.Text,
+		CardsV2: []chatCard{{
+			CardID: "queue-alert",
+			Card: cardBody{
+				Header:   cardHeader{Title: summary.Title, Subtitle: alert.VHost + " / " + display.QueueName(alert.QueueName)},
+				Sections: sections,
+			},
+		}},
+	}
+}
@@ -0,0 +1,15 @@
+package notify
+
+import (
+	"go-rmq-monitor/internal/slack"
+)
+
+// Notifier delivers queue alerts to a chat platform other than Slack
+type Notifier interface {
+	// Name identifies the notifier in logs and metrics
+	Name() string
+	// SendAlert delivers a queue stuck or recovery alert
+	SendAlert(alert slack.QueueAlert) error
+	// Ping checks the credentials and endpoints without notifying anyone
+	Ping() error
+}
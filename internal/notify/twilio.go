@@ -0,0 +1,161 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go-rmq-monitor/internal/slack"
+)
+
+// ErrRateLimited is returned when an SMS is dropped by the hourly cap
+var ErrRateLimited = errors.New("sms rate limit reached")
+
+// twilioAPI is the base URL of the Twilio REST API
+const twilioAPI = "https://api.twilio.com"
+
+// maxSMSLength keeps messages within two SMS segments
+const maxSMSLength = 300
+
+// TwilioConfig identifies the Twilio account and phone numbers used for SMS
+type TwilioConfig struct {
+	AccountSID string
+	AuthToken  string
+	From       string
+	To         []string
+	MaxPerHour int // Messages allowed per rolling hour across all recipients
+}
+
+// Twilio sends SMS messages through the Twilio Messages API
+type Twilio struct {
+	config     TwilioConfig
+	display    slack.Display
+	httpClient *http.Client
+	sent       []time.Time // Send times within the last hour
+	mu         sync.Mutex
+}
+
+// NewTwilio creates an SMS notifier
+func NewTwilio(config TwilioConfig, display slack.Display, timeout time.Duration) *Twilio {
+	return &Twilio{
+		config:     config,
+		display:    display,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Name identifies the notifier in logs and metrics
+func (t *Twilio) Name() string {
+	return "twilio"
+}
+
+// SendAlert texts the alert to every recipient, each message counting towards the hourly cap
+// Recipients over the cap are skipped; returns ErrRateLimited when none could be texted
+func (t *Twilio) SendAlert(alert slack.QueueAlert) error {
+	body := formatSMS(slack.Summarize(alert, t.display), alert, t.display)
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", twilioAPI, url.PathEscape(t.config.AccountSID))
+
+	var lastError error
+	delivered := false
+	for _, to := range t.config.To {
+		if !t.allow(time.Now()) {
+			if lastError == nil && !delivered {
+				lastError = ErrRateLimited
+			}
+			break
+		}
+		form := url.Values{
+			"From": {t.config.From},
+			"To":   {to},
+			"Body": {body},
+		}
+		req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+		if err != nil {
+			return fmt.Errorf("failed to create twilio request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetBasicAuth(t.config.AccountSID, t.config.AuthToken)
+
+		resp, err := t.httpClient.Do(req)
+		if err != nil {
+			lastError = fmt.Errorf("twilio request for %s failed: %w", to, err)
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+			lastError = fmt.Errorf("twilio returned status %d for %s", resp.StatusCode, to)
+			continue
+		}
+		delivered = true
+	}
+
+	if !delivered {
+		return lastError
+	}
+	return nil
+}
+
+// Ping fetches the account to check the credentials without sending an SMS
+func (t *Twilio) Ping() error {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/2010-04-01/Accounts/%s.json", twilioAPI, url.PathEscape(t.config.AccountSID)), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create twilio request: %w", err)
+	}
+	req.SetBasicAuth(t.config.AccountSID, t.config.AuthToken)
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("twilio request failed: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("twilio returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// allow records a message if it fits in the rolling hourly cap
+func (t *Twilio) allow(now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	recent := t.sent[:0]
+	for _, sentAt := range t.sent {
+		if now.Sub(sentAt) < time.Hour {
+			recent = append(recent, sentAt)
+		}
+	}
+	t.sent = recent
+	if len(t.sent) >= t.config.MaxPerHour {
+		return false
+	}
+	t.sent = append(t.sent, now)
+	return true
+}
+
+// formatSMS renders a short plain text message, truncated to fit two segments
+func formatSMS(summary slack.Summary, alert slack.QueueAlert, display slack.Display) string {
+	lines := []string{strings.ReplaceAll(summary.Text, "`", "")}
+	for _, field := range summary.Fields {
+		if field.Label == "" || field.Value == display.QueueName(alert.QueueName) {
+			continue
+		}
+		lines = append(lines, field.Label+": "+field.Value)
+		if len(lines) == 4 {
+			break
+		}
+	}
+	if alert.Reason != "" {
+		lines = append(lines, alert.Reason)
+	}
+
+	text := strings.Join(lines, "\n")
+	if runes := []rune(text); len(runes) > maxSMSLength {
+		text = string(runes[:maxSMSLength-1]) + "…"
+	}
+	return text
+}
@@ -0,0 +1,117 @@
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go-rmq-monitor/internal/slack"
+)
+
+// ZulipConfig identifies the bot and stream Zulip messages are sent to
+type ZulipConfig struct {
+	Site     string // Base URL, e.g. https://example.zulipchat.com
+	BotEmail string
+	APIKey   string
+	Stream   string
+	Topic    string // Defaults to the queue name so recoveries follow their alert
+}
+
+// Zulip sends markdown messages to a Zulip stream through the messages API
+type Zulip struct {
+	config     ZulipConfig
+	display    slack.Display
+	httpClient *http.Client
+}
+
+// NewZulip creates a notifier sending to the configured stream
+func NewZulip(config ZulipConfig, display slack.Display, timeout time.Duration) *Zulip {
+	config.Site = strings.TrimSuffix(config.Site, "/")
+	return &Zulip{
+		config:     config,
+		display:    display,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Name identifies the notifier in logs and metrics
+func (z *Zulip) Name() string {
+	return "zulip"
+}
+
+// SendAlert posts the alert as a stream message
+func (z *Zulip) SendAlert(alert slack.QueueAlert) error {
+	topic := z.config.Topic
+	if topic == "" {
+		topic = z.display.QueueName(alert.QueueName)
+	}
+	form := url.Values{
+		"type":    {"stream"},
+		"to":      {z.config.Stream},
+		"topic":   {topic},
+		"content": {formatZulipMessage(slack.Summarize(alert, z.display))},
+	}
+
+	req, err := http.NewRequest(http.MethodPost, z.config.Site+"/api/v1/messages", strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create zulip request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(z.config.BotEmail, z.config.APIKey)
+
+	resp, err := z.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("zulip request failed: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("zulip returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Ping fetches the bot's own profile to check the site and credentials
+func (z *Zulip) Ping() error {
+	req, err := http.NewRequest(http.MethodGet, z.config.Site+"/api/v1/users/me", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create zulip request: %w", err)
+	}
+	req.SetBasicAuth(z.config.BotEmail, z.config.APIKey)
+
+	resp, err := z.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("zulip request failed: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("zulip returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// formatZulipMessage renders a summary as Zulip markdown
+func formatZulipMessage(summary slack.Summary) string {
+	var b strings.Builder
+	b.WriteString("**" + summary.Title + "**\n\n")
+	for _, field := range summary.Fields {
+		fmt.Fprintf(&b, "- **%s:** %s\n", field.Label, field.Value)
+	}
+	for _, section := range summary.Sections {
+		b.WriteString("\n")
+		switch {
+		case section.Label == "":
+			b.WriteString(strings.Join(section.Lines, "\n") + "\n")
+		case len(section.Lines) == 1:
+			fmt.Fprintf(&b, "**%s:** %s\n", section.Label, section.Lines[0])
+		default:
+			fmt.Fprintf(&b, "**%s:**\n", section.Label)
+			for _, line := range section.Lines {
+				b.WriteString("- " + line + "\n")
+			}
+		}
+	}
+	b.WriteString("\n" + summary.Footer)
+	return b.String()
+}
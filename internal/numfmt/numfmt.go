@@ -0,0 +1,72 @@
+package numfmt
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Format holds the digit separators used to render numbers
+type Format struct {
+	Thousands string // Between groups of three digits, may be empty
+	Decimal   string // Between the integer and fractional digits
+}
+
+// formats maps a language code to its conventional separators
+var formats = map[string]Format{
+	"en": {Thousands: ",", Decimal: "."},
+	"nl": {Thousands: ".", Decimal: ","},
+	"de": {Thousands: ".", Decimal: ","},
+	"fr": {Thousands: " ", Decimal: ","},
+}
+
+// ForLanguage returns the separators of a language, falling back to English
+func ForLanguage(language string) Format {
+	if format, ok := formats[language]; ok {
+		return format
+	}
+	return formats["en"]
+}
+
+// Int renders an integer with every group of three digits separated
+func (f Format) Int(n int) string {
+	if n < 0 {
+		return "-" + f.Int(-n)
+	}
+	return f.group(strconv.Itoa(n))
+}
+
+// Float renders a number with the given number of fractional digits
+func (f Format) Float(v float64, precision int) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'f', precision, 64)
+	}
+	digits := strconv.FormatFloat(math.Abs(v), 'f', precision, 64)
+	sign := ""
+	if v < 0 && strings.Trim(digits, "0.") != "" {
+		sign = "-"
+	}
+	integer, fraction, hasFraction := strings.Cut(digits, ".")
+	if !hasFraction {
+		return sign + f.group(integer)
+	}
+	return sign + f.group(integer) + f.Decimal + fraction
+}
+
+// group inserts the thousands separator into a string of digits
+func (f Format) group(digits string) string {
+	if len(digits) <= 3 || f.Thousands == "" {
+		return digits
+	}
+	head := len(digits) % 3
+	if head == 0 {
+		head = 3
+	}
+	var b strings.Builder
+	b.WriteString(digits[:head])
+	for i := head; i < len(digits); i += 3 {
+		b.WriteString(f.Thousands)
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
@@ -0,0 +1,150 @@
+package ownership
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// BackstageConfig locates the catalog and the annotations ownership is read from
+type BackstageConfig struct {
+	URL                  string // Backstage backend base URL, e.g. https://backstage.example.com
+	Token                string // Bearer token for the catalog API, optional
+	QueueAnnotation      string // Component annotation listing its queues, comma-separated
+	SlackAnnotation      string // Group annotation holding the Slack user group
+	EscalationAnnotation string // Group annotation holding the escalation contact
+}
+
+// Backstage reads queue ownership from a Backstage software catalog
+// Components list their queues in an annotation; their owning group provides
+// the Slack user group and escalation contact, falling back to the group email
+type Backstage struct {
+	config     BackstageConfig
+	httpClient *http.Client
+}
+
+// backstageEntity holds the catalog entity fields used for ownership
+type backstageEntity struct {
+	Metadata struct {
+		Name        string            `json:"name"`
+		Namespace   string            `json:"namespace"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+	Spec struct {
+		Owner   string `json:"owner"`
+		Profile struct {
+			DisplayName string `json:"displayName"`
+			Email       string `json:"email"`
+		} `json:"profile"`
+	} `json:"spec"`
+}
+
+// NewBackstage creates a source reading the catalog API
+func NewBackstage(config BackstageConfig, timeout time.Duration) *Backstage {
+	config.URL = strings.TrimSuffix(config.URL, "/")
+	return &Backstage{
+		config:     config,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Name identifies the source in logs
+func (b *Backstage) Name() string {
+	return "backstage"
+}
+
+// Fetch lists annotated components and all groups, and resolves each component's owner
+// Components owned by an unknown group keep the owner reference as team name
+func (b *Backstage) Fetch() ([]Entry, error) {
+	components, err := b.entities("kind=component,metadata.annotations." + b.config.QueueAnnotation)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := b.entities("kind=group")
+	if err != nil {
+		return nil, err
+	}
+
+	owners := make(map[string]Owner, len(groups))
+	for _, group := range groups {
+		owner := Owner{
+			Team:       group.Metadata.Name,
+			SlackGroup: group.Metadata.Annotations[b.config.SlackAnnotation],
+			Escalation: group.Metadata.Annotations[b.config.EscalationAnnotation],
+		}
+		if group.Spec.Profile.DisplayName != "" {
+			owner.Team = group.Spec.Profile.DisplayName
+		}
+		if owner.Escalation == "" {
+			owner.Escalation = group.Spec.Profile.Email
+		}
+		owners[entityRef(group.Metadata.Namespace, group.Metadata.Name)] = owner
+	}
+
+	entries := make([]Entry, 0, len(components))
+	for _, component := range components {
+		var queues []string
+		for _, queue := range strings.Split(component.Metadata.Annotations[b.config.QueueAnnotation], ",") {
+			if queue = strings.TrimSpace(queue); queue != "" {
+				queues = append(queues, queue)
+			}
+		}
+		if len(queues) == 0 || component.Spec.Owner == "" {
+			continue
+		}
+		owner, known := owners[ownerRef(component.Spec.Owner, component.Metadata.Namespace)]
+		if !known {
+			owner = Owner{Team: component.Spec.Owner}
+		}
+		entries = append(entries, Entry{Queues: queues, Owner: owner})
+	}
+	return entries, nil
+}
+
+// entities fetches the catalog entities matching a filter
+func (b *Backstage) entities(filter string) ([]backstageEntity, error) {
+	req, err := http.NewRequest(http.MethodGet, b.config.URL+"/api/catalog/entities?filter="+url.QueryEscape(filter), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backstage request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if b.config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+b.config.Token)
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("backstage request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("backstage returned status %d", resp.StatusCode)
+	}
+
+	var entities []backstageEntity
+	if err := json.NewDecoder(resp.Body).Decode(&entities); err != nil {
+		return nil, fmt.Errorf("failed to decode backstage entities: %w", err)
+	}
+	return entities, nil
+}
+
+// ownerRef normalizes a spec.owner reference like "group:default/payments" or "payments"
+// Owners without a namespace live in the namespace of the owned entity
+func ownerRef(ref, namespace string) string {
+	ref = strings.TrimPrefix(ref, "group:")
+	if ns, name, qualified := strings.Cut(ref, "/"); qualified {
+		return entityRef(ns, name)
+	}
+	return entityRef(namespace, ref)
+}
+
+// entityRef builds the lookup key of a group
+func entityRef(namespace, name string) string {
+	if namespace == "" {
+		namespace = "default"
+	}
+	return strings.ToLower(namespace + "/" + name)
+}
@@ -0,0 +1,78 @@
+package ownership
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/spf13/viper"
+)
+
+// File reads queue ownership from a YAML mapping, e.g.
+//
+//	owners:
+//	  - team: payments
+//	    slack_group: S0123ABCD
+//	    escalation: "PagerDuty payments-primary"
+//	    queues: ["payments.*", "invoices"]
+type File struct {
+	path string
+}
+
+// fileEntry is one owner in the mapping file
+type fileEntry struct {
+	Team       string   `mapstructure:"team"`
+	SlackGroup string   `mapstructure:"slack_group"`
+	Escalation string   `mapstructure:"escalation"`
+	Queues     []string `mapstructure:"queues"`
+}
+
+// NewFile creates a source reading the mapping file at path
+func NewFile(path string) *File {
+	return &File{path: path}
+}
+
+// Name identifies the source in logs
+func (f *File) Name() string {
+	return "file"
+}
+
+// Fetch rereads the mapping file, so edits apply without a restart
+func (f *File) Fetch() ([]Entry, error) {
+	v := viper.New()
+	v.SetConfigFile(f.path)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read ownership file: %w", err)
+	}
+
+	var mapping struct {
+		Owners []fileEntry `mapstructure:"owners"`
+	}
+	if err := v.Unmarshal(&mapping); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ownership file: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(mapping.Owners))
+	for i, owner := range mapping.Owners {
+		if owner.Team == "" {
+			return nil, fmt.Errorf("ownership file owners[%d]: team is required", i)
+		}
+		if len(owner.Queues) == 0 {
+			return nil, fmt.Errorf("ownership file owners[%d]: queues is required", i)
+		}
+		for _, pattern := range owner.Queues {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("ownership file owners[%d]: invalid queue pattern %q", i, pattern)
+			}
+		}
+		entries = append(entries, Entry{
+			Queues: owner.Queues,
+			Owner: Owner{
+				Team:       owner.Team,
+				SlackGroup: owner.SlackGroup,
+				Escalation: owner.Escalation,
+			},
+		})
+	}
+	return entries, nil
+}
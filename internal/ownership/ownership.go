@@ -0,0 +1,97 @@
+package ownership
+
+import (
+	"path"
+	"sync"
+	"time"
+)
+
+// Owner is the team responsible for a set of queues
+type Owner struct {
+	Team       string
+	SlackGroup string // Slack user group ID or mention, e.g. S0123ABCD or @payments-oncall
+	Escalation string // Free-form escalation contact, e.g. a pager rotation or phone number
+}
+
+// Entry assigns an owner to the queues matching any of its patterns
+type Entry struct {
+	Queues []string // Queue names or path.Match glob patterns
+	Owner  Owner
+}
+
+// Matches reports whether the entry covers a queue
+func (e Entry) Matches(queue string) bool {
+	for _, pattern := range e.Queues {
+		if matched, _ := path.Match(pattern, queue); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Source fetches the queue ownership entries from a service catalog
+type Source interface {
+	Name() string
+	Fetch() ([]Entry, error)
+}
+
+// Directory resolves queue owners from a source, refetching it periodically
+// The last successfully fetched entries stay in use while the source fails
+type Directory struct {
+	source  Source
+	refresh time.Duration
+
+	mu        sync.RWMutex
+	entries   []Entry
+	fetchedAt time.Time // Last fetch attempt, successful or not
+}
+
+// NewDirectory creates an empty directory, filled on the first Refresh
+func NewDirectory(source Source, refresh time.Duration) *Directory {
+	return &Directory{source: source, refresh: refresh}
+}
+
+// Source returns the name of the directory's source
+func (d *Directory) Source() string {
+	return d.source.Name()
+}
+
+// Refresh refetches the entries when the refresh interval has passed since the last attempt
+func (d *Directory) Refresh(now time.Time) error {
+	d.mu.RLock()
+	stale := d.fetchedAt.IsZero() || now.Sub(d.fetchedAt) >= d.refresh
+	d.mu.RUnlock()
+	if !stale {
+		return nil
+	}
+
+	entries, err := d.source.Fetch()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.fetchedAt = now
+	if err != nil {
+		return err
+	}
+	d.entries = entries
+	return nil
+}
+
+// Len returns the number of ownership entries in use
+func (d *Directory) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.entries)
+}
+
+// Lookup returns the owner of the first entry matching the queue
+func (d *Directory) Lookup(queue string) (Owner, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, entry := range d.entries {
+		if entry.Matches(queue) {
+			return entry.Owner, true
+		}
+	}
+	return Owner{}, false
+}
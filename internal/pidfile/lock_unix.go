@@ -0,0 +1,22 @@
+//go:build !windows
+
+package pidfile
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// lockingSupported reports whether PID files are protected by file locks
+const lockingSupported = true
+
+// lock takes an exclusive flock on the file without blocking
+// Returns false when another process holds the lock
+func lock(file *os.File) (bool, error) {
+	err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -0,0 +1,14 @@
+//go:build windows
+
+package pidfile
+
+import "os"
+
+// lockingSupported reports whether PID files are protected by file locks
+// Windows relies on exclusive creation and the PID check alone
+const lockingSupported = false
+
+// lock always succeeds, as no lock is taken
+func lock(file *os.File) (bool, error) {
+	return true, nil
+}
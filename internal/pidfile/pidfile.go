@@ -1,7 +1,9 @@
 package pidfile
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -9,9 +11,18 @@ import (
 	"syscall"
 )
 
+// maxAttempts bounds the retries when the PID file is replaced or removed while it is being locked
+const maxAttempts = 5
+
+// errReplaced reports that the locked file is no longer the one at the path
+var errReplaced = errors.New("PID file replaced while locking")
+
 // PIDFile represents a PID file lock
+// The file stays open and locked while the instance runs, so the lock is released
+// by the kernel even when the process is killed
 type PIDFile struct {
 	path string
+	file *os.File
 }
 
 // New creates a new PID file at the specified path
@@ -21,30 +32,9 @@ func New(path string) *PIDFile {
 
 // Create creates and locks the PID file
 // Returns an error if another instance is already running
+// A new file is created exclusively (O_EXCL); an existing one is taken over when no running
+// instance holds its lock, so of several instances starting at once only one succeeds
 func (p *PIDFile) Create() error {
-	// Check if PID file already exists
-	if _, err := os.Stat(p.path); err == nil {
-		// File exists, check if process is still running
-		data, err := os.ReadFile(p.path)
-		if err != nil {
-			return fmt.Errorf("failed to read existing PID file: %w", err)
-		}
-
-		pidStr := strings.TrimSpace(string(data))
-		pid, err := strconv.Atoi(pidStr)
-		if err != nil {
-			// Invalid PID file, remove it
-			os.Remove(p.path)
-		} else {
-			// Check if process is still running
-			if isProcessRunning(pid) {
-				return fmt.Errorf("another instance is already running (PID: %d)", pid)
-			}
-			// Process is dead, remove stale PID file
-			os.Remove(p.path)
-		}
-	}
-
 	// Create directory if it doesn't exist
 	dir := filepath.Dir(p.path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -56,28 +46,126 @@ func (p *PIDFile) Create() error {
 		return fmt.Errorf("failed to create PID file directory: %w", err)
 	}
 
-	// Write current PID to file
-	pid := os.Getpid()
-	if err := os.WriteFile(p.path, []byte(fmt.Sprintf("%d\n", pid)), 0644); err != nil {
-		// If we can't write to /var/run, try /tmp as fallback
-		if strings.HasPrefix(p.path, "/var/run/") {
-			p.path = "/tmp/" + filepath.Base(p.path)
-			return p.Create() // Retry with /tmp path
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		file, err := p.open()
+		if errors.Is(err, errReplaced) {
+			continue
 		}
-		return fmt.Errorf("failed to write PID file: %w", err)
+		if err != nil {
+			// If we can't write to /var/run, try /tmp as fallback
+			if os.IsPermission(err) && strings.HasPrefix(p.path, "/var/run/") {
+				p.path = "/tmp/" + filepath.Base(p.path)
+				return p.Create() // Retry with /tmp path
+			}
+			return fmt.Errorf("failed to open PID file: %w", err)
+		}
+
+		err = p.acquire(file)
+		if errors.Is(err, errReplaced) {
+			file.Close()
+			continue
+		}
+		if err != nil {
+			file.Close()
+			return err
+		}
+		p.file = file
+		return nil
+	}
+	return fmt.Errorf("failed to lock PID file %s: it kept being replaced", p.path)
+}
+
+// open creates the PID file exclusively, or opens the existing one
+func (p *PIDFile) open() (*os.File, error) {
+	file, err := os.OpenFile(p.path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0644)
+	if os.IsExist(err) {
+		file, err = os.OpenFile(p.path, os.O_RDWR, 0644)
+		if os.IsNotExist(err) {
+			// Removed by its exiting owner in the meantime, create it on the next attempt
+			return nil, errReplaced
+		}
+	}
+	return file, err
+}
+
+// acquire locks an opened PID file and writes the current PID to it
+// Returns errReplaced when the file was removed or replaced before it was locked
+func (p *PIDFile) acquire(file *os.File) error {
+	locked, err := lock(file)
+	if err != nil {
+		return fmt.Errorf("failed to lock PID file: %w", err)
+	}
+	if !locked {
+		if pid, ok := readPID(file); ok {
+			return fmt.Errorf("another instance is already running (PID: %d)", pid)
+		}
+		return fmt.Errorf("another instance is already running (PID file %s is locked)", p.path)
+	}
+
+	// The owner may have removed the file between our open and lock, leaving us a lock nobody checks
+	opened, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat PID file: %w", err)
+	}
+	current, err := os.Stat(p.path)
+	if os.IsNotExist(err) || (err == nil && !os.SameFile(opened, current)) {
+		return errReplaced
+	}
+	if err != nil {
+		return fmt.Errorf("failed to stat PID file: %w", err)
 	}
 
+	// Without file locks, a file is only stale once its process is gone
+	if pid, ok := readPID(file); ok && pid != os.Getpid() && isProcessRunning(pid) && !lockingSupported {
+		return fmt.Errorf("another instance is already running (PID: %d)", pid)
+	}
+
+	// Take over a stale file or fill a new one
+	if err := file.Truncate(0); err != nil {
+		return fmt.Errorf("failed to write PID file: %w", err)
+	}
+	if _, err := file.WriteAt([]byte(fmt.Sprintf("%d\n", os.Getpid())), 0); err != nil {
+		return fmt.Errorf("failed to write PID file: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("failed to write PID file: %w", err)
+	}
 	return nil
 }
 
 // Remove removes the PID file
+// The file is removed before its lock is released, so a starting instance never locks a removed file unnoticed
 func (p *PIDFile) Remove() error {
+	if p.file == nil {
+		return nil
+	}
+	if lockingSupported {
+		defer p.file.Close()
+	} else {
+		// Open files cannot be removed on Windows, and there is no lock to hold until then
+		p.file.Close()
+	}
+	p.file = nil
 	if err := os.Remove(p.path); err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to remove PID file: %w", err)
 	}
 	return nil
 }
 
+// readPID returns the PID stored in a PID file
+func readPID(file *os.File) (int, bool) {
+	data := make([]byte, 32)
+	n, err := file.ReadAt(data, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data[:n])))
+	if err != nil || pid <= 0 {
+		return 0, false
+	}
+	return pid, true
+}
+
 // isProcessRunning checks if a process with the given PID is running
 func isProcessRunning(pid int) bool {
 	// Send signal 0 to check if process exists
@@ -114,6 +202,16 @@ func GetDefaultPath(configPath string) string {
 	return "/tmp/go-rmq-monitor.pid"
 }
 
+// GetInstancePath returns the default PID file path of a named instance, e.g. go-rmq-monitor-eu.pid
+// so instances on one host do not take each other for a running copy
+func GetInstancePath(configPath, instance string) string {
+	path := GetDefaultPath(configPath)
+	if instance == "" {
+		return path
+	}
+	return strings.TrimSuffix(path, ".pid") + "-" + instance + ".pid"
+}
+
 // isWritable checks if a directory is writable
 func isWritable(path string) bool {
 	// Try to create a temporary file to test write permissions
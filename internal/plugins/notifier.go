@@ -0,0 +1,60 @@
+package plugins
+
+import (
+	"fmt"
+
+	"go-rmq-monitor/internal/slack"
+	"go-rmq-monitor/pluginapi"
+)
+
+// Notifier adapts a plugin notifier to notify.Notifier
+// A panicking plugin fails the call instead of the monitor
+type Notifier struct {
+	name     string
+	notifier pluginapi.Notifier
+}
+
+// NewNotifier wraps the notifier of a loaded plugin
+func NewNotifier(p Plugin) *Notifier {
+	return &Notifier{name: p.Name, notifier: p.Notifier}
+}
+
+// Name identifies the plugin in logs and metrics
+func (n *Notifier) Name() string {
+	return n.name
+}
+
+// SendAlert delivers a queue alert through the plugin
+func (n *Notifier) SendAlert(alert slack.QueueAlert) (err error) {
+	defer recoverInto(&err)
+	return n.notifier.SendAlert(pluginapi.Alert{
+		Type:             string(alert.Type),
+		Queue:            alert.QueueName,
+		VHost:            alert.VHost,
+		Node:             alert.Node,
+		Priority:         alert.Priority,
+		Reason:           alert.Reason,
+		MessagesReady:    alert.MessagesReady,
+		Consumers:        alert.Consumers,
+		ConsumeRate:      alert.ConsumeRate,
+		AckRate:          alert.AckRate,
+		PublishRate:      alert.PublishRate,
+		ConsecutiveStuck: alert.ConsecutiveStuck,
+		Timestamp:        alert.Timestamp,
+		StuckDuration:    alert.StuckDuration,
+		IncidentID:       alert.IncidentID,
+	})
+}
+
+// Ping checks the plugin's endpoints
+func (n *Notifier) Ping() (err error) {
+	defer recoverInto(&err)
+	return n.notifier.Ping()
+}
+
+// recoverInto turns a plugin panic into an error
+func recoverInto(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("plugin panicked: %v", r)
+	}
+}
@@ -0,0 +1,61 @@
+// Package plugins loads the Go plugins declared in config
+package plugins
+
+import (
+	"fmt"
+	"plugin"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/pluginapi"
+)
+
+// symbolName is the variable a plugin exports its implementation as
+const symbolName = "Plugin"
+
+// Plugin is a loaded and initialized plugin
+type Plugin struct {
+	Name     string
+	Path     string
+	Detector pluginapi.Detector // nil if the plugin adds no detection check
+	Notifier pluginapi.Notifier // nil if the plugin delivers no alerts
+}
+
+// Load opens and initializes the configured plugins in order
+// Go plugins cannot be unloaded, so a plugin loaded twice shares its state
+func Load(cfgs []config.PluginConfig) ([]Plugin, error) {
+	loaded := make([]Plugin, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		p, err := load(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("plugin %s: %w", cfg.Name, err)
+		}
+		loaded = append(loaded, p)
+	}
+	return loaded, nil
+}
+
+func load(cfg config.PluginConfig) (Plugin, error) {
+	library, err := plugin.Open(cfg.Path)
+	if err != nil {
+		return Plugin{}, err
+	}
+	symbol, err := library.Lookup(symbolName)
+	if err != nil {
+		return Plugin{}, err
+	}
+	implementation, ok := symbol.(pluginapi.Plugin)
+	if !ok {
+		return Plugin{}, fmt.Errorf("exported %s does not implement pluginapi.Plugin (built against another version?)", symbolName)
+	}
+
+	p := Plugin{Name: cfg.Name, Path: cfg.Path}
+	p.Detector, _ = implementation.(pluginapi.Detector)
+	p.Notifier, _ = implementation.(pluginapi.Notifier)
+	if p.Detector == nil && p.Notifier == nil {
+		return Plugin{}, fmt.Errorf("exported %s implements neither pluginapi.Detector nor pluginapi.Notifier", symbolName)
+	}
+	if err := implementation.Init(cfg.Options); err != nil {
+		return Plugin{}, fmt.Errorf("init failed: %w", err)
+	}
+	return p, nil
+}
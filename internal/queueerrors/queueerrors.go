@@ -0,0 +1,181 @@
+// Package queueerrors remembers the last check error affecting each queue and the data gaps errors leave,
+// such as a failed management API call or a configured queue missing on the broker
+package queueerrors
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// MissingQueue is the error recorded for configured queues the broker does not report
+const MissingQueue = "queue not found on the broker"
+
+// Entry is the last error affecting a queue
+type Entry struct {
+	Queue     string
+	Error     string
+	At        time.Time // When the error last occurred
+	NoData    bool      // No data for the queue since the error
+	GapStart  time.Time // Last data before the ongoing or latest gap, or the first error if there was none
+	GapEnd    time.Time // When data returned after the latest gap, zero while it lasts
+	LastCheck time.Time // Last time data for the queue was received
+}
+
+// Gap returns the length of the ongoing or latest data gap as of now, zero if there was none
+func (e Entry) Gap(now time.Time) time.Duration {
+	if e.GapStart.IsZero() {
+		return 0
+	}
+	if e.NoData {
+		return now.Sub(e.GapStart)
+	}
+	return e.GapEnd.Sub(e.GapStart)
+}
+
+// Tracker records errors and data per queue
+type Tracker struct {
+	entries map[string]*Entry
+	seen    map[string]time.Time // Last data per queue, also for queues without errors
+	mu      sync.Mutex
+}
+
+// New creates an empty tracker
+func New() *Tracker {
+	return &Tracker{
+		entries: make(map[string]*Entry),
+		seen:    make(map[string]time.Time),
+	}
+}
+
+// Known returns the queues data was received for, which a failed queue listing affects
+func (t *Tracker) Known() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	queues := make([]string, 0, len(t.seen))
+	for queue := range t.seen {
+		queues = append(queues, queue)
+	}
+	sort.Strings(queues)
+	return queues
+}
+
+// Failed records an error that left queues without data, starting or extending their data gap
+// Returns the queues whose gap started with this error
+func (t *Tracker) Failed(queues []string, message string, now time.Time) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var started []string
+	for _, queue := range queues {
+		entry := t.entry(queue)
+		entry.Error = message
+		entry.At = now
+		if entry.NoData {
+			continue
+		}
+		entry.NoData = true
+		entry.GapStart = t.seen[queue]
+		if entry.GapStart.IsZero() {
+			entry.GapStart = now
+		}
+		entry.GapEnd = time.Time{}
+		started = append(started, queue)
+	}
+	return started
+}
+
+// Degraded records an error that cost a queue some detail but not its data, so it leaves no gap
+func (t *Tracker) Degraded(queue, message string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	entry := t.entry(queue)
+	entry.Error = message
+	entry.At = now
+}
+
+// Observed records data for queues and ends their data gaps
+// Returns the gaps that ended, by queue
+func (t *Tracker) Observed(queues []string, now time.Time) map[string]time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	gaps := make(map[string]time.Duration)
+	for _, queue := range queues {
+		t.seen[queue] = now
+		entry, exists := t.entries[queue]
+		if !exists {
+			continue
+		}
+		entry.LastCheck = now
+		if entry.NoData {
+			entry.NoData = false
+			entry.GapEnd = now
+			gaps[queue] = entry.Gap(now)
+		}
+	}
+	return gaps
+}
+
+// RecentGap returns the latest data gap of a queue if it ended within window before now
+func (t *Tracker) RecentGap(queue string, window time.Duration, now time.Time) (time.Duration, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	entry, exists := t.entries[queue]
+	if !exists || entry.NoData || entry.GapEnd.IsZero() || now.Sub(entry.GapEnd) > window {
+		return 0, false
+	}
+	return entry.Gap(now), true
+}
+
+// Get returns the last error of a queue
+func (t *Tracker) Get(queue string) (Entry, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	entry, exists := t.entries[queue]
+	if !exists {
+		return Entry{}, false
+	}
+	return *entry, true
+}
+
+// Entries returns the last error of every queue that had one, most recent first
+func (t *Tracker) Entries() []Entry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	entries := make([]Entry, 0, len(t.entries))
+	for _, entry := range t.entries {
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].At.Equal(entries[j].At) {
+			return entries[i].At.After(entries[j].At)
+		}
+		return entries[i].Queue < entries[j].Queue
+	})
+	return entries
+}
+
+// Forget drops queues no longer monitored, except those still failing
+func (t *Tracker) Forget(keep map[string]bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for queue := range t.seen {
+		if !keep[queue] {
+			delete(t.seen, queue)
+		}
+	}
+	for queue, entry := range t.entries {
+		if !keep[queue] && !entry.NoData {
+			delete(t.entries, queue)
+		}
+	}
+}
+
+// entry returns the entry of a queue, creating it (caller must hold the lock)
+func (t *Tracker) entry(queue string) *Entry {
+	entry, exists := t.entries[queue]
+	if !exists {
+		entry = &Entry{Queue: queue, LastCheck: t.seen[queue]}
+		t.entries[queue] = entry
+	}
+	return entry
+}
@@ -0,0 +1,137 @@
+// Package queuename shortens long, generated queue names for display in notifications and the dashboard
+// Full names are kept everywhere else, such as logs, metric labels, event payloads and Slack button values
+package queuename
+
+import (
+	"path"
+	"strings"
+	"unicode/utf8"
+)
+
+// separators split the segments of a queue name
+const separators = "-._:"
+
+// minHashLength is the shortest hex segment taken for a hash
+const minHashLength = 8
+
+// Alias gives queues matching a pattern a fixed display name
+type Alias struct {
+	Pattern string // Queue name or glob pattern, e.g. "amq.gen-*"
+	Name    string
+}
+
+// Namer maps queue names to display names
+// A nil Namer displays names unchanged
+type Namer struct {
+	aliases     []Alias
+	stripHashes bool
+	maxLength   int
+}
+
+// New creates a namer, or returns nil when no option changes names
+// maxLength is in characters, 0 for no limit
+func New(aliases []Alias, stripHashes bool, maxLength int) *Namer {
+	if len(aliases) == 0 && !stripHashes && maxLength <= 0 {
+		return nil
+	}
+	return &Namer{aliases: aliases, stripHashes: stripHashes, maxLength: maxLength}
+}
+
+// Name returns the display name of a queue: the first matching alias, otherwise
+// the name without hash suffixes, shortened in the middle to the maximum length
+func (n *Namer) Name(queue string) string {
+	if n == nil {
+		return queue
+	}
+	for _, alias := range n.aliases {
+		if matched, _ := path.Match(alias.Pattern, queue); matched {
+			return alias.Name
+		}
+	}
+	name := queue
+	if n.stripHashes {
+		name = StripHashes(name)
+	}
+	return Truncate(name, n.maxLength)
+}
+
+// Names returns the display names of queues
+func (n *Namer) Names(queues []string) []string {
+	if n == nil {
+		return queues
+	}
+	names := make([]string, len(queues))
+	for i, queue := range queues {
+		names[i] = n.Name(queue)
+	}
+	return names
+}
+
+// StripHashes removes trailing UUIDs and hex hashes of at least 8 characters, such as
+// "-5f2c9a1b" or ".3fa85f64-5717-4562-b3fc-2c963f66afa6", that clients append to queue names
+// A name that would be left empty is returned unchanged
+func StripHashes(name string) string {
+	for {
+		stripped := strings.TrimRight(name, separators)
+		if i := len(stripped) - 36; i > 0 && isUUID(stripped[i:]) && strings.ContainsRune(separators, rune(stripped[i-1])) {
+			stripped = stripped[:i-1]
+		} else if i := strings.LastIndexAny(stripped, separators); i > 0 && isHash(stripped[i+1:]) {
+			stripped = stripped[:i]
+		}
+		if stripped == name || strings.TrimRight(stripped, separators) == "" {
+			return name
+		}
+		name = stripped
+	}
+}
+
+// Truncate shortens a name longer than max characters by replacing its middle with "…",
+// keeping the start and the end, which usually tell queues apart; max 0 disables
+func Truncate(name string, max int) string {
+	if max <= 0 || utf8.RuneCountInString(name) <= max {
+		return name
+	}
+	runes := []rune(name)
+	head := max / 2
+	tail := max - head - 1
+	return string(runes[:head]) + "…" + string(runes[len(runes)-tail:])
+}
+
+// isHash reports whether a segment is hex of hash length mixing digits and letters,
+// so dates and numeric IDs such as "20240101" are kept
+func isHash(segment string) bool {
+	if len(segment) < minHashLength {
+		return false
+	}
+	digit, letter := false, false
+	for _, r := range segment {
+		switch {
+		case r >= '0' && r <= '9':
+			digit = true
+		case r >= 'a' && r <= 'f', r >= 'A' && r <= 'F':
+			letter = true
+		default:
+			return false
+		}
+	}
+	return digit && letter
+}
+
+// isUUID reports whether s is a UUID in its 8-4-4-4-12 hex form
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, r := range s {
+		if i == 8 || i == 13 || i == 18 || i == 23 {
+			if r != '-' {
+				return false
+			}
+			continue
+		}
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'f' || r >= 'A' && r <= 'F') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go-rmq-monitor/internal/config"
+)
+
+// amqpDialTimeout bounds connecting to the AMQP listener
+const amqpDialTimeout = 10 * time.Second
+
+// AMQPSession is a connection to the broker's AMQP listener with one channel in confirm mode,
+// used to publish and consume test messages the way applications do
+type AMQPSession struct {
+	conn    *amqp.Connection
+	channel *amqp.Channel
+}
+
+// DialAMQP connects to the AMQP listener of the configured broker and vhost
+func DialAMQP(cfg *config.RabbitMQConfig, connectionName string) (*AMQPSession, error) {
+	conn, err := amqp.DialConfig(cfg.GetAMQPURL(), amqp.Config{
+		Dial:       amqp.DefaultDial(amqpDialTimeout),
+		Properties: amqp.Table{"connection_name": connectionName},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to AMQP: %w", err)
+	}
+	channel, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to open AMQP channel: %w", err)
+	}
+	if err := channel.Confirm(false); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+	return &AMQPSession{conn: conn, channel: channel}, nil
+}
+
+// Publish publishes a text message to a queue through the default exchange
+// and waits up to timeout for the broker to confirm it
+func (s *AMQPSession) Publish(queueName, payload string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	confirmation, err := s.channel.PublishWithDeferredConfirmWithContext(ctx, "", queueName, false, false, amqp.Publishing{
+		ContentType: "text/plain",
+		Body:        []byte(payload),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish to %s: %w", queueName, err)
+	}
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("publish to %s not confirmed: %w", queueName, err)
+	}
+	if !acked {
+		return fmt.Errorf("broker rejected the message published to %s", queueName)
+	}
+	return nil
+}
+
+// Consume attaches a consumer to a queue, waits up to timeout for a message, acknowledges it
+// and returns its payload; the consumer is cancelled before returning
+func (s *AMQPSession) Consume(queueName, consumerTag string, timeout time.Duration) (string, error) {
+	if err := s.channel.Qos(1, 0, false); err != nil {
+		return "", fmt.Errorf("failed to set prefetch: %w", err)
+	}
+	deliveries, err := s.channel.Consume(queueName, consumerTag, false, false, false, false, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to consume from %s: %w", queueName, err)
+	}
+	defer s.channel.Cancel(consumerTag, false)
+
+	select {
+	case delivery, ok := <-deliveries:
+		if !ok {
+			return "", fmt.Errorf("consumer on %s closed by the broker", queueName)
+		}
+		if err := delivery.Ack(false); err != nil {
+			return "", fmt.Errorf("failed to acknowledge the message: %w", err)
+		}
+		return string(delivery.Body), nil
+	case <-time.After(timeout):
+		return "", fmt.Errorf("no message delivered from %s within %s", queueName, timeout)
+	}
+}
+
+// Close closes the channel and the connection
+func (s *AMQPSession) Close() error {
+	s.channel.Close()
+	return s.conn.Close()
+}
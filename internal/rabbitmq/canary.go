@@ -0,0 +1,54 @@
+package rabbitmq
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// declareQueueRequest is the body of the management API queue declaration endpoint
+type declareQueueRequest struct {
+	Durable    bool                   `json:"durable"`
+	AutoDelete bool                   `json:"auto_delete"`
+	Arguments  map[string]interface{} `json:"arguments"`
+}
+
+// DeclareCanaryQueue declares a transient queue for end-to-end tests
+// The broker deletes the queue after it has been unused for expires, should it be left behind
+func (c *Client) DeclareCanaryQueue(queueName string, expires time.Duration) error {
+	body, err := json.Marshal(declareQueueRequest{
+		Arguments: map[string]interface{}{"x-expires": expires.Milliseconds()},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPut, c.client.Endpoint+c.queuePath(queueName), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if err := c.managementDo(req, nil); err != nil {
+		return fmt.Errorf("failed to declare queue %s: %w", queueName, err)
+	}
+	return nil
+}
+
+// DeleteQueue deletes a queue and any messages in it
+func (c *Client) DeleteQueue(queueName string) error {
+	req, err := http.NewRequest(http.MethodDelete, c.client.Endpoint+c.queuePath(queueName), nil)
+	if err != nil {
+		return err
+	}
+	if err := c.managementDo(req, nil); err != nil {
+		return fmt.Errorf("failed to delete queue %s: %w", queueName, err)
+	}
+	return nil
+}
+
+// queuePath returns the management API path of a queue in the client's vhost
+func (c *Client) queuePath(queueName string) string {
+	return fmt.Sprintf("/api/queues/%s/%s", url.PathEscape(c.vhost), url.PathEscape(queueName))
+}
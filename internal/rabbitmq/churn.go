@@ -0,0 +1,33 @@
+package rabbitmq
+
+import "fmt"
+
+// QueueChurn is the cluster-wide rate queues are declared, created and deleted at, per second
+type QueueChurn struct {
+	DeclaredRate float64
+	CreatedRate  float64
+	DeletedRate  float64
+}
+
+// overviewChurn is the part of the management API overview holding churn rates
+type overviewChurn struct {
+	ChurnRates struct {
+		QueueDeclaredDetails rateDetails `json:"queue_declared_details"`
+		QueueCreatedDetails  rateDetails `json:"queue_created_details"`
+		QueueDeletedDetails  rateDetails `json:"queue_deleted_details"`
+	} `json:"churn_rates"`
+}
+
+// GetQueueChurn returns the queue churn rates of the cluster
+// The broker measures them continuously, so queues living shorter than a check interval are counted too
+func (c *Client) GetQueueChurn() (*QueueChurn, error) {
+	var overview overviewChurn
+	if err := c.managementGet("/api/overview", &overview); err != nil {
+		return nil, fmt.Errorf("failed to get churn rates: %w", err)
+	}
+	return &QueueChurn{
+		DeclaredRate: overview.ChurnRates.QueueDeclaredDetails.Rate,
+		CreatedRate:  overview.ChurnRates.QueueCreatedDetails.Rate,
+		DeletedRate:  overview.ChurnRates.QueueDeletedDetails.Rate,
+	}, nil
+}
@@ -1,7 +1,12 @@
 package rabbitmq
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
 	rabbithole "github.com/michaelklishin/rabbit-hole/v3"
 	"go-rmq-monitor/internal/config"
@@ -17,13 +22,48 @@ type Client struct {
 type QueueInfo struct {
 	Name            string
 	VHost           string
+	Type            string // classic, quorum or stream
 	MessagesReady   int
 	Messages        int
+	MessagesUnacked int
 	Consumers       int
 	ConsumeRate     float64
 	AckRate         float64
 	PublishRate     float64
-	State           string
+	RedeliverRate   float64
+	// Share of time consumers could take deliveries (0-1), -1 if not reported
+	ConsumerUtilisation float64
+	PriorityLengths     map[int]int // Ready messages per priority, only for tracked priority queues
+	State               string
+	IdleSince           time.Time // Broker time the queue became idle, zero while active
+	Exclusive           bool      // Owned by a single connection, deleted when it closes
+	AutoDelete          bool      // Deleted once its last consumer leaves
+	// Per-message TTL from the x-message-ttl argument or a message-ttl policy, 0 if unset
+	MessageTTL time.Duration
+	// Messages expired per second, measured from the queue's expiry tracking queue
+	ExpireRate    float64
+	ExpiryTracked bool // ExpireRate was measured rather than left for estimation
+	// Consumers from identities the queue's rule does not allow, and allowed identities without consumers
+	IdentityViolations []string
+	Node               string // Node hosting the queue, the leader of a replicated queue
+}
+
+// NodeInfo contains relevant broker node status
+type NodeInfo struct {
+	Name          string
+	Running       bool
+	MemAlarm      bool
+	DiskFreeAlarm bool
+	Uptime        uint64 // Milliseconds since node start
+}
+
+// PolicyInfo contains a policy definition summary
+type PolicyInfo struct {
+	Name       string
+	Pattern    string
+	ApplyTo    string
+	Priority   int
+	Definition string // Definition serialized for change comparison
 }
 
 // NewClient creates a new RabbitMQ API client
@@ -46,6 +86,36 @@ func NewClient(cfg *config.RabbitMQConfig) (*Client, error) {
 	}, nil
 }
 
+// SetCredentials replaces the username and password used for later requests, such as after a password rotation
+// Must not be called while requests are in flight
+func (c *Client) SetCredentials(username, password string) {
+	c.client.Username = username
+	c.client.Password = password
+}
+
+// IsUnauthorized reports whether a request failed because the management API rejected the credentials
+// rabbit-hole reports a 401 as a plain error rather than an ErrorResponse, so the message is matched
+func IsUnauthorized(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "401 Unauthorized")
+}
+
+// IsPermissionDenied reports whether the management API refused a request because the user lacks
+// a tag or vhost permission for the endpoint, such as a management user reading /api/nodes
+func IsPermissionDenied(err error) bool {
+	if err == nil {
+		return false
+	}
+	var response rabbithole.ErrorResponse
+	if errors.As(err, &response) {
+		return response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden
+	}
+	var status statusError
+	if errors.As(err, &status) {
+		return status.code == http.StatusUnauthorized || status.code == http.StatusForbidden
+	}
+	return IsUnauthorized(err)
+}
+
 // GetQueues returns information about all queues in the vhost
 func (c *Client) GetQueues() ([]QueueInfo, error) {
 	// Pass vhost directly - rabbit-hole library handles URL encoding internally
@@ -54,9 +124,13 @@ func (c *Client) GetQueues() ([]QueueInfo, error) {
 		return nil, fmt.Errorf("failed to list queues: %w", err)
 	}
 
+	// Fields rabbit-hole does not decode; without them TTLs come from queue arguments only
+	// and idle_since is unknown, which leaves it out of the clock skew check
+	extras, _ := c.listQueueExtras()
+
 	result := make([]QueueInfo, 0, len(queues))
 	for _, q := range queues {
-		info := c.convertQueueInfo(&q)
+		info := c.convertQueueInfo(&q, extras[q.Name])
 		result = append(result, info)
 	}
 
@@ -71,62 +145,155 @@ func (c *Client) GetQueue(queueName string) (*QueueInfo, error) {
 		return nil, fmt.Errorf("failed to get queue %s: %w", queueName, err)
 	}
 
-	info := c.convertDetailedQueueInfo(queue)
+	extras, _ := c.getQueueExtras(queueName)
+	info := c.convertDetailedQueueInfo(queue, extras)
 	return &info, nil
 }
 
+// GetNodes returns status information about all cluster nodes
+func (c *Client) GetNodes() ([]NodeInfo, error) {
+	nodes, err := c.client.ListNodes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	result := make([]NodeInfo, 0, len(nodes))
+	for _, n := range nodes {
+		result = append(result, NodeInfo{
+			Name:          n.Name,
+			Running:       n.IsRunning,
+			MemAlarm:      n.MemAlarm,
+			DiskFreeAlarm: n.DiskFreeAlarm,
+			Uptime:        n.Uptime,
+		})
+	}
+
+	return result, nil
+}
+
+// GetPolicies returns the policies defined in the vhost
+func (c *Client) GetPolicies() ([]PolicyInfo, error) {
+	policies, err := c.client.ListPoliciesIn(c.vhost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list policies: %w", err)
+	}
+
+	result := make([]PolicyInfo, 0, len(policies))
+	for _, p := range policies {
+		definition, _ := json.Marshal(p.Definition)
+		result = append(result, PolicyInfo{
+			Name:       p.Name,
+			Pattern:    p.Pattern,
+			ApplyTo:    p.ApplyTo,
+			Priority:   p.Priority,
+			Definition: string(definition),
+		})
+	}
+
+	return result, nil
+}
+
 // convertQueueInfo converts rabbithole.QueueInfo to our QueueInfo
-func (c *Client) convertQueueInfo(q *rabbithole.QueueInfo) QueueInfo {
+func (c *Client) convertQueueInfo(q *rabbithole.QueueInfo, extras queueExtras) QueueInfo {
 	info := QueueInfo{
-		Name:          q.Name,
-		VHost:         q.Vhost,
-		MessagesReady: q.MessagesReady,
-		Messages:      q.Messages,
-		Consumers:     q.Consumers,
-		State:         "",
+		Name:            q.Name,
+		VHost:           q.Vhost,
+		Type:            q.Type,
+		MessagesReady:   q.MessagesReady,
+		Messages:        q.Messages,
+		MessagesUnacked: q.MessagesUnacknowledged,
+		Consumers:       q.Consumers,
+		State:           "",
+		IdleSince:       parseIdleSince(extras.IdleSince),
+		Exclusive:       q.Exclusive,
+		AutoDelete:      bool(q.AutoDelete),
 	}
+	info.ConsumerUtilisation = consumerUtilisation(q.Consumers, q.ConsumerUtilisation)
+	info.MessageTTL = messageTTL(q.Arguments, extras.EffectivePolicyDefinition)
+	info.Node = homeNode(q.Node, q.Leader)
 
 	// Extract rates from message stats
 	if q.MessageStats != nil {
 		info.ConsumeRate = float64(q.MessageStats.DeliverGetDetails.Rate)
 		info.AckRate = float64(q.MessageStats.AckDetails.Rate)
 		info.PublishRate = float64(q.MessageStats.PublishDetails.Rate)
+		info.RedeliverRate = float64(q.MessageStats.RedeliverDetails.Rate)
 	}
 
 	return info
 }
 
 // convertDetailedQueueInfo converts rabbithole.DetailedQueueInfo to our QueueInfo
-func (c *Client) convertDetailedQueueInfo(q *rabbithole.DetailedQueueInfo) QueueInfo {
+func (c *Client) convertDetailedQueueInfo(q *rabbithole.DetailedQueueInfo, extras queueExtras) QueueInfo {
 	info := QueueInfo{
-		Name:          q.Name,
-		VHost:         q.Vhost,
-		MessagesReady: q.MessagesReady,
-		Messages:      q.Messages,
-		Consumers:     q.Consumers,
-		State:         "", // State field not available in v3
+		Name:            q.Name,
+		VHost:           q.Vhost,
+		Type:            q.Type,
+		MessagesReady:   q.MessagesReady,
+		Messages:        q.Messages,
+		MessagesUnacked: q.MessagesUnacknowledged,
+		Consumers:       q.Consumers,
+		State:           "", // State field not available in v3
+		IdleSince:       parseIdleSince(extras.IdleSince),
+		Exclusive:       q.Exclusive,
+		AutoDelete:      bool(q.AutoDelete),
 	}
+	info.ConsumerUtilisation = consumerUtilisation(q.Consumers, q.ConsumerUtilisation)
+	info.MessageTTL = messageTTL(q.Arguments, extras.EffectivePolicyDefinition)
+	info.Node = homeNode(q.Node, q.Leader)
 
 	// Extract rates from message stats
 	if q.MessageStats != nil {
 		info.ConsumeRate = float64(q.MessageStats.DeliverGetDetails.Rate)
 		info.AckRate = float64(q.MessageStats.AckDetails.Rate)
 		info.PublishRate = float64(q.MessageStats.PublishDetails.Rate)
+		info.RedeliverRate = float64(q.MessageStats.RedeliverDetails.Rate)
 	}
 
 	return info
 }
 
+// consumerUtilisation normalizes the reported utilisation, which is omitted without consumers
+func consumerUtilisation(consumers int, utilisation float64) float64 {
+	if consumers == 0 {
+		return -1
+	}
+	return utilisation
+}
+
+// messageTTL returns the per-message TTL in effect for a queue
+// Like the broker, the lower of the queue argument and the policy value applies
+func messageTTL(arguments, policy map[string]interface{}) time.Duration {
+	var ttl time.Duration
+	for _, value := range []interface{}{arguments["x-message-ttl"], policy["message-ttl"]} {
+		milliseconds, ok := value.(float64)
+		if !ok || milliseconds < 0 {
+			continue
+		}
+		if candidate := time.Duration(milliseconds) * time.Millisecond; ttl == 0 || candidate < ttl {
+			ttl = candidate
+		}
+	}
+	return ttl
+}
+
 // FilterQueues returns only the queues specified in the filter list
-// If the filter list is empty, returns all queues
-func FilterQueues(allQueues []QueueInfo, filter []config.QueueConfig) []QueueInfo {
+// If the filter list is empty, returns all queues except the auto-created ones exclude leaves out
+// Queues that are disabled in config are never returned
+func FilterQueues(allQueues []QueueInfo, filter []config.QueueConfig, exclude config.AutoExcludeConfig) []QueueInfo {
 	if len(filter) == 0 {
-		return allQueues
+		result := make([]QueueInfo, 0, len(allQueues))
+		for _, q := range allQueues {
+			if !exclude.Excludes(q.Name, q.Exclusive, q.AutoDelete) {
+				result = append(result, q)
+			}
+		}
+		return result
 	}
 
 	filterMap := make(map[string]bool)
 	for _, qCfg := range filter {
-		filterMap[qCfg.Name] = true
+		filterMap[qCfg.Name] = qCfg.IsEnabled()
 	}
 
 	result := make([]QueueInfo, 0)
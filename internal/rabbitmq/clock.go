@@ -0,0 +1,73 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// idleSinceLayouts are the idle_since formats of the management API
+// Older brokers omit the zone, such timestamps are read as UTC
+var idleSinceLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.000-07:00",
+	"2006-01-02 15:04:05",
+}
+
+// parseIdleSince parses a queue's idle_since, returning the zero time if it is unset or unknown
+func parseIdleSince(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	for _, layout := range idleSinceLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// ClockReading compares the broker clock with the local clock
+type ClockReading struct {
+	BrokerTime time.Time
+	Skew       time.Duration // Broker clock minus local clock
+	// Error margin of Skew: the Date header has second resolution and the request takes time
+	Uncertainty time.Duration
+}
+
+// GetClockReading reads the broker clock from the Date header of a management API response
+// The header is set on every response, so the status of the request does not matter
+func (c *Client) GetClockReading() (*ClockReading, error) {
+	req, err := http.NewRequest(http.MethodGet, c.client.Endpoint+"/api/whoami", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(c.client.Username, c.client.Password)
+
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	sent := time.Now()
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read broker clock: %w", err)
+	}
+	received := time.Now()
+	resp.Body.Close()
+
+	date := resp.Header.Get("Date")
+	if date == "" {
+		return nil, fmt.Errorf("management API response has no Date header")
+	}
+	brokerTime, err := http.ParseTime(date)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Date header %q: %w", date, err)
+	}
+
+	// The header truncates to the second, so the broker time lies within the following second
+	roundTrip := received.Sub(sent)
+	local := sent.Add(roundTrip / 2)
+	return &ClockReading{
+		BrokerTime:  brokerTime,
+		Skew:        brokerTime.Add(500 * time.Millisecond).Sub(local),
+		Uncertainty: 500*time.Millisecond + roundTrip/2,
+	}, nil
+}
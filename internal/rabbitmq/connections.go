@@ -0,0 +1,43 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Protocol families of plugin connections
+const (
+	ProtocolAMQP  = "amqp"
+	ProtocolMQTT  = "mqtt"
+	ProtocolSTOMP = "stomp"
+)
+
+// GetConnectionCounts returns the number of open connections in the vhost per protocol family
+func (c *Client) GetConnectionCounts() (map[string]int, error) {
+	connections, err := c.client.ListConnections()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list connections: %w", err)
+	}
+
+	counts := make(map[string]int)
+	for _, connection := range connections {
+		if connection.Vhost != c.vhost {
+			continue
+		}
+		counts[protocolFamily(connection.Protocol)]++
+	}
+	return counts, nil
+}
+
+// protocolFamily maps a reported protocol such as "MQTT 3-1-1" or "Web STOMP 1.2" to its family
+func protocolFamily(protocol string) string {
+	lower := strings.ToLower(protocol)
+	switch {
+	case strings.Contains(lower, ProtocolMQTT):
+		return ProtocolMQTT
+	case strings.Contains(lower, ProtocolSTOMP):
+		return ProtocolSTOMP
+	default:
+		return ProtocolAMQP
+	}
+}
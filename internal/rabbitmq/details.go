@@ -0,0 +1,237 @@
+package rabbitmq
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ConsumerDetail describes a consumer attached to a queue and its connection
+type ConsumerDetail struct {
+	ConsumerTag    string
+	ConnectionName string
+	ChannelNumber  int
+	PeerHost       string
+	PeerPort       int
+	User           string
+	PrefetchCount  int
+	AckRequired    bool
+}
+
+// MessageInfo describes a message at the head of a queue
+// The payload is never retrieved, only its size
+type MessageInfo struct {
+	Exchange     string
+	RoutingKey   string
+	Redelivered  bool
+	PayloadBytes int
+	MessageID    string
+	ContentType  string
+	Headers      map[string]interface{}
+}
+
+// queueExtrasColumns limits queue listings to the fields in queueExtras
+const queueExtrasColumns = "name,idle_since,effective_policy_definition"
+
+// queueExtras holds queue fields the management API reports but rabbit-hole does not decode
+type queueExtras struct {
+	Name                      string                 `json:"name"`
+	IdleSince                 string                 `json:"idle_since"`
+	EffectivePolicyDefinition map[string]interface{} `json:"effective_policy_definition"`
+}
+
+// listQueueExtras returns the extra fields of every queue in the vhost, by queue name
+func (c *Client) listQueueExtras() (map[string]queueExtras, error) {
+	var queues []queueExtras
+	path := fmt.Sprintf("/api/queues/%s?columns=%s", url.PathEscape(c.vhost), queueExtrasColumns)
+	if err := c.managementGet(path, &queues); err != nil {
+		return nil, err
+	}
+	result := make(map[string]queueExtras, len(queues))
+	for _, queue := range queues {
+		result[queue.Name] = queue
+	}
+	return result, nil
+}
+
+// getQueueExtras returns the extra fields of a queue
+func (c *Client) getQueueExtras(queueName string) (queueExtras, error) {
+	var queue queueExtras
+	path := fmt.Sprintf("/api/queues/%s/%s?columns=%s", url.PathEscape(c.vhost), url.PathEscape(queueName), queueExtrasColumns)
+	err := c.managementGet(path, &queue)
+	return queue, err
+}
+
+// GetConsumers returns the consumers attached to a queue
+func (c *Client) GetConsumers(queueName string) ([]ConsumerDetail, error) {
+	consumers, err := c.client.ListConsumersIn(c.vhost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list consumers: %w", err)
+	}
+
+	result := make([]ConsumerDetail, 0)
+	for _, consumer := range consumers {
+		if consumer.Queue.Name != queueName {
+			continue
+		}
+		result = append(result, ConsumerDetail{
+			ConsumerTag:    consumer.ConsumerTag,
+			ConnectionName: consumer.ChannelDetails.ConnectionName,
+			ChannelNumber:  consumer.ChannelDetails.Number,
+			PeerHost:       consumer.ChannelDetails.PeerHost,
+			PeerPort:       int(consumer.ChannelDetails.PeerPort),
+			User:           consumer.ChannelDetails.User,
+			PrefetchCount:  consumer.PrefetchCount,
+			AckRequired:    bool(consumer.AcknowledgementMode),
+		})
+	}
+
+	return result, nil
+}
+
+// ConnectionActivity contains the traffic of a client connection
+type ConnectionActivity struct {
+	Name     string
+	PeerHost string
+	State    string  // running, blocked, flow, ...
+	RecvRate float64 // Bytes per second received from the client
+	SendRate float64 // Bytes per second sent to the client
+}
+
+// connectionResponse holds the connection fields used for activity
+type connectionResponse struct {
+	Name           string `json:"name"`
+	PeerHost       string `json:"peer_host"`
+	State          string `json:"state"`
+	RecvOctDetails struct {
+		Rate float64 `json:"rate"`
+	} `json:"recv_oct_details"`
+	SendOctDetails struct {
+		Rate float64 `json:"rate"`
+	} `json:"send_oct_details"`
+}
+
+// GetConnectionActivity returns the current traffic rates of a connection
+func (c *Client) GetConnectionActivity(connectionName string) (*ConnectionActivity, error) {
+	var connection connectionResponse
+	if err := c.managementGet("/api/connections/"+url.PathEscape(connectionName), &connection); err != nil {
+		return nil, fmt.Errorf("failed to get connection %s: %w", connectionName, err)
+	}
+	return &ConnectionActivity{
+		Name:     connection.Name,
+		PeerHost: connection.PeerHost,
+		State:    connection.State,
+		RecvRate: connection.RecvOctDetails.Rate,
+		SendRate: connection.SendOctDetails.Rate,
+	}, nil
+}
+
+// getMessagesRequest is the body of the management API get messages endpoint
+type getMessagesRequest struct {
+	Count    int    `json:"count"`
+	AckMode  string `json:"ackmode"`
+	Encoding string `json:"encoding"`
+	Truncate int    `json:"truncate,omitempty"`
+}
+
+// getMessagesResponse is a single message returned by the get messages endpoint
+type getMessagesResponse struct {
+	Exchange     string `json:"exchange"`
+	RoutingKey   string `json:"routing_key"`
+	Redelivered  bool   `json:"redelivered"`
+	PayloadBytes int    `json:"payload_bytes"`
+	Properties   struct {
+		MessageID   string                 `json:"message_id"`
+		ContentType string                 `json:"content_type"`
+		Headers     map[string]interface{} `json:"headers"`
+	} `json:"properties"`
+}
+
+// PeekMessages returns up to count messages from the head of a queue
+// Messages are requeued; the payload is truncated to a single byte and discarded
+func (c *Client) PeekMessages(queueName string, count int) ([]MessageInfo, error) {
+	body, err := json.Marshal(getMessagesRequest{
+		Count:    count,
+		AckMode:  "ack_requeue_true",
+		Encoding: "auto",
+		Truncate: 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	path := fmt.Sprintf("/api/queues/%s/%s/get", url.PathEscape(c.vhost), url.PathEscape(queueName))
+	var messages []getMessagesResponse
+	if err := c.managementPost(path, body, &messages); err != nil {
+		return nil, fmt.Errorf("failed to peek messages in %s: %w", queueName, err)
+	}
+
+	result := make([]MessageInfo, 0, len(messages))
+	for _, message := range messages {
+		result = append(result, MessageInfo{
+			Exchange:     message.Exchange,
+			RoutingKey:   message.RoutingKey,
+			Redelivered:  message.Redelivered,
+			PayloadBytes: message.PayloadBytes,
+			MessageID:    message.Properties.MessageID,
+			ContentType:  message.Properties.ContentType,
+			Headers:      message.Properties.Headers,
+		})
+	}
+
+	return result, nil
+}
+
+// managementPost sends a POST request to a management API path not covered by rabbit-hole
+func (c *Client) managementPost(path string, body []byte, result interface{}) error {
+	req, err := http.NewRequest(http.MethodPost, c.client.Endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	return c.managementDo(req, result)
+}
+
+// managementGet sends a GET request to a management API path not covered by rabbit-hole
+func (c *Client) managementGet(path string, result interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, c.client.Endpoint+path, nil)
+	if err != nil {
+		return err
+	}
+	return c.managementDo(req, result)
+}
+
+// statusError is a management API request answered with an error status
+type statusError struct {
+	code    int
+	message string
+}
+
+func (e statusError) Error() string {
+	return fmt.Sprintf("management API returned %d: %s", e.code, e.message)
+}
+
+// managementDo authenticates and sends a management API request, decoding the JSON response
+func (c *Client) managementDo(req *http.Request, result interface{}) error {
+	req.SetBasicAuth(c.client.Username, c.client.Password)
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return statusError{code: resp.StatusCode, message: string(bytes.TrimSpace(message))}
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
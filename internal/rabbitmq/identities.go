@@ -0,0 +1,48 @@
+package rabbitmq
+
+import "fmt"
+
+// ConsumerIdentity identifies the client behind a consumer
+type ConsumerIdentity struct {
+	Application string // Client-provided connection name, empty if the client sets none
+	User        string
+	PeerHost    string
+}
+
+// String describes the identity, e.g. "orders-worker (orders@10.0.0.5)"
+func (i ConsumerIdentity) String() string {
+	if i.Application == "" {
+		return fmt.Sprintf("%s@%s", i.User, i.PeerHost)
+	}
+	return fmt.Sprintf("%s (%s@%s)", i.Application, i.User, i.PeerHost)
+}
+
+// GetConsumerIdentities returns the identities of the consumers of every queue in the vhost, by queue name
+func (c *Client) GetConsumerIdentities() (map[string][]ConsumerIdentity, error) {
+	consumers, err := c.client.ListConsumersIn(c.vhost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list consumers: %w", err)
+	}
+	connections, err := c.client.ListVhostConnections(c.vhost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list connections: %w", err)
+	}
+
+	applications := make(map[string]string, len(connections))
+	for _, connection := range connections {
+		if name, ok := connection.ClientProperties["connection_name"].(string); ok {
+			applications[connection.Name] = name
+		}
+	}
+
+	result := make(map[string][]ConsumerIdentity)
+	for _, consumer := range consumers {
+		details := consumer.ChannelDetails
+		result[consumer.Queue.Name] = append(result[consumer.Queue.Name], ConsumerIdentity{
+			Application: applications[details.ConnectionName],
+			User:        details.User,
+			PeerHost:    details.PeerHost,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,33 @@
+package rabbitmq
+
+import "fmt"
+
+// nodeDrainState is the maintenance status of a node as reported by /api/nodes
+type nodeDrainState struct {
+	Name         string `json:"name"`
+	BeingDrained bool   `json:"being_drained"`
+}
+
+// GetDrainingNodes returns the nodes in maintenance mode, which are being drained for an upgrade
+// Brokers before 3.8 have no maintenance mode and report no node as draining
+func (c *Client) GetDrainingNodes() (map[string]bool, error) {
+	var nodes []nodeDrainState
+	if err := c.managementGet("/api/nodes?columns=name,being_drained", &nodes); err != nil {
+		return nil, fmt.Errorf("failed to get node maintenance status: %w", err)
+	}
+	draining := make(map[string]bool)
+	for _, node := range nodes {
+		if node.BeingDrained {
+			draining[node.Name] = true
+		}
+	}
+	return draining, nil
+}
+
+// homeNode returns the node a queue lives on: the leader of a replicated queue, else its node
+func homeNode(node, leader string) string {
+	if leader != "" {
+		return leader
+	}
+	return node
+}
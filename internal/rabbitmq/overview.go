@@ -0,0 +1,58 @@
+package rabbitmq
+
+import "fmt"
+
+// ClusterOverview summarizes broker-wide load
+type ClusterOverview struct {
+	Connections        int
+	Channels           int
+	Queues             int
+	Consumers          int
+	Messages           int
+	MessagesReady      int
+	MessagesUnacked    int
+	PublishRate        float64
+	DeliverRate        float64
+	AckRate            float64
+	ErlangProcesses    int // Summed across running nodes
+	ErlangProcessLimit int // Summed across running nodes
+	Nodes              int
+	RunningNodes       int
+}
+
+// GetClusterOverview returns object totals, message rates and Erlang process usage
+func (c *Client) GetClusterOverview() (*ClusterOverview, error) {
+	overview, err := c.client.Overview()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overview: %w", err)
+	}
+
+	nodes, err := c.client.ListNodes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	result := &ClusterOverview{
+		Connections:     overview.ObjectTotals.Connections,
+		Channels:        overview.ObjectTotals.Channels,
+		Queues:          overview.ObjectTotals.Queues,
+		Consumers:       overview.ObjectTotals.Consumers,
+		Messages:        overview.QueueTotals.Messages,
+		MessagesReady:   overview.QueueTotals.MessagesReady,
+		MessagesUnacked: overview.QueueTotals.MessagesUnacknowledged,
+		PublishRate:     float64(overview.MessageStats.PublishDetails.Rate),
+		DeliverRate:     float64(overview.MessageStats.DeliverGetDetails.Rate),
+		AckRate:         float64(overview.MessageStats.AckDetails.Rate),
+		Nodes:           len(nodes),
+	}
+	for _, node := range nodes {
+		if !node.IsRunning {
+			continue
+		}
+		result.RunningNodes++
+		result.ErlangProcesses += node.ProcUsed
+		result.ErlangProcessLimit += node.ProcTotal
+	}
+
+	return result, nil
+}
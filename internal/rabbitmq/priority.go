@@ -0,0 +1,48 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// priorityQueueStatus holds the parts of a queue's details needed for priority bands
+type priorityQueueStatus struct {
+	BackingQueueStatus struct {
+		PriorityLengths map[string]int `json:"priority_lengths"`
+	} `json:"backing_queue_status"`
+}
+
+// GetPriorityLengths returns the number of ready messages per priority for a classic priority queue
+// Returns nil if the broker does not report priority bands for the queue
+func (c *Client) GetPriorityLengths(queueName string) (map[int]int, error) {
+	path := fmt.Sprintf("/api/queues/%s/%s?columns=backing_queue_status", url.PathEscape(c.vhost), url.PathEscape(queueName))
+	var status priorityQueueStatus
+	if err := c.managementGet(path, &status); err != nil {
+		return nil, fmt.Errorf("failed to get priority lengths for %s: %w", queueName, err)
+	}
+
+	if len(status.BackingQueueStatus.PriorityLengths) == 0 {
+		return nil, nil
+	}
+	lengths := make(map[int]int, len(status.BackingQueueStatus.PriorityLengths))
+	for priority, length := range status.BackingQueueStatus.PriorityLengths {
+		p, err := strconv.Atoi(priority)
+		if err != nil {
+			continue
+		}
+		lengths[p] = length
+	}
+	return lengths, nil
+}
+
+// DepthAtOrAbove sums the messages with a priority of at least minPriority
+func DepthAtOrAbove(lengths map[int]int, minPriority int) int {
+	depth := 0
+	for priority, length := range lengths {
+		if priority >= minPriority {
+			depth += length
+		}
+	}
+	return depth
+}
@@ -0,0 +1,132 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// QuarantineHeader records the queue a quarantined message was moved from
+const QuarantineHeader = "x-quarantined-from"
+
+// movedMessage is a message fetched with its payload so it can be republished
+type movedMessage struct {
+	Exchange        string                 `json:"exchange"`
+	RoutingKey      string                 `json:"routing_key"`
+	Redelivered     bool                   `json:"redelivered"`
+	Payload         string                 `json:"payload"`
+	PayloadBytes    int                    `json:"payload_bytes"`
+	PayloadEncoding string                 `json:"payload_encoding"`
+	Properties      map[string]interface{} `json:"properties"`
+}
+
+// publishRequest is the body of the management API publish endpoint
+type publishRequest struct {
+	Properties      map[string]interface{} `json:"properties"`
+	RoutingKey      string                 `json:"routing_key"`
+	Payload         string                 `json:"payload"`
+	PayloadEncoding string                 `json:"payload_encoding"`
+}
+
+// MoveMessages moves up to count messages from the head of a queue to the target queue
+// Messages are published through the default exchange; any message that cannot be
+// delivered to the target is published back to the source queue
+func (c *Client) MoveMessages(queueName, target string, count int) ([]MessageInfo, error) {
+	// Check the target first so messages are never taken without somewhere to put them
+	if _, err := c.client.GetQueue(c.vhost, target); err != nil {
+		return nil, fmt.Errorf("quarantine queue %s is not available: %w", target, err)
+	}
+
+	body, err := json.Marshal(getMessagesRequest{
+		Count:    count,
+		AckMode:  "ack_requeue_false",
+		Encoding: "base64",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	path := fmt.Sprintf("/api/queues/%s/%s/get", url.PathEscape(c.vhost), url.PathEscape(queueName))
+	var messages []movedMessage
+	if err := c.managementPost(path, body, &messages); err != nil {
+		return nil, fmt.Errorf("failed to get messages from %s: %w", queueName, err)
+	}
+
+	moved := make([]MessageInfo, 0, len(messages))
+	for i, message := range messages {
+		if err := c.publishMoved(message, target, queueName); err != nil {
+			// Return this and all remaining messages to the source queue
+			for _, remaining := range messages[i:] {
+				if restoreErr := c.publish(remaining, queueName); restoreErr != nil {
+					return moved, fmt.Errorf("failed to quarantine message and to restore it to %s: %v (restore: %w)", queueName, err, restoreErr)
+				}
+			}
+			return moved, fmt.Errorf("failed to quarantine message, restored to %s: %w", queueName, err)
+		}
+		moved = append(moved, message.info())
+	}
+
+	return moved, nil
+}
+
+// publishMoved publishes a message to the target queue, tagging it with its source queue
+func (c *Client) publishMoved(message movedMessage, target, source string) error {
+	tagged := message
+	tagged.Properties = make(map[string]interface{}, len(message.Properties)+1)
+	for key, value := range message.Properties {
+		tagged.Properties[key] = value
+	}
+	headers := make(map[string]interface{})
+	if existing, ok := message.Properties["headers"].(map[string]interface{}); ok {
+		for key, value := range existing {
+			headers[key] = value
+		}
+	}
+	headers[QuarantineHeader] = source
+	tagged.Properties["headers"] = headers
+
+	return c.publish(tagged, target)
+}
+
+// publish sends a message to a queue through the default exchange
+func (c *Client) publish(message movedMessage, queueName string) error {
+	properties := message.Properties
+	if properties == nil {
+		properties = map[string]interface{}{}
+	}
+	body, err := json.Marshal(publishRequest{
+		Properties:      properties,
+		RoutingKey:      queueName,
+		Payload:         message.Payload,
+		PayloadEncoding: message.PayloadEncoding,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	path := fmt.Sprintf("/api/exchanges/%s/amq.default/publish", url.PathEscape(c.vhost))
+	var result struct {
+		Routed bool `json:"routed"`
+	}
+	if err := c.managementPost(path, body, &result); err != nil {
+		return err
+	}
+	if !result.Routed {
+		return fmt.Errorf("queue %s does not exist", queueName)
+	}
+	return nil
+}
+
+// info describes a moved message without its payload
+func (m movedMessage) info() MessageInfo {
+	info := MessageInfo{
+		Exchange:     m.Exchange,
+		RoutingKey:   m.RoutingKey,
+		Redelivered:  m.Redelivered,
+		PayloadBytes: m.PayloadBytes,
+	}
+	info.MessageID, _ = m.Properties["message_id"].(string)
+	info.ContentType, _ = m.Properties["content_type"].(string)
+	info.Headers, _ = m.Properties["headers"].(map[string]interface{})
+	return info
+}
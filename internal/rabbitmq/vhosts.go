@@ -0,0 +1,52 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// VHostTotals are the message totals and rates of a whole vhost
+type VHostTotals struct {
+	Name            string
+	Messages        int
+	MessagesReady   int
+	MessagesUnacked int
+	PublishRate     float64
+	ConsumeRate     float64
+	AckRate         float64
+}
+
+// vhostDetails is the part of the management API vhost object holding its totals
+type vhostDetails struct {
+	Name            string `json:"name"`
+	Messages        int    `json:"messages"`
+	MessagesReady   int    `json:"messages_ready"`
+	MessagesUnacked int    `json:"messages_unacknowledged"`
+	MessageStats    struct {
+		PublishDetails    rateDetails `json:"publish_details"`
+		DeliverGetDetails rateDetails `json:"deliver_get_details"`
+		AckDetails        rateDetails `json:"ack_details"`
+	} `json:"message_stats"`
+}
+
+// rateDetails is a management API rate
+type rateDetails struct {
+	Rate float64 `json:"rate"`
+}
+
+// GetVHostTotals returns the totals of any vhost the monitoring user can see, not only the configured one
+func (c *Client) GetVHostTotals(vhost string) (*VHostTotals, error) {
+	var details vhostDetails
+	if err := c.managementGet("/api/vhosts/"+url.PathEscape(vhost), &details); err != nil {
+		return nil, fmt.Errorf("failed to get vhost %s: %w", vhost, err)
+	}
+	return &VHostTotals{
+		Name:            details.Name,
+		Messages:        details.Messages,
+		MessagesReady:   details.MessagesReady,
+		MessagesUnacked: details.MessagesUnacked,
+		PublishRate:     details.MessageStats.PublishDetails.Rate,
+		ConsumeRate:     details.MessageStats.DeliverGetDetails.Rate,
+		AckRate:         details.MessageStats.AckDetails.Rate,
+	}, nil
+}
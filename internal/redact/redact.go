@@ -0,0 +1,122 @@
+package redact
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/rabbitmq"
+)
+
+// Redactor applies privacy rules to enrichment data before it is sent anywhere
+type Redactor struct {
+	allowedHeaders map[string]bool
+	maskIPs        bool
+}
+
+// New creates a redactor from the privacy config
+// Header names are matched case-insensitively
+func New(cfg config.PrivacyConfig) *Redactor {
+	allowed := make(map[string]bool, len(cfg.HeaderAllowlist))
+	for _, header := range cfg.HeaderAllowlist {
+		allowed[strings.ToLower(header)] = true
+	}
+	return &Redactor{
+		allowedHeaders: allowed,
+		maskIPs:        cfg.MaskIPs,
+	}
+}
+
+// Consumers returns consumer details with peer addresses masked if configured
+func (r *Redactor) Consumers(consumers []rabbitmq.ConsumerDetail) []rabbitmq.ConsumerDetail {
+	result := make([]rabbitmq.ConsumerDetail, 0, len(consumers))
+	for _, consumer := range consumers {
+		if r.maskIPs {
+			consumer.PeerHost = MaskIP(consumer.PeerHost)
+			consumer.ConnectionName = maskAddresses(consumer.ConnectionName)
+		}
+		result = append(result, consumer)
+	}
+	return result
+}
+
+// Messages returns message details keeping only allowlisted headers
+// The message ID is kept only when "message_id" is allowlisted
+func (r *Redactor) Messages(messages []rabbitmq.MessageInfo) []rabbitmq.MessageInfo {
+	result := make([]rabbitmq.MessageInfo, 0, len(messages))
+	for _, message := range messages {
+		headers := make(map[string]interface{})
+		for name, value := range message.Headers {
+			if r.allowedHeaders[strings.ToLower(name)] {
+				headers[name] = value
+			}
+		}
+		message.Headers = headers
+		if !r.allowedHeaders["message_id"] {
+			message.MessageID = ""
+		}
+		result = append(result, message)
+	}
+	return result
+}
+
+// MaskIP hides the host part of an IP address
+// IPv4 keeps the first two octets, IPv6 the first two groups; other values are returned unchanged
+func MaskIP(host string) string {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return host
+	}
+	if v4 := ip.To4(); v4 != nil {
+		return fmt.Sprintf("%d.%d.x.x", v4[0], v4[1])
+	}
+	groups := strings.SplitN(ip.String(), ":", 3)
+	return groups[0] + ":" + groups[1] + ":x"
+}
+
+// maskAddresses masks IP addresses inside a connection name like "10.0.0.5:51234 -> 10.0.0.9:5672"
+func maskAddresses(name string) string {
+	fields := strings.Fields(name)
+	for i, field := range fields {
+		host, port, err := net.SplitHostPort(field)
+		if err != nil {
+			fields[i] = MaskIP(field)
+			continue
+		}
+		fields[i] = net.JoinHostPort(MaskIP(host), port)
+	}
+	return strings.Join(fields, " ")
+}
+
+// DescribeConsumer formats a consumer for notifications
+func DescribeConsumer(consumer rabbitmq.ConsumerDetail) string {
+	description := fmt.Sprintf("%s from %s (user %s, prefetch %d)", consumer.ConsumerTag, net.JoinHostPort(consumer.PeerHost, fmt.Sprint(consumer.PeerPort)), consumer.User, consumer.PrefetchCount)
+	if !consumer.AckRequired {
+		description += ", auto-ack"
+	}
+	return description
+}
+
+// DescribeMessage formats a message for notifications
+// Headers are listed in name order; the payload is only described by size
+func DescribeMessage(message rabbitmq.MessageInfo) string {
+	description := fmt.Sprintf("%d bytes via %q routing key %q", message.PayloadBytes, message.Exchange, message.RoutingKey)
+	if message.Redelivered {
+		description += ", redelivered"
+	}
+	if message.MessageID != "" {
+		description += ", id " + message.MessageID
+	}
+
+	names := make([]string, 0, len(message.Headers))
+	for name := range message.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		description += fmt.Sprintf(", %s=%v", name, message.Headers[name])
+	}
+	return description
+}
@@ -0,0 +1,102 @@
+package report
+
+import (
+	"math"
+	"time"
+
+	"go-rmq-monitor/internal/history"
+)
+
+// week is the period baselines are shifted by, so a window is compared with the same weekdays and times
+const week = 7 * 24 * time.Hour
+
+// baselineBuckets is the resolution of the baseline profile, hourly for a weekly window
+const baselineBuckets = 168
+
+// A queue regressed when a value at least doubled compared to its baseline and grew by more than a minimum,
+// so quiet queues are not flagged over a handful of messages or a single alert
+const (
+	regressionFactor       = 2
+	regressionMinBacklog   = 10 // Average ready messages
+	regressionMinIncidents = 2
+	regressionMinAlerting  = 30 * time.Minute
+)
+
+// Baseline summarizes a queue over the same window in previous weeks
+type Baseline struct {
+	Weeks          int           // Previous weeks with samples of the queue
+	AverageBacklog float64       // Average over the weeks
+	PeakBacklog    float64       // Average of the weekly peaks
+	Incidents      float64       // Average alerts per week
+	Alerting       time.Duration // Average time alerting per week
+	Points         []Point       // Average backlog at the same time of the week, on this window's time axis
+
+	BacklogRegressed   bool // Average backlog doubled
+	IncidentsRegressed bool // Alerts doubled
+	AlertingRegressed  bool // Time alerting doubled
+}
+
+// Regressed reports whether anything got worse compared to previous weeks
+func (b *Baseline) Regressed() bool {
+	return b != nil && (b.BacklogRegressed || b.IncidentsRegressed || b.AlertingRegressed)
+}
+
+// buildBaseline summarizes a queue over [from, to) shifted back by each of the previous weeks and
+// compares the current summary with it; returns nil when no previous week has samples
+func buildBaseline(current QueueSummary, records []history.Record, from, to time.Time, weeks int) *Baseline {
+	baseline := &Baseline{}
+	span := to.Sub(from)
+	var sums [baselineBuckets]float64
+	var counts [baselineBuckets]int
+	var backlog, peak, incidents float64
+	var alerting time.Duration
+	for k := 1; k <= weeks; k++ {
+		shift := time.Duration(k) * week
+		summary := summarize(current.Queue, records, from.Add(-shift), to.Add(-shift))
+		if summary.Samples == 0 {
+			continue
+		}
+		baseline.Weeks++
+		backlog += summary.AverageBacklog
+		peak += float64(summary.PeakBacklog)
+		incidents += float64(summary.Incidents)
+		alerting += summary.Alerting
+
+		for _, record := range records {
+			offset := record.Timestamp.Sub(from.Add(-shift))
+			if offset < 0 || offset >= span {
+				continue
+			}
+			bucket := int(int64(offset) * baselineBuckets / int64(span))
+			sums[bucket] += float64(record.MessagesReady)
+			counts[bucket]++
+		}
+	}
+	if baseline.Weeks == 0 {
+		return nil
+	}
+
+	weekCount := float64(baseline.Weeks)
+	baseline.AverageBacklog = backlog / weekCount
+	baseline.PeakBacklog = peak / weekCount
+	baseline.Incidents = incidents / weekCount
+	baseline.Alerting = alerting / time.Duration(baseline.Weeks)
+	for bucket, count := range counts {
+		if count == 0 {
+			continue
+		}
+		middle := time.Duration((int64(bucket)*2 + 1) * int64(span) / (2 * baselineBuckets))
+		baseline.Points = append(baseline.Points, Point{
+			Time:          from.Add(middle),
+			MessagesReady: int(math.Round(sums[bucket] / float64(count))),
+		})
+	}
+
+	baseline.BacklogRegressed = current.AverageBacklog >= regressionFactor*baseline.AverageBacklog &&
+		current.AverageBacklog-baseline.AverageBacklog >= regressionMinBacklog
+	baseline.IncidentsRegressed = float64(current.Incidents) >= regressionFactor*baseline.Incidents &&
+		float64(current.Incidents)-baseline.Incidents >= regressionMinIncidents
+	baseline.AlertingRegressed = current.Alerting >= regressionFactor*baseline.Alerting &&
+		current.Alerting-baseline.Alerting >= regressionMinAlerting
+	return baseline
+}
@@ -0,0 +1,160 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-rmq-monitor/internal/config"
+)
+
+// pdfTimeout bounds the PDF conversion command
+const pdfTimeout = 2 * time.Minute
+
+// Delivery says where a rendered report goes
+type Delivery struct {
+	HTMLPath   string   // File the HTML is written to
+	PDFCommand []string // Converts the HTML file to a PDF next to it, empty to skip
+	Email      config.ReportEmailConfig
+	Subject    string
+}
+
+// Deliver writes the HTML report, converts it to PDF if a command is configured and emails both if recipients are
+// Without an HTML path the files are written to a temporary directory removed afterwards
+// Returns the files that were kept
+func Deliver(page []byte, delivery Delivery) ([]string, error) {
+	htmlPath := delivery.HTMLPath
+	if htmlPath == "" {
+		dir, err := os.MkdirTemp("", "rmq-monitor-report-")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temporary report directory: %w", err)
+		}
+		defer os.RemoveAll(dir)
+		htmlPath = filepath.Join(dir, "report.html")
+	} else if err := os.MkdirAll(filepath.Dir(htmlPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create report directory: %w", err)
+	}
+	if err := os.WriteFile(htmlPath, page, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write report: %w", err)
+	}
+	files := []string{htmlPath}
+
+	var pdf []byte
+	if len(delivery.PDFCommand) > 0 {
+		pdfPath := strings.TrimSuffix(htmlPath, filepath.Ext(htmlPath)) + ".pdf"
+		if err := convertPDF(delivery.PDFCommand, htmlPath, pdfPath); err != nil {
+			return kept(files, delivery), err
+		}
+		files = append(files, pdfPath)
+		var err error
+		if pdf, err = os.ReadFile(pdfPath); err != nil {
+			return kept(files, delivery), fmt.Errorf("failed to read PDF report: %w", err)
+		}
+	}
+
+	if delivery.Email.Enabled() {
+		if err := sendEmail(delivery.Email, delivery.Subject, page, pdf); err != nil {
+			return kept(files, delivery), err
+		}
+	}
+	return kept(files, delivery), nil
+}
+
+// kept returns the files that outlive Deliver, none when they were written to a temporary directory
+func kept(files []string, delivery Delivery) []string {
+	if delivery.HTMLPath == "" {
+		return nil
+	}
+	return files
+}
+
+// Subject returns the email subject of a report
+func Subject(r Report, location *time.Location) string {
+	return fmt.Sprintf("Queue report %s – %s: %d alert(s)", r.From.In(location).Format("2006-01-02"), r.To.In(location).Format("2006-01-02"), r.Incidents)
+}
+
+// convertPDF runs the PDF command with the HTML and PDF paths appended, such as wkhtmltopdf
+func convertPDF(command []string, htmlPath, pdfPath string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), pdfTimeout)
+	defer cancel()
+
+	args := append(append([]string{}, command[1:]...), htmlPath, pdfPath)
+	output, err := exec.CommandContext(ctx, command[0], args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("PDF conversion with %s failed: %w: %s", command[0], err, bytes.TrimSpace(output))
+	}
+	if _, err := os.Stat(pdfPath); err != nil {
+		return fmt.Errorf("PDF conversion with %s wrote no PDF: %w", command[0], err)
+	}
+	return nil
+}
+
+// sendEmail sends the HTML report as the message body, with the PDF attached when there is one
+func sendEmail(cfg config.ReportEmailConfig, subject string, page, pdf []byte) error {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	htmlPart, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/html; charset=utf-8"},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return err
+	}
+	writeBase64(htmlPart, page)
+	if pdf != nil {
+		pdfPart, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {"application/pdf"},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {`attachment; filename="report.pdf"`},
+		})
+		if err != nil {
+			return err
+		}
+		writeBase64(pdfPart, pdf)
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", cfg.From)
+	fmt.Fprintf(&message, "To: %s\r\n", strings.Join(cfg.To, ", "))
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&message, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&message, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&message, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", writer.Boundary())
+	message.Write(body.Bytes())
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.SMTPHost)
+	}
+	address := cfg.SMTPHost + ":" + strconv.Itoa(cfg.SMTPPort)
+	if err := smtp.SendMail(address, auth, cfg.From, cfg.To, message.Bytes()); err != nil {
+		return fmt.Errorf("failed to email report: %w", err)
+	}
+	return nil
+}
+
+// writeBase64 writes data base64-encoded in lines of 76 characters, as MIME requires
+func writeBase64(w io.Writer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		w.Write([]byte(encoded[:76] + "\r\n"))
+		encoded = encoded[76:]
+	}
+	w.Write([]byte(encoded + "\r\n"))
+}
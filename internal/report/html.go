@@ -0,0 +1,264 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"go-rmq-monitor/internal/history"
+	"go-rmq-monitor/internal/numfmt"
+)
+
+// maxChartedQueues is the most queues charted in detail; the table lists every queue
+const maxChartedQueues = 25
+
+// Chart dimensions in pixels
+const (
+	chartWidth  = 720
+	chartHeight = 140
+	chartLeft   = 60 // Room for the y axis labels
+	barHeight   = 18
+)
+
+// highUtilisation is the average utilisation flagged in the queue table
+const highUtilisation = 0.8
+
+// stateColors shades the states of a queue's timeline behind its backlog chart
+var stateColors = map[string]string{
+	history.StateAlerting:   "#f8d7da",
+	history.StateRecovering: "#fff3cd",
+}
+
+// HTML renders the report as a self-contained HTML page with inline SVG charts
+// Times are shown in location and numbers in format
+func (r Report) HTML(location *time.Location, format numfmt.Format) ([]byte, error) {
+	funcs := template.FuncMap{
+		"time": func(t time.Time) string {
+			return t.In(location).Format("Mon 2006-01-02 15:04")
+		},
+		"duration": formatDuration,
+		"number": func(n interface{}) string {
+			switch v := n.(type) {
+			case int:
+				return format.Int(v)
+			case float64:
+				return format.Float(v, 0)
+			}
+			return fmt.Sprint(n)
+		},
+		"rate": func(rate float64) string {
+			return format.Float(rate, 2) + " msg/s"
+		},
+		"percent": func(ratio float64) string {
+			return format.Float(ratio*100, 0) + "%"
+		},
+		"high": func(ratio float64) bool {
+			return ratio >= highUtilisation
+		},
+		"backlogChart": func(queue QueueSummary) template.HTML {
+			return backlogChart(queue, r.From, r.To, location, format)
+		},
+		"alertingChart": func() template.HTML {
+			return alertingChart(r.Queues)
+		},
+		"regressed": func() []QueueSummary {
+			var regressed []QueueSummary
+			for _, queue := range r.Queues {
+				if queue.Baseline.Regressed() {
+					regressed = append(regressed, queue)
+				}
+			}
+			return regressed
+		},
+		"charted": func() []QueueSummary {
+			if len(r.Queues) > maxChartedQueues {
+				return r.Queues[:maxChartedQueues]
+			}
+			return r.Queues
+		},
+	}
+	tmpl, err := template.New("report").Funcs(funcs).Parse(pageTemplate)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, r); err != nil {
+		return nil, fmt.Errorf("failed to render report: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// backlogChart draws a queue's ready messages over the period, shading the time it spent alerting or recovering
+func backlogChart(queue QueueSummary, from, to time.Time, location *time.Location, format numfmt.Format) template.HTML {
+	plotWidth := float64(chartWidth - chartLeft)
+	span := to.Sub(from).Seconds()
+	x := func(t time.Time) float64 {
+		return float64(chartLeft) + plotWidth*t.Sub(from).Seconds()/span
+	}
+	peak := queue.PeakBacklog
+	if queue.Baseline != nil {
+		for _, point := range queue.Baseline.Points {
+			if point.MessagesReady > peak {
+				peak = point.MessagesReady
+			}
+		}
+	}
+	if peak < 1 {
+		peak = 1
+	}
+	y := func(messages int) float64 {
+		return float64(chartHeight-20) * (1 - float64(messages)/float64(peak))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg viewBox="0 0 %d %d" width="%d" height="%d" role="img">`, chartWidth, chartHeight, chartWidth, chartHeight)
+	for _, interval := range queue.Timeline.Intervals {
+		if color, shaded := stateColors[interval.State]; shaded {
+			fmt.Fprintf(&b, `<rect x="%.1f" y="0" width="%.1f" height="%d" fill="%s"/>`,
+				x(interval.Start), x(interval.End)-x(interval.Start), chartHeight-20, color)
+		}
+	}
+	polyline := func(chartPoints []Point) string {
+		points := make([]string, 0, len(chartPoints))
+		for _, point := range chartPoints {
+			points = append(points, fmt.Sprintf("%.1f,%.1f", x(point.Time), y(point.MessagesReady)))
+		}
+		return strings.Join(points, " ")
+	}
+	if queue.Baseline != nil {
+		fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="#8c959f" stroke-width="1" stroke-dasharray="4 3"/>`, polyline(queue.Baseline.Points))
+	}
+	fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="#1f6feb" stroke-width="1.5"/>`, polyline(queue.Points))
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#999"/>`, chartLeft, chartHeight-20, chartWidth, chartHeight-20)
+	fmt.Fprintf(&b, `<text x="%d" y="12" text-anchor="end" class="axis">%s</text>`, chartLeft-6, format.Int(peak))
+	fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end" class="axis">0</text>`, chartLeft-6, chartHeight-20)
+	fmt.Fprintf(&b, `<text x="%d" y="%d" class="axis">%s</text>`, chartLeft, chartHeight-4, from.In(location).Format("Mon 02 Jan"))
+	fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end" class="axis">%s</text>`, chartWidth, chartHeight-4, to.In(location).Format("Mon 02 Jan"))
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// alertingChart draws a horizontal bar per queue that spent time alerting, longest first
+func alertingChart(queues []QueueSummary) template.HTML {
+	var alerting []QueueSummary
+	for _, queue := range queues {
+		if queue.Alerting > 0 && len(alerting) < maxChartedQueues {
+			alerting = append(alerting, queue)
+		}
+	}
+	if len(alerting) == 0 {
+		return ""
+	}
+	longest := alerting[0].Alerting.Seconds()
+	labelWidth := 240
+	height := len(alerting) * (barHeight + 6)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg viewBox="0 0 %d %d" width="%d" height="%d" role="img">`, chartWidth, height, chartWidth, height)
+	for i, queue := range alerting {
+		top := i * (barHeight + 6)
+		width := float64(chartWidth-labelWidth-90) * queue.Alerting.Seconds() / longest
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end" class="label">%s</text>`, labelWidth-8, top+barHeight-5, template.HTMLEscapeString(queue.Queue))
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%.1f" height="%d" fill="#d1242f"/>`, labelWidth, top, width, barHeight)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d" class="label">%s</text>`, float64(labelWidth)+width+6, top+barHeight-5, formatDuration(queue.Alerting))
+	}
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// formatDuration formats a duration in days, hours and minutes
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d < time.Minute {
+		return "0m"
+	}
+	days, hours, minutes := int(d/(24*time.Hour)), int(d%(24*time.Hour)/time.Hour), int(d%time.Hour/time.Minute)
+	var parts []string
+	if days > 0 {
+		parts = append(parts, fmt.Sprintf("%dd", days))
+	}
+	if hours > 0 {
+		parts = append(parts, fmt.Sprintf("%dh", hours))
+	}
+	if minutes > 0 {
+		parts = append(parts, fmt.Sprintf("%dm", minutes))
+	}
+	return strings.Join(parts, " ")
+}
+
+// pageTemplate is the report layout, styled inline so the file renders the same when emailed or converted to PDF
+const pageTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Queue report {{time .From}} – {{time .To}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #1f2328; margin: 32px; max-width: 960px; }
+h1 { font-size: 22px; margin-bottom: 4px; }
+h2 { font-size: 17px; margin-top: 32px; border-bottom: 1px solid #d0d7de; padding-bottom: 4px; }
+h3 { font-size: 15px; margin: 24px 0 4px; }
+.period, .meta { color: #59636e; font-size: 13px; }
+.totals { display: flex; gap: 32px; margin: 16px 0; }
+.totals div { font-size: 13px; color: #59636e; }
+.totals strong { display: block; font-size: 22px; color: #1f2328; }
+table { border-collapse: collapse; width: 100%; font-size: 13px; }
+th, td { text-align: right; padding: 4px 8px; border-bottom: 1px solid #eaeef2; }
+th:first-child, td:first-child { text-align: left; }
+td.alerting { color: #d1242f; font-weight: 600; }
+.axis, .label { font-size: 11px; fill: #59636e; }
+.legend span { display: inline-block; width: 10px; height: 10px; margin: 0 4px 0 12px; }
+section { page-break-inside: avoid; }
+</style>
+</head>
+<body>
+<h1>Queue report</h1>
+<div class="period">{{time .From}} – {{time .To}}</div>
+
+<div class="totals">
+<div><strong>{{len .Queues}}</strong>queues with history</div>
+<div><strong>{{.Incidents}}</strong>alerts</div>
+<div><strong>{{duration .Alerting}}</strong>total time alerting</div>
+{{if .BaselineWeeks}}<div><strong>{{.Regressions}}</strong>regressions vs. previous {{.BaselineWeeks}} weeks</div>{{end}}
+</div>
+
+{{with regressed}}
+<h2>Regressions</h2>
+<table>
+<tr><th>Queue</th><th>Average backlog</th><th>Baseline</th><th>Alerts</th><th>Baseline</th><th>Time alerting</th><th>Baseline</th><th>Weeks</th></tr>
+{{range .}}
+<tr><td>{{.Queue}}</td><td{{if .Baseline.BacklogRegressed}} class="alerting"{{end}}>{{number .AverageBacklog}}</td><td>{{number .Baseline.AverageBacklog}}</td><td{{if .Baseline.IncidentsRegressed}} class="alerting"{{end}}>{{.Incidents}}</td><td>{{printf "%.1f" .Baseline.Incidents}}</td><td{{if .Baseline.AlertingRegressed}} class="alerting"{{end}}>{{duration .Alerting}}</td><td>{{duration .Baseline.Alerting}}</td><td>{{.Baseline.Weeks}}</td></tr>
+{{end}}
+</table>
+<p class="meta">Queues whose average backlog, alerts or time alerting at least doubled compared to the same weekdays and times in previous weeks, such as after a release.</p>
+{{end}}
+
+{{with alertingChart}}
+<h2>Time alerting by queue</h2>
+{{.}}
+{{end}}
+
+<h2>Queues</h2>
+<table>
+<tr><th>Queue</th><th>Alerts</th><th>Time alerting</th><th>Peak backlog</th><th>Peak at</th><th>Average backlog</th><th>Publish rate</th><th>Consume rate</th><th>Capacity</th><th>Utilisation</th></tr>
+{{range .Queues}}
+<tr><td>{{.Queue}}</td><td>{{.Incidents}}</td><td{{if .Alerting}} class="alerting"{{end}}>{{duration .Alerting}}</td><td>{{number .PeakBacklog}}</td><td>{{time .PeakAt}}</td><td>{{number .AverageBacklog}}</td><td>{{rate .PublishRate}}</td><td>{{rate .ConsumeRate}}</td>{{if .Capacity}}<td>{{rate .Capacity}}</td><td{{if high .Utilisation}} class="alerting"{{end}}>{{percent .Utilisation}}</td>{{else}}<td>–</td><td>–</td>{{end}}</tr>
+{{end}}
+</table>
+<p class="meta">Capacity is the consume rate sustained while messages were waiting; queues that never built a backlog have none. Utilisation is the average publish rate relative to it.</p>
+
+<h2>Ready messages</h2>
+<div class="legend meta">Shaded:<span style="background:#f8d7da"></span>alerting<span style="background:#fff3cd"></span>recovering{{if .BaselineWeeks}}<span style="background:#8c959f;height:2px;vertical-align:middle"></span>previous weeks{{end}}</div>
+{{range charted}}
+<section>
+<h3>{{.Queue}}</h3>
+<div class="meta">{{.Samples}} samples · peak {{number .PeakBacklog}} · {{.Incidents}} alert(s){{range .Timeline.Events}}{{if eq .Type "alerting"}}<br>{{time .Timestamp}}: {{.Reason}}{{end}}{{end}}</div>
+{{backlogChart .}}
+</section>
+{{end}}
+
+<p class="meta">Generated {{time .GeneratedAt}} by go-rmq-monitor from stored history.</p>
+</body>
+</html>
+`
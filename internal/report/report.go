@@ -0,0 +1,165 @@
+// Package report builds operations reports from stored queue history
+package report
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"go-rmq-monitor/internal/capacity"
+	"go-rmq-monitor/internal/history"
+)
+
+// maxPoints is the most backlog samples charted per queue; longer histories keep the peak of each bucket
+const maxPoints = 300
+
+// Report summarizes every queue with history over a period
+type Report struct {
+	From        time.Time
+	To          time.Time
+	GeneratedAt time.Time
+	Queues      []QueueSummary // Longest time alerting first, then largest peak backlog
+	Incidents   int            // Alerts started across all queues
+	Alerting    time.Duration  // Time spent alerting across all queues
+
+	BaselineWeeks int // Previous weeks queues are compared with, 0 when not compared
+	Regressions   int // Queues that got worse compared to previous weeks
+}
+
+// QueueSummary describes one queue over the report period
+type QueueSummary struct {
+	Queue          string
+	Samples        int
+	PeakBacklog    int
+	PeakAt         time.Time
+	AverageBacklog float64
+	PublishRate    float64       // Average over the samples, msg/s
+	ConsumeRate    float64       // Average over the samples, msg/s
+	Capacity       float64       // Sustainable consume rate estimated from the samples, 0 without enough busy samples
+	Utilisation    float64       // Average publish rate relative to the capacity
+	Incidents      int           // Times the queue started alerting
+	Alerting       time.Duration // Time spent alerting, including recovering
+	Timeline       history.Timeline
+	Points         []Point   // Backlog samples for the chart
+	Baseline       *Baseline // Same window in previous weeks, nil without history for it
+}
+
+// Point is a charted backlog sample
+type Point struct {
+	Time          time.Time
+	MessagesReady int
+}
+
+// Build summarizes the chronological records of each queue within [from, to)
+// With baselineWeeks, each queue is compared with the same window in that many previous weeks
+func Build(records map[string][]history.Record, from, to, now time.Time, baselineWeeks int) Report {
+	report := Report{From: from, To: to, GeneratedAt: now, BaselineWeeks: baselineWeeks}
+	for queue, queueRecords := range records {
+		summary := summarize(queue, queueRecords, from, to)
+		if summary.Samples == 0 {
+			continue
+		}
+		if baselineWeeks > 0 {
+			summary.Baseline = buildBaseline(summary, queueRecords, from, to, baselineWeeks)
+			if summary.Baseline.Regressed() {
+				report.Regressions++
+			}
+		}
+		report.Queues = append(report.Queues, summary)
+		report.Incidents += summary.Incidents
+		report.Alerting += summary.Alerting
+	}
+	sort.Slice(report.Queues, func(i, j int) bool {
+		a, b := report.Queues[i], report.Queues[j]
+		if a.Alerting != b.Alerting {
+			return a.Alerting > b.Alerting
+		}
+		if a.PeakBacklog != b.PeakBacklog {
+			return a.PeakBacklog > b.PeakBacklog
+		}
+		return a.Queue < b.Queue
+	})
+	return report
+}
+
+// summarize computes the statistics of one queue's samples within [from, to)
+func summarize(queue string, records []history.Record, from, to time.Time) QueueSummary {
+	summary := QueueSummary{
+		Queue:    queue,
+		Timeline: history.BuildTimeline(queue, records, from, to),
+	}
+	var backlog, publish, consume float64
+	var window []history.Record
+	for _, record := range records {
+		if record.Timestamp.Before(from) || !record.Timestamp.Before(to) {
+			continue
+		}
+		window = append(window, record)
+		summary.Samples++
+		backlog += float64(record.MessagesReady)
+		publish += record.PublishRate
+		consume += record.ConsumeRate
+		if record.MessagesReady > summary.PeakBacklog || summary.PeakAt.IsZero() {
+			summary.PeakBacklog = record.MessagesReady
+			summary.PeakAt = record.Timestamp
+		}
+		summary.Points = append(summary.Points, Point{Time: record.Timestamp, MessagesReady: record.MessagesReady})
+	}
+	if summary.Samples == 0 {
+		return summary
+	}
+	summary.AverageBacklog = backlog / float64(summary.Samples)
+	summary.PublishRate = publish / float64(summary.Samples)
+	summary.ConsumeRate = consume / float64(summary.Samples)
+	summary.Points = downsample(summary.Points, maxPoints)
+	if estimate, ok := capacity.EstimateQueue(queue, window, capacity.DefaultMinSamples); ok {
+		summary.Capacity = estimate.Capacity
+		summary.Utilisation = estimate.Utilisation(summary.PublishRate)
+	}
+
+	for _, event := range summary.Timeline.Events {
+		if event.Type == history.StateAlerting {
+			summary.Incidents++
+		}
+	}
+	for _, interval := range summary.Timeline.Intervals {
+		if interval.State == history.StateAlerting || interval.State == history.StateRecovering {
+			summary.Alerting += interval.End.Sub(interval.Start)
+		}
+	}
+	return summary
+}
+
+// downsample reduces points to at most limit, keeping the largest backlog of each bucket so peaks stay visible
+func downsample(points []Point, limit int) []Point {
+	if len(points) <= limit {
+		return points
+	}
+	result := make([]Point, 0, limit)
+	for bucket := 0; bucket < limit; bucket++ {
+		start, end := bucket*len(points)/limit, (bucket+1)*len(points)/limit
+		peak := points[start]
+		for _, point := range points[start+1 : end] {
+			if point.MessagesReady > peak.MessagesReady {
+				peak = point
+			}
+		}
+		result = append(result, peak)
+	}
+	return result
+}
+
+// Generate loads the history file and builds the report of the window ending at to
+// compared with the same window in baselineWeeks previous weeks
+func Generate(historyPath string, window time.Duration, to time.Time, baselineWeeks int) (Report, error) {
+	from := to.Add(-window)
+	records, err := history.Load(historyPath, from.Add(-time.Duration(baselineWeeks)*week))
+	if err != nil {
+		return Report{}, err
+	}
+	report := Build(records, from, to, time.Now(), baselineWeeks)
+	if len(report.Queues) == 0 {
+		return report, fmt.Errorf("no history between %s and %s", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+	return report, nil
+}
@@ -0,0 +1,329 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+)
+
+// valueType is the static type of an expression node
+type valueType int
+
+const (
+	typeNumber valueType = iota
+	typeBool
+)
+
+func (t valueType) String() string {
+	if t == typeBool {
+		return "boolean"
+	}
+	return "number"
+}
+
+// node is a type-checked expression tree node
+type node interface {
+	typ() valueType
+}
+
+// numberNode is a numeric literal
+type numberNode struct{ value float64 }
+
+// boolNode is a true or false literal
+type boolNode struct{ value bool }
+
+// fieldNode reads a field of the latest sample
+type fieldNode struct{ field string }
+
+// callNode aggregates a field over the history window
+type callNode struct {
+	function string
+	field    string
+}
+
+// unaryNode applies - or ! to its operand
+type unaryNode struct {
+	operator string
+	operand  node
+}
+
+// binaryNode applies an arithmetic, comparison or logical operator
+type binaryNode struct {
+	operator    string
+	left, right node
+}
+
+func (numberNode) typ() valueType { return typeNumber }
+func (boolNode) typ() valueType   { return typeBool }
+func (fieldNode) typ() valueType  { return typeNumber }
+func (callNode) typ() valueType   { return typeNumber }
+
+func (n unaryNode) typ() valueType {
+	if n.operator == "!" {
+		return typeBool
+	}
+	return typeNumber
+}
+
+func (n binaryNode) typ() valueType {
+	switch n.operator {
+	case "+", "-", "*", "/":
+		return typeNumber
+	}
+	return typeBool
+}
+
+// parser is a recursive descent parser over the lexed tokens
+//
+//	rule    = or [ "for" NUMBER ( "check" | "checks" ) ]
+//	or      = and { "||" and }
+//	and     = not { "&&" not }
+//	not     = "!" not | compare
+//	compare = sum [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" ) sum ]
+//	sum     = product { ( "+" | "-" ) product }
+//	product = unary { ( "*" | "/" ) unary }
+//	unary   = "-" unary | primary
+//	primary = NUMBER | "true" | "false" | FIELD | FUNCTION "(" FIELD ")" | "(" or ")"
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the next token when it is the given operator
+func (p *parser) accept(operator string) bool {
+	if t := p.peek(); t.kind == tokenOperator && t.text == operator {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, unexpected(t, what)
+	}
+	return t, nil
+}
+
+// unexpected describes a token that does not fit the grammar
+func unexpected(t token, want string) error {
+	if t.kind == tokenEOF {
+		return fmt.Errorf("unexpected end of expression, expected %s", want)
+	}
+	return fmt.Errorf("unexpected %q at position %d, expected %s", t.text, t.pos, want)
+}
+
+// parseRule parses a whole rule, returning its condition and the checks it must hold for
+func (p *parser) parseRule() (node, int, error) {
+	condition, err := p.parseOr()
+	if err != nil {
+		return nil, 0, err
+	}
+	if condition.typ() != typeBool {
+		return nil, 0, fmt.Errorf("expression is a number, expected a condition")
+	}
+
+	checks := 0
+	if t := p.peek(); t.kind == tokenIdent && t.text == "for" {
+		p.next()
+		count, err := p.expect(tokenNumber, "a number of checks")
+		if err != nil {
+			return nil, 0, err
+		}
+		if count.number < 1 || count.number != float64(int(count.number)) {
+			return nil, 0, fmt.Errorf("invalid number of checks %q at position %d", count.text, count.pos)
+		}
+		unit := p.next()
+		if unit.kind != tokenIdent || (unit.text != "check" && unit.text != "checks") {
+			return nil, 0, unexpected(unit, `"checks"`)
+		}
+		checks = int(count.number)
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, 0, unexpected(t, "an operator")
+	}
+	return condition, checks, nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	return p.parseLogical("||", p.parseAnd)
+}
+
+func (p *parser) parseAnd() (node, error) {
+	return p.parseLogical("&&", p.parseNot)
+}
+
+// parseLogical parses a chain of one logical operator over boolean operands
+func (p *parser) parseLogical(operator string, operand func() (node, error)) (node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		pos := p.peek().pos
+		if !p.accept(operator) {
+			return left, nil
+		}
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		if left.typ() != typeBool || right.typ() != typeBool {
+			return nil, fmt.Errorf("%s at position %d needs conditions on both sides", operator, pos)
+		}
+		left = binaryNode{operator: operator, left: left, right: right}
+	}
+}
+
+func (p *parser) parseNot() (node, error) {
+	pos := p.peek().pos
+	if !p.accept("!") {
+		return p.parseCompare()
+	}
+	operand, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	if operand.typ() != typeBool {
+		return nil, fmt.Errorf("! at position %d needs a condition", pos)
+	}
+	return unaryNode{operator: "!", operand: operand}, nil
+}
+
+func (p *parser) parseCompare() (node, error) {
+	left, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	t := p.peek()
+	if t.kind != tokenOperator {
+		return left, nil
+	}
+	switch t.text {
+	case "==", "!=", "<", "<=", ">", ">=":
+	default:
+		return left, nil
+	}
+	p.next()
+	right, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if left.typ() != typeNumber || right.typ() != typeNumber {
+		return nil, fmt.Errorf("%s at position %d compares numbers", t.text, t.pos)
+	}
+	return binaryNode{operator: t.text, left: left, right: right}, nil
+}
+
+func (p *parser) parseSum() (node, error) {
+	return p.parseArithmetic([]string{"+", "-"}, p.parseProduct)
+}
+
+func (p *parser) parseProduct() (node, error) {
+	return p.parseArithmetic([]string{"*", "/"}, p.parseUnary)
+}
+
+// parseArithmetic parses a left-associative chain of arithmetic operators over numeric operands
+func (p *parser) parseArithmetic(operators []string, operand func() (node, error)) (node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		matched := false
+		for _, operator := range operators {
+			if p.accept(operator) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return left, nil
+		}
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		if left.typ() != typeNumber || right.typ() != typeNumber {
+			return nil, fmt.Errorf("%s at position %d needs numbers on both sides", t.text, t.pos)
+		}
+		left = binaryNode{operator: t.text, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	pos := p.peek().pos
+	if !p.accept("-") {
+		return p.parsePrimary()
+	}
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	if operand.typ() != typeNumber {
+		return nil, fmt.Errorf("- at position %d needs a number", pos)
+	}
+	return unaryNode{operator: "-", operand: operand}, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		return numberNode{value: t.number}, nil
+	case tokenLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenRParen, `")"`); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	case tokenIdent:
+		switch t.text {
+		case "true", "false":
+			return boolNode{value: t.text == "true"}, nil
+		}
+		if p.peek().kind == tokenLParen {
+			return p.parseCall(t)
+		}
+		if !isField(t.text) {
+			return nil, fmt.Errorf("unknown field %q at position %d (known: %s)", t.text, t.pos, strings.Join(Fields, ", "))
+		}
+		return fieldNode{field: t.text}, nil
+	}
+	return nil, unexpected(t, "a field, number or (")
+}
+
+// parseCall parses a history function applied to a field
+func (p *parser) parseCall(name token) (node, error) {
+	if _, known := functions[name.text]; !known {
+		return nil, fmt.Errorf("unknown function %q at position %d (known: %s)", name.text, name.pos, strings.Join(FunctionNames(), ", "))
+	}
+	p.next()
+	field, err := p.expect(tokenIdent, "a field name")
+	if err != nil {
+		return nil, err
+	}
+	if !isField(field.text) {
+		return nil, fmt.Errorf("unknown field %q at position %d (known: %s)", field.text, field.pos, strings.Join(Fields, ", "))
+	}
+	if _, err := p.expect(tokenRParen, `")"`); err != nil {
+		return nil, err
+	}
+	return callNode{function: name.text, field: field.text}, nil
+}
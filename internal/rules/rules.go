@@ -0,0 +1,192 @@
+// Package rules implements the expression language of user-defined detection rules
+//
+// A rule is a condition over the fields of the latest queue sample, optionally followed by
+// "for N checks", e.g. "messages_ready > 5000 && consume_rate < publish_rate * 0.5 for 3 checks".
+// Functions such as avg(publish_rate) aggregate a field over the samples kept in history.
+package rules
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Fields lists the queue fields rules can read, as named in expressions
+var Fields = []string{
+	"messages_ready",
+	"messages_unacked",
+	"messages",
+	"consumers",
+	"publish_rate",
+	"consume_rate",
+	"ack_rate",
+	"redeliver_rate",
+	"expire_rate",
+	"consumer_utilisation",
+	"high_priority_depth",
+}
+
+// Sample holds the field values of one check, keyed by field name
+type Sample map[string]float64
+
+// functions aggregate a field over the history window, oldest sample first
+var functions = map[string]func(values []float64) float64{
+	"avg": func(values []float64) float64 {
+		sum := 0.0
+		for _, value := range values {
+			sum += value
+		}
+		return sum / float64(len(values))
+	},
+	"min": func(values []float64) float64 {
+		result := values[0]
+		for _, value := range values[1:] {
+			result = math.Min(result, value)
+		}
+		return result
+	},
+	"max": func(values []float64) float64 {
+		result := values[0]
+		for _, value := range values[1:] {
+			result = math.Max(result, value)
+		}
+		return result
+	},
+	// delta is the change from the oldest sample to the latest
+	"delta": func(values []float64) float64 {
+		return values[len(values)-1] - values[0]
+	},
+	// prev is the value at the previous check, or the latest value without one
+	"prev": func(values []float64) float64 {
+		if len(values) < 2 {
+			return values[0]
+		}
+		return values[len(values)-2]
+	},
+}
+
+// FunctionNames returns the names of the history functions, sorted
+func FunctionNames() []string {
+	names := make([]string, 0, len(functions))
+	for name := range functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isField reports whether name is a known field
+func isField(name string) bool {
+	for _, field := range Fields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Program is a compiled rule
+type Program struct {
+	source    string
+	condition node
+	checks    int
+}
+
+// Compile parses and type-checks a rule
+func Compile(source string) (*Program, error) {
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 1 {
+		return nil, fmt.Errorf("expression is empty")
+	}
+	p := &parser{tokens: tokens}
+	condition, checks, err := p.parseRule()
+	if err != nil {
+		return nil, err
+	}
+	return &Program{source: source, condition: condition, checks: checks}, nil
+}
+
+// String returns the source of the rule
+func (p *Program) String() string {
+	return p.source
+}
+
+// Checks returns the consecutive checks the condition must hold for, 0 when the rule has no "for" clause
+func (p *Program) Checks() int {
+	return p.checks
+}
+
+// Eval evaluates the condition over history, oldest sample first, the last one being the latest check
+// Returns false when history is empty; division by zero follows floating point rules,
+// so comparisons against 0/0 never match
+func (p *Program) Eval(history []Sample) bool {
+	if len(history) == 0 {
+		return false
+	}
+	return evalBool(p.condition, history)
+}
+
+func evalBool(n node, history []Sample) bool {
+	switch n := n.(type) {
+	case boolNode:
+		return n.value
+	case unaryNode:
+		return !evalBool(n.operand, history)
+	case binaryNode:
+		switch n.operator {
+		case "&&":
+			return evalBool(n.left, history) && evalBool(n.right, history)
+		case "||":
+			return evalBool(n.left, history) || evalBool(n.right, history)
+		}
+		left, right := evalNumber(n.left, history), evalNumber(n.right, history)
+		switch n.operator {
+		case "==":
+			return left == right
+		case "!=":
+			return left != right
+		case "<":
+			return left < right
+		case "<=":
+			return left <= right
+		case ">":
+			return left > right
+		case ">=":
+			return left >= right
+		}
+	}
+	return false
+}
+
+func evalNumber(n node, history []Sample) float64 {
+	switch n := n.(type) {
+	case numberNode:
+		return n.value
+	case fieldNode:
+		return history[len(history)-1][n.field]
+	case callNode:
+		values := make([]float64, len(history))
+		for i, sample := range history {
+			values[i] = sample[n.field]
+		}
+		return functions[n.function](values)
+	case unaryNode:
+		return -evalNumber(n.operand, history)
+	case binaryNode:
+		left, right := evalNumber(n.left, history), evalNumber(n.right, history)
+		switch n.operator {
+		case "+":
+			return left + right
+		case "-":
+			return left - right
+		case "*":
+			return left * right
+		case "/":
+			return left / right
+		}
+	}
+	return 0
+}
@@ -0,0 +1,176 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEvalPrecedence(t *testing.T) {
+	tests := []struct {
+		source string
+		want   bool
+	}{
+		{"1 + 2 * 3 == 7", true},
+		{"(1 + 2) * 3 == 9", true},
+		{"10 - 4 - 3 == 3", true},
+		{"8 / 4 / 2 == 1", true},
+		{"-2 * 3 == -6", true},
+		{"--2 == 2", true},
+		{"2 - -2 == 4", true},
+		{"true || false && false", true},
+		{"(true || false) && false", false},
+		{"!false && false", false},
+		{"!(false && false)", true},
+		{"!!true", true},
+		{"consumers + 1 > consumers * 2", true},
+		{"messages_ready > 5000 && consume_rate < publish_rate * 0.5", true},
+		{"messages_ready > 5000 && consume_rate > publish_rate * 0.5", false},
+	}
+	history := []Sample{{"consumers": 0, "messages_ready": 6000, "consume_rate": 1, "publish_rate": 10}}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			program, err := Compile(tt.source)
+			if err != nil {
+				t.Fatalf("Compile: %v", err)
+			}
+			if got := program.Eval(history); got != tt.want {
+				t.Errorf("Eval = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileForClause(t *testing.T) {
+	tests := []struct {
+		source string
+		checks int
+	}{
+		{"consumers == 0", 0},
+		{"consumers == 0 for 1 check", 1},
+		{"consumers == 0 for 3 checks", 3},
+		{"(consumers == 0 || messages_ready > 10) for 12 checks", 12},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			program, err := Compile(tt.source)
+			if err != nil {
+				t.Fatalf("Compile: %v", err)
+			}
+			if got := program.Checks(); got != tt.checks {
+				t.Errorf("Checks = %d, want %d", got, tt.checks)
+			}
+			if got := program.String(); got != tt.source {
+				t.Errorf("String = %q, want %q", got, tt.source)
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		source string
+		err    string
+	}{
+		{"", "expression is empty"},
+		{"   ", "expression is empty"},
+		{"messages_ready", "expression is a number"},
+		{"messages_ready + 1", "expression is a number"},
+		{"consumers && true", "&& at position 10 needs conditions on both sides"},
+		{"true || consumers", "|| at position 5 needs conditions on both sides"},
+		{"!consumers", "! at position 0 needs a condition"},
+		{"-true == 1", "- at position 0 needs a number"},
+		{"true < 1", "< at position 5 compares numbers"},
+		{"true + 1 > 0", "+ at position 5 needs numbers on both sides"},
+		{"consumers * false > 0", "* at position 10 needs numbers on both sides"},
+		{"queue_depth > 1", `unknown field "queue_depth"`},
+		{"sum(consumers) > 1", `unknown function "sum"`},
+		{"avg(depth) > 1", `unknown field "depth"`},
+		{"avg(1) > 1", "expected a field name"},
+		{"consumers > 1 for 0 checks", `invalid number of checks "0"`},
+		{"consumers > 1 for 2.5 checks", `invalid number of checks "2.5"`},
+		{"consumers > 1 for checks", "expected a number of checks"},
+		{"consumers > 1 for 3 minutes", `unexpected "minutes" at position 20, expected "checks"`},
+		{"consumers > 1 for 3", `unexpected end of expression, expected "checks"`},
+		{"consumers > 1 consumers", `unexpected "consumers" at position 14, expected an operator`},
+		{"1 < 2 < 3", `unexpected "<" at position 6, expected an operator`},
+		{"(consumers > 1", `unexpected end of expression, expected ")"`},
+		{"consumers >", "unexpected end of expression, expected a field, number or ("},
+		{"consumers $ 1", `unexpected '$' at position 10`},
+		{"1..2 > 1", `invalid number "1..2" at position 0`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			_, err := Compile(tt.source)
+			if err == nil {
+				t.Fatalf("Compile succeeded, want error containing %q", tt.err)
+			}
+			if !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Compile error = %q, want it to contain %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestEvalDivisionByZero(t *testing.T) {
+	tests := []struct {
+		source string
+		want   bool
+	}{
+		// A positive number divided by zero is +Inf
+		{"messages_ready / consumers > 100", true},
+		{"-messages_ready / consumers < -100", true},
+		// 0/0 is NaN, which no comparison matches, not even with itself
+		{"consumers / consumers >= 0", false},
+		{"consumers / consumers < 0", false},
+		{"consumers / consumers == consumers / consumers", false},
+		{"consumers / consumers != consumers / consumers", true},
+		{"!(consumers / consumers >= 0)", true},
+	}
+	history := []Sample{{"messages_ready": 500, "consumers": 0}}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			program, err := Compile(tt.source)
+			if err != nil {
+				t.Fatalf("Compile: %v", err)
+			}
+			if got := program.Eval(history); got != tt.want {
+				t.Errorf("Eval = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvalHistoryFunctions(t *testing.T) {
+	history := []Sample{
+		{"publish_rate": 1},
+		{"publish_rate": 2},
+		{"publish_rate": 6},
+	}
+	tests := []struct {
+		source  string
+		history []Sample
+		want    bool
+	}{
+		{"avg(publish_rate) == 3", history, true},
+		{"min(publish_rate) == 1", history, true},
+		{"max(publish_rate) == 6", history, true},
+		{"delta(publish_rate) == 5", history, true},
+		{"prev(publish_rate) == 2", history, true},
+		{"publish_rate == 6", history, true},
+		{"publish_rate > prev(publish_rate) * 2", history, true},
+		{"prev(publish_rate) == 6", history[2:], true},
+		{"delta(publish_rate) == 0", history[2:], true},
+		{"true", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			program, err := Compile(tt.source)
+			if err != nil {
+				t.Fatalf("Compile: %v", err)
+			}
+			if got := program.Eval(tt.history); got != tt.want {
+				t.Errorf("Eval = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
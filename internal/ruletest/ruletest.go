@@ -0,0 +1,213 @@
+// Package ruletest replays scenario fixtures through the configured detection settings and rules
+package ruletest
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go-rmq-monitor/internal/analyzer"
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/rabbitmq"
+
+	"github.com/spf13/viper"
+)
+
+// Verdicts a fixture can expect after a check
+const (
+	StateAlerting    = "alerting"
+	StateNotAlerting = "not_alerting"
+	StateRecovering  = "recovering" // Alerting with a decreasing backlog
+)
+
+// Suite is a fixtures file
+type Suite struct {
+	Interval  time.Duration `mapstructure:"interval"` // Time between checks, monitor.interval when unset
+	Scenarios []Scenario    `mapstructure:"scenarios"`
+}
+
+// Scenario is a sequence of checks of one queue with the verdicts expected along the way
+type Scenario struct {
+	Name   string  `mapstructure:"name"`
+	Queue  string  `mapstructure:"queue"` // Queue whose detection settings and rules apply, global ones when not in config
+	Checks []Check `mapstructure:"checks"`
+	Expect string  `mapstructure:"expect"` // Verdict after the last check
+	Reason string  `mapstructure:"reason"` // Substring expected in the reason of the latest alert
+}
+
+// Check is the queue's metrics at one check, optionally with the verdict expected after it
+type Check struct {
+	MessagesReady       int      `mapstructure:"messages_ready"`
+	MessagesUnacked     int      `mapstructure:"messages_unacked"`
+	Consumers           int      `mapstructure:"consumers"`
+	PublishRate         float64  `mapstructure:"publish_rate"`
+	ConsumeRate         float64  `mapstructure:"consume_rate"`
+	AckRate             float64  `mapstructure:"ack_rate"`
+	RedeliverRate       float64  `mapstructure:"redeliver_rate"`
+	ExpireRate          float64  `mapstructure:"expire_rate"`
+	ConsumerUtilisation *float64 `mapstructure:"consumer_utilisation"` // Not reported when unset
+	Repeat              int      `mapstructure:"repeat"`               // Times the check is taken, 1 when unset
+	Expect              string   `mapstructure:"expect"`               // Verdict after the check, or after its last repeat
+}
+
+// Load reads and validates a fixtures file
+func Load(path string) (*Suite, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read fixtures: %w", err)
+	}
+
+	var suite Suite
+	if err := v.Unmarshal(&suite); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal fixtures: %w", err)
+	}
+	if len(suite.Scenarios) == 0 {
+		return nil, fmt.Errorf("%s has no scenarios", path)
+	}
+	if suite.Interval < 0 {
+		return nil, fmt.Errorf("interval must not be negative")
+	}
+	for i, scenario := range suite.Scenarios {
+		if scenario.Name == "" {
+			return nil, fmt.Errorf("scenarios[%d]: name is required", i)
+		}
+		if len(scenario.Checks) == 0 {
+			return nil, fmt.Errorf("scenario %q has no checks", scenario.Name)
+		}
+		if !isVerdict(scenario.Expect) {
+			return nil, fmt.Errorf("scenario %q: invalid expect %q (alerting, not_alerting, recovering)", scenario.Name, scenario.Expect)
+		}
+		for j, check := range scenario.Checks {
+			if !isVerdict(check.Expect) {
+				return nil, fmt.Errorf("scenario %q: checks[%d] has invalid expect %q (alerting, not_alerting, recovering)", scenario.Name, j, check.Expect)
+			}
+			if check.Repeat < 0 {
+				return nil, fmt.Errorf("scenario %q: checks[%d] repeat must not be negative", scenario.Name, j)
+			}
+		}
+	}
+	return &suite, nil
+}
+
+// isVerdict reports whether an expectation is empty or a known verdict
+func isVerdict(verdict string) bool {
+	switch verdict {
+	case "", StateAlerting, StateNotAlerting, StateRecovering:
+		return true
+	}
+	return false
+}
+
+// Result is the outcome of one scenario
+type Result struct {
+	Scenario string
+	Passed   bool
+	Verdicts []string // Verdict after each check, repeats included
+	Reason   string   // Reason of the latest alert, empty if the queue never alerted
+	Failures []string // Expectations that were not met
+}
+
+// Run replays every scenario through a fresh analyzer with the config's detection settings and rules
+// Plugin detectors are not loaded
+func Run(cfg *config.Config, suite *Suite) ([]Result, error) {
+	rules, err := analyzer.CompileRules(cfg.Monitor.Rules)
+	if err != nil {
+		return nil, err
+	}
+	interval := suite.Interval
+	if interval == 0 {
+		interval = cfg.Monitor.Interval
+	}
+
+	results := make([]Result, 0, len(suite.Scenarios))
+	for _, scenario := range suite.Scenarios {
+		results = append(results, run(cfg, rules, interval, scenario))
+	}
+	return results, nil
+}
+
+// run replays one scenario, starting from a fixed time so results do not depend on the clock
+func run(cfg *config.Config, rules []analyzer.Rule, interval time.Duration, scenario Scenario) Result {
+	queueName := scenario.Queue
+	if queueName == "" {
+		queueName = "ruletest"
+	}
+	detector := analyzer.New(&cfg.Monitor.Detection)
+	for _, queueCfg := range cfg.Monitor.Queues {
+		if queueCfg.Name == queueName {
+			detector.SetQueueConfig(queueName, cfg.Monitor.GetQueueDetectionConfig(&queueCfg))
+		}
+	}
+	detector.SetRules(rules)
+
+	result := Result{Scenario: scenario.Name}
+	now := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	verdict := StateNotAlerting
+	for i, check := range scenario.Checks {
+		repeat := check.Repeat
+		if repeat == 0 {
+			repeat = 1
+		}
+		for r := 0; r < repeat; r++ {
+			analysis := detector.AnalyzeAt([]rabbitmq.QueueInfo{queueInfo(queueName, check)}, now)
+			for _, alert := range analysis.StuckAlerts {
+				result.Reason = alert.Reason
+			}
+			state, _ := detector.SnapshotQueue(queueName)
+			verdict = verdictOf(state)
+			result.Verdicts = append(result.Verdicts, verdict)
+			now = now.Add(interval)
+		}
+		if check.Expect != "" && check.Expect != verdict {
+			result.Failures = append(result.Failures, fmt.Sprintf("check %d: expected %s, got %s", i+1, check.Expect, verdict))
+		}
+	}
+
+	if scenario.Expect != "" && scenario.Expect != verdict {
+		result.Failures = append(result.Failures, fmt.Sprintf("expected %s after the last check, got %s", scenario.Expect, verdict))
+	}
+	if scenario.Reason != "" && !strings.Contains(result.Reason, scenario.Reason) {
+		if result.Reason == "" {
+			result.Failures = append(result.Failures, fmt.Sprintf("expected an alert with reason %q, got no alert", scenario.Reason))
+		} else {
+			result.Failures = append(result.Failures, fmt.Sprintf("expected reason %q, got %q", scenario.Reason, result.Reason))
+		}
+	}
+	result.Passed = len(result.Failures) == 0
+	return result
+}
+
+// queueInfo returns the queue as the management API would report it at a check
+func queueInfo(queueName string, check Check) rabbitmq.QueueInfo {
+	utilisation := -1.0
+	if check.ConsumerUtilisation != nil {
+		utilisation = *check.ConsumerUtilisation
+	}
+	return rabbitmq.QueueInfo{
+		Name:                queueName,
+		Messages:            check.MessagesReady + check.MessagesUnacked,
+		MessagesReady:       check.MessagesReady,
+		MessagesUnacked:     check.MessagesUnacked,
+		Consumers:           check.Consumers,
+		PublishRate:         check.PublishRate,
+		ConsumeRate:         check.ConsumeRate,
+		AckRate:             check.AckRate,
+		RedeliverRate:       check.RedeliverRate,
+		ExpireRate:          check.ExpireRate,
+		ExpiryTracked:       true,
+		ConsumerUtilisation: utilisation,
+	}
+}
+
+// verdictOf returns the verdict for a queue's state after a check
+func verdictOf(state analyzer.QueueState) string {
+	if state.LastKnownState != StateAlerting {
+		return StateNotAlerting
+	}
+	if state.IsRecovering() {
+		return StateRecovering
+	}
+	return StateAlerting
+}
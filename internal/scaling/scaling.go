@@ -0,0 +1,118 @@
+// Package scaling turns queue backlogs into desired consumer counts for autoscalers such as KEDA or the HPA
+package scaling
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Target describes how fast a deployment's consumers work and how far it may scale
+type Target struct {
+	Name         string
+	ConsumerRate float64       // Messages per second one consumer handles
+	DrainTime    time.Duration // Time a backlog should drain in
+	MinConsumers int
+	MaxConsumers int // 0 for no upper bound
+}
+
+// Load is what a target's queues hold and receive, summed across the queues
+type Load struct {
+	Queues        int
+	MessagesReady int
+	PublishRate   float64
+	Consumers     int
+}
+
+// Hint is the desired consumer count of a target as of its last check
+type Hint struct {
+	Target           string    `json:"target"`
+	Queues           int       `json:"queues"`
+	MessagesReady    int       `json:"messages_ready"`
+	PublishRate      float64   `json:"publish_rate"`
+	Consumers        int       `json:"consumers"`
+	DesiredConsumers int       `json:"desired_consumers"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// Compute returns the consumers a target needs to keep up with publishing and drain its backlog within the drain time:
+// (publish rate + ready messages / drain time) / consumer rate, rounded up and bounded by the target's limits
+func Compute(target Target, load Load, now time.Time) Hint {
+	demand := load.PublishRate
+	if target.DrainTime > 0 {
+		demand += float64(load.MessagesReady) / target.DrainTime.Seconds()
+	}
+	desired := 0
+	if target.ConsumerRate > 0 {
+		desired = int(math.Ceil(demand / target.ConsumerRate))
+	}
+	if desired < target.MinConsumers {
+		desired = target.MinConsumers
+	}
+	if target.MaxConsumers > 0 && desired > target.MaxConsumers {
+		desired = target.MaxConsumers
+	}
+	return Hint{
+		Target:           target.Name,
+		Queues:           load.Queues,
+		MessagesReady:    load.MessagesReady,
+		PublishRate:      load.PublishRate,
+		Consumers:        load.Consumers,
+		DesiredConsumers: desired,
+		UpdatedAt:        now,
+	}
+}
+
+// Board holds the latest hint of every target
+type Board struct {
+	hints map[string]Hint
+	mu    sync.RWMutex
+}
+
+// New creates an empty board
+func New() *Board {
+	return &Board{hints: make(map[string]Hint)}
+}
+
+// Update stores the hints computed in a check cycle
+func (b *Board) Update(hints []Hint) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, hint := range hints {
+		b.hints[hint.Target] = hint
+	}
+}
+
+// HandleGet returns the hint of the target in the request path as JSON
+// Suits the KEDA metrics-api scaler with valueLocation desired_consumers
+// Registered as GET /api/scaling/{target}
+func (b *Board) HandleGet(w http.ResponseWriter, r *http.Request) {
+	b.mu.RLock()
+	hint, exists := b.hints[r.PathValue("target")]
+	b.mu.RUnlock()
+	if !exists {
+		http.Error(w, "unknown scaling target or not checked yet", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(hint)
+}
+
+// HandleList returns the hints of all targets as JSON
+// Registered as GET /api/scaling
+func (b *Board) HandleList(w http.ResponseWriter, r *http.Request) {
+	b.mu.RLock()
+	hints := make([]Hint, 0, len(b.hints))
+	for _, hint := range b.hints {
+		hints = append(hints, hint)
+	}
+	b.mu.RUnlock()
+	sort.Slice(hints, func(i, j int) bool { return hints[i].Target < hints[j].Target })
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(hints)
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"go-rmq-monitor/internal/config"
+)
+
+// roleLevels orders roles so that each includes the permissions of lower ones
+var roleLevels = map[string]int{
+	config.RoleReadOnly: 1,
+	config.RoleSilencer: 2,
+	config.RoleAdmin:    3,
+}
+
+// identityKey is the request context key holding the authenticated token name
+type identityKey struct{}
+
+// Identity returns the name of the token that authenticated the request
+// Returns an empty string when API auth is disabled
+func Identity(r *http.Request) string {
+	name, _ := r.Context().Value(identityKey{}).(string)
+	return name
+}
+
+// HandleAPI registers a control API handler that requires at least the given role
+// An empty role registers the handler unprotected; without configured tokens only
+// read_only handlers are served, and roles above it are always rejected
+func (s *Server) HandleAPI(pattern, role string, handler func(http.ResponseWriter, *http.Request)) {
+	if role == "" || (len(s.tokens) == 0 && role == config.RoleReadOnly) {
+		s.mux.HandleFunc(pattern, handler)
+		return
+	}
+	if len(s.tokens) == 0 {
+		s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "requires role "+role+", configure server.auth.tokens to enable", http.StatusForbidden)
+		})
+		return
+	}
+	s.mux.HandleFunc(pattern, s.requireRole(role, handler))
+}
+
+// requireRole wraps a handler with bearer token authentication and a role check
+func (s *Server) requireRole(role string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || token == "" {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "missing bearer token", http.StatusUnauthorized)
+			return
+		}
+
+		entry, ok := s.lookupToken(token)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+		if roleLevels[entry.Role] < roleLevels[role] {
+			http.Error(w, "requires role "+role, http.StatusForbidden)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), identityKey{}, entry.Name)
+		handler(w, r.WithContext(ctx))
+	}
+}
+
+// lookupToken finds the configured entry for a token in constant time per entry
+func (s *Server) lookupToken(token string) (config.APITokenConfig, bool) {
+	var match config.APITokenConfig
+	found := false
+	for _, entry := range s.tokens {
+		if subtle.ConstantTimeCompare([]byte(entry.Token), []byte(token)) == 1 {
+			match = entry
+			found = true
+		}
+	}
+	return match, found
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"go-rmq-monitor/internal/config"
+)
+
+// Server is the embedded HTTP server exposing monitor endpoints
+type Server struct {
+	httpServer *http.Server
+	mux        *http.ServeMux
+	tokens     []config.APITokenConfig
+	tlsConfig  config.ServerTLSConfig
+}
+
+// New creates a new HTTP server listening on the configured address
+// Client certificates are required when a client CA is configured
+func New(cfg config.ServerConfig) (*Server, error) {
+	mux := http.NewServeMux()
+
+	httpServer := &http.Server{
+		Addr:              cfg.ListenAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	if cfg.TLS.Enabled() {
+		httpServer.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		if cfg.TLS.ClientCAFile != "" {
+			caPEM, err := os.ReadFile(cfg.TLS.ClientCAFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read client CA file: %w", err)
+			}
+			clientCAs := x509.NewCertPool()
+			if !clientCAs.AppendCertsFromPEM(caPEM) {
+				return nil, fmt.Errorf("no certificates found in client CA file %s", cfg.TLS.ClientCAFile)
+			}
+			httpServer.TLSConfig.ClientCAs = clientCAs
+			httpServer.TLSConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+
+	return &Server{
+		httpServer: httpServer,
+		mux:        mux,
+		tokens:     cfg.Auth.Tokens,
+		tlsConfig:  cfg.TLS,
+	}, nil
+}
+
+// Handle registers a handler for the given pattern
+// Handlers registered this way are never protected by API auth
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// HandleFunc registers a handler function for the given pattern
+func (s *Server) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	s.mux.HandleFunc(pattern, handler)
+}
+
+// Start begins serving requests and blocks until the server is shut down
+// Serves HTTPS when a certificate is configured
+func (s *Server) Start() error {
+	var err error
+	if s.tlsConfig.Enabled() {
+		err = s.httpServer.ListenAndServeTLS(s.tlsConfig.CertFile, s.tlsConfig.KeyFile)
+	} else {
+		err = s.httpServer.ListenAndServe()
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("http server failed: %w", err)
+	}
+	return nil
+}
+
+// Shutdown gracefully stops the server
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
+}
+
+// Addr returns the address the server listens on
+func (s *Server) Addr() string {
+	return s.httpServer.Addr
+}
@@ -0,0 +1,218 @@
+package silence
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"go-rmq-monitor/internal/server"
+)
+
+// External tools silences are mirrored from
+const (
+	SourceAlertmanager = "alertmanager"
+	SourcePagerDuty    = "pagerduty"
+)
+
+// Mirror creates or updates the silence mirrored from an external source, matched by Source and SourceID
+// Returns the stored silence and whether it was created
+func (s *Store) Mirror(silence Silence) (Silence, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, existing := range s.silences {
+		if existing.Source == silence.Source && existing.SourceID == silence.SourceID {
+			silence.ID = existing.ID
+			silence.CreatedAt = existing.CreatedAt
+			s.silences[i] = silence
+			return silence, false
+		}
+	}
+	if silence.ID == "" {
+		silence.ID = newID()
+	}
+	s.silences = append(s.silences, silence)
+	return silence, true
+}
+
+// ExpireMirrored removes the silence mirrored from an external source
+func (s *Store) ExpireMirrored(source, sourceID string) (Silence, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, silence := range s.silences {
+		if silence.Source == source && silence.SourceID == sourceID {
+			s.silences = append(s.silences[:i], s.silences[i+1:]...)
+			return silence, nil
+		}
+	}
+	return Silence{}, ErrNotFound
+}
+
+// syncResult is the JSON response of the external silence endpoints
+type syncResult struct {
+	Mirrored int `json:"mirrored"`
+	Expired  int `json:"expired"`
+	Ignored  int `json:"ignored"` // Entries that name no monitored queue
+}
+
+// apply mirrors an external silence, or expires its mirror once it has ended
+func (s *Store) apply(silence Silence, now time.Time, identity string, result *syncResult) {
+	if !now.Before(silence.EndsAt) {
+		expired, err := s.ExpireMirrored(silence.Source, silence.SourceID)
+		if err == nil {
+			result.Expired++
+			if s.onChange != nil {
+				s.onChange(EventExpired, expired, silence.Source, identity)
+			}
+		}
+		return
+	}
+
+	silence.CreatedBy = silence.Source
+	silence.Identity = identity
+	silence.CreatedAt = now
+	mirrored, created := s.Mirror(silence)
+	result.Mirrored++
+	if created && s.onChange != nil {
+		s.onChange(EventCreated, mirrored, silence.Source, identity)
+	}
+}
+
+// alertmanagerPayload is the body of an Alertmanager webhook notification
+type alertmanagerPayload struct {
+	Alerts []struct {
+		Status      string            `json:"status"`
+		Labels      map[string]string `json:"labels"`
+		Annotations map[string]string `json:"annotations"`
+		StartsAt    time.Time         `json:"startsAt"`
+		EndsAt      time.Time         `json:"endsAt"`
+		Fingerprint string            `json:"fingerprint"`
+	} `json:"alerts"`
+}
+
+// HandleAlertmanager returns a handler mirroring Alertmanager alerts into silences
+// Firing alerts silence the comma-separated queues in queueLabel until the alert ends, or for
+// defaultDuration when it carries no end time; resolved alerts expire their silence
+// Registered as POST /api/silences/alertmanager
+func (s *Store) HandleAlertmanager(queueLabel string, defaultDuration time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var payload alertmanagerPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			http.Error(w, "invalid JSON body", http.StatusBadRequest)
+			return
+		}
+
+		now := time.Now()
+		var result syncResult
+		for _, alert := range payload.Alerts {
+			queues := splitQueues(alert.Labels[queueLabel])
+			if len(queues) == 0 || alert.Fingerprint == "" {
+				result.Ignored++
+				continue
+			}
+			silence := Silence{
+				Queues:   queues,
+				Reason:   alertmanagerReason(alert.Labels, alert.Annotations),
+				StartsAt: alert.StartsAt,
+				EndsAt:   alert.EndsAt,
+				Source:   SourceAlertmanager,
+				SourceID: alert.Fingerprint,
+			}
+			if alert.Status == "resolved" {
+				silence.EndsAt = now
+			} else if !silence.EndsAt.After(now) {
+				silence.EndsAt = now.Add(defaultDuration)
+			}
+			s.apply(silence, now, server.Identity(r), &result)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	}
+}
+
+// alertmanagerReason describes a mirrored alert by its summary, falling back to its name
+func alertmanagerReason(labels, annotations map[string]string) string {
+	for _, reason := range []string{annotations["summary"], annotations["description"], labels["alertname"]} {
+		if reason != "" {
+			return reason
+		}
+	}
+	return "Alertmanager alert"
+}
+
+// pagerDutyWindow is a PagerDuty maintenance window as returned by its REST API
+type pagerDutyWindow struct {
+	ID          string    `json:"id"`
+	Description string    `json:"description"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	Services    []struct {
+		ID string `json:"id"`
+	} `json:"services"`
+}
+
+// pagerDutyPayload holds one maintenance window or a list of them
+type pagerDutyPayload struct {
+	Window  *pagerDutyWindow  `json:"maintenance_window"`
+	Windows []pagerDutyWindow `json:"maintenance_windows"`
+}
+
+// HandlePagerDuty returns a handler mirroring PagerDuty maintenance windows into silences
+// services maps lowercased PagerDuty service IDs to the queues they cover; windows that have
+// ended, including windows ended early, expire their silence
+// Registered as POST /api/silences/pagerduty
+func (s *Store) HandlePagerDuty(services map[string][]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var payload pagerDutyPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			http.Error(w, "invalid JSON body", http.StatusBadRequest)
+			return
+		}
+		windows := payload.Windows
+		if payload.Window != nil {
+			windows = append(windows, *payload.Window)
+		}
+
+		now := time.Now()
+		var result syncResult
+		for _, window := range windows {
+			var queues []string
+			for _, service := range window.Services {
+				queues = append(queues, services[strings.ToLower(service.ID)]...)
+			}
+			if len(queues) == 0 || window.ID == "" {
+				result.Ignored++
+				continue
+			}
+			reason := window.Description
+			if reason == "" {
+				reason = "PagerDuty maintenance window"
+			}
+			s.apply(Silence{
+				Queues:   queues,
+				Reason:   reason,
+				StartsAt: window.StartTime,
+				EndsAt:   window.EndTime,
+				Source:   SourcePagerDuty,
+				SourceID: window.ID,
+			}, now, server.Identity(r), &result)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	}
+}
+
+// splitQueues splits a comma-separated list of queue names
+func splitQueues(value string) []string {
+	var queues []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			queues = append(queues, name)
+		}
+	}
+	return queues
+}
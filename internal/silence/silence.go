@@ -0,0 +1,220 @@
+package silence
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"go-rmq-monitor/internal/server"
+)
+
+// ErrNotFound is returned when expiring a silence that does not exist
+var ErrNotFound = errors.New("silence not found")
+
+// Silence mutes notifications for a set of queues until it expires
+type Silence struct {
+	ID        string    `json:"id"`
+	Queues    []string  `json:"queues"`
+	Team      string    `json:"team,omitempty"` // Owning team, empty for platform silences
+	Reason    string    `json:"reason,omitempty"`
+	StartsAt  time.Time `json:"starts_at,omitempty"` // Zero for silences in effect from creation
+	EndsAt    time.Time `json:"ends_at"`
+	CreatedBy string    `json:"created_by,omitempty"` // Empty for silences defined in config
+	Identity  string    `json:"identity,omitempty"`   // Authenticated API token name, if API auth is enabled
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	Source    string    `json:"source,omitempty"`    // External tool the silence is mirrored from
+	SourceID  string    `json:"source_id,omitempty"` // ID of the silence or window in the external tool
+}
+
+// Silence lifecycle events passed to the change callback
+const (
+	EventCreated = "created"
+	EventExpired = "expired"
+)
+
+// IsActive reports whether the silence is in effect at the given time
+func (s Silence) IsActive(now time.Time) bool {
+	return !now.Before(s.StartsAt) && now.Before(s.EndsAt)
+}
+
+// Covers reports whether the silence applies to a queue
+func (s Silence) Covers(queueName string) bool {
+	for _, name := range s.Queues {
+		if name == queueName {
+			return true
+		}
+	}
+	return false
+}
+
+// Store holds silences shared by all notifiers
+type Store struct {
+	onChange func(event string, silence Silence, actor, identity string)
+	silences []Silence
+	mu       sync.RWMutex
+}
+
+// New creates a silence store with the given initial silences
+// onChange, if not nil, is called when a silence is created or expired through the API
+func New(silences []Silence, onChange func(event string, silence Silence, actor, identity string)) *Store {
+	store := &Store{}
+	for _, silence := range silences {
+		store.Add(silence)
+	}
+	store.onChange = onChange
+	return store
+}
+
+// Add registers a new silence, assigning an ID if it has none
+func (s *Store) Add(silence Silence) Silence {
+	if silence.ID == "" {
+		silence.ID = newID()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.silences = append(s.silences, silence)
+	return silence
+}
+
+// Expire removes a silence before it ends
+func (s *Store) Expire(id string) (Silence, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, silence := range s.silences {
+		if silence.ID == id {
+			s.silences = append(s.silences[:i], s.silences[i+1:]...)
+			return silence, nil
+		}
+	}
+	return Silence{}, ErrNotFound
+}
+
+// Active returns the silence covering a queue at the given time, if any
+func (s *Store) Active(queueName string, now time.Time) (Silence, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, silence := range s.silences {
+		if silence.IsActive(now) && silence.Covers(queueName) {
+			return silence, true
+		}
+	}
+	return Silence{}, false
+}
+
+// List returns all silences active or starting later at the given time, ending soonest first
+// Expired silences are dropped from the store
+func (s *Store) List(now time.Time) []Silence {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := make([]Silence, 0, len(s.silences))
+	for _, silence := range s.silences {
+		if now.Before(silence.EndsAt) {
+			current = append(current, silence)
+		}
+	}
+	s.silences = current
+
+	result := append([]Silence(nil), current...)
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].EndsAt.Before(result[j].EndsAt)
+	})
+	return result
+}
+
+// newID returns a random silence ID
+func newID() string {
+	buf := make([]byte, 8)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// createRequest is the JSON body accepted by the silence endpoint
+// Either duration (e.g. "2h") or ends_at must be set
+type createRequest struct {
+	Queues   []string  `json:"queues"`
+	By       string    `json:"by"`
+	Reason   string    `json:"reason"`
+	Duration string    `json:"duration"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+// HandleCreate creates a silence
+// Registered as POST /api/silences
+func (s *Store) HandleCreate(w http.ResponseWriter, r *http.Request) {
+	var req createRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+	if req.By == "" {
+		http.Error(w, "\"by\" is required", http.StatusBadRequest)
+		return
+	}
+	if len(req.Queues) == 0 {
+		http.Error(w, "\"queues\" is required", http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now()
+	endsAt := req.EndsAt
+	if req.Duration != "" {
+		duration, err := time.ParseDuration(req.Duration)
+		if err != nil || duration <= 0 {
+			http.Error(w, "invalid \"duration\"", http.StatusBadRequest)
+			return
+		}
+		endsAt = now.Add(duration)
+	}
+	if !endsAt.After(now) {
+		http.Error(w, "\"duration\" or a future \"ends_at\" is required", http.StatusBadRequest)
+		return
+	}
+
+	silence := s.Add(Silence{
+		Queues:    req.Queues,
+		Reason:    req.Reason,
+		EndsAt:    endsAt,
+		CreatedBy: req.By,
+		Identity:  server.Identity(r),
+		CreatedAt: now,
+	})
+	if s.onChange != nil {
+		s.onChange(EventCreated, silence, req.By, silence.Identity)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(silence)
+}
+
+// HandleExpire removes the silence in the request path
+// The optional "by" query parameter names who expired it
+// Registered as DELETE /api/silences/{id}
+func (s *Store) HandleExpire(w http.ResponseWriter, r *http.Request) {
+	silence, err := s.Expire(r.PathValue("id"))
+	if errors.Is(err, ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if s.onChange != nil {
+		s.onChange(EventExpired, silence, r.URL.Query().Get("by"), server.Identity(r))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(silence)
+}
+
+// HandleList returns all active and pending silences as JSON
+// Registered as GET /api/silences
+func (s *Store) HandleList(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.List(time.Now()))
+}
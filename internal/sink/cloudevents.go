@@ -0,0 +1,63 @@
+package sink
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// cloudEventsSpecVersion is the CloudEvents specification version produced
+const cloudEventsSpecVersion = "1.0"
+
+// Envelope wraps events before they are sent, as CloudEvents or as plain events
+type Envelope struct {
+	CloudEvents bool
+	Source      string // CloudEvents source attribute
+	TypePrefix  string // Prepended to the event type, e.g. "com.example.rabbitmq"
+}
+
+// CloudEvent is an event in the CloudEvents 1.0 structured JSON format
+type CloudEvent struct {
+	SpecVersion     string `json:"specversion"`
+	ID              string `json:"id"`
+	Source          string `json:"source"`
+	Type            string `json:"type"`
+	Subject         string `json:"subject,omitempty"`
+	Time            string `json:"time"`
+	DataContentType string `json:"datacontenttype"`
+	DataSchema      string `json:"dataschema"`
+	Data            Event  `json:"data"`
+}
+
+// Wrap returns the value to send for an event
+func (e Envelope) Wrap(event Event) interface{} {
+	if !e.CloudEvents {
+		return event
+	}
+	return CloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              newEventID(),
+		Source:          e.Source,
+		Type:            e.TypePrefix + "." + event.Type,
+		Subject:         event.Queue,
+		Time:            event.Timestamp.UTC().Format(time.RFC3339Nano),
+		DataContentType: "application/json",
+		DataSchema:      SchemaID,
+		Data:            event,
+	}
+}
+
+// ContentType returns the media type of wrapped events sent over HTTP
+func (e Envelope) ContentType() string {
+	if e.CloudEvents {
+		return "application/cloudevents+json"
+	}
+	return "application/json"
+}
+
+// newEventID returns a random CloudEvents ID
+func newEventID() string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
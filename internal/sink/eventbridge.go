@@ -0,0 +1,113 @@
+package sink
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go-rmq-monitor/internal/awsauth"
+)
+
+// EventBridge puts events on an Amazon EventBridge event bus
+// The event type becomes the detail-type, so rules can match e.g. queue_stuck
+type EventBridge struct {
+	eventBus    string
+	source      string
+	region      string
+	endpoint    string
+	credentials *awsauth.Provider
+	httpClient  *http.Client
+}
+
+// eventBridgeEntry is a single entry of a PutEvents request
+type eventBridgeEntry struct {
+	Source       string `json:"Source"`
+	DetailType   string `json:"DetailType"`
+	Detail       string `json:"Detail"`
+	EventBusName string `json:"EventBusName"`
+	Time         int64  `json:"Time"`
+}
+
+// NewEventBridge creates a sink putting events on the bus in region
+func NewEventBridge(eventBus, source, region string, credentials *awsauth.Provider, timeout time.Duration) *EventBridge {
+	return &EventBridge{
+		eventBus:    eventBus,
+		source:      source,
+		region:      region,
+		endpoint:    "https://events." + region + ".amazonaws.com/",
+		credentials: credentials,
+		httpClient:  &http.Client{Timeout: timeout},
+	}
+}
+
+// Name identifies the sink in logs and metrics
+func (e *EventBridge) Name() string {
+	return "eventbridge"
+}
+
+// Publish puts the event with the plain event as its detail
+// EventBridge has its own envelope, so CloudEvents wrapping does not apply
+func (e *EventBridge) Publish(event Event) error {
+	detail, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal eventbridge detail: %w", err)
+	}
+	body, err := json.Marshal(map[string][]eventBridgeEntry{
+		"Entries": {{
+			Source:       e.source,
+			DetailType:   event.Type,
+			Detail:       string(detail),
+			EventBusName: e.eventBus,
+			Time:         event.Timestamp.Unix(),
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal eventbridge request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create eventbridge request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "AWSEvents.PutEvents")
+	if err := signAWS(req, e.credentials, "events", e.region); err != nil {
+		return err
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("eventbridge request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Type             string `json:"__type"`
+		Message          string `json:"message"`
+		FailedEntryCount int    `json:"FailedEntryCount"`
+		Entries          []struct {
+			ErrorCode    string `json:"ErrorCode"`
+			ErrorMessage string `json:"ErrorMessage"`
+		} `json:"Entries"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil && resp.StatusCode == http.StatusOK {
+		return fmt.Errorf("failed to decode eventbridge response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("eventbridge returned status %d: %s %s", resp.StatusCode, result.Type, result.Message)
+	}
+
+	// Entries can fail individually with a 200 status
+	if result.FailedEntryCount > 0 && len(result.Entries) > 0 {
+		return fmt.Errorf("eventbridge rejected event: %s: %s", result.Entries[0].ErrorCode, result.Entries[0].ErrorMessage)
+	}
+	return nil
+}
+
+// Close releases idle connections
+func (e *EventBridge) Close() error {
+	e.httpClient.CloseIdleConnections()
+	return nil
+}
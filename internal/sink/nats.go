@@ -0,0 +1,103 @@
+package sink
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// NATS publishes events to a subject through the NATS client
+// The connection is opened on first use; the client reconnects by itself afterwards
+type NATS struct {
+	serverURL string
+	subject   string
+	token     string
+	envelope  Envelope
+	timeout   time.Duration
+	conn      *nats.Conn
+	mu        sync.Mutex
+}
+
+// NewNATS creates a sink publishing to subject on the server at serverURL
+// serverURL is nats://host:port or tls://host:port; credentials may be part of the URL
+func NewNATS(serverURL, subject, token string, envelope Envelope, timeout time.Duration) (*NATS, error) {
+	parsed, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nats url: %w", err)
+	}
+	if parsed.Scheme != "nats" && parsed.Scheme != "tls" {
+		return nil, fmt.Errorf("nats url must use the nats:// or tls:// scheme")
+	}
+
+	return &NATS{
+		serverURL: serverURL,
+		subject:   subject,
+		token:     token,
+		envelope:  envelope,
+		timeout:   timeout,
+	}, nil
+}
+
+// Name identifies the sink in logs and metrics
+func (n *NATS) Name() string {
+	return "nats"
+}
+
+// Publish sends the event as JSON and waits for the server to confirm it was processed
+func (n *NATS) Publish(event Event) error {
+	payload, err := json.Marshal(n.envelope.Wrap(event))
+	if err != nil {
+		return fmt.Errorf("failed to marshal nats message: %w", err)
+	}
+
+	conn, err := n.connection()
+	if err != nil {
+		return err
+	}
+	if err := conn.Publish(n.subject, payload); err != nil {
+		return fmt.Errorf("failed to publish to nats: %w", err)
+	}
+	if err := conn.FlushTimeout(n.timeout); err != nil {
+		return fmt.Errorf("failed to flush nats message: %w", err)
+	}
+	return nil
+}
+
+// Close drains pending messages and closes the server connection
+func (n *NATS) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.conn == nil {
+		return nil
+	}
+	err := n.conn.Drain()
+	n.conn = nil
+	return err
+}
+
+// connection returns the open connection, connecting when there is none or the last one was closed
+func (n *NATS) connection() (*nats.Conn, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.conn != nil && !n.conn.IsClosed() {
+		return n.conn, nil
+	}
+
+	options := []nats.Option{
+		nats.Name("go-rmq-monitor"),
+		nats.Timeout(n.timeout),
+	}
+	if n.token != "" {
+		options = append(options, nats.Token(n.token))
+	}
+	conn, err := nats.Connect(n.serverURL, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nats: %w", err)
+	}
+	n.conn = conn
+	return conn, nil
+}
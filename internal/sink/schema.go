@@ -0,0 +1,47 @@
+package sink
+
+// SchemaVersion is the version of the event contract, sent as schema_version in every event
+// The minor version grows when fields are added; the major version changes when fields are
+// removed, renamed or change type, so consumers can accept any minor version of their major
+const SchemaVersion = "1.0"
+
+// SchemaID identifies the JSON schema of the current major version, also sent as the CloudEvents dataschema
+const SchemaID = "urn:go-rmq-monitor:schema:event:v1"
+
+// JSONSchema describes Event as JSON Schema (draft 2020-12), printed by the schema command
+// Additional properties are allowed so consumers validating against 1.x accept later minor versions
+const JSONSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "urn:go-rmq-monitor:schema:event:v1",
+  "title": "go-rmq-monitor event",
+  "description": "A queue incident or check cycle published to event sinks, webhooks and the live event stream. Version 1.0.",
+  "type": "object",
+  "additionalProperties": true,
+  "required": [
+    "schema_version", "type", "timestamp", "cluster", "vhost", "check_id",
+    "queue", "incident_id", "priority", "reason", "messages_ready", "consumers", "consume_rate", "publish_rate", "stuck_duration_seconds",
+    "tracked_queues", "alerting_queues", "duration_seconds", "error"
+  ],
+  "properties": {
+    "schema_version": {"type": "string", "pattern": "^1\\.[0-9]+$", "description": "Version of this contract; 1.x only adds fields"},
+    "type": {"type": "string", "enum": ["queue_stuck", "queue_recovered", "check"], "description": "Event type"},
+    "timestamp": {"type": "string", "format": "date-time", "description": "When the queue changed state or the check started"},
+    "cluster": {"type": "string", "description": "Management API host"},
+    "vhost": {"type": "string", "description": "Virtual host of the queue, or the monitored vhost for check events"},
+    "check_id": {"type": "string", "description": "Check cycle that produced the event"},
+    "queue": {"type": "string", "description": "Full queue name; empty for check events"},
+    "incident_id": {"type": "string", "description": "Shared by a queue's stuck and recovered events; empty for check events"},
+    "priority": {"type": "string", "description": "Queue priority class, e.g. critical; empty when none is configured"},
+    "reason": {"type": "string", "description": "Why the queue is considered stuck or recovered"},
+    "messages_ready": {"type": "integer", "minimum": 0, "description": "Messages ready for delivery"},
+    "consumers": {"type": "integer", "minimum": 0, "description": "Consumers of the queue"},
+    "consume_rate": {"type": "number", "minimum": 0, "description": "Messages consumed per second"},
+    "publish_rate": {"type": "number", "minimum": 0, "description": "Messages published per second"},
+    "stuck_duration_seconds": {"type": "number", "minimum": 0, "description": "How long the queue was stuck; 0 except for queue_recovered events"},
+    "tracked_queues": {"type": "integer", "minimum": 0, "description": "Queues tracked by the check; 0 for queue events"},
+    "alerting_queues": {"type": "integer", "minimum": 0, "description": "Queues alerting after the check; 0 for queue events"},
+    "duration_seconds": {"type": "number", "minimum": 0, "description": "Duration of the check; 0 for queue events"},
+    "error": {"type": "string", "description": "Why the check failed; empty on success and for queue events"}
+  }
+}
+`
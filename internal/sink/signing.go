@@ -0,0 +1,23 @@
+package sink
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// Headers of signed webhook requests
+const (
+	SignatureHeader = "X-RMQ-Monitor-Signature"
+	TimestampHeader = "X-RMQ-Monitor-Timestamp"
+)
+
+// Sign returns the signature of a request body sent at the given Unix time
+// The signature is "v1=" followed by the hex HMAC-SHA256 of "v1:<timestamp>:<body>"
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v1:" + strconv.FormatInt(timestamp, 10) + ":"))
+	mac.Write(body)
+	return "v1=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,53 @@
+package sink
+
+import (
+	"time"
+)
+
+// Event types published to the sinks
+const (
+	TypeQueueStuck     = "queue_stuck"
+	TypeQueueRecovered = "queue_recovered"
+	TypeCheck          = "check"
+)
+
+// Event is a structured record of a queue incident or check cycle
+// All fields are always present so the event maps onto a fixed schema
+type Event struct {
+	SchemaVersion string    `json:"schema_version"` // SchemaVersion of the contract the event follows
+	Type          string    `json:"type"`
+	Timestamp     time.Time `json:"timestamp"`
+	Cluster       string    `json:"cluster"` // Management API host
+	VHost         string    `json:"vhost"`
+	CheckID       string    `json:"check_id"` // Check cycle that produced the event
+	// Queue events
+	Queue                string  `json:"queue"`
+	IncidentID           string  `json:"incident_id"`
+	Priority             string  `json:"priority"`
+	Reason               string  `json:"reason"`
+	MessagesReady        int     `json:"messages_ready"`
+	Consumers            int     `json:"consumers"`
+	ConsumeRate          float64 `json:"consume_rate"`
+	PublishRate          float64 `json:"publish_rate"`
+	StuckDurationSeconds float64 `json:"stuck_duration_seconds"` // Recovery events only
+	// Check events
+	TrackedQueues   int     `json:"tracked_queues"`
+	AlertingQueues  int     `json:"alerting_queues"`
+	DurationSeconds float64 `json:"duration_seconds"`
+	Error           string  `json:"error"`
+}
+
+// Key returns the record key, so events of one queue keep their order on partitioned topics
+func (e Event) Key() string {
+	if e.Queue != "" {
+		return e.Queue
+	}
+	return e.Type
+}
+
+// Sink publishes events to an external system
+type Sink interface {
+	Name() string
+	Publish(event Event) error
+	Close() error
+}
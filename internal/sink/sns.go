@@ -0,0 +1,133 @@
+package sink
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go-rmq-monitor/internal/awsauth"
+)
+
+// maxSNSSubjectLength is the longest subject SNS accepts
+const maxSNSSubjectLength = 100
+
+// SNS publishes events to an Amazon SNS topic
+// Events carry "type" and "queue" message attributes for subscription filter policies
+type SNS struct {
+	topicARN    string
+	region      string
+	endpoint    string
+	envelope    Envelope
+	credentials *awsauth.Provider
+	httpClient  *http.Client
+}
+
+// NewSNS creates a sink publishing to the topic, in the topic's region
+func NewSNS(topicARN string, credentials *awsauth.Provider, envelope Envelope, timeout time.Duration) (*SNS, error) {
+	region, err := arnRegion(topicARN)
+	if err != nil {
+		return nil, err
+	}
+	return &SNS{
+		topicARN:    topicARN,
+		region:      region,
+		endpoint:    "https://sns." + region + ".amazonaws.com/",
+		envelope:    envelope,
+		credentials: credentials,
+		httpClient:  &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Name identifies the sink in logs and metrics
+func (s *SNS) Name() string {
+	return "sns"
+}
+
+// Publish sends the event as the JSON message body
+func (s *SNS) Publish(event Event) error {
+	message, err := json.Marshal(s.envelope.Wrap(event))
+	if err != nil {
+		return fmt.Errorf("failed to marshal sns message: %w", err)
+	}
+
+	form := url.Values{
+		"Action":                         {"Publish"},
+		"Version":                        {"2010-03-31"},
+		"TopicArn":                       {s.topicARN},
+		"Message":                        {string(message)},
+		"Subject":                        {snsSubject(event)},
+		"MessageAttributes.entry.1.Name": {"type"},
+		"MessageAttributes.entry.1.Value.DataType":    {"String"},
+		"MessageAttributes.entry.1.Value.StringValue": {event.Type},
+	}
+	if event.Queue != "" {
+		form.Set("MessageAttributes.entry.2.Name", "queue")
+		form.Set("MessageAttributes.entry.2.Value.DataType", "String")
+		form.Set("MessageAttributes.entry.2.Value.StringValue", event.Queue)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create sns request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	if err := signAWS(req, s.credentials, "sns", s.region); err != nil {
+		return err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("sns request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var failure struct {
+			Code    string `xml:"Error>Code"`
+			Message string `xml:"Error>Message"`
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if xml.Unmarshal(body, &failure) == nil && failure.Code != "" {
+			return fmt.Errorf("sns returned status %d: %s: %s", resp.StatusCode, failure.Code, failure.Message)
+		}
+		return fmt.Errorf("sns returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Close releases idle connections
+func (s *SNS) Close() error {
+	s.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// snsSubject returns a short subject line for email subscriptions
+func snsSubject(event Event) string {
+	subject := "RabbitMQ " + strings.ReplaceAll(event.Type, "_", " ")
+	if event.Queue != "" {
+		subject += ": " + event.Queue
+	}
+	if len(subject) > maxSNSSubjectLength {
+		subject = subject[:maxSNSSubjectLength]
+	}
+	return subject
+}
+
+// arnRegion returns the region of an ARN (arn:partition:service:region:account:resource)
+func arnRegion(arn string) (string, error) {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" || parts[3] == "" {
+		return "", fmt.Errorf("invalid ARN %q", arn)
+	}
+	return parts[3], nil
+}
+
+// signAWS signs a request with the provider's current credentials
+func signAWS(req *http.Request, credentials *awsauth.Provider, service, region string) error {
+	return credentials.Sign(req, service, region)
+}
@@ -0,0 +1,81 @@
+package sink
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Webhook posts events as JSON to generic HTTP endpoints
+type Webhook struct {
+	urls          []string
+	envelope      Envelope
+	signingSecret string // Signs requests with an HMAC header if set
+	httpClient    *http.Client
+}
+
+// NewWebhook creates a sink posting to every URL
+func NewWebhook(urls []string, envelope Envelope, signingSecret string, timeout time.Duration) *Webhook {
+	return &Webhook{
+		urls:          urls,
+		envelope:      envelope,
+		signingSecret: signingSecret,
+		httpClient:    &http.Client{Timeout: timeout},
+	}
+}
+
+// Name identifies the sink in logs and metrics
+func (w *Webhook) Name() string {
+	return "webhook"
+}
+
+// Publish posts the event to every URL
+// Succeeds if at least one endpoint accepted it with a 2xx status
+func (w *Webhook) Publish(event Event) error {
+	body, err := json.Marshal(w.envelope.Wrap(event))
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook event: %w", err)
+	}
+
+	var lastError error
+	delivered := false
+	for i, url := range w.urls {
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			lastError = fmt.Errorf("webhook %d has an invalid URL: %w", i+1, err)
+			continue
+		}
+		req.Header.Set("Content-Type", w.envelope.ContentType())
+		if w.signingSecret != "" {
+			timestamp := time.Now().Unix()
+			req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+			req.Header.Set(SignatureHeader, Sign(w.signingSecret, timestamp, body))
+		}
+
+		resp, err := w.httpClient.Do(req)
+		if err != nil {
+			lastError = fmt.Errorf("webhook %d failed: %w", i+1, err)
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			lastError = fmt.Errorf("webhook %d returned status %d", i+1, resp.StatusCode)
+			continue
+		}
+		delivered = true
+	}
+
+	if !delivered {
+		return lastError
+	}
+	return nil
+}
+
+// Close releases idle connections
+func (w *Webhook) Close() error {
+	w.httpClient.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,114 @@
+package slack
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Action IDs of the buttons on alert messages
+const (
+	ActionAcknowledge   = "acknowledge_alert"
+	ActionFalsePositive = "false_positive_alert"
+)
+
+// ActionFunc handles a button click for a queue by a Slack user
+type ActionFunc func(queueName, user string) error
+
+// interactionPayload is the subset of a Slack block_actions payload we use
+type interactionPayload struct {
+	Type string `json:"type"`
+	User struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+		Name     string `json:"name"`
+	} `json:"user"`
+	Actions []struct {
+		ActionID string `json:"action_id"`
+		Value    string `json:"value"`
+	} `json:"actions"`
+}
+
+// NewActionHandler returns an HTTP handler for Slack interactivity requests
+// Every request must carry a valid signature made with the signing secret, which Slack
+// sends instead of API credentials; without a secret all requests are rejected
+// handlers maps action IDs to the function called with the queue name and Slack user
+// Clicks on buttons without a handler are ignored
+func NewActionHandler(signingSecret string, handlers map[string]ActionFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+
+		if err := verifySignature(signingSecret, r.Header, body, time.Now()); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			http.Error(w, "invalid form body", http.StatusBadRequest)
+			return
+		}
+
+		var payload interactionPayload
+		if err := json.Unmarshal([]byte(form.Get("payload")), &payload); err != nil {
+			http.Error(w, "invalid payload", http.StatusBadRequest)
+			return
+		}
+
+		user := payload.User.Username
+		if user == "" {
+			user = payload.User.Name
+		}
+		if user == "" {
+			user = payload.User.ID
+		}
+
+		for _, action := range payload.Actions {
+			handler, exists := handlers[action.ActionID]
+			if !exists {
+				continue
+			}
+			if err := handler(action.Value, user); err != nil {
+				http.Error(w, err.Error(), http.StatusConflict)
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// verifySignature checks the Slack request signature and rejects stale requests
+func verifySignature(signingSecret string, header http.Header, body []byte, now time.Time) error {
+	if signingSecret == "" {
+		return fmt.Errorf("no signing secret configured")
+	}
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("missing or invalid request timestamp")
+	}
+	if now.Sub(time.Unix(seconds, 0)).Abs() > 5*time.Minute {
+		return fmt.Errorf("request timestamp too old")
+	}
+
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	mac.Write([]byte("v0:" + timestamp + ":" + string(body)))
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(header.Get("X-Slack-Signature"))) {
+		return fmt.Errorf("invalid request signature")
+	}
+	return nil
+}
@@ -4,18 +4,48 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"time"
 )
 
 // Config represents Slack notification configuration
 type Config struct {
-	Enabled          bool          `yaml:"enabled"`
-	WebhookURLs      []string      `yaml:"webhook_urls"`
-	AlertCooldown    time.Duration `yaml:"alert_cooldown"`
-	SendRecovery     bool          `yaml:"send_recovery"`
-	RecoveryCooldown time.Duration `yaml:"recovery_cooldown"`
-	Timeout          time.Duration `yaml:"timeout"`
+	Enabled          bool                      `yaml:"enabled"`
+	WebhookURLs      []string                  `yaml:"webhook_urls"`
+	AlertCooldown    time.Duration             `yaml:"alert_cooldown"`
+	SendRecovery     bool                      `yaml:"send_recovery"`
+	RecoveryCooldown time.Duration             `yaml:"recovery_cooldown"`
+	Timeout          time.Duration             `yaml:"timeout"`
+	Display          Display                   `yaml:"-"`
+	WebhookLanguages map[string]string         `yaml:"-"` // Per-webhook language overrides
+	Webhooks         map[string]WebhookOptions `yaml:"-"` // Per-webhook channel and mention settings
+}
+
+// WebhookOptions customizes the messages posted to one webhook
+type WebhookOptions struct {
+	Channel string // Channel override, honored by legacy webhooks
+	Mention string // Slack mention syntax, e.g. "<!here>", added to problem notifications
+}
+
+// FormatMention converts @here, @channel and @everyone to Slack mention syntax
+// Other mentions, such as "<!subteam^S0123ABC>" or "<@U0123ABC>", are used as is
+func FormatMention(mention string) string {
+	switch mention {
+	case "@here", "@channel", "@everyone":
+		return "<!" + strings.TrimPrefix(mention, "@") + ">"
+	}
+	return mention
+}
+
+// FormatGroupMention converts a Slack user group ID like S0123ABC to mention syntax
+// Anything else is passed to FormatMention
+func FormatGroupMention(group string) string {
+	if len(group) >= 9 && group[0] == 'S' && strings.ToUpper(group) == group && !strings.ContainsAny(group, "<@ ") {
+		return "<!subteam^" + group + ">"
+	}
+	return FormatMention(group)
 }
 
 // Client handles Slack webhook notifications
@@ -40,17 +70,258 @@ func New(config Config) *Client {
 
 // SendAlert sends a queue alert to all configured Slack webhooks
 func (c *Client) SendAlert(alert QueueAlert) error {
+	return c.SendAlertTo(alert, c.config.WebhookURLs)
+}
+
+// SendAlertTo sends a queue alert to the given Slack webhooks
+// Used to route alerts to webhooks other than the configured defaults
+func (c *Client) SendAlertTo(alert QueueAlert, webhookURLs []string) error {
+	if !c.config.Enabled {
+		return nil
+	}
+
+	if len(webhookURLs) == 0 {
+		return fmt.Errorf("no slack webhook URLs configured")
+	}
+
+	return c.sendLocalized(func(display Display) Message {
+		return FormatAlert(alert, display)
+	}, webhookURLs, alert.Type == AlertTypeAlerting)
+}
+
+// SendClusterAlert sends a cluster-wide problem notification to the given Slack webhooks
+func (c *Client) SendClusterAlert(alert ClusterAlert, webhookURLs []string) error {
+	if !c.config.Enabled {
+		return nil
+	}
+
+	if len(webhookURLs) == 0 {
+		return fmt.Errorf("no slack webhook URLs configured")
+	}
+
+	return c.sendLocalized(func(display Display) Message {
+		return FormatClusterAlert(alert, display)
+	}, webhookURLs, !alert.Resolved)
+}
+
+// SendConnectionAlert sends a protocol connection count notification to the given Slack webhooks
+func (c *Client) SendConnectionAlert(alert ConnectionAlert, webhookURLs []string) error {
+	if !c.config.Enabled {
+		return nil
+	}
+
+	if len(webhookURLs) == 0 {
+		return fmt.Errorf("no slack webhook URLs configured")
+	}
+
+	return c.sendLocalized(func(display Display) Message {
+		return FormatConnectionAlert(alert, display)
+	}, webhookURLs, !alert.Resolved)
+}
+
+// SendVHostAlert sends a vhost-wide limit notification to the given Slack webhooks
+func (c *Client) SendVHostAlert(alert VHostAlert, webhookURLs []string) error {
+	if !c.config.Enabled {
+		return nil
+	}
+
+	if len(webhookURLs) == 0 {
+		return fmt.Errorf("no slack webhook URLs configured")
+	}
+
+	return c.sendLocalized(func(display Display) Message {
+		return FormatVHostAlert(alert, display)
+	}, webhookURLs, !alert.Resolved)
+}
+
+// SendServiceAlert sends a service-wide limit notification to the given Slack webhooks
+func (c *Client) SendServiceAlert(alert ServiceAlert, webhookURLs []string) error {
+	if !c.config.Enabled {
+		return nil
+	}
+
+	if len(webhookURLs) == 0 {
+		return fmt.Errorf("no slack webhook URLs configured")
+	}
+
+	return c.sendLocalized(func(display Display) Message {
+		return FormatServiceAlert(alert, display)
+	}, webhookURLs, !alert.Resolved)
+}
+
+// SendShardAlert sends a shard outlier notification to the given Slack webhooks
+func (c *Client) SendShardAlert(alert ShardAlert, webhookURLs []string) error {
 	if !c.config.Enabled {
 		return nil
 	}
 
-	if len(c.config.WebhookURLs) == 0 {
+	if len(webhookURLs) == 0 {
 		return fmt.Errorf("no slack webhook URLs configured")
 	}
 
-	// Format the message once
-	message := FormatAlert(alert)
+	return c.sendLocalized(func(display Display) Message {
+		return FormatShardAlert(alert, display)
+	}, webhookURLs, !alert.Resolved)
+}
+
+// SendChurnAlert sends a queue churn notification to the given Slack webhooks
+func (c *Client) SendChurnAlert(alert ChurnAlert, webhookURLs []string) error {
+	if !c.config.Enabled {
+		return nil
+	}
+
+	if len(webhookURLs) == 0 {
+		return fmt.Errorf("no slack webhook URLs configured")
+	}
+
+	return c.sendLocalized(func(display Display) Message {
+		return FormatChurnAlert(alert, display)
+	}, webhookURLs, !alert.Resolved)
+}
+
+// SendComparisonAlert sends a queue comparison notification to the given Slack webhooks
+func (c *Client) SendComparisonAlert(alert ComparisonAlert, webhookURLs []string) error {
+	if !c.config.Enabled {
+		return nil
+	}
+
+	if len(webhookURLs) == 0 {
+		return fmt.Errorf("no slack webhook URLs configured")
+	}
+
+	return c.sendLocalized(func(display Display) Message {
+		return FormatComparisonAlert(alert, display)
+	}, webhookURLs, !alert.Resolved)
+}
+
+// SendDeadLetterAlert sends a dead-letter queue growth notification to the given Slack webhooks
+func (c *Client) SendDeadLetterAlert(alert DeadLetterAlert, webhookURLs []string) error {
+	if !c.config.Enabled {
+		return nil
+	}
 
+	if len(webhookURLs) == 0 {
+		return fmt.Errorf("no slack webhook URLs configured")
+	}
+
+	return c.sendLocalized(func(display Display) Message {
+		return FormatDeadLetterAlert(alert, display)
+	}, webhookURLs, !alert.Resolved)
+}
+
+// SendSLOBurnAlert sends an error budget burn rate notification to the given Slack webhooks
+func (c *Client) SendSLOBurnAlert(alert SLOBurnAlert, webhookURLs []string) error {
+	if !c.config.Enabled {
+		return nil
+	}
+
+	if len(webhookURLs) == 0 {
+		return fmt.Errorf("no slack webhook URLs configured")
+	}
+
+	return c.sendLocalized(func(display Display) Message {
+		return FormatSLOBurnAlert(alert, display)
+	}, webhookURLs, !alert.Resolved)
+}
+
+// SendCapacityAlert sends a capacity utilisation notification to the given Slack webhooks
+func (c *Client) SendCapacityAlert(alert CapacityAlert, webhookURLs []string) error {
+	if !c.config.Enabled {
+		return nil
+	}
+
+	if len(webhookURLs) == 0 {
+		return fmt.Errorf("no slack webhook URLs configured")
+	}
+
+	return c.sendLocalized(func(display Display) Message {
+		return FormatCapacityAlert(alert, display)
+	}, webhookURLs, !alert.Resolved)
+}
+
+// SendAuthAlert sends a management API authentication failure notification to the given Slack webhooks
+func (c *Client) SendAuthAlert(alert AuthAlert, webhookURLs []string) error {
+	if !c.config.Enabled {
+		return nil
+	}
+
+	if len(webhookURLs) == 0 {
+		return fmt.Errorf("no slack webhook URLs configured")
+	}
+
+	return c.sendLocalized(func(display Display) Message {
+		return FormatAuthAlert(alert, display)
+	}, webhookURLs, !alert.Resolved)
+}
+
+// SendLatencyAlert sends a slow notification delivery notification to the given Slack webhooks
+func (c *Client) SendLatencyAlert(alert LatencyAlert, webhookURLs []string) error {
+	if !c.config.Enabled {
+		return nil
+	}
+
+	if len(webhookURLs) == 0 {
+		return fmt.Errorf("no slack webhook URLs configured")
+	}
+
+	return c.sendLocalized(func(display Display) Message {
+		return FormatLatencyAlert(alert, display)
+	}, webhookURLs, !alert.Resolved)
+}
+
+// SendSLOReport sends a monthly SLO report to the given Slack webhooks
+func (c *Client) SendSLOReport(report SLOReport, webhookURLs []string) error {
+	if !c.config.Enabled {
+		return nil
+	}
+
+	if len(webhookURLs) == 0 {
+		return fmt.Errorf("no slack webhook URLs configured")
+	}
+
+	return c.sendLocalized(func(display Display) Message {
+		return FormatSLOReport(report, display)
+	}, webhookURLs, false)
+}
+
+// sendLocalized formats a message once per channel language and posts it
+// Problem notifications carry the webhooks' mentions
+// Succeeds if at least one webhook accepted the message
+func (c *Client) sendLocalized(format func(Display) Message, webhookURLs []string, problem bool) error {
+	languages := make([]string, 0, 1)
+	byLanguage := make(map[string][]string)
+	for _, webhookURL := range webhookURLs {
+		language := c.config.Display.Language
+		if override, ok := c.config.WebhookLanguages[webhookURL]; ok {
+			language = override
+		}
+		if _, seen := byLanguage[language]; !seen {
+			languages = append(languages, language)
+		}
+		byLanguage[language] = append(byLanguage[language], webhookURL)
+	}
+
+	var lastError error
+	delivered := false
+	for _, language := range languages {
+		display := c.config.Display
+		display.Language = language
+		if err := c.sendMessage(format(display), byLanguage[language], problem); err != nil {
+			lastError = err
+			continue
+		}
+		delivered = true
+	}
+
+	if !delivered {
+		return lastError
+	}
+	return nil
+}
+
+// sendMessage posts a formatted message to every webhook
+// Succeeds if at least one webhook accepted the message
+func (c *Client) sendMessage(message Message, webhookURLs []string, problem bool) error {
 	// Marshal to JSON once
 	payload, err := json.Marshal(message)
 	if err != nil {
@@ -61,16 +332,26 @@ func (c *Client) SendAlert(alert QueueAlert) error {
 	var lastError error
 	successCount := 0
 
-	for i, webhookURL := range c.config.WebhookURLs {
+	for i, webhookURL := range webhookURLs {
 		if webhookURL == "" {
 			continue
 		}
 
+		// Webhooks with a channel or mention get their own copy of the message
+		body := payload
+		if options, ok := c.config.Webhooks[webhookURL]; ok {
+			custom, err := json.Marshal(withOptions(message, options, problem))
+			if err != nil {
+				return fmt.Errorf("failed to marshal slack message: %w", err)
+			}
+			body = custom
+		}
+
 		// Send to Slack
 		resp, err := c.httpClient.Post(
 			webhookURL,
 			"application/json",
-			bytes.NewBuffer(payload),
+			bytes.NewBuffer(body),
 		)
 		if err != nil {
 			lastError = fmt.Errorf("webhook %d failed: %w", i+1, err)
@@ -96,6 +377,38 @@ func (c *Client) SendAlert(alert QueueAlert) error {
 	return nil
 }
 
+// PingWebhook checks that a webhook is live without posting a message
+// Slack rejects the empty payload of a live webhook with status 400,
+// while revoked or unknown webhooks answer 403, 404 or 410
+func (c *Client) PingWebhook(webhookURL string) error {
+	resp, err := c.httpClient.Post(webhookURL, "application/json", strings.NewReader("{}"))
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusBadRequest {
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+	return fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// withOptions applies a webhook's channel override and, for problem notifications, its mention
+func withOptions(message Message, options WebhookOptions, problem bool) Message {
+	if options.Channel != "" {
+		message.Channel = options.Channel
+	}
+	if problem && options.Mention != "" {
+		message.Text = options.Mention + " " + message.Text
+		// Mentions only notify from blocks when the message has them
+		if len(message.Blocks) > 0 {
+			mention := Block{Type: "section", Text: &TextObject{Type: "mrkdwn", Text: options.Mention}}
+			message.Blocks = append([]Block{mention}, message.Blocks...)
+		}
+	}
+	return message
+}
+
 // GetConfig returns the client configuration
 func (c *Client) GetConfig() Config {
 	return c.config
@@ -2,146 +2,1152 @@ package slack
 
 import (
 	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
+
+	"go-rmq-monitor/internal/numfmt"
+	"go-rmq-monitor/internal/queuename"
 )
 
+// DefaultTimeFormat is the Go layout used for timestamps when none is configured
+const DefaultTimeFormat = "2006-01-02 15:04:05 MST"
+
+// Display controls how timestamps and text appear in messages
+type Display struct {
+	Location   *time.Location   // Defaults to UTC
+	TimeFormat string           // Go time layout, defaults to DefaultTimeFormat
+	Language   string           // Built-in template language, defaults to DefaultLanguage
+	Templates  *Templates       // Optional custom templates for queue alerts
+	Numbers    *numfmt.Format   // Overrides the language's digit separators
+	QueueNames *queuename.Namer // Shortens long queue names, nil shows them unchanged
+}
+
+// QueueName returns the name a queue is shown under
+func (d Display) QueueName(name string) string {
+	return d.QueueNames.Name(name)
+}
+
+// languageOrDefault returns the display language, falling back to DefaultLanguage
+func (d Display) languageOrDefault() string {
+	if d.Language == "" {
+		return DefaultLanguage
+	}
+	return d.Language
+}
+
+// numbers returns the digit separators, overridden or following the display language
+func (d Display) numbers() numfmt.Format {
+	if d.Numbers != nil {
+		return *d.Numbers
+	}
+	return numfmt.ForLanguage(d.Language)
+}
+
+// FormatNumber renders a count with the display's thousands separator
+func (d Display) FormatNumber(n int) string {
+	return d.numbers().Int(n)
+}
+
+// FormatRate renders a message rate with two decimals in the display's format
+func (d Display) FormatRate(rate float64) string {
+	return d.numbers().Float(rate, 2) + " msg/s"
+}
+
+// FormatTime renders a timestamp in the display timezone and format
+func (d Display) FormatTime(t time.Time) string {
+	location := d.Location
+	if location == nil {
+		location = time.UTC
+	}
+	layout := d.TimeFormat
+	if layout == "" {
+		layout = DefaultTimeFormat
+	}
+	return t.In(location).Format(layout)
+}
+
 // FormatAlert formats a QueueAlert into a Slack message
-func FormatAlert(alert QueueAlert) Message {
-	if alert.Type == AlertTypeAlerting {
-		return formatAlertingMessage(alert)
+// Custom templates take precedence over the built-in layouts
+func FormatAlert(alert QueueAlert, display Display) Message {
+	message := formatAlert(alert, display)
+	if correlation := alert.correlation(); correlation != "" {
+		element := TextObject{Type: "mrkdwn", Text: correlation}
+		if last := len(message.Blocks) - 1; last >= 0 && message.Blocks[last].Type == "context" {
+			message.Blocks[last].Elements = append(message.Blocks[last].Elements, element)
+		} else {
+			message.Blocks = append(message.Blocks, Block{Type: "context", Elements: []TextObject{element}})
+		}
 	}
-	return formatNotAlertingMessage(alert)
+	return message
+}
+
+// correlation renders the incident and check IDs that trace a notification back to its logs
+func (a QueueAlert) correlation() string {
+	ids := make([]string, 0, 2)
+	for _, id := range []string{a.IncidentID, a.CheckID} {
+		if id != "" {
+			ids = append(ids, "`"+id+"`")
+		}
+	}
+	if len(ids) == 0 {
+		return ""
+	}
+	return "🔗 " + strings.Join(ids, " · ")
+}
+
+// formatAlert renders a queue alert with its custom template or built-in layout
+func formatAlert(alert QueueAlert, display Display) Message {
+	if tmpl := display.Templates.forAlert(alert.Type); tmpl != nil {
+		// Templates are dry-run at load time, so fall back to the built-in layout on failure
+		if message, err := renderTemplate(tmpl, alert, display); err == nil {
+			return message
+		}
+	}
+	switch alert.Type {
+	case AlertTypeAlerting:
+		return formatAlertingMessage(alert, display)
+	case AlertTypeReminder:
+		return formatReminderMessage(alert, display)
+	case AlertTypeRecovering:
+		return formatRecoveringMessage(alert, display)
+	}
+	return formatNotAlertingMessage(alert, display)
 }
 
 // formatAlertingMessage creates a Slack message for an alerting queue
-func formatAlertingMessage(alert QueueAlert) Message {
-	timestamp := alert.Timestamp.UTC().Format("2006-01-02 15:04:05 UTC")
+func formatAlertingMessage(alert QueueAlert, display Display) Message {
+	timestamp := display.FormatTime(alert.Timestamp)
+	c := catalogFor(display.Language)
 
-	return Message{
-		Text: fmt.Sprintf("🚨 Queue `%s` is alerting!", alert.QueueName),
+	detailFields := []TextObject{
+		{Type: "mrkdwn", Text: field(c.ConsecutiveStuck, fmt.Sprintf(c.Checks, alert.ConsecutiveStuck))},
+	}
+	if alert.Priority != "" {
+		detailFields = append(detailFields, TextObject{Type: "mrkdwn", Text: field(c.Priority, alert.Priority)})
+	}
+	if alert.Node != "" {
+		detailFields = append(detailFields, TextObject{Type: "mrkdwn", Text: field(c.Node, "`"+alert.Node+"`")})
+	}
+	if alert.DeadLetterQueue != "" {
+		detailFields = append(detailFields, TextObject{Type: "mrkdwn", Text: field(c.DeadLetters, formatDeadLetters(alert, display))})
+	}
+	if len(alert.PriorityLengths) > 0 {
+		detailFields = append(detailFields, TextObject{Type: "mrkdwn", Text: field(c.PriorityBacklog, formatPriorityLengths(alert.PriorityLengths, display))})
+	}
+	if owner := alert.Owner; owner != nil {
+		detailFields = append(detailFields, TextObject{Type: "mrkdwn", Text: field(c.Owner, owner.Team)})
+		if owner.Escalation != "" {
+			detailFields = append(detailFields, TextObject{Type: "mrkdwn", Text: field(c.Escalation, owner.Escalation)})
+		}
+	}
+
+	message := Message{
+		Text: fmt.Sprintf(c.AlertText, display.QueueName(alert.QueueName)),
 		Blocks: []Block{
 			{
 				Type: "header",
 				Text: &TextObject{
 					Type: "plain_text",
-					Text: "🚨 Queue Alert",
+					Text: c.AlertHeader,
 				},
 			},
 			{
 				Type: "section",
 				Fields: []TextObject{
-					{Type: "mrkdwn", Text: fmt.Sprintf("*Queue:*\n`%s`", alert.QueueName)},
-					{Type: "mrkdwn", Text: fmt.Sprintf("*VHost:*\n`%s`", alert.VHost)},
-					{Type: "mrkdwn", Text: fmt.Sprintf("*Messages:*\n%s 📊", formatNumber(alert.MessagesReady))},
-					{Type: "mrkdwn", Text: fmt.Sprintf("*Consumers:*\n%d 👷", alert.Consumers)},
+					{Type: "mrkdwn", Text: field(c.Queue, "`"+display.QueueName(alert.QueueName)+"`")},
+					{Type: "mrkdwn", Text: field(c.VHost, "`"+alert.VHost+"`")},
+					{Type: "mrkdwn", Text: field(c.Messages, display.FormatNumber(alert.MessagesReady)+" 📊")},
+					{Type: "mrkdwn", Text: field(c.Consumers, fmt.Sprintf("%d 👷", alert.Consumers))},
 				},
 			},
 			{
 				Type: "section",
 				Fields: []TextObject{
-					{Type: "mrkdwn", Text: fmt.Sprintf("*Consume Rate:*\n%.2f msg/s", alert.ConsumeRate)},
-					{Type: "mrkdwn", Text: fmt.Sprintf("*Ack Rate:*\n%.2f msg/s", alert.AckRate)},
-					{Type: "mrkdwn", Text: fmt.Sprintf("*Publish Rate:*\n%.2f msg/s", alert.PublishRate)},
-					{Type: "mrkdwn", Text: "*Monitor Status:*\n🔴 Alerting"},
+					{Type: "mrkdwn", Text: field(c.ConsumeRate, display.FormatRate(alert.ConsumeRate))},
+					{Type: "mrkdwn", Text: field(c.AckRate, display.FormatRate(alert.AckRate))},
+					{Type: "mrkdwn", Text: field(c.PublishRate, display.FormatRate(alert.PublishRate))},
+					{Type: "mrkdwn", Text: field(c.MonitorStatus, c.StatusAlerting)},
+				},
+			},
+			{
+				Type:   "section",
+				Fields: detailFields,
+			},
+			{
+				Type: "section",
+				Text: &TextObject{
+					Type: "mrkdwn",
+					Text: fmt.Sprintf("*%s:* %s", c.Problem, alert.Reason),
+				},
+			},
+		},
+	}
+
+	if len(alert.DownstreamQueues) > 0 {
+		message.Blocks = append(message.Blocks, Block{
+			Type: "section",
+			Text: &TextObject{
+				Type: "mrkdwn",
+				Text: fmt.Sprintf(c.LikelyRootCause, len(alert.DownstreamQueues), strings.Join(display.QueueNames.Names(alert.DownstreamQueues), "`, `")),
+			},
+		})
+	}
+
+	if len(alert.BrokerEvents) > 0 {
+		message.Blocks = append(message.Blocks, Block{
+			Type: "section",
+			Text: &TextObject{
+				Type: "mrkdwn",
+				Text: "*" + c.RecentBrokerEvents + ":*\n• " + strings.Join(alert.BrokerEvents, "\n• "),
+			},
+		})
+	}
+
+	if len(alert.ConsumerDetails) > 0 {
+		message.Blocks = append(message.Blocks, Block{
+			Type: "section",
+			Text: &TextObject{
+				Type: "mrkdwn",
+				Text: "*" + c.Consumers + ":*\n• " + strings.Join(alert.ConsumerDetails, "\n• "),
+			},
+		})
+	}
+
+	if len(alert.HeadMessages) > 0 {
+		message.Blocks = append(message.Blocks, Block{
+			Type: "section",
+			Text: &TextObject{
+				Type: "mrkdwn",
+				Text: "*" + c.HeadMessages + ":*\n• " + strings.Join(alert.HeadMessages, "\n• "),
+			},
+		})
+	}
+
+	if quarantine := alert.Quarantine; quarantine != nil {
+		label := fmt.Sprintf(c.Quarantined, display.QueueName(quarantine.Queue))
+		if quarantine.DryRun {
+			label = fmt.Sprintf(c.QuarantineDryRun, display.QueueName(quarantine.Queue))
+		}
+		text := "*" + label + ":*"
+		if len(quarantine.Messages) > 0 {
+			text += "\n• " + strings.Join(quarantine.Messages, "\n• ")
+		}
+		if quarantine.Error != "" {
+			text += "\n⚠️ " + fmt.Sprintf(c.QuarantineFailed, quarantine.Error)
+		}
+		message.Blocks = append(message.Blocks, Block{
+			Type: "section",
+			Text: &TextObject{
+				Type: "mrkdwn",
+				Text: text,
+			},
+		})
+	}
+
+	if actions, ok := actionsBlock(alert, c); ok {
+		message.Blocks = append(message.Blocks, actions)
+	}
+
+	// Mention the owning team after the header so the alert notifies them
+	if owner := alert.Owner; owner != nil && owner.SlackGroup != "" {
+		mention := FormatGroupMention(owner.SlackGroup)
+		message.Text = mention + " " + message.Text
+		message.Blocks = append(message.Blocks[:1], append([]Block{{
+			Type: "section",
+			Text: &TextObject{Type: "mrkdwn", Text: "👥 " + mention},
+		}}, message.Blocks[1:]...)...)
+	}
+
+	message.Blocks = append(message.Blocks, Block{
+		Type: "context",
+		Elements: []TextObject{
+			{Type: "mrkdwn", Text: fmt.Sprintf("🕒 %s: %s", c.AlertedAt, timestamp)},
+		},
+	})
+
+	return message
+}
+
+// actionsBlock builds the interactive buttons enabled for an alert
+func actionsBlock(alert QueueAlert, c catalog) (Block, bool) {
+	buttons := make([]Button, 0, 2)
+	if alert.AckButton {
+		buttons = append(buttons, Button{
+			Type:     "button",
+			Text:     TextObject{Type: "plain_text", Text: c.Acknowledge},
+			ActionID: ActionAcknowledge,
+			Value:    alert.QueueName,
+			Style:    "primary",
+		})
+	}
+	if alert.FalsePositiveButton {
+		buttons = append(buttons, Button{
+			Type:     "button",
+			Text:     TextObject{Type: "plain_text", Text: c.FalsePositive},
+			ActionID: ActionFalsePositive,
+			Value:    alert.QueueName,
+		})
+	}
+	if len(buttons) == 0 {
+		return Block{}, false
+	}
+	return Block{Type: "actions", Buttons: buttons}, true
+}
+
+// field formats a bold label above its value
+func field(label, value string) string {
+	return fmt.Sprintf("*%s:*\n%s", label, value)
+}
+
+// formatNotAlertingMessage creates a Slack message for a recovered queue
+func formatNotAlertingMessage(alert QueueAlert, display Display) Message {
+	timestamp := display.FormatTime(alert.Timestamp)
+	duration := FormatDuration(alert.StuckDuration, display.Language)
+	c := catalogFor(display.Language)
+
+	recoveryFields := []TextObject{
+		{Type: "mrkdwn", Text: field(c.PublishRate, display.FormatRate(alert.PublishRate))},
+	}
+	if alert.AcknowledgedBy != "" {
+		recoveryFields = append(recoveryFields, TextObject{Type: "mrkdwn", Text: field(c.AcknowledgedBy, alert.AcknowledgedBy)})
+	}
+	if alert.DeadLetterQueue != "" {
+		recoveryFields = append(recoveryFields, TextObject{Type: "mrkdwn", Text: field(c.DeadLetters, formatDeadLetters(alert, display))})
+	}
+
+	message := Message{
+		Text: fmt.Sprintf(c.RecoveryText, display.QueueName(alert.QueueName)),
+		Blocks: []Block{
+			{
+				Type: "header",
+				Text: &TextObject{
+					Type: "plain_text",
+					Text: c.RecoveryHeader,
 				},
 			},
 			{
 				Type: "section",
 				Fields: []TextObject{
-					{Type: "mrkdwn", Text: fmt.Sprintf("*Consecutive Stuck:*\n%d checks", alert.ConsecutiveStuck)},
+					{Type: "mrkdwn", Text: field(c.Queue, "`"+display.QueueName(alert.QueueName)+"`")},
+					{Type: "mrkdwn", Text: field(c.VHost, "`"+alert.VHost+"`")},
+					{Type: "mrkdwn", Text: field(c.WasAlertingFor, duration+" ⏱️")},
+					{Type: "mrkdwn", Text: field(c.MonitorStatus, c.StatusNotAlerting)},
 				},
 			},
 			{
 				Type: "section",
+				Fields: []TextObject{
+					{Type: "mrkdwn", Text: field(c.CurrentMessages, display.FormatNumber(alert.MessagesReady))},
+					{Type: "mrkdwn", Text: field(c.Consumers, fmt.Sprintf("%d", alert.Consumers))},
+					{Type: "mrkdwn", Text: field(c.ConsumeRate, display.FormatRate(alert.ConsumeRate))},
+					{Type: "mrkdwn", Text: field(c.AckRate, display.FormatRate(alert.AckRate))},
+				},
+			},
+			{
+				Type:   "section",
+				Fields: recoveryFields,
+			},
+		},
+	}
+
+	if incident := alert.Incident; incident != nil {
+		message.Blocks = append(message.Blocks, Block{
+			Type: "section",
+			Fields: []TextObject{
+				{Type: "mrkdwn", Text: field(c.PeakBacklog, display.FormatNumber(incident.PeakBacklog)+" 📈")},
+				{Type: "mrkdwn", Text: field(c.ProcessedSincePeak, display.FormatNumber(incident.Processed))},
+				{Type: "mrkdwn", Text: field(c.AverageDrainRate, display.FormatRate(incident.DrainRate))},
+			},
+		})
+	}
+
+	message.Blocks = append(message.Blocks, Block{
+		Type: "context",
+		Elements: []TextObject{
+			{Type: "mrkdwn", Text: fmt.Sprintf("🕒 %s: %s", c.NoLongerAlertingAt, timestamp)},
+		},
+	})
+
+	return message
+}
+
+// formatReminderMessage creates a Slack message for a queue that is still alerting
+func formatReminderMessage(alert QueueAlert, display Display) Message {
+	c := catalogFor(display.Language)
+	return Message{
+		Text: fmt.Sprintf(c.ReminderText, display.QueueName(alert.QueueName)),
+		Blocks: []Block{
+			{
+				Type: "header",
 				Text: &TextObject{
-					Type: "mrkdwn",
-					Text: fmt.Sprintf("*Problem:* %s", alert.Reason),
+					Type: "plain_text",
+					Text: c.ReminderHeader,
+				},
+			},
+			{
+				Type: "section",
+				Fields: []TextObject{
+					{Type: "mrkdwn", Text: field(c.Queue, "`"+display.QueueName(alert.QueueName)+"`")},
+					{Type: "mrkdwn", Text: field(c.VHost, "`"+alert.VHost+"`")},
+					{Type: "mrkdwn", Text: field(c.AlertingFor, FormatDuration(alert.StuckDuration, display.Language)+" ⏱️")},
+					{Type: "mrkdwn", Text: field(c.MonitorStatus, c.StatusAlerting)},
+				},
+			},
+			{
+				Type: "section",
+				Fields: []TextObject{
+					{Type: "mrkdwn", Text: field(c.Messages, display.FormatNumber(alert.MessagesReady)+" 📊")},
+					{Type: "mrkdwn", Text: field(c.ChangeSinceAlert, formatChange(alert.InitialMessages, alert.MessagesReady, display))},
+					{Type: "mrkdwn", Text: field(c.Consumers, fmt.Sprintf("%d 👷", alert.Consumers))},
+					{Type: "mrkdwn", Text: field(c.ConsumeRate, display.FormatRate(alert.ConsumeRate))},
 				},
 			},
 			{
 				Type: "context",
 				Elements: []TextObject{
-					{Type: "mrkdwn", Text: fmt.Sprintf("🕒 Alerted at: %s", timestamp)},
+					{Type: "mrkdwn", Text: fmt.Sprintf("🕒 %s", display.FormatTime(alert.Timestamp))},
 				},
 			},
 		},
 	}
 }
 
-// formatNotAlertingMessage creates a Slack message for a recovered queue
-func formatNotAlertingMessage(alert QueueAlert) Message {
-	timestamp := alert.Timestamp.UTC().Format("2006-01-02 15:04:05 UTC")
-	duration := formatDuration(alert.StuckDuration)
-
+// formatRecoveringMessage creates a Slack message for an alerting queue whose backlog is decreasing
+func formatRecoveringMessage(alert QueueAlert, display Display) Message {
+	c := catalogFor(display.Language)
 	return Message{
-		Text: fmt.Sprintf("✅ Queue `%s` is no longer alerting!", alert.QueueName),
+		Text: fmt.Sprintf(c.RecoveringText, display.QueueName(alert.QueueName)),
 		Blocks: []Block{
 			{
 				Type: "header",
 				Text: &TextObject{
 					Type: "plain_text",
-					Text: "✅ Queue No Longer Alerting",
+					Text: c.RecoveringHeader,
 				},
 			},
 			{
 				Type: "section",
 				Fields: []TextObject{
-					{Type: "mrkdwn", Text: fmt.Sprintf("*Queue:*\n`%s`", alert.QueueName)},
-					{Type: "mrkdwn", Text: fmt.Sprintf("*VHost:*\n`%s`", alert.VHost)},
-					{Type: "mrkdwn", Text: fmt.Sprintf("*Was Alerting For:*\n%s ⏱️", duration)},
-					{Type: "mrkdwn", Text: "*Monitor Status:*\n🟢 Not Alerting"},
+					{Type: "mrkdwn", Text: field(c.Queue, "`"+display.QueueName(alert.QueueName)+"`")},
+					{Type: "mrkdwn", Text: field(c.VHost, "`"+alert.VHost+"`")},
+					{Type: "mrkdwn", Text: field(c.AlertingFor, FormatDuration(alert.StuckDuration, display.Language)+" ⏱️")},
+					{Type: "mrkdwn", Text: field(c.MonitorStatus, c.StatusRecovering)},
 				},
 			},
 			{
 				Type: "section",
 				Fields: []TextObject{
-					{Type: "mrkdwn", Text: fmt.Sprintf("*Current Messages:*\n%s", formatNumber(alert.MessagesReady))},
-					{Type: "mrkdwn", Text: fmt.Sprintf("*Consumers:*\n%d", alert.Consumers)},
-					{Type: "mrkdwn", Text: fmt.Sprintf("*Consume Rate:*\n%.2f msg/s", alert.ConsumeRate)},
-					{Type: "mrkdwn", Text: fmt.Sprintf("*Ack Rate:*\n%.2f msg/s", alert.AckRate)},
+					{Type: "mrkdwn", Text: field(c.Messages, display.FormatNumber(alert.MessagesReady)+" 📊")},
+					{Type: "mrkdwn", Text: field(c.DrainRate, display.FormatRate(alert.DrainRate))},
+					{Type: "mrkdwn", Text: field(c.Consumers, fmt.Sprintf("%d 👷", alert.Consumers))},
+					{Type: "mrkdwn", Text: field(c.ConsumeRate, display.FormatRate(alert.ConsumeRate))},
 				},
 			},
 			{
+				Type: "context",
+				Elements: []TextObject{
+					{Type: "mrkdwn", Text: fmt.Sprintf("🕒 %s", display.FormatTime(alert.Timestamp))},
+				},
+			},
+		},
+	}
+}
+
+// formatChange renders a backlog change like "+1,200 (800 → 2,000)"
+func formatChange(from, to int, display Display) string {
+	delta := display.FormatNumber(to - from)
+	if to > from {
+		delta = "+" + delta
+	}
+	return fmt.Sprintf("%s (%s → %s)", delta, display.FormatNumber(from), display.FormatNumber(to))
+}
+
+// FormatClusterAlert formats a ClusterAlert into a Slack message
+func FormatClusterAlert(alert ClusterAlert, display Display) Message {
+	timestamp := display.FormatTime(alert.Timestamp)
+	percent := 0.0
+	if alert.TotalQueues > 0 {
+		percent = float64(len(alert.AlertingQueues)) / float64(alert.TotalQueues) * 100
+	}
+
+	c := catalogFor(display.Language)
+
+	header := c.ClusterHeader
+	text := fmt.Sprintf(c.ClusterText, len(alert.AlertingQueues), alert.TotalQueues)
+	status := c.ClusterSuppressed
+	if alert.Resolved {
+		header = c.ClusterResolvedHeader
+		text = fmt.Sprintf(c.ClusterResolvedText, len(alert.AlertingQueues), alert.TotalQueues)
+		status = c.ClusterResumed
+	}
+
+	blocks := []Block{
+		{
+			Type: "header",
+			Text: &TextObject{
+				Type: "plain_text",
+				Text: header,
+			},
+		},
+		{
+			Type: "section",
+			Fields: []TextObject{
+				{Type: "mrkdwn", Text: field(c.AlertingQueues, fmt.Sprintf(c.OfQueues, len(alert.AlertingQueues), alert.TotalQueues, percent))},
+				{Type: "mrkdwn", Text: field(c.Threshold, fmt.Sprintf("%.0f%%", alert.ThresholdPercent))},
+				{Type: "mrkdwn", Text: field(c.MonitorStatus, status)},
+			},
+		},
+	}
+
+	if overview := alert.Overview; overview != nil {
+		processes := display.FormatNumber(overview.ErlangProcesses)
+		if overview.ErlangProcessLimit > 0 {
+			percent := float64(overview.ErlangProcesses) / float64(overview.ErlangProcessLimit) * 100
+			processes = fmt.Sprintf("%s / %s (%.0f%%)", processes, display.FormatNumber(overview.ErlangProcessLimit), percent)
+		}
+		blocks = append(blocks,
+			Block{
 				Type: "section",
+				Text: &TextObject{Type: "mrkdwn", Text: "*" + c.BrokerOverview + ":*"},
 				Fields: []TextObject{
-					{Type: "mrkdwn", Text: fmt.Sprintf("*Publish Rate:*\n%.2f msg/s", alert.PublishRate)},
+					{Type: "mrkdwn", Text: field(c.Nodes, fmt.Sprintf(c.NodesRunning, overview.RunningNodes, overview.Nodes))},
+					{Type: "mrkdwn", Text: field(c.ErlangProcesses, processes)},
+					{Type: "mrkdwn", Text: field(c.Connections, display.FormatNumber(overview.Connections))},
+					{Type: "mrkdwn", Text: field(c.Channels, display.FormatNumber(overview.Channels))},
+					{Type: "mrkdwn", Text: field(c.Queues, display.FormatNumber(overview.Queues))},
+					{Type: "mrkdwn", Text: field(c.Consumers, display.FormatNumber(overview.Consumers))},
 				},
 			},
+			Block{
+				Type: "section",
+				Fields: []TextObject{
+					{Type: "mrkdwn", Text: field(c.Messages, fmt.Sprintf(c.MessagesUnacked, display.FormatNumber(overview.Messages), display.FormatNumber(overview.MessagesUnacked)))},
+					{Type: "mrkdwn", Text: field(c.PublishRate, display.FormatRate(overview.PublishRate))},
+					{Type: "mrkdwn", Text: field(c.DeliverRate, display.FormatRate(overview.DeliverRate))},
+					{Type: "mrkdwn", Text: field(c.AckRate, display.FormatRate(overview.AckRate))},
+				},
+			},
+		)
+	}
+
+	if len(alert.AlertingQueues) > 0 {
+		blocks = append(blocks, Block{
+			Type: "section",
+			Text: &TextObject{
+				Type: "mrkdwn",
+				Text: fmt.Sprintf("*%s:* `%s`", c.Queues, strings.Join(truncateList(display.QueueNames.Names(alert.AlertingQueues), 20, c), "`, `")),
+			},
+		})
+	}
+
+	blocks = append(blocks, Block{
+		Type: "context",
+		Elements: []TextObject{
+			{Type: "mrkdwn", Text: fmt.Sprintf("🕒 %s: %s", c.At, timestamp)},
+		},
+	})
+
+	return Message{
+		Text:   text,
+		Blocks: blocks,
+	}
+}
+
+// FormatConnectionAlert creates a Slack message for a low protocol connection count
+func FormatConnectionAlert(alert ConnectionAlert, display Display) Message {
+	c := catalogFor(display.Language)
+
+	header := fmt.Sprintf(c.ConnectionsHeader, alert.Protocol)
+	text := fmt.Sprintf(c.ConnectionsText, alert.Connections, alert.Protocol, alert.MinConnections)
+	if alert.Resolved {
+		header = fmt.Sprintf(c.ConnectionsResolvedHeader, alert.Protocol)
+		text = fmt.Sprintf(c.ConnectionsResolvedText, alert.Connections, alert.Protocol, alert.MinConnections)
+	}
+
+	fields := []TextObject{
+		{Type: "mrkdwn", Text: field(c.OpenConnections, display.FormatNumber(alert.Connections))},
+		{Type: "mrkdwn", Text: field(c.Minimum, display.FormatNumber(alert.MinConnections))},
+		{Type: "mrkdwn", Text: field(c.VHost, "`"+alert.VHost+"`")},
+	}
+	if alert.Resolved {
+		fields = append(fields, TextObject{Type: "mrkdwn", Text: field(c.WasAlertingFor, FormatDuration(alert.AlertDuration, display.Language))})
+	}
+
+	return Message{
+		Text: text,
+		Blocks: []Block{
+			{
+				Type: "header",
+				Text: &TextObject{Type: "plain_text", Text: header},
+			},
+			{
+				Type:   "section",
+				Fields: fields,
+			},
 			{
 				Type: "context",
 				Elements: []TextObject{
-					{Type: "mrkdwn", Text: fmt.Sprintf("🕒 No longer alerting at: %s", timestamp)},
+					{Type: "mrkdwn", Text: fmt.Sprintf("🕒 %s: %s", c.At, display.FormatTime(alert.Timestamp))},
 				},
 			},
 		},
 	}
 }
 
-// formatNumber formats a number with commas
-func formatNumber(n int) string {
-	if n < 1000 {
-		return fmt.Sprintf("%d", n)
+// FormatVHostAlert creates a Slack message for a vhost exceeding or returning within its limits
+func FormatVHostAlert(alert VHostAlert, display Display) Message {
+	c := catalogFor(display.Language)
+
+	header := c.VHostHeader
+	text := fmt.Sprintf(c.VHostText, alert.VHost)
+	if alert.Resolved {
+		header = c.VHostResolvedHeader
+		text = fmt.Sprintf(c.VHostResolvedText, alert.VHost)
+	}
+
+	limits := alert.Limits
+	fields := []TextObject{
+		{Type: "mrkdwn", Text: field(c.VHost, "`"+alert.VHost+"`")},
+		{Type: "mrkdwn", Text: field(c.TotalMessages, withLimit(
+			fmt.Sprintf(c.MessagesUnacked, display.FormatNumber(alert.Messages), display.FormatNumber(alert.MessagesUnacked)),
+			"≤ "+display.FormatNumber(limits.MaxMessages), limits.MaxMessages > 0, alert.Breached["messages"]))},
+		{Type: "mrkdwn", Text: field(c.ReadyMessages, withLimit(display.FormatNumber(alert.MessagesReady),
+			"≤ "+display.FormatNumber(limits.MaxMessagesReady), limits.MaxMessagesReady > 0, alert.Breached["messages_ready"]))},
+		{Type: "mrkdwn", Text: field(c.PublishRate, withLimit(display.FormatRate(alert.PublishRate),
+			"≤ "+display.FormatRate(limits.MaxPublishRate), limits.MaxPublishRate > 0, alert.Breached["publish_rate"]))},
+		{Type: "mrkdwn", Text: field(c.ConsumeRate, withLimit(display.FormatRate(alert.ConsumeRate),
+			"≥ "+display.FormatRate(limits.MinConsumeRate), limits.MinConsumeRate > 0, alert.Breached["consume_rate"]))},
+	}
+	if alert.Resolved {
+		fields = append(fields, TextObject{Type: "mrkdwn", Text: field(c.WasAlertingFor, FormatDuration(alert.AlertDuration, display.Language))})
+	}
+
+	return Message{
+		Text: text,
+		Blocks: []Block{
+			{
+				Type: "header",
+				Text: &TextObject{Type: "plain_text", Text: header},
+			},
+			{
+				Type:   "section",
+				Fields: fields,
+			},
+			{
+				Type: "context",
+				Elements: []TextObject{
+					{Type: "mrkdwn", Text: fmt.Sprintf("🕒 %s: %s", c.At, display.FormatTime(alert.Timestamp))},
+				},
+			},
+		},
 	}
-	return fmt.Sprintf("%d,%03d", n/1000, n%1000)
 }
 
-// formatDuration formats a duration in human-readable format
-func formatDuration(d time.Duration) string {
-	if d < time.Minute {
-		return fmt.Sprintf("%d seconds", int(d.Seconds()))
+// FormatServiceAlert creates a Slack message for a service exceeding its limits across its queues
+func FormatServiceAlert(alert ServiceAlert, display Display) Message {
+	c := catalogFor(display.Language)
+
+	header := c.ServiceHeader
+	text := fmt.Sprintf(c.ServiceText, alert.Service, alert.Queues)
+	if alert.Resolved {
+		header = c.ServiceResolvedHeader
+		text = fmt.Sprintf(c.ServiceResolvedText, alert.Service)
+	}
+
+	limits := alert.Limits
+	lag := FormatDuration(alert.Lag, display.Language)
+	if alert.Stalled {
+		lag = c.Stalled
+	}
+	fields := []TextObject{
+		{Type: "mrkdwn", Text: field(c.Service, "`"+alert.Service+"`")},
+		{Type: "mrkdwn", Text: field(c.Queues, fmt.Sprintf("%d", alert.Queues))},
+		{Type: "mrkdwn", Text: field(c.ReadyMessages, withLimit(display.FormatNumber(alert.MessagesReady),
+			"≤ "+display.FormatNumber(limits.MaxMessagesReady), limits.MaxMessagesReady > 0, alert.Breached["messages_ready"]))},
+		{Type: "mrkdwn", Text: field(c.Consumers, withLimit(fmt.Sprintf("%d", alert.Consumers),
+			fmt.Sprintf("≥ %d", limits.MinConsumers), limits.MinConsumers > 0, alert.Breached["consumers"]))},
+		{Type: "mrkdwn", Text: field(c.PublishRate, display.FormatRate(alert.PublishRate))},
+		{Type: "mrkdwn", Text: field(c.ConsumeRate, withLimit(display.FormatRate(alert.ConsumeRate),
+			"≥ "+display.FormatRate(limits.MinConsumeRate), limits.MinConsumeRate > 0, alert.Breached["consume_rate"]))},
+		{Type: "mrkdwn", Text: field(c.Lag, withLimit(lag,
+			"≤ "+FormatDuration(limits.MaxLag, display.Language), limits.MaxLag > 0, alert.Breached["lag"]))},
+	}
+	if alert.Resolved {
+		fields = append(fields, TextObject{Type: "mrkdwn", Text: field(c.WasAlertingFor, FormatDuration(alert.AlertDuration, display.Language))})
 	}
-	if d < time.Hour {
-		minutes := int(d.Minutes())
-		seconds := int(d.Seconds()) % 60
-		if seconds == 0 {
-			return fmt.Sprintf("%d minutes", minutes)
+
+	return Message{
+		Text: text,
+		Blocks: []Block{
+			{
+				Type: "header",
+				Text: &TextObject{Type: "plain_text", Text: header},
+			},
+			{
+				Type:   "section",
+				Fields: fields,
+			},
+			{
+				Type: "context",
+				Elements: []TextObject{
+					{Type: "mrkdwn", Text: fmt.Sprintf("🕒 %s: %s", c.At, display.FormatTime(alert.Timestamp))},
+				},
+			},
+		},
+	}
+}
+
+// FormatShardAlert creates a Slack message for a shard falling behind the other queues of its service
+func FormatShardAlert(alert ShardAlert, display Display) Message {
+	c := catalogFor(display.Language)
+
+	header := c.ShardHeader
+	text := fmt.Sprintf(c.ShardText, display.QueueName(alert.QueueName), alert.Service)
+	if alert.Resolved {
+		header = c.ShardResolvedHeader
+		text = fmt.Sprintf(c.ShardResolvedText, display.QueueName(alert.QueueName), alert.Service)
+	}
+
+	// Each value is shown next to the siblings' median, flagged when the shard is an outlier
+	withMedian := func(value, median string, outlying bool) string {
+		value = fmt.Sprintf("%s · %s: %s", value, c.SiblingMedian, median)
+		if outlying {
+			value += " ⚠️"
+		}
+		return value
+	}
+	fields := []TextObject{
+		{Type: "mrkdwn", Text: field(c.Queue, "`"+display.QueueName(alert.QueueName)+"`")},
+		{Type: "mrkdwn", Text: field(c.Service, fmt.Sprintf("`%s` (%d)", alert.Service, alert.Shards))},
+		{Type: "mrkdwn", Text: field(c.ReadyMessages, withMedian(display.FormatNumber(alert.MessagesReady),
+			display.FormatNumber(int(math.Round(alert.SiblingMessages))), alert.Outlying["messages_ready"]))},
+		{Type: "mrkdwn", Text: field(c.ConsumeRate, withMedian(display.FormatRate(alert.ConsumeRate),
+			display.FormatRate(alert.SiblingConsumeRate), alert.Outlying["consume_rate"]))},
+		{Type: "mrkdwn", Text: field(c.Consumers, fmt.Sprintf("%d", alert.Consumers))},
+	}
+	if alert.Resolved {
+		fields = append(fields, TextObject{Type: "mrkdwn", Text: field(c.WasAlertingFor, FormatDuration(alert.AlertDuration, display.Language))})
+	}
+
+	return Message{
+		Text: text,
+		Blocks: []Block{
+			{
+				Type: "header",
+				Text: &TextObject{Type: "plain_text", Text: header},
+			},
+			{
+				Type:   "section",
+				Fields: fields,
+			},
+			{
+				Type: "context",
+				Elements: []TextObject{
+					{Type: "mrkdwn", Text: fmt.Sprintf("🕒 %s: %s", c.At, display.FormatTime(alert.Timestamp))},
+				},
+			},
+		},
+	}
+}
+
+// FormatChurnAlert creates a Slack message for queues being created or deleted at an abnormal rate
+func FormatChurnAlert(alert ChurnAlert, display Display) Message {
+	c := catalogFor(display.Language)
+	perMinute := func(rate float64) string {
+		return fmt.Sprintf(c.PerMinute, display.FormatNumber(int(math.Round(rate))))
+	}
+
+	header := c.ChurnHeader
+	text := fmt.Sprintf(c.ChurnText, perMinute(alert.CreatedRate), perMinute(alert.DeletedRate))
+	if alert.Resolved {
+		header = c.ChurnResolvedHeader
+		text = fmt.Sprintf(c.ChurnResolvedText, perMinute(alert.CreatedRate), perMinute(alert.DeletedRate))
+	}
+
+	fields := []TextObject{
+		{Type: "mrkdwn", Text: field(c.QueuesCreated, withLimit(perMinute(alert.CreatedRate),
+			"≤ "+perMinute(alert.MaxCreated), alert.MaxCreated > 0, alert.MaxCreated > 0 && alert.CreatedRate > alert.MaxCreated))},
+		{Type: "mrkdwn", Text: field(c.QueuesDeleted, withLimit(perMinute(alert.DeletedRate),
+			"≤ "+perMinute(alert.MaxDeleted), alert.MaxDeleted > 0, alert.MaxDeleted > 0 && alert.DeletedRate > alert.MaxDeleted))},
+	}
+	if alert.Resolved {
+		fields = append(fields, TextObject{Type: "mrkdwn", Text: field(c.WasAlertingFor, FormatDuration(alert.AlertDuration, display.Language))})
+	}
+
+	return Message{
+		Text: text,
+		Blocks: []Block{
+			{
+				Type: "header",
+				Text: &TextObject{Type: "plain_text", Text: header},
+			},
+			{
+				Type:   "section",
+				Fields: fields,
+			},
+			{
+				Type: "context",
+				Elements: []TextObject{
+					{Type: "mrkdwn", Text: fmt.Sprintf("🕒 %s: %s", c.At, display.FormatTime(alert.Timestamp))},
+				},
+			},
+		},
+	}
+}
+
+// FormatComparisonAlert creates a Slack message for a queue diverging from its namesake in another vhost or cluster
+func FormatComparisonAlert(alert ComparisonAlert, display Display) Message {
+	c := catalogFor(display.Language)
+
+	header := c.ComparisonHeader
+	text := fmt.Sprintf(c.ComparisonText, display.QueueName(alert.QueueName), alert.Source, alert.Target)
+	switch {
+	case alert.Resolved:
+		header = c.ComparisonResolvedHeader
+		text = fmt.Sprintf(c.ComparisonResolvedText, display.QueueName(alert.QueueName), alert.Source, alert.Target)
+	case alert.Missing:
+		text = fmt.Sprintf(c.ComparisonMissingText, display.QueueName(alert.QueueName), alert.Source, alert.Target)
+	}
+
+	// Each value shows both sides, flagged when they diverge beyond the tolerance
+	sides := func(source, target string, diverged bool) string {
+		if alert.Missing {
+			target = c.Missing
+		}
+		value := fmt.Sprintf("%s: %s · %s: %s", alert.Source, source, alert.Target, target)
+		if diverged {
+			value += " ⚠️"
 		}
-		return fmt.Sprintf("%d minutes %d seconds", minutes, seconds)
+		return value
 	}
-	hours := int(d.Hours())
-	minutes := int(d.Minutes()) % 60
-	if minutes == 0 {
-		return fmt.Sprintf("%d hours", hours)
+	fields := []TextObject{
+		{Type: "mrkdwn", Text: field(c.Queue, "`"+display.QueueName(alert.QueueName)+"`")},
+		{Type: "mrkdwn", Text: field(c.Tolerance, fmt.Sprintf("±%.0f%%", alert.Tolerance*100))},
+		{Type: "mrkdwn", Text: field(c.ReadyMessages, sides(display.FormatNumber(alert.SourceStats.MessagesReady),
+			display.FormatNumber(alert.TargetStats.MessagesReady), alert.Diverged["messages_ready"]))},
+		{Type: "mrkdwn", Text: field(c.PublishRate, sides(display.FormatRate(alert.SourceStats.PublishRate),
+			display.FormatRate(alert.TargetStats.PublishRate), alert.Diverged["publish_rate"]))},
+		{Type: "mrkdwn", Text: field(c.ConsumeRate, sides(display.FormatRate(alert.SourceStats.ConsumeRate),
+			display.FormatRate(alert.TargetStats.ConsumeRate), alert.Diverged["consume_rate"]))},
+	}
+	if alert.Resolved {
+		fields = append(fields, TextObject{Type: "mrkdwn", Text: field(c.WasAlertingFor, FormatDuration(alert.AlertDuration, display.Language))})
+	}
+
+	return Message{
+		Text: text,
+		Blocks: []Block{
+			{
+				Type: "header",
+				Text: &TextObject{Type: "plain_text", Text: header},
+			},
+			{
+				Type:   "section",
+				Fields: fields,
+			},
+			{
+				Type: "context",
+				Elements: []TextObject{
+					{Type: "mrkdwn", Text: fmt.Sprintf("🕒 %s: %s", c.At, display.FormatTime(alert.Timestamp))},
+				},
+			},
+		},
+	}
+}
+
+// formatDeadLetters shows the paired dead-letter queue of a queue and its depth
+func formatDeadLetters(alert QueueAlert, display Display) string {
+	return fmt.Sprintf("`%s`: %s", display.QueueName(alert.DeadLetterQueue), display.FormatNumber(alert.DeadLetterMessages))
+}
+
+// FormatDeadLetterAlert creates a Slack message for a growing dead-letter queue, referencing the queue it belongs to
+func FormatDeadLetterAlert(alert DeadLetterAlert, display Display) Message {
+	c := catalogFor(display.Language)
+
+	header := c.DeadLetterHeader
+	text := fmt.Sprintf(c.DeadLetterText, display.QueueName(alert.DeadLetterQueue), display.QueueName(alert.QueueName))
+	if alert.Resolved {
+		header = c.DeadLetterResolvedHeader
+		text = fmt.Sprintf(c.DeadLetterResolvedText, display.QueueName(alert.DeadLetterQueue), display.QueueName(alert.QueueName))
+	}
+
+	fields := []TextObject{
+		{Type: "mrkdwn", Text: field(c.DeadLetterQueue, "`"+display.QueueName(alert.DeadLetterQueue)+"`")},
+		{Type: "mrkdwn", Text: field(c.OriginQueue, "`"+display.QueueName(alert.QueueName)+"`")},
+		{Type: "mrkdwn", Text: field(c.Messages, display.FormatNumber(alert.Messages))},
+		{Type: "mrkdwn", Text: field(c.Growth, "+"+display.FormatNumber(alert.Growth))},
+	}
+	if alert.Resolved {
+		fields = append(fields, TextObject{Type: "mrkdwn", Text: field(c.WasAlertingFor, FormatDuration(alert.AlertDuration, display.Language))})
+	}
+
+	return Message{
+		Text: text,
+		Blocks: []Block{
+			{
+				Type: "header",
+				Text: &TextObject{Type: "plain_text", Text: header},
+			},
+			{
+				Type:   "section",
+				Fields: fields,
+			},
+			{
+				Type: "context",
+				Elements: []TextObject{
+					{Type: "mrkdwn", Text: fmt.Sprintf("🕒 %s: %s", c.At, display.FormatTime(alert.Timestamp))},
+				},
+			},
+		},
+	}
+}
+
+// withLimit appends a configured limit to a value, flagging the value when the limit is breached
+func withLimit(value, limit string, limited, breached bool) string {
+	if !limited {
+		return value
+	}
+	if breached {
+		return fmt.Sprintf("%s ⚠️ (%s)", value, limit)
+	}
+	return fmt.Sprintf("%s (%s)", value, limit)
+}
+
+// FormatSLOBurnAlert creates a Slack message for a queue burning its error budget too fast
+func FormatSLOBurnAlert(alert SLOBurnAlert, display Display) Message {
+	c := catalogFor(display.Language)
+
+	header := c.SLOBurnHeader
+	text := fmt.Sprintf(c.SLOBurnText, display.QueueName(alert.QueueName), alert.BurnRate)
+	if alert.Resolved {
+		header = c.SLOBurnResolvedHeader
+		text = fmt.Sprintf(c.SLOBurnResolvedText, display.QueueName(alert.QueueName), alert.BurnRate)
+	}
+
+	return Message{
+		Text: text,
+		Blocks: []Block{
+			{
+				Type: "header",
+				Text: &TextObject{Type: "plain_text", Text: header},
+			},
+			{
+				Type: "section",
+				Text: &TextObject{Type: "mrkdwn", Text: text},
+				Fields: []TextObject{
+					{Type: "mrkdwn", Text: field(c.BurnRate, fmt.Sprintf("%.1fx (%s %.1fx, %s)", alert.BurnRate, c.Threshold, alert.Threshold, FormatDuration(alert.Window, display.Language)))},
+					{Type: "mrkdwn", Text: field(c.BudgetRemaining, fmt.Sprintf("%.1f%%", alert.BudgetRemaining))},
+					{Type: "mrkdwn", Text: field(c.SLOTarget, formatPercent(alert.Target))},
+				},
+			},
+			{
+				Type: "context",
+				Elements: []TextObject{
+					{Type: "mrkdwn", Text: fmt.Sprintf("🕒 %s: %s", c.At, display.FormatTime(alert.Timestamp))},
+				},
+			},
+		},
+	}
+}
+
+// FormatCapacityAlert creates a Slack message for a queue whose publish rate nears what its consumers can sustain
+func FormatCapacityAlert(alert CapacityAlert, display Display) Message {
+	c := catalogFor(display.Language)
+	utilisation := fmt.Sprintf("%.0f%%", alert.Utilisation*100)
+
+	header := c.CapacityHeader
+	text := fmt.Sprintf(c.CapacityText, display.QueueName(alert.QueueName), utilisation)
+	if alert.Resolved {
+		header = c.CapacityResolvedHeader
+		text = fmt.Sprintf(c.CapacityResolvedText, display.QueueName(alert.QueueName), utilisation)
+	}
+
+	return Message{
+		Text: text,
+		Blocks: []Block{
+			{
+				Type: "header",
+				Text: &TextObject{Type: "plain_text", Text: header},
+			},
+			{
+				Type: "section",
+				Text: &TextObject{Type: "mrkdwn", Text: text},
+				Fields: []TextObject{
+					{Type: "mrkdwn", Text: field(c.Utilisation, fmt.Sprintf("%s (%s %.0f%%)", utilisation, c.Threshold, alert.Threshold*100))},
+					{Type: "mrkdwn", Text: field(c.PublishRate, display.FormatRate(alert.PublishRate))},
+					{Type: "mrkdwn", Text: field(c.Capacity, display.FormatRate(alert.Capacity))},
+				},
+			},
+			{
+				Type: "context",
+				Elements: []TextObject{
+					{Type: "mrkdwn", Text: fmt.Sprintf("🕒 %s: %s", c.At, display.FormatTime(alert.Timestamp))},
+				},
+			},
+		},
+	}
+}
+
+// FormatAuthAlert creates a Slack message for the management API rejecting the monitor's credentials
+func FormatAuthAlert(alert AuthAlert, display Display) Message {
+	c := catalogFor(display.Language)
+
+	header := c.AuthHeader
+	text := fmt.Sprintf(c.AuthText, alert.Broker, alert.Username)
+	if alert.Resolved {
+		header = c.AuthResolvedHeader
+		text = fmt.Sprintf(c.AuthResolvedText, alert.Broker, alert.Username)
+	}
+
+	fields := []TextObject{
+		{Type: "mrkdwn", Text: field(c.Broker, alert.Broker)},
+		{Type: "mrkdwn", Text: field(c.User, alert.Username)},
+	}
+	if alert.Resolved {
+		fields = append(fields, TextObject{Type: "mrkdwn", Text: field(c.WasAlertingFor, FormatDuration(alert.AlertDuration, display.Language))})
+	} else if alert.Reload != "" {
+		fields = append(fields, TextObject{Type: "mrkdwn", Text: field(c.CredentialReload, alert.Reload)})
+	}
+
+	return Message{
+		Text: text,
+		Blocks: []Block{
+			{
+				Type: "header",
+				Text: &TextObject{Type: "plain_text", Text: header},
+			},
+			{
+				Type:   "section",
+				Text:   &TextObject{Type: "mrkdwn", Text: text},
+				Fields: fields,
+			},
+			{
+				Type: "context",
+				Elements: []TextObject{
+					{Type: "mrkdwn", Text: fmt.Sprintf("🕒 %s: %s", c.At, display.FormatTime(alert.Timestamp))},
+				},
+			},
+		},
+	}
+}
+
+// FormatLatencyAlert creates a Slack message for notifications delivered slower than allowed
+func FormatLatencyAlert(alert LatencyAlert, display Display) Message {
+	c := catalogFor(display.Language)
+
+	latency := FormatDuration(alert.Latency, display.Language)
+	limit := FormatDuration(alert.MaxLatency, display.Language)
+	header := c.LatencyHeader
+	text := fmt.Sprintf(c.LatencyText, alert.Channel, latency, limit)
+	if alert.Resolved {
+		header = c.LatencyResolvedHeader
+		text = fmt.Sprintf(c.LatencyResolvedText, alert.Channel, limit)
+	}
+
+	fields := []TextObject{
+		{Type: "mrkdwn", Text: field(c.Channel, alert.Channel)},
+		{Type: "mrkdwn", Text: field(c.DeliveryLatency, latency)},
+		{Type: "mrkdwn", Text: field(c.LatencyLimit, limit)},
+		{Type: "mrkdwn", Text: field(c.Queue, "`"+display.QueueName(alert.QueueName)+"`")},
+	}
+	if alert.Resolved {
+		fields = append(fields, TextObject{Type: "mrkdwn", Text: field(c.WasAlertingFor, FormatDuration(alert.AlertDuration, display.Language))})
+	}
+
+	return Message{
+		Text: text,
+		Blocks: []Block{
+			{
+				Type: "header",
+				Text: &TextObject{Type: "plain_text", Text: header},
+			},
+			{
+				Type:   "section",
+				Text:   &TextObject{Type: "mrkdwn", Text: text},
+				Fields: fields,
+			},
+			{
+				Type: "context",
+				Elements: []TextObject{
+					{Type: "mrkdwn", Text: fmt.Sprintf("🕒 %s: %s", c.At, display.FormatTime(alert.Timestamp))},
+				},
+			},
+		},
+	}
+}
+
+// FormatSLOReport creates a Slack message summarizing a month's availability per queue
+func FormatSLOReport(report SLOReport, display Display) Message {
+	c := catalogFor(display.Language)
+
+	met := 0
+	lines := make([]string, 0, len(report.Queues))
+	for _, queue := range report.Queues {
+		status := c.SLOMissed
+		if queue.Availability >= report.Target {
+			status = c.SLOMet
+			met++
+		}
+		line := fmt.Sprintf("`%s` %.3f%% · %s %.1f%% · %s", display.QueueName(queue.QueueName), queue.Availability, c.BudgetRemaining, queue.BudgetRemaining, status)
+		if queue.Incidents > 0 {
+			line += " · " + plural(queue.Incidents, c.Incident, c.Incidents)
+			if queue.MTTA > 0 {
+				line += " · MTTA " + FormatDuration(queue.MTTA, display.Language)
+			}
+			line += " · MTTR " + FormatDuration(queue.MTTR, display.Language)
+		}
+		lines = append(lines, line)
+	}
+	text := fmt.Sprintf(c.SLOReportText, met, len(report.Queues), formatPercent(report.Target), report.Month)
+
+	return Message{
+		Text: text,
+		Blocks: []Block{
+			{
+				Type: "header",
+				Text: &TextObject{Type: "plain_text", Text: fmt.Sprintf(c.SLOReportHeader, report.Month)},
+			},
+			{
+				Type: "section",
+				Text: &TextObject{Type: "mrkdwn", Text: text},
+			},
+			{
+				Type: "section",
+				Text: &TextObject{Type: "mrkdwn", Text: strings.Join(truncateList(lines, 30, c), "\n")},
+			},
+		},
+	}
+}
+
+// formatPercent formats a percentage without trailing zeros, e.g. 99.5%
+func formatPercent(percent float64) string {
+	return strconv.FormatFloat(percent, 'f', -1, 64) + "%"
+}
+
+// formatPriorityLengths lists the backlog per priority, highest priority first
+func formatPriorityLengths(lengths map[int]int, display Display) string {
+	priorities := make([]int, 0, len(lengths))
+	for priority := range lengths {
+		priorities = append(priorities, priority)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(priorities)))
+
+	parts := make([]string, 0, len(priorities))
+	for _, priority := range priorities {
+		parts = append(parts, fmt.Sprintf("P%d: %s", priority, display.FormatNumber(lengths[priority])))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// truncateList limits a list to max entries, noting how many were omitted
+func truncateList(items []string, max int, c catalog) []string {
+	if len(items) <= max {
+		return items
 	}
-	return fmt.Sprintf("%d hours %d minutes", hours, minutes)
+	result := make([]string, 0, max+1)
+	result = append(result, items[:max]...)
+	return append(result, fmt.Sprintf(c.AndMore, len(items)-max))
 }
@@ -0,0 +1,902 @@
+package slack
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DefaultLanguage is used when no language is configured
+const DefaultLanguage = "en"
+
+// catalog holds the translated strings of the built-in templates
+// Entries containing verbs are fmt format strings
+type catalog struct {
+	AlertText          string
+	AlertHeader        string
+	Queue              string
+	VHost              string
+	Messages           string
+	Consumers          string
+	ConsumeRate        string
+	AckRate            string
+	PublishRate        string
+	MonitorStatus      string
+	StatusAlerting     string
+	ConsecutiveStuck   string
+	Checks             string
+	Priority           string
+	PriorityBacklog    string
+	Problem            string
+	LikelyRootCause    string
+	RecentBrokerEvents string
+	HeadMessages       string
+	Acknowledge        string
+	FalsePositive      string
+	AlertedAt          string
+	Quarantined        string
+	QuarantineDryRun   string
+	QuarantineFailed   string
+	Owner              string
+	Escalation         string
+	Node               string
+	DeadLetters        string
+
+	RecoveryText       string
+	RecoveryHeader     string
+	WasAlertingFor     string
+	StatusNotAlerting  string
+	CurrentMessages    string
+	AcknowledgedBy     string
+	NoLongerAlertingAt string
+	PeakBacklog        string
+	ProcessedSincePeak string
+	AverageDrainRate   string
+
+	ReminderText     string
+	ReminderHeader   string
+	AlertingFor      string
+	ChangeSinceAlert string
+
+	RecoveringText   string
+	RecoveringHeader string
+	StatusRecovering string
+	DrainRate        string
+
+	ClusterHeader         string
+	ClusterText           string
+	ClusterSuppressed     string
+	ClusterResolvedHeader string
+	ClusterResolvedText   string
+	ClusterResumed        string
+	AlertingQueues        string
+	OfQueues              string
+	Threshold             string
+	Queues                string
+	At                    string
+	AndMore               string
+	BrokerOverview        string
+	Nodes                 string
+	NodesRunning          string
+	ErlangProcesses       string
+	Connections           string
+	Channels              string
+	MessagesUnacked       string
+	DeliverRate           string
+
+	ConnectionsHeader         string
+	ConnectionsText           string
+	ConnectionsResolvedHeader string
+	ConnectionsResolvedText   string
+	OpenConnections           string
+	Minimum                   string
+
+	VHostHeader         string
+	VHostText           string
+	VHostResolvedHeader string
+	VHostResolvedText   string
+	TotalMessages       string
+	ReadyMessages       string
+
+	ServiceHeader         string
+	ServiceText           string
+	ServiceResolvedHeader string
+	ServiceResolvedText   string
+	Service               string
+	Lag                   string
+	Stalled               string
+
+	ShardHeader         string
+	ShardText           string
+	ShardResolvedHeader string
+	ShardResolvedText   string
+	SiblingMedian       string
+
+	ChurnHeader         string
+	ChurnText           string
+	ChurnResolvedHeader string
+	ChurnResolvedText   string
+	QueuesCreated       string
+	QueuesDeleted       string
+	PerMinute           string
+
+	ComparisonHeader         string
+	ComparisonText           string
+	ComparisonMissingText    string
+	ComparisonResolvedHeader string
+	ComparisonResolvedText   string
+	Tolerance                string
+	Missing                  string
+
+	DeadLetterHeader         string
+	DeadLetterText           string
+	DeadLetterResolvedHeader string
+	DeadLetterResolvedText   string
+	DeadLetterQueue          string
+	OriginQueue              string
+	Growth                   string
+
+	SLOBurnHeader         string
+	SLOBurnText           string
+	SLOBurnResolvedHeader string
+	SLOBurnResolvedText   string
+	BurnRate              string
+	BudgetRemaining       string
+	Availability          string
+	SLOTarget             string
+	SLOReportHeader       string
+	SLOReportText         string
+	SLOMet                string
+	SLOMissed             string
+
+	CapacityHeader         string
+	CapacityText           string
+	CapacityResolvedHeader string
+	CapacityResolvedText   string
+	Capacity               string
+	Utilisation            string
+
+	AuthHeader         string
+	AuthText           string
+	AuthResolvedHeader string
+	AuthResolvedText   string
+	Broker             string
+	User               string
+	CredentialReload   string
+
+	LatencyHeader         string
+	LatencyText           string
+	LatencyResolvedHeader string
+	LatencyResolvedText   string
+	Channel               string
+	DeliveryLatency       string
+	LatencyLimit          string
+
+	Second, Seconds string
+	Minute, Minutes string
+	Hour, Hours     string
+
+	Incident, Incidents string
+}
+
+// catalogs maps a language code to its built-in template strings
+var catalogs = map[string]catalog{
+	"en": {
+		AlertText:          "🚨 Queue `%s` is alerting!",
+		AlertHeader:        "🚨 Queue Alert",
+		Queue:              "Queue",
+		VHost:              "VHost",
+		Messages:           "Messages",
+		Consumers:          "Consumers",
+		ConsumeRate:        "Consume Rate",
+		AckRate:            "Ack Rate",
+		PublishRate:        "Publish Rate",
+		MonitorStatus:      "Monitor Status",
+		StatusAlerting:     "🔴 Alerting",
+		ConsecutiveStuck:   "Consecutive Stuck",
+		Checks:             "%d checks",
+		Priority:           "Priority",
+		PriorityBacklog:    "Priority Backlog",
+		Problem:            "Problem",
+		LikelyRootCause:    "*Likely Root Cause:* %d dependent queue(s) also stuck: `%s`",
+		RecentBrokerEvents: "Recent Broker Events",
+		HeadMessages:       "Head Messages",
+		Acknowledge:        "Acknowledge",
+		FalsePositive:      "False Positive",
+		AlertedAt:          "Alerted at",
+		Quarantined:        "Quarantined to `%s`",
+		QuarantineDryRun:   "Would quarantine to `%s` (dry run)",
+		QuarantineFailed:   "Quarantine failed: %s",
+		Owner:              "Owner",
+		Escalation:         "Escalation",
+		Node:               "Node",
+		DeadLetters:        "Dead letters",
+
+		RecoveryText:       "✅ Queue `%s` is no longer alerting!",
+		RecoveryHeader:     "✅ Queue No Longer Alerting",
+		WasAlertingFor:     "Was Alerting For",
+		StatusNotAlerting:  "🟢 Not Alerting",
+		CurrentMessages:    "Current Messages",
+		AcknowledgedBy:     "Acknowledged By",
+		NoLongerAlertingAt: "No longer alerting at",
+		PeakBacklog:        "Peak Backlog",
+		ProcessedSincePeak: "Processed During Recovery",
+		AverageDrainRate:   "Average Drain Rate",
+
+		ReminderText:     "⏰ Queue `%s` is still alerting",
+		ReminderHeader:   "⏰ Queue Still Alerting",
+		AlertingFor:      "Alerting For",
+		ChangeSinceAlert: "Change Since Alert",
+
+		RecoveringText:   "📉 Queue `%s` is recovering",
+		RecoveringHeader: "📉 Queue Recovering",
+		StatusRecovering: "🟡 Recovering",
+		DrainRate:        "Drain Rate",
+
+		ClusterHeader:         "🔥 Cluster-Wide Problem",
+		ClusterText:           "🔥 %d of %d queues are alerting - likely a broker-level problem!",
+		ClusterSuppressed:     "🔴 Per-queue notifications suppressed",
+		ClusterResolvedHeader: "✅ Cluster-Wide Problem Resolved",
+		ClusterResolvedText:   "✅ Cluster-wide problem resolved, %d of %d queues still alerting",
+		ClusterResumed:        "🟢 Per-queue notifications resumed",
+		AlertingQueues:        "Alerting Queues",
+		OfQueues:              "%d of %d (%.0f%%)",
+		Threshold:             "Threshold",
+		Queues:                "Queues",
+		At:                    "At",
+		AndMore:               "... and %d more",
+		BrokerOverview:        "Broker Overview",
+		Nodes:                 "Nodes",
+		NodesRunning:          "%d of %d running",
+		ErlangProcesses:       "Erlang Processes",
+		Connections:           "Connections",
+		Channels:              "Channels",
+		MessagesUnacked:       "%s (%s unacked)",
+		DeliverRate:           "Deliver Rate",
+
+		ConnectionsHeader:         "📉 Low %s Connections",
+		ConnectionsText:           "📉 Only %d %s connections open (minimum %d) - devices may be disconnected!",
+		ConnectionsResolvedHeader: "✅ %s Connections Restored",
+		ConnectionsResolvedText:   "✅ %d %s connections open again (minimum %d)",
+		OpenConnections:           "Open Connections",
+		Minimum:                   "Minimum",
+
+		VHostHeader:         "📦 VHost Limits Exceeded",
+		VHostText:           "📦 VHost `%s` exceeds its limits",
+		VHostResolvedHeader: "✅ VHost Back Within Limits",
+		VHostResolvedText:   "✅ VHost `%s` is back within its limits",
+		TotalMessages:       "Total Messages",
+		ReadyMessages:       "Ready Messages",
+
+		ServiceHeader:         "🧩 Service Limits Exceeded",
+		ServiceText:           "🧩 Service `%s` exceeds its limits across %d queues",
+		ServiceResolvedHeader: "✅ Service Back Within Limits",
+		ServiceResolvedText:   "✅ Service `%s` is back within its limits",
+		Service:               "Service",
+		Lag:                   "Lag",
+		Stalled:               "not draining",
+
+		ShardHeader:         "🧩 Shard Falling Behind",
+		ShardText:           "🧩 Shard `%s` of service `%s` falls behind its siblings",
+		ShardResolvedHeader: "✅ Shard Back in Line",
+		ShardResolvedText:   "✅ Shard `%s` of service `%s` is back in line with its siblings",
+		SiblingMedian:       "siblings",
+
+		ChurnHeader:         "🌀 High Queue Churn",
+		ChurnText:           "🌀 %s queues created and %s deleted per minute - short-lived queues degrade the cluster!",
+		ChurnResolvedHeader: "✅ Queue Churn Back to Normal",
+		ChurnResolvedText:   "✅ Queue churn back to normal: %s queues created and %s deleted per minute",
+		QueuesCreated:       "Queues Created",
+		QueuesDeleted:       "Queues Deleted",
+		PerMinute:           "%s/min",
+
+		ComparisonHeader:         "⚖️ Queue Diverges Between Environments",
+		ComparisonText:           "⚖️ Queue `%s` on %s diverges from %s",
+		ComparisonMissingText:    "⚖️ Queue `%s` on %s is missing on %s",
+		ComparisonResolvedHeader: "✅ Queue Back in Line",
+		ComparisonResolvedText:   "✅ Queue `%s` on %s is back in line with %s",
+		Tolerance:                "Tolerance",
+		Missing:                  "missing",
+
+		DeadLetterHeader:         "📥 Dead-Letter Queue Growing",
+		DeadLetterText:           "📥 Dead-letter queue `%s` of `%s` is growing",
+		DeadLetterResolvedHeader: "✅ Dead-Letter Queue Stable",
+		DeadLetterResolvedText:   "✅ Dead-letter queue `%s` of `%s` stopped growing",
+		DeadLetterQueue:          "Dead-letter queue",
+		OriginQueue:              "Originating queue",
+		Growth:                   "Growth",
+
+		SLOBurnHeader:         "🔥 Error Budget Burning",
+		SLOBurnText:           "🔥 Queue `%s` is burning its error budget %.1fx faster than sustainable!",
+		SLOBurnResolvedHeader: "✅ Error Budget Burn Resolved",
+		SLOBurnResolvedText:   "✅ Queue `%s` is back to a sustainable burn rate (%.1fx)",
+		BurnRate:              "Burn Rate",
+		BudgetRemaining:       "Budget Remaining",
+		Availability:          "Availability",
+		SLOTarget:             "SLO Target",
+		SLOReportHeader:       "📅 SLO Report %s",
+		SLOReportText:         "📅 %d of %d queues met the %s availability objective in %s",
+		SLOMet:                "✅ met",
+		SLOMissed:             "❌ missed",
+
+		CapacityHeader:         "📈 Queue Nearing Capacity",
+		CapacityText:           "📈 Queue `%s` receives %s of what its consumers can sustain",
+		CapacityResolvedHeader: "✅ Queue Back Within Capacity",
+		CapacityResolvedText:   "✅ Queue `%s` is back at %s of its capacity",
+		Capacity:               "Capacity",
+		Utilisation:            "Utilisation",
+
+		AuthHeader:         "🔐 RabbitMQ Authentication Failed",
+		AuthText:           "🔐 The management API at %s rejects user `%s` - no queues are checked until the credentials are fixed!",
+		AuthResolvedHeader: "✅ RabbitMQ Authentication Restored",
+		AuthResolvedText:   "✅ The management API at %s accepts user `%s` again",
+		Broker:             "Broker",
+		User:               "User",
+		CredentialReload:   "Credential reload",
+
+		LatencyHeader:         "🐢 Slow Notification Delivery",
+		LatencyText:           "🐢 Notifications on %s took %s from detection to delivery (limit %s) - alerts may arrive late!",
+		LatencyResolvedHeader: "✅ Notification Delivery Back to Normal",
+		LatencyResolvedText:   "✅ Notifications on %s are delivered within %s again",
+		Channel:               "Channel",
+		DeliveryLatency:       "Delivery latency",
+		LatencyLimit:          "Limit",
+
+		Second: "second", Seconds: "seconds",
+		Minute: "minute", Minutes: "minutes",
+		Hour: "hour", Hours: "hours",
+		Incident: "incident", Incidents: "incidents",
+	},
+	"nl": {
+		AlertText:          "🚨 Queue `%s` geeft een alarm!",
+		AlertHeader:        "🚨 Queue-alarm",
+		Queue:              "Queue",
+		VHost:              "VHost",
+		Messages:           "Berichten",
+		Consumers:          "Consumers",
+		ConsumeRate:        "Consumptiesnelheid",
+		AckRate:            "Ack-snelheid",
+		PublishRate:        "Publicatiesnelheid",
+		MonitorStatus:      "Monitorstatus",
+		StatusAlerting:     "🔴 Alarm",
+		ConsecutiveStuck:   "Opeenvolgend vastgelopen",
+		Checks:             "%d controles",
+		Priority:           "Prioriteit",
+		PriorityBacklog:    "Achterstand per prioriteit",
+		Problem:            "Probleem",
+		LikelyRootCause:    "*Waarschijnlijke oorzaak:* %d afhankelijke queue(s) ook vastgelopen: `%s`",
+		RecentBrokerEvents: "Recente broker-gebeurtenissen",
+		HeadMessages:       "Eerste berichten",
+		Acknowledge:        "Bevestigen",
+		FalsePositive:      "Vals alarm",
+		AlertedAt:          "Alarm om",
+		Quarantined:        "Verplaatst naar quarantaine `%s`",
+		QuarantineDryRun:   "Zou naar quarantaine `%s` verplaatsen (proefrun)",
+		QuarantineFailed:   "Quarantaine mislukt: %s",
+		Owner:              "Eigenaar",
+		Escalation:         "Escalatie",
+		Node:               "Node",
+		DeadLetters:        "Dead letters",
+
+		RecoveryText:       "✅ Queue `%s` geeft geen alarm meer!",
+		RecoveryHeader:     "✅ Queue niet langer in alarm",
+		WasAlertingFor:     "Duur van het alarm",
+		StatusNotAlerting:  "🟢 Geen alarm",
+		CurrentMessages:    "Huidige berichten",
+		AcknowledgedBy:     "Bevestigd door",
+		NoLongerAlertingAt: "Geen alarm meer om",
+		PeakBacklog:        "Piekachterstand",
+		ProcessedSincePeak: "Verwerkt tijdens herstel",
+		AverageDrainRate:   "Gemiddelde afbouwsnelheid",
+
+		ReminderText:     "⏰ Queue `%s` geeft nog steeds alarm",
+		ReminderHeader:   "⏰ Queue nog steeds in alarm",
+		AlertingFor:      "Al in alarm",
+		ChangeSinceAlert: "Verschil sinds alarm",
+
+		RecoveringText:   "📉 Queue `%s` herstelt",
+		RecoveringHeader: "📉 Queue herstelt",
+		StatusRecovering: "🟡 Herstellend",
+		DrainRate:        "Afbouwsnelheid",
+
+		ClusterHeader:         "🔥 Clusterbreed probleem",
+		ClusterText:           "🔥 %d van %d queues geven een alarm - waarschijnlijk een probleem met de broker!",
+		ClusterSuppressed:     "🔴 Meldingen per queue onderdrukt",
+		ClusterResolvedHeader: "✅ Clusterbreed probleem opgelost",
+		ClusterResolvedText:   "✅ Clusterbreed probleem opgelost, %d van %d queues nog in alarm",
+		ClusterResumed:        "🟢 Meldingen per queue hervat",
+		AlertingQueues:        "Queues in alarm",
+		OfQueues:              "%d van %d (%.0f%%)",
+		Threshold:             "Drempel",
+		Queues:                "Queues",
+		At:                    "Om",
+		AndMore:               "... en nog %d",
+		BrokerOverview:        "Broker-overzicht",
+		Nodes:                 "Nodes",
+		NodesRunning:          "%d van %d actief",
+		ErlangProcesses:       "Erlang-processen",
+		Connections:           "Verbindingen",
+		Channels:              "Kanalen",
+		MessagesUnacked:       "%s (%s onbevestigd)",
+		DeliverRate:           "Afleversnelheid",
+
+		ConnectionsHeader:         "📉 Weinig %s-verbindingen",
+		ConnectionsText:           "📉 Slechts %d %s-verbindingen open (minimaal %d) - apparaten zijn mogelijk losgekoppeld!",
+		ConnectionsResolvedHeader: "✅ %s-verbindingen hersteld",
+		ConnectionsResolvedText:   "✅ Weer %d %s-verbindingen open (minimaal %d)",
+		OpenConnections:           "Open verbindingen",
+		Minimum:                   "Minimum",
+
+		VHostHeader:         "📦 VHost-limieten overschreden",
+		VHostText:           "📦 VHost `%s` overschrijdt zijn limieten",
+		VHostResolvedHeader: "✅ VHost weer binnen limieten",
+		VHostResolvedText:   "✅ VHost `%s` is weer binnen zijn limieten",
+		TotalMessages:       "Totaal berichten",
+		ReadyMessages:       "Klaarstaande berichten",
+
+		ServiceHeader:         "🧩 Servicelimieten overschreden",
+		ServiceText:           "🧩 Service `%s` overschrijdt zijn limieten over %d queues",
+		ServiceResolvedHeader: "✅ Service weer binnen limieten",
+		ServiceResolvedText:   "✅ Service `%s` is weer binnen zijn limieten",
+		Service:               "Service",
+		Lag:                   "Achterstand",
+		Stalled:               "loopt niet leeg",
+
+		ShardHeader:         "🧩 Shard loopt achter",
+		ShardText:           "🧩 Shard `%s` van service `%s` loopt achter op de andere shards",
+		ShardResolvedHeader: "✅ Shard weer gelijk",
+		ShardResolvedText:   "✅ Shard `%s` van service `%s` is weer gelijk aan de andere shards",
+		SiblingMedian:       "andere shards",
+
+		ChurnHeader:         "🌀 Hoog queue-verloop",
+		ChurnText:           "🌀 %s queues aangemaakt en %s verwijderd per minuut - kortlevende queues belasten het cluster!",
+		ChurnResolvedHeader: "✅ Queue-verloop weer normaal",
+		ChurnResolvedText:   "✅ Queue-verloop weer normaal: %s queues aangemaakt en %s verwijderd per minuut",
+		QueuesCreated:       "Queues aangemaakt",
+		QueuesDeleted:       "Queues verwijderd",
+		PerMinute:           "%s/min",
+
+		ComparisonHeader:         "⚖️ Queue wijkt af tussen omgevingen",
+		ComparisonText:           "⚖️ Queue `%s` op %s wijkt af van %s",
+		ComparisonMissingText:    "⚖️ Queue `%s` op %s ontbreekt op %s",
+		ComparisonResolvedHeader: "✅ Queue weer gelijk",
+		ComparisonResolvedText:   "✅ Queue `%s` op %s is weer gelijk aan %s",
+		Tolerance:                "Tolerantie",
+		Missing:                  "ontbreekt",
+
+		DeadLetterHeader:         "📥 Dead-letter queue groeit",
+		DeadLetterText:           "📥 Dead-letter queue `%s` van `%s` groeit",
+		DeadLetterResolvedHeader: "✅ Dead-letter queue stabiel",
+		DeadLetterResolvedText:   "✅ Dead-letter queue `%s` van `%s` groeit niet meer",
+		DeadLetterQueue:          "Dead-letter queue",
+		OriginQueue:              "Bronqueue",
+		Growth:                   "Groei",
+
+		SLOBurnHeader:         "🔥 Foutbudget raakt op",
+		SLOBurnText:           "🔥 Queue `%s` verbruikt zijn foutbudget %.1fx sneller dan houdbaar!",
+		SLOBurnResolvedHeader: "✅ Verbruik foutbudget hersteld",
+		SLOBurnResolvedText:   "✅ Queue `%s` verbruikt zijn foutbudget weer houdbaar (%.1fx)",
+		BurnRate:              "Verbruikssnelheid",
+		BudgetRemaining:       "Resterend budget",
+		Availability:          "Beschikbaarheid",
+		SLOTarget:             "SLO-doel",
+		SLOReportHeader:       "📅 SLO-rapport %s",
+		SLOReportText:         "📅 %d van %d queues haalden het beschikbaarheidsdoel van %s in %s",
+		SLOMet:                "✅ gehaald",
+		SLOMissed:             "❌ gemist",
+
+		CapacityHeader:         "📈 Queue nadert capaciteit",
+		CapacityText:           "📈 Queue `%s` ontvangt %s van wat zijn consumers aankunnen",
+		CapacityResolvedHeader: "✅ Queue weer binnen capaciteit",
+		CapacityResolvedText:   "✅ Queue `%s` zit weer op %s van zijn capaciteit",
+		Capacity:               "Capaciteit",
+		Utilisation:            "Benutting",
+
+		AuthHeader:         "🔐 RabbitMQ-authenticatie mislukt",
+		AuthText:           "🔐 De management-API op %s weigert gebruiker `%s` - er worden geen queues gecontroleerd tot de inloggegevens hersteld zijn!",
+		AuthResolvedHeader: "✅ RabbitMQ-authenticatie hersteld",
+		AuthResolvedText:   "✅ De management-API op %s accepteert gebruiker `%s` weer",
+		Broker:             "Broker",
+		User:               "Gebruiker",
+		CredentialReload:   "Inloggegevens herladen",
+
+		LatencyHeader:         "🐢 Trage aflevering van meldingen",
+		LatencyText:           "🐢 Meldingen via %s deden %s over van detectie tot aflevering (limiet %s) - alerts komen mogelijk te laat aan!",
+		LatencyResolvedHeader: "✅ Aflevering van meldingen weer normaal",
+		LatencyResolvedText:   "✅ Meldingen via %s worden weer binnen %s afgeleverd",
+		Channel:               "Kanaal",
+		DeliveryLatency:       "Afleververtraging",
+		LatencyLimit:          "Limiet",
+
+		Second: "seconde", Seconds: "seconden",
+		Minute: "minuut", Minutes: "minuten",
+		Hour: "uur", Hours: "uur",
+		Incident: "incident", Incidents: "incidenten",
+	},
+	"de": {
+		AlertText:          "🚨 Queue `%s` ist im Alarmzustand!",
+		AlertHeader:        "🚨 Queue-Alarm",
+		Queue:              "Queue",
+		VHost:              "VHost",
+		Messages:           "Nachrichten",
+		Consumers:          "Consumer",
+		ConsumeRate:        "Verbrauchsrate",
+		AckRate:            "Ack-Rate",
+		PublishRate:        "Veröffentlichungsrate",
+		MonitorStatus:      "Monitorstatus",
+		StatusAlerting:     "🔴 Alarm",
+		ConsecutiveStuck:   "Aufeinanderfolgend blockiert",
+		Checks:             "%d Prüfungen",
+		Priority:           "Priorität",
+		PriorityBacklog:    "Rückstand pro Priorität",
+		Problem:            "Problem",
+		LikelyRootCause:    "*Wahrscheinliche Ursache:* %d abhängige Queue(s) ebenfalls blockiert: `%s`",
+		RecentBrokerEvents: "Aktuelle Broker-Ereignisse",
+		HeadMessages:       "Erste Nachrichten",
+		Acknowledge:        "Bestätigen",
+		FalsePositive:      "Fehlalarm",
+		AlertedAt:          "Alarm um",
+		Quarantined:        "In Quarantäne verschoben nach `%s`",
+		QuarantineDryRun:   "Würde in Quarantäne verschieben nach `%s` (Probelauf)",
+		QuarantineFailed:   "Quarantäne fehlgeschlagen: %s",
+		Owner:              "Verantwortlich",
+		Escalation:         "Eskalation",
+		Node:               "Knoten",
+		DeadLetters:        "Dead Letters",
+
+		RecoveryText:       "✅ Queue `%s` ist nicht mehr im Alarmzustand!",
+		RecoveryHeader:     "✅ Queue nicht mehr im Alarmzustand",
+		WasAlertingFor:     "Alarmdauer",
+		StatusNotAlerting:  "🟢 Kein Alarm",
+		CurrentMessages:    "Aktuelle Nachrichten",
+		AcknowledgedBy:     "Bestätigt von",
+		NoLongerAlertingAt: "Kein Alarm mehr um",
+		PeakBacklog:        "Höchster Rückstand",
+		ProcessedSincePeak: "Während der Erholung verarbeitet",
+		AverageDrainRate:   "Durchschnittliche Abbaurate",
+
+		ReminderText:     "⏰ Queue `%s` ist weiterhin im Alarmzustand",
+		ReminderHeader:   "⏰ Queue weiterhin im Alarmzustand",
+		AlertingFor:      "Im Alarmzustand seit",
+		ChangeSinceAlert: "Änderung seit Alarm",
+
+		RecoveringText:   "📉 Queue `%s` erholt sich",
+		RecoveringHeader: "📉 Queue erholt sich",
+		StatusRecovering: "🟡 Erholt sich",
+		DrainRate:        "Abbaurate",
+
+		ClusterHeader:         "🔥 Clusterweites Problem",
+		ClusterText:           "🔥 %d von %d Queues sind im Alarmzustand - wahrscheinlich ein Broker-Problem!",
+		ClusterSuppressed:     "🔴 Benachrichtigungen pro Queue unterdrückt",
+		ClusterResolvedHeader: "✅ Clusterweites Problem behoben",
+		ClusterResolvedText:   "✅ Clusterweites Problem behoben, %d von %d Queues noch im Alarmzustand",
+		ClusterResumed:        "🟢 Benachrichtigungen pro Queue fortgesetzt",
+		AlertingQueues:        "Queues im Alarmzustand",
+		OfQueues:              "%d von %d (%.0f%%)",
+		Threshold:             "Schwellenwert",
+		Queues:                "Queues",
+		At:                    "Um",
+		AndMore:               "... und %d weitere",
+		BrokerOverview:        "Broker-Übersicht",
+		Nodes:                 "Knoten",
+		NodesRunning:          "%d von %d aktiv",
+		ErlangProcesses:       "Erlang-Prozesse",
+		Connections:           "Verbindungen",
+		Channels:              "Kanäle",
+		MessagesUnacked:       "%s (%s unbestätigt)",
+		DeliverRate:           "Zustellrate",
+
+		ConnectionsHeader:         "📉 Wenige %s-Verbindungen",
+		ConnectionsText:           "📉 Nur %d %s-Verbindungen offen (Minimum %d) - Geräte sind möglicherweise getrennt!",
+		ConnectionsResolvedHeader: "✅ %s-Verbindungen wiederhergestellt",
+		ConnectionsResolvedText:   "✅ Wieder %d %s-Verbindungen offen (Minimum %d)",
+		OpenConnections:           "Offene Verbindungen",
+		Minimum:                   "Minimum",
+
+		VHostHeader:         "📦 VHost-Limits überschritten",
+		VHostText:           "📦 VHost `%s` überschreitet seine Limits",
+		VHostResolvedHeader: "✅ VHost wieder innerhalb der Limits",
+		VHostResolvedText:   "✅ VHost `%s` ist wieder innerhalb seiner Limits",
+		TotalMessages:       "Nachrichten gesamt",
+		ReadyMessages:       "Bereite Nachrichten",
+
+		ServiceHeader:         "🧩 Service-Limits überschritten",
+		ServiceText:           "🧩 Service `%s` überschreitet seine Limits über %d Queues",
+		ServiceResolvedHeader: "✅ Service wieder innerhalb der Limits",
+		ServiceResolvedText:   "✅ Service `%s` ist wieder innerhalb seiner Limits",
+		Service:               "Service",
+		Lag:                   "Rückstand",
+		Stalled:               "wird nicht abgebaut",
+
+		ShardHeader:         "🧩 Shard fällt zurück",
+		ShardText:           "🧩 Shard `%s` von Service `%s` fällt hinter die anderen Shards zurück",
+		ShardResolvedHeader: "✅ Shard wieder im Einklang",
+		ShardResolvedText:   "✅ Shard `%s` von Service `%s` ist wieder im Einklang mit den anderen Shards",
+		SiblingMedian:       "andere Shards",
+
+		ChurnHeader:         "🌀 Hohe Queue-Fluktuation",
+		ChurnText:           "🌀 %s Queues pro Minute erstellt und %s gelöscht - kurzlebige Queues belasten den Cluster!",
+		ChurnResolvedHeader: "✅ Queue-Fluktuation wieder normal",
+		ChurnResolvedText:   "✅ Queue-Fluktuation wieder normal: %s Queues pro Minute erstellt und %s gelöscht",
+		QueuesCreated:       "Erstellte Queues",
+		QueuesDeleted:       "Gelöschte Queues",
+		PerMinute:           "%s/min",
+
+		ComparisonHeader:         "⚖️ Queue weicht zwischen Umgebungen ab",
+		ComparisonText:           "⚖️ Queue `%s` auf %s weicht von %s ab",
+		ComparisonMissingText:    "⚖️ Queue `%s` auf %s fehlt auf %s",
+		ComparisonResolvedHeader: "✅ Queue wieder im Einklang",
+		ComparisonResolvedText:   "✅ Queue `%s` auf %s ist wieder im Einklang mit %s",
+		Tolerance:                "Toleranz",
+		Missing:                  "fehlt",
+
+		DeadLetterHeader:         "📥 Dead-Letter-Queue wächst",
+		DeadLetterText:           "📥 Dead-Letter-Queue `%s` von `%s` wächst",
+		DeadLetterResolvedHeader: "✅ Dead-Letter-Queue stabil",
+		DeadLetterResolvedText:   "✅ Dead-Letter-Queue `%s` von `%s` wächst nicht mehr",
+		DeadLetterQueue:          "Dead-Letter-Queue",
+		OriginQueue:              "Ursprungs-Queue",
+		Growth:                   "Zuwachs",
+
+		SLOBurnHeader:         "🔥 Fehlerbudget schwindet",
+		SLOBurnText:           "🔥 Queue `%s` verbraucht ihr Fehlerbudget %.1fx schneller als tragbar!",
+		SLOBurnResolvedHeader: "✅ Fehlerbudget-Verbrauch normalisiert",
+		SLOBurnResolvedText:   "✅ Queue `%s` verbraucht ihr Fehlerbudget wieder tragbar (%.1fx)",
+		BurnRate:              "Verbrauchsrate",
+		BudgetRemaining:       "Verbleibendes Budget",
+		Availability:          "Verfügbarkeit",
+		SLOTarget:             "SLO-Ziel",
+		SLOReportHeader:       "📅 SLO-Bericht %s",
+		SLOReportText:         "📅 %d von %d Queues haben das Verfügbarkeitsziel von %s in %s erreicht",
+		SLOMet:                "✅ erreicht",
+		SLOMissed:             "❌ verfehlt",
+
+		CapacityHeader:         "📈 Queue nahe der Kapazität",
+		CapacityText:           "📈 Queue `%s` erhält %s dessen, was ihre Consumer verkraften",
+		CapacityResolvedHeader: "✅ Queue wieder innerhalb der Kapazität",
+		CapacityResolvedText:   "✅ Queue `%s` liegt wieder bei %s ihrer Kapazität",
+		Capacity:               "Kapazität",
+		Utilisation:            "Auslastung",
+
+		AuthHeader:         "🔐 RabbitMQ-Authentifizierung fehlgeschlagen",
+		AuthText:           "🔐 Die Management-API auf %s lehnt Benutzer `%s` ab - keine Queues werden geprüft, bis die Zugangsdaten korrigiert sind!",
+		AuthResolvedHeader: "✅ RabbitMQ-Authentifizierung wiederhergestellt",
+		AuthResolvedText:   "✅ Die Management-API auf %s akzeptiert Benutzer `%s` wieder",
+		Broker:             "Broker",
+		User:               "Benutzer",
+		CredentialReload:   "Zugangsdaten neu laden",
+
+		LatencyHeader:         "🐢 Langsame Zustellung von Benachrichtigungen",
+		LatencyText:           "🐢 Benachrichtigungen über %s brauchten %s von der Erkennung bis zur Zustellung (Grenze %s) - Alarme kommen möglicherweise zu spät an!",
+		LatencyResolvedHeader: "✅ Zustellung von Benachrichtigungen wieder normal",
+		LatencyResolvedText:   "✅ Benachrichtigungen über %s werden wieder innerhalb von %s zugestellt",
+		Channel:               "Kanal",
+		DeliveryLatency:       "Zustellverzögerung",
+		LatencyLimit:          "Grenze",
+
+		Second: "Sekunde", Seconds: "Sekunden",
+		Minute: "Minute", Minutes: "Minuten",
+		Hour: "Stunde", Hours: "Stunden",
+		Incident: "Vorfall", Incidents: "Vorfälle",
+	},
+	"fr": {
+		AlertText:          "🚨 La file `%s` est en alerte !",
+		AlertHeader:        "🚨 Alerte de file",
+		Queue:              "File",
+		VHost:              "VHost",
+		Messages:           "Messages",
+		Consumers:          "Consommateurs",
+		ConsumeRate:        "Débit de consommation",
+		AckRate:            "Débit d'ack",
+		PublishRate:        "Débit de publication",
+		MonitorStatus:      "Statut du moniteur",
+		StatusAlerting:     "🔴 En alerte",
+		ConsecutiveStuck:   "Blocages consécutifs",
+		Checks:             "%d vérifications",
+		Priority:           "Priorité",
+		PriorityBacklog:    "Arriéré par priorité",
+		Problem:            "Problème",
+		LikelyRootCause:    "*Cause probable :* %d file(s) dépendante(s) également bloquée(s) : `%s`",
+		RecentBrokerEvents: "Événements récents du broker",
+		HeadMessages:       "Premiers messages",
+		Acknowledge:        "Acquitter",
+		FalsePositive:      "Faux positif",
+		AlertedAt:          "Alerte à",
+		Quarantined:        "Mis en quarantaine dans `%s`",
+		QuarantineDryRun:   "Serait mis en quarantaine dans `%s` (simulation)",
+		QuarantineFailed:   "Échec de la mise en quarantaine : %s",
+		Owner:              "Propriétaire",
+		Escalation:         "Escalade",
+		Node:               "Nœud",
+		DeadLetters:        "Messages rejetés",
+
+		RecoveryText:       "✅ La file `%s` n'est plus en alerte !",
+		RecoveryHeader:     "✅ File plus en alerte",
+		WasAlertingFor:     "En alerte pendant",
+		StatusNotAlerting:  "🟢 Pas en alerte",
+		CurrentMessages:    "Messages actuels",
+		AcknowledgedBy:     "Acquitté par",
+		NoLongerAlertingAt: "Plus en alerte à",
+		PeakBacklog:        "Pic d'arriéré",
+		ProcessedSincePeak: "Traités pendant la reprise",
+		AverageDrainRate:   "Débit moyen de résorption",
+
+		ReminderText:     "⏰ La file `%s` est toujours en alerte",
+		ReminderHeader:   "⏰ File toujours en alerte",
+		AlertingFor:      "En alerte depuis",
+		ChangeSinceAlert: "Évolution depuis l'alerte",
+
+		RecoveringText:   "📉 La file `%s` se rétablit",
+		RecoveringHeader: "📉 File en rétablissement",
+		StatusRecovering: "🟡 En rétablissement",
+		DrainRate:        "Débit de résorption",
+
+		ClusterHeader:         "🔥 Problème à l'échelle du cluster",
+		ClusterText:           "🔥 %d files sur %d sont en alerte - probablement un problème du broker !",
+		ClusterSuppressed:     "🔴 Notifications par file suspendues",
+		ClusterResolvedHeader: "✅ Problème du cluster résolu",
+		ClusterResolvedText:   "✅ Problème du cluster résolu, %d files sur %d encore en alerte",
+		ClusterResumed:        "🟢 Notifications par file reprises",
+		AlertingQueues:        "Files en alerte",
+		OfQueues:              "%d sur %d (%.0f%%)",
+		Threshold:             "Seuil",
+		Queues:                "Files",
+		At:                    "À",
+		AndMore:               "... et %d de plus",
+		BrokerOverview:        "Vue d'ensemble du broker",
+		Nodes:                 "Nœuds",
+		NodesRunning:          "%d sur %d actifs",
+		ErlangProcesses:       "Processus Erlang",
+		Connections:           "Connexions",
+		Channels:              "Canaux",
+		MessagesUnacked:       "%s (%s non acquittés)",
+		DeliverRate:           "Débit de livraison",
+
+		ConnectionsHeader:         "📉 Peu de connexions %s",
+		ConnectionsText:           "📉 Seulement %d connexions %s ouvertes (minimum %d) - des appareils sont peut-être déconnectés !",
+		ConnectionsResolvedHeader: "✅ Connexions %s rétablies",
+		ConnectionsResolvedText:   "✅ De nouveau %d connexions %s ouvertes (minimum %d)",
+		OpenConnections:           "Connexions ouvertes",
+		Minimum:                   "Minimum",
+
+		VHostHeader:         "📦 Limites du VHost dépassées",
+		VHostText:           "📦 Le VHost `%s` dépasse ses limites",
+		VHostResolvedHeader: "✅ VHost de nouveau dans ses limites",
+		VHostResolvedText:   "✅ Le VHost `%s` est de nouveau dans ses limites",
+		TotalMessages:       "Messages au total",
+		ReadyMessages:       "Messages prêts",
+
+		ServiceHeader:         "🧩 Limites du service dépassées",
+		ServiceText:           "🧩 Le service `%s` dépasse ses limites sur %d queues",
+		ServiceResolvedHeader: "✅ Service de nouveau dans ses limites",
+		ServiceResolvedText:   "✅ Le service `%s` est de nouveau dans ses limites",
+		Service:               "Service",
+		Lag:                   "Retard",
+		Stalled:               "ne se vide pas",
+
+		ShardHeader:         "🧩 Shard en retard",
+		ShardText:           "🧩 Le shard `%s` du service `%s` est en retard sur les autres",
+		ShardResolvedHeader: "✅ Shard de nouveau aligné",
+		ShardResolvedText:   "✅ Le shard `%s` du service `%s` est de nouveau aligné sur les autres",
+		SiblingMedian:       "autres shards",
+
+		ChurnHeader:         "🌀 Renouvellement de queues élevé",
+		ChurnText:           "🌀 %s queues créées et %s supprimées par minute - les queues éphémères dégradent le cluster !",
+		ChurnResolvedHeader: "✅ Renouvellement de queues revenu à la normale",
+		ChurnResolvedText:   "✅ Renouvellement de queues revenu à la normale : %s queues créées et %s supprimées par minute",
+		QueuesCreated:       "Queues créées",
+		QueuesDeleted:       "Queues supprimées",
+		PerMinute:           "%s/min",
+
+		ComparisonHeader:         "⚖️ Queue divergente entre environnements",
+		ComparisonText:           "⚖️ La queue `%s` sur %s diverge de %s",
+		ComparisonMissingText:    "⚖️ La queue `%s` sur %s est absente de %s",
+		ComparisonResolvedHeader: "✅ Queue de nouveau alignée",
+		ComparisonResolvedText:   "✅ La queue `%s` sur %s est de nouveau alignée sur %s",
+		Tolerance:                "Tolérance",
+		Missing:                  "absente",
+
+		DeadLetterHeader:         "📥 Queue de lettres mortes en croissance",
+		DeadLetterText:           "📥 La queue de lettres mortes `%s` de `%s` grandit",
+		DeadLetterResolvedHeader: "✅ Queue de lettres mortes stable",
+		DeadLetterResolvedText:   "✅ La queue de lettres mortes `%s` de `%s` ne grandit plus",
+		DeadLetterQueue:          "Queue de lettres mortes",
+		OriginQueue:              "Queue d'origine",
+		Growth:                   "Croissance",
+
+		SLOBurnHeader:         "🔥 Budget d'erreur en cours d'épuisement",
+		SLOBurnText:           "🔥 La queue `%s` consomme son budget d'erreur %.1fx plus vite que soutenable !",
+		SLOBurnResolvedHeader: "✅ Consommation du budget d'erreur rétablie",
+		SLOBurnResolvedText:   "✅ La queue `%s` consomme à nouveau son budget à un rythme soutenable (%.1fx)",
+		BurnRate:              "Taux de consommation",
+		BudgetRemaining:       "Budget restant",
+		Availability:          "Disponibilité",
+		SLOTarget:             "Objectif SLO",
+		SLOReportHeader:       "📅 Rapport SLO %s",
+		SLOReportText:         "📅 %d queues sur %d ont atteint l'objectif de disponibilité de %s en %s",
+		SLOMet:                "✅ atteint",
+		SLOMissed:             "❌ manqué",
+
+		CapacityHeader:         "📈 Queue proche de sa capacité",
+		CapacityText:           "📈 La queue `%s` reçoit %s de ce que ses consommateurs peuvent absorber",
+		CapacityResolvedHeader: "✅ Queue de nouveau dans sa capacité",
+		CapacityResolvedText:   "✅ La queue `%s` est revenue à %s de sa capacité",
+		Capacity:               "Capacité",
+		Utilisation:            "Utilisation",
+
+		AuthHeader:         "🔐 Échec d'authentification RabbitMQ",
+		AuthText:           "🔐 L'API de management sur %s refuse l'utilisateur `%s` - aucune queue n'est vérifiée tant que les identifiants ne sont pas corrigés !",
+		AuthResolvedHeader: "✅ Authentification RabbitMQ rétablie",
+		AuthResolvedText:   "✅ L'API de management sur %s accepte de nouveau l'utilisateur `%s`",
+		Broker:             "Broker",
+		User:               "Utilisateur",
+		CredentialReload:   "Rechargement des identifiants",
+
+		LatencyHeader:         "🐢 Livraison lente des notifications",
+		LatencyText:           "🐢 Les notifications via %s ont mis %s entre la détection et la livraison (limite %s) - les alertes risquent d'arriver en retard !",
+		LatencyResolvedHeader: "✅ Livraison des notifications revenue à la normale",
+		LatencyResolvedText:   "✅ Les notifications via %s sont de nouveau livrées en moins de %s",
+		Channel:               "Canal",
+		DeliveryLatency:       "Délai de livraison",
+		LatencyLimit:          "Limite",
+
+		Second: "seconde", Seconds: "secondes",
+		Minute: "minute", Minutes: "minutes",
+		Hour: "heure", Hours: "heures",
+		Incident: "incident", Incidents: "incidents",
+	},
+}
+
+// IsSupportedLanguage reports whether a built-in catalog exists for the language
+func IsSupportedLanguage(language string) bool {
+	_, ok := catalogs[language]
+	return ok
+}
+
+// SupportedLanguages returns the codes of all built-in languages
+func SupportedLanguages() []string {
+	languages := make([]string, 0, len(catalogs))
+	for language := range catalogs {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// catalogFor returns the catalog for a language, falling back to English
+func catalogFor(language string) catalog {
+	if c, ok := catalogs[language]; ok {
+		return c
+	}
+	return catalogs[DefaultLanguage]
+}
+
+// plural picks the singular or plural unit for n
+func plural(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
+
+// FormatDuration formats a duration in human-readable form in the given language
+func FormatDuration(d time.Duration, language string) string {
+	c := catalogFor(language)
+	if d < time.Minute {
+		return plural(int(d.Seconds()), c.Second, c.Seconds)
+	}
+	if d < time.Hour {
+		minutes := int(d.Minutes())
+		seconds := int(d.Seconds()) % 60
+		if seconds == 0 {
+			return plural(minutes, c.Minute, c.Minutes)
+		}
+		return plural(minutes, c.Minute, c.Minutes) + " " + plural(seconds, c.Second, c.Seconds)
+	}
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	if minutes == 0 {
+		return plural(hours, c.Hour, c.Hours)
+	}
+	return plural(hours, c.Hour, c.Hours) + " " + plural(minutes, c.Minute, c.Minutes)
+}
@@ -0,0 +1,177 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Summary is a queue alert rendered as plain labelled text
+// Used by notifiers for chat platforms other than Slack, with the same language catalogs
+type Summary struct {
+	Alerting bool
+	Title    string           // Header, e.g. "🚨 Queue Alert"
+	Text     string           // One-line summary naming the queue
+	Fields   []SummaryField   // Short labelled values
+	Sections []SummarySection // Longer details such as the problem and broker events
+	Footer   string           // Alert or recovery timestamp
+}
+
+// SummaryField is a short labelled value
+type SummaryField struct {
+	Label string
+	Value string
+}
+
+// SummarySection is a labelled block of lines, the label may be empty
+type SummarySection struct {
+	Label string
+	Lines []string
+}
+
+// Summarize renders a queue alert for non-Slack notifiers
+// Custom templates and interactive buttons only apply to Slack messages
+func Summarize(alert QueueAlert, display Display) Summary {
+	summary := summarize(alert, display)
+	if correlation := alert.correlation(); correlation != "" {
+		summary.Footer += " · " + strings.ReplaceAll(correlation, "`", "")
+	}
+	return summary
+}
+
+// summarize renders the labelled text of a queue alert
+func summarize(alert QueueAlert, display Display) Summary {
+	c := catalogFor(display.Language)
+	timestamp := display.FormatTime(alert.Timestamp)
+
+	if alert.Type == AlertTypeReminder {
+		return Summary{
+			Alerting: true,
+			Title:    c.ReminderHeader,
+			Text:     fmt.Sprintf(c.ReminderText, display.QueueName(alert.QueueName)),
+			Fields: []SummaryField{
+				{c.Queue, display.QueueName(alert.QueueName)},
+				{c.VHost, alert.VHost},
+				{c.AlertingFor, FormatDuration(alert.StuckDuration, display.Language)},
+				{c.Messages, display.FormatNumber(alert.MessagesReady)},
+				{c.ChangeSinceAlert, formatChange(alert.InitialMessages, alert.MessagesReady, display)},
+				{c.Consumers, fmt.Sprintf("%d", alert.Consumers)},
+				{c.ConsumeRate, display.FormatRate(alert.ConsumeRate)},
+			},
+			Footer: "🕒 " + timestamp,
+		}
+	}
+
+	if alert.Type == AlertTypeRecovering {
+		return Summary{
+			Title: c.RecoveringHeader,
+			Text:  fmt.Sprintf(c.RecoveringText, display.QueueName(alert.QueueName)),
+			Fields: []SummaryField{
+				{c.Queue, display.QueueName(alert.QueueName)},
+				{c.VHost, alert.VHost},
+				{c.AlertingFor, FormatDuration(alert.StuckDuration, display.Language)},
+				{c.Messages, display.FormatNumber(alert.MessagesReady)},
+				{c.DrainRate, display.FormatRate(alert.DrainRate)},
+				{c.Consumers, fmt.Sprintf("%d", alert.Consumers)},
+				{c.ConsumeRate, display.FormatRate(alert.ConsumeRate)},
+			},
+			Footer: "🕒 " + timestamp,
+		}
+	}
+
+	if alert.Type != AlertTypeAlerting {
+		summary := Summary{
+			Title: c.RecoveryHeader,
+			Text:  fmt.Sprintf(c.RecoveryText, display.QueueName(alert.QueueName)),
+			Fields: []SummaryField{
+				{c.Queue, display.QueueName(alert.QueueName)},
+				{c.VHost, alert.VHost},
+				{c.WasAlertingFor, FormatDuration(alert.StuckDuration, display.Language)},
+				{c.CurrentMessages, display.FormatNumber(alert.MessagesReady)},
+				{c.Consumers, fmt.Sprintf("%d", alert.Consumers)},
+				{c.ConsumeRate, display.FormatRate(alert.ConsumeRate)},
+				{c.AckRate, display.FormatRate(alert.AckRate)},
+				{c.PublishRate, display.FormatRate(alert.PublishRate)},
+			},
+			Footer: fmt.Sprintf("🕒 %s: %s", c.NoLongerAlertingAt, timestamp),
+		}
+		if alert.AcknowledgedBy != "" {
+			summary.Fields = append(summary.Fields, SummaryField{c.AcknowledgedBy, alert.AcknowledgedBy})
+		}
+		if alert.DeadLetterQueue != "" {
+			summary.Fields = append(summary.Fields, SummaryField{c.DeadLetters, display.QueueName(alert.DeadLetterQueue) + ": " + display.FormatNumber(alert.DeadLetterMessages)})
+		}
+		if incident := alert.Incident; incident != nil {
+			summary.Fields = append(summary.Fields,
+				SummaryField{c.PeakBacklog, display.FormatNumber(incident.PeakBacklog)},
+				SummaryField{c.ProcessedSincePeak, display.FormatNumber(incident.Processed)},
+				SummaryField{c.AverageDrainRate, display.FormatRate(incident.DrainRate)},
+			)
+		}
+		return summary
+	}
+
+	summary := Summary{
+		Alerting: true,
+		Title:    c.AlertHeader,
+		Text:     fmt.Sprintf(c.AlertText, display.QueueName(alert.QueueName)),
+		Fields: []SummaryField{
+			{c.Queue, display.QueueName(alert.QueueName)},
+			{c.VHost, alert.VHost},
+			{c.Messages, display.FormatNumber(alert.MessagesReady)},
+			{c.Consumers, fmt.Sprintf("%d", alert.Consumers)},
+			{c.ConsumeRate, display.FormatRate(alert.ConsumeRate)},
+			{c.AckRate, display.FormatRate(alert.AckRate)},
+			{c.PublishRate, display.FormatRate(alert.PublishRate)},
+			{c.ConsecutiveStuck, fmt.Sprintf(c.Checks, alert.ConsecutiveStuck)},
+		},
+		Sections: []SummarySection{
+			{Label: c.Problem, Lines: []string{alert.Reason}},
+		},
+		Footer: fmt.Sprintf("🕒 %s: %s", c.AlertedAt, timestamp),
+	}
+	if alert.Priority != "" {
+		summary.Fields = append(summary.Fields, SummaryField{c.Priority, alert.Priority})
+	}
+	if alert.Node != "" {
+		summary.Fields = append(summary.Fields, SummaryField{c.Node, alert.Node})
+	}
+	if alert.DeadLetterQueue != "" {
+		summary.Fields = append(summary.Fields, SummaryField{c.DeadLetters, display.QueueName(alert.DeadLetterQueue) + ": " + display.FormatNumber(alert.DeadLetterMessages)})
+	}
+	if len(alert.PriorityLengths) > 0 {
+		summary.Fields = append(summary.Fields, SummaryField{c.PriorityBacklog, formatPriorityLengths(alert.PriorityLengths, display)})
+	}
+	if owner := alert.Owner; owner != nil {
+		summary.Fields = append(summary.Fields, SummaryField{c.Owner, owner.Team})
+		if owner.Escalation != "" {
+			summary.Fields = append(summary.Fields, SummaryField{c.Escalation, owner.Escalation})
+		}
+	}
+
+	if len(alert.DownstreamQueues) > 0 {
+		// The catalog text carries Slack bold markers around its label
+		rootCause := fmt.Sprintf(c.LikelyRootCause, len(alert.DownstreamQueues), strings.Join(display.QueueNames.Names(alert.DownstreamQueues), "`, `"))
+		summary.Sections = append(summary.Sections, SummarySection{Lines: []string{strings.ReplaceAll(rootCause, "*", "")}})
+	}
+	if len(alert.BrokerEvents) > 0 {
+		summary.Sections = append(summary.Sections, SummarySection{Label: c.RecentBrokerEvents, Lines: alert.BrokerEvents})
+	}
+	if len(alert.ConsumerDetails) > 0 {
+		summary.Sections = append(summary.Sections, SummarySection{Label: c.Consumers, Lines: alert.ConsumerDetails})
+	}
+	if len(alert.HeadMessages) > 0 {
+		summary.Sections = append(summary.Sections, SummarySection{Label: c.HeadMessages, Lines: alert.HeadMessages})
+	}
+	if quarantine := alert.Quarantine; quarantine != nil {
+		section := SummarySection{Label: fmt.Sprintf(c.Quarantined, display.QueueName(quarantine.Queue)), Lines: quarantine.Messages}
+		if quarantine.DryRun {
+			section.Label = fmt.Sprintf(c.QuarantineDryRun, display.QueueName(quarantine.Queue))
+		}
+		if quarantine.Error != "" {
+			section.Lines = append(append([]string{}, section.Lines...), "⚠️ "+fmt.Sprintf(c.QuarantineFailed, quarantine.Error))
+		}
+		summary.Sections = append(summary.Sections, section)
+	}
+
+	return summary
+}
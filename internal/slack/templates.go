@@ -0,0 +1,118 @@
+package slack
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"text/template"
+	"time"
+)
+
+// Templates holds custom message templates that replace the built-in layouts
+// A nil template keeps the built-in layout for that alert type
+type Templates struct {
+	Alerting *template.Template
+	Recovery *template.Template
+}
+
+// TemplateData is passed to custom templates
+type TemplateData struct {
+	QueueAlert
+	Language    string
+	DisplayName string // Queue name as shown in notifications
+}
+
+// LoadTemplates parses custom template files, skipping empty paths
+func LoadTemplates(alertingPath, recoveryPath string) (*Templates, error) {
+	alerting, err := loadTemplate("alerting", alertingPath)
+	if err != nil {
+		return nil, err
+	}
+	recovery, err := loadTemplate("recovery", recoveryPath)
+	if err != nil {
+		return nil, err
+	}
+	if alerting == nil && recovery == nil {
+		return nil, nil
+	}
+	templates := &Templates{Alerting: alerting, Recovery: recovery}
+
+	// Render a sample alert so template errors surface at startup
+	sample := QueueAlert{QueueName: "sample", Timestamp: time.Now(), StuckDuration: time.Minute}
+	for _, alertType := range []AlertType{AlertTypeAlerting, AlertTypeNotAlerting} {
+		sample.Type = alertType
+		if tmpl := templates.forAlert(alertType); tmpl != nil {
+			if _, err := renderTemplate(tmpl, sample, Display{}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return templates, nil
+}
+
+// loadTemplate parses a single template file
+func loadTemplate(name, path string) (*template.Template, error) {
+	if path == "" {
+		return nil, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s template: %w", name, err)
+	}
+	// Functions are rebound per render so they follow the channel language
+	tmpl, err := template.New(name).Funcs(templateFuncs(Display{})).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s template: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// templateFuncs returns the helpers available to custom templates
+func templateFuncs(display Display) template.FuncMap {
+	return template.FuncMap{
+		"duration": func(d time.Duration) string { return FormatDuration(d, display.Language) },
+		"number":   display.FormatNumber,
+		"rate":     display.FormatRate,
+		"time":     display.FormatTime,
+	}
+}
+
+// forAlert returns the custom template for an alert type, or nil
+func (t *Templates) forAlert(alertType AlertType) *template.Template {
+	if t == nil {
+		return nil
+	}
+	switch alertType {
+	case AlertTypeAlerting:
+		return t.Alerting
+	case AlertTypeNotAlerting:
+		return t.Recovery
+	}
+	return nil
+}
+
+// renderTemplate executes a custom template into a Slack message
+func renderTemplate(tmpl *template.Template, alert QueueAlert, display Display) (Message, error) {
+	clone, err := tmpl.Clone()
+	if err != nil {
+		return Message{}, err
+	}
+	var buf bytes.Buffer
+	data := TemplateData{QueueAlert: alert, Language: display.languageOrDefault(), DisplayName: display.QueueName(alert.QueueName)}
+	if err := clone.Funcs(templateFuncs(display)).Execute(&buf, data); err != nil {
+		return Message{}, fmt.Errorf("failed to render %s template: %w", tmpl.Name(), err)
+	}
+	text := buf.String()
+	message := Message{
+		Text: text,
+		Blocks: []Block{
+			{Type: "section", Text: &TextObject{Type: "mrkdwn", Text: text}},
+		},
+	}
+	if alert.Type == AlertTypeAlerting {
+		if actions, ok := actionsBlock(alert, catalogFor(display.Language)); ok {
+			message.Blocks = append(message.Blocks, actions)
+		}
+	}
+	return message, nil
+}
@@ -1,6 +1,9 @@
 package slack
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Message represents a Slack message with blocks
 type Message struct {
@@ -11,10 +14,35 @@ type Message struct {
 
 // Block represents a Slack block
 type Block struct {
-	Type     string        `json:"type"`
-	Text     *TextObject   `json:"text,omitempty"`
-	Fields   []TextObject  `json:"fields,omitempty"`
-	Elements []TextObject  `json:"elements,omitempty"`
+	Type     string       `json:"type"`
+	Text     *TextObject  `json:"text,omitempty"`
+	Fields   []TextObject `json:"fields,omitempty"`
+	Elements []TextObject `json:"elements,omitempty"`
+	Buttons  []Button     `json:"-"` // Elements of an "actions" block
+}
+
+// Button represents a Slack button element
+type Button struct {
+	Type     string     `json:"type"`
+	Text     TextObject `json:"text"`
+	ActionID string     `json:"action_id"`
+	Value    string     `json:"value,omitempty"`
+	Style    string     `json:"style,omitempty"`
+}
+
+// MarshalJSON encodes buttons as the elements of an actions block
+func (b Block) MarshalJSON() ([]byte, error) {
+	type plainBlock Block
+	if len(b.Buttons) == 0 {
+		return json.Marshal(plainBlock(b))
+	}
+	return json.Marshal(struct {
+		Type     string   `json:"type"`
+		Elements []Button `json:"elements"`
+	}{
+		Type:     b.Type,
+		Elements: b.Buttons,
+	})
 }
 
 // TextObject represents a Slack text object
@@ -29,20 +57,274 @@ type AlertType string
 const (
 	AlertTypeAlerting    AlertType = "alerting"
 	AlertTypeNotAlerting AlertType = "not_alerting"
+	AlertTypeReminder    AlertType = "reminder"   // Queue still alerting long after its alert
+	AlertTypeRecovering  AlertType = "recovering" // Alerting queue whose backlog started decreasing
 )
 
 // QueueAlert contains information for Slack notifications
 type QueueAlert struct {
-	Type             AlertType
-	QueueName        string
-	VHost            string
-	MessagesReady    int
-	Consumers        int
-	ConsumeRate      float64
-	AckRate          float64
-	PublishRate      float64
-	ConsecutiveStuck int
-	Reason           string
+	Type                AlertType
+	QueueName           string
+	Priority            string
+	VHost               string
+	Node                string // Node hosting the queue, the leader of a replicated queue
+	DeadLetterQueue     string // Dead-letter queue paired with the queue by naming convention, if any
+	DeadLetterMessages  int    // Messages in the dead-letter queue
+	MessagesReady       int
+	Consumers           int
+	ConsumeRate         float64
+	AckRate             float64
+	PublishRate         float64
+	ConsecutiveStuck    int
+	Reason              string
+	Timestamp           time.Time
+	StuckDuration       time.Duration  // For recovery alerts
+	BrokerEvents        []string       // Recent broker events near the transition
+	DownstreamQueues    []string       // Dependent queues stuck because of this queue
+	AckButton           bool           // Add an acknowledge button to alerting messages
+	AcknowledgedBy      string         // Who acknowledged the incident, for recovery alerts
+	FalsePositiveButton bool           // Add a "false positive" button to alerting messages
+	ConsumerDetails     []string       // Redacted consumer descriptions
+	HeadMessages        []string       // Redacted descriptions of messages at the head of the queue
+	Quarantine          *Quarantine    // Poison messages moved out of the queue, if any
+	PriorityLengths     map[int]int    // Ready messages per priority for tracked priority queues
+	Incident            *IncidentStats // Backlog statistics from history, for recovery alerts
+	InitialMessages     int            // Ready messages when the alert was sent, for reminders
+	DrainRate           float64        // Backlog decrease in msg/s, for recovering alerts
+	Owner               *QueueOwner    // Owning team from the ownership directory, for alerting messages
+	IncidentID          string         // Incident the notification belongs to
+	CheckID             string         // Check cycle that produced the notification
+}
+
+// QueueOwner is the team responsible for a queue
+type QueueOwner struct {
+	Team       string
+	SlackGroup string // Slack user group ID or mention, mentioned in stuck alerts
+	Escalation string // Escalation contact shown in stuck alerts
+}
+
+// IncidentStats summarizes how a recovered queue's backlog built up and drained
+type IncidentStats struct {
+	PeakBacklog int       // Most ready messages during the incident
+	PeakAt      time.Time // When the backlog peaked
+	Processed   int       // Messages acknowledged between the peak and recovery
+	DrainRate   float64   // Average backlog reduction from the peak in msg/s
+}
+
+// Quarantine describes messages moved to the quarantine queue
+type Quarantine struct {
+	Queue    string   // Target quarantine queue
+	DryRun   bool     // Messages were only inspected, not moved
+	Messages []string // Redacted descriptions of the (would-be) moved messages
+	Error    string   // Why the move failed, if it did
+}
+
+// ClusterAlert contains information for cluster-wide problem notifications
+type ClusterAlert struct {
+	Resolved         bool
+	AlertingQueues   []string
+	TotalQueues      int
+	ThresholdPercent float64
 	Timestamp        time.Time
-	StuckDuration    time.Duration // For recovery alerts
+	Overview         *ClusterOverview // Broker load at alert time, nil if unavailable
+}
+
+// ConnectionAlert contains information for low protocol connection count notifications
+type ConnectionAlert struct {
+	Resolved       bool
+	Protocol       string // Display name such as MQTT or STOMP
+	Connections    int
+	MinConnections int
+	VHost          string
+	Timestamp      time.Time
+	AlertDuration  time.Duration // How long connections were low, for recoveries
+}
+
+// VHostAlert contains information for vhost-wide limit notifications
+type VHostAlert struct {
+	Resolved        bool
+	VHost           string
+	Messages        int
+	MessagesReady   int
+	MessagesUnacked int
+	PublishRate     float64
+	ConsumeRate     float64
+	Limits          VHostLimits
+	Breached        map[string]bool // Limits exceeded, by name: messages, messages_ready, publish_rate, consume_rate
+	Timestamp       time.Time
+	AlertDuration   time.Duration // How long the vhost exceeded its limits, for recoveries
+}
+
+// VHostLimits are the limits of a vhost rule, 0 when not checked
+type VHostLimits struct {
+	MaxMessages      int
+	MaxMessagesReady int
+	MaxPublishRate   float64
+	MinConsumeRate   float64
+}
+
+// ServiceAlert contains information for service-wide limit notifications across grouped queues
+type ServiceAlert struct {
+	Resolved        bool
+	Service         string
+	Queues          int // Queues the totals cover
+	MessagesReady   int
+	MessagesUnacked int
+	Consumers       int
+	PublishRate     float64
+	ConsumeRate     float64
+	Lag             time.Duration // Time to drain the backlog at the current consume rate
+	Stalled         bool          // Messages are ready but none are consumed, so the lag is unbounded
+	Limits          ServiceLimits
+	Breached        map[string]bool // Limits exceeded, by name: messages_ready, consumers, consume_rate, lag
+	Timestamp       time.Time
+	AlertDuration   time.Duration // How long the service exceeded its limits, for recoveries
+}
+
+// ServiceLimits are the limits of a service, 0 when not checked
+type ServiceLimits struct {
+	MaxMessagesReady int
+	MinConsumers     int
+	MinConsumeRate   float64
+	MaxLag           time.Duration
+}
+
+// ShardAlert contains information for a service queue falling behind its sibling shards
+type ShardAlert struct {
+	Resolved           bool
+	Service            string
+	QueueName          string
+	Shards             int // Queues of the service, including this one
+	MessagesReady      int
+	Consumers          int
+	ConsumeRate        float64
+	SiblingMessages    float64         // Median ready messages of the other shards
+	SiblingConsumeRate float64         // Median consume rate of the other shards
+	Outlying           map[string]bool // Values beyond the outlier factor, by name: messages_ready or consume_rate
+	Timestamp          time.Time
+	AlertDuration      time.Duration // How long the shard fell behind, for recoveries
+}
+
+// ChurnAlert contains information for queue churn notifications
+type ChurnAlert struct {
+	Resolved      bool
+	CreatedRate   float64 // Queues created per minute
+	DeletedRate   float64 // Queues deleted per minute
+	MaxCreated    float64 // 0 when not checked
+	MaxDeleted    float64 // 0 when not checked
+	Timestamp     time.Time
+	AlertDuration time.Duration // How long churn was high, for recoveries
+}
+
+// ComparisonAlert contains information for a queue diverging from its namesake in another vhost or cluster
+type ComparisonAlert struct {
+	Resolved      bool
+	QueueName     string
+	Source        string // Monitored side, such as its vhost
+	Target        string // Compared side
+	Missing       bool   // The queue does not exist on the compared side
+	SourceStats   ComparisonStats
+	TargetStats   ComparisonStats
+	Diverged      map[string]bool // Diverging values by name: messages_ready, publish_rate or consume_rate
+	Tolerance     float64         // Largest relative difference allowed
+	Timestamp     time.Time
+	AlertDuration time.Duration // How long the queues diverged, for recoveries
+}
+
+// DeadLetterAlert contains information for a growing dead-letter queue
+type DeadLetterAlert struct {
+	Resolved        bool
+	QueueName       string // Queue whose failed messages are dead-lettered
+	DeadLetterQueue string
+	Messages        int // Messages in the dead-letter queue
+	Growth          int // Messages gained since growth began
+	Timestamp       time.Time
+	AlertDuration   time.Duration // How long the dead-letter queue grew, for recoveries
+}
+
+// ComparisonStats are the compared values of one side
+type ComparisonStats struct {
+	MessagesReady int
+	PublishRate   float64
+	ConsumeRate   float64
+}
+
+// SLOBurnAlert contains information for error budget burn rate notifications
+type SLOBurnAlert struct {
+	Resolved        bool
+	QueueName       string
+	Priority        string
+	BurnRate        float64
+	Threshold       float64
+	Window          time.Duration
+	BudgetRemaining float64 // Percent of the month's error budget left
+	Target          float64 // Availability objective in percent
+	Timestamp       time.Time
+}
+
+// CapacityAlert contains information for a queue whose publish rate nears its consumers' capacity
+type CapacityAlert struct {
+	Resolved    bool
+	QueueName   string
+	Priority    string
+	PublishRate float64
+	Capacity    float64 // Sustainable consume rate estimated from history, msg/s
+	Utilisation float64 // Publish rate relative to capacity
+	Threshold   float64 // Utilisation that alerts
+	Timestamp   time.Time
+}
+
+// AuthAlert contains information for the management API rejecting the monitor's credentials
+type AuthAlert struct {
+	Resolved      bool
+	Broker        string // Management API host and port
+	Username      string
+	Reload        string // Why reloading the credentials did not help, for firing alerts
+	Timestamp     time.Time
+	AlertDuration time.Duration // How long authentication failed, for recoveries
+}
+
+// LatencyAlert contains information for slow notification delivery notifications
+type LatencyAlert struct {
+	Resolved      bool
+	Channel       string        // Notification channel, e.g. slack or zulip
+	Latency       time.Duration // From detection to delivery of the latest notification on the channel
+	MaxLatency    time.Duration
+	QueueName     string // Queue of the latest notification
+	Timestamp     time.Time
+	AlertDuration time.Duration // How long delivery was slow, for recoveries
+}
+
+// SLOReport contains a month's availability per queue
+type SLOReport struct {
+	Month  string // YYYY-MM
+	Target float64
+	Queues []SLOQueue
+}
+
+// SLOQueue is a single queue's line in an SLO report
+type SLOQueue struct {
+	QueueName       string
+	Availability    float64
+	BudgetRemaining float64
+	Incidents       int
+	MTTA            time.Duration // Zero when no incident was acknowledged
+	MTTR            time.Duration
+}
+
+// ClusterOverview summarizes broker-wide load for cluster alerts
+type ClusterOverview struct {
+	Connections        int
+	Channels           int
+	Queues             int
+	Consumers          int
+	Messages           int
+	MessagesUnacked    int
+	PublishRate        float64
+	DeliverRate        float64
+	AckRate            float64
+	ErlangProcesses    int
+	ErlangProcessLimit int
+	Nodes              int
+	RunningNodes       int
 }
@@ -0,0 +1,293 @@
+package slo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Config contains availability objective parameters
+type Config struct {
+	Target         float64        // Percent of the month a queue must not be stuck, e.g. 99.5
+	BurnRateWindow time.Duration  // Window burn rates are computed over
+	BurnRateAlert  float64        // Alert when the budget burns this many times faster than sustainable (0 disables)
+	MaxGap         time.Duration  // Longer gaps between checks (e.g. monitor downtime) are not counted
+	Location       *time.Location // Timezone of month boundaries
+}
+
+// QueueStatus describes a queue's availability in the current month
+type QueueStatus struct {
+	Queue                  string  `json:"queue"`
+	AvailabilityPercent    float64 `json:"availability_percent"`
+	BudgetRemainingPercent float64 `json:"budget_remaining_percent"`
+	BurnRate               float64 `json:"burn_rate"`
+	Burning                bool    `json:"burning"`
+	MonitoredSeconds       float64 `json:"monitored_seconds"`
+	StuckSeconds           float64 `json:"stuck_seconds"`
+	Incidents              int     `json:"incidents"`
+	MTTASeconds            float64 `json:"mtta_seconds,omitempty"` // Mean time to acknowledge over acknowledged incidents
+	MTTRSeconds            float64 `json:"mttr_seconds,omitempty"` // Mean time from alert to recovery
+}
+
+// Report summarizes all queues' availability for a month
+type Report struct {
+	Month  string        `json:"month"` // YYYY-MM
+	Target float64       `json:"target"`
+	Queues []QueueStatus `json:"queues"`
+}
+
+// Transition is a queue starting or stopping to burn its error budget too fast
+type Transition struct {
+	Queue                  string
+	Burning                bool
+	BurnRate               float64
+	BudgetRemainingPercent float64
+	Timestamp              time.Time
+}
+
+// usage is a queue's accounting for the current month
+type usage struct {
+	MonitoredSeconds float64 `json:"monitored_seconds"`
+	StuckSeconds     float64 `json:"stuck_seconds"`
+	Incidents        int     `json:"incidents,omitempty"`
+	Acknowledged     int     `json:"acknowledged,omitempty"`
+	AckSeconds       float64 `json:"ack_seconds,omitempty"`
+	RecoverSeconds   float64 `json:"recover_seconds,omitempty"`
+}
+
+// sample is the time between two checks and whether the queue was stuck in it
+type sample struct {
+	at      time.Time
+	elapsed time.Duration
+	stuck   bool
+}
+
+// persisted is the on-disk form of the month's accounting
+type persisted struct {
+	Month  string            `json:"month"`
+	Queues map[string]*usage `json:"queues"`
+}
+
+// Tracker accounts per-queue availability against the objective
+type Tracker struct {
+	cfg      Config
+	path     string
+	month    string
+	usage    map[string]*usage
+	samples  map[string][]sample
+	burning  map[string]bool
+	lastSeen time.Time
+	mu       sync.RWMutex
+}
+
+// Open creates a tracker, restoring the current month's accounting from path if present
+func Open(path string, cfg Config) (*Tracker, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create SLO directory: %w", err)
+	}
+
+	t := &Tracker{
+		cfg:     cfg,
+		path:    path,
+		usage:   make(map[string]*usage),
+		samples: make(map[string][]sample),
+		burning: make(map[string]bool),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return t, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SLO state: %w", err)
+	}
+	var state persisted
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse SLO state: %w", err)
+	}
+	t.month = state.Month
+	if state.Queues != nil {
+		t.usage = state.Queues
+	}
+	return t, nil
+}
+
+// Observe accounts the time since the previous check to every queue
+// stuck holds whether each tracked queue is alerting
+// Returns burn rate transitions and, on the first check of a new month, the previous month's report
+func (t *Tracker) Observe(stuck map[string]bool, now time.Time) ([]Transition, *Report, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var report *Report
+	month := now.In(t.cfg.Location).Format("2006-01")
+	if month != t.month {
+		if t.month != "" && len(t.usage) > 0 {
+			report = &Report{Month: t.month, Target: t.cfg.Target, Queues: t.statusLocked(t.month)}
+		}
+		t.month = month
+		t.usage = make(map[string]*usage)
+	}
+
+	elapsed := now.Sub(t.lastSeen)
+	if t.lastSeen.IsZero() || elapsed > t.cfg.MaxGap || elapsed < 0 {
+		elapsed = 0
+	}
+	t.lastSeen = now
+
+	transitions := make([]Transition, 0)
+	for queue, isStuck := range stuck {
+		u, exists := t.usage[queue]
+		if !exists {
+			u = &usage{}
+			t.usage[queue] = u
+		}
+		if elapsed == 0 {
+			continue
+		}
+		u.MonitoredSeconds += elapsed.Seconds()
+		if isStuck {
+			u.StuckSeconds += elapsed.Seconds()
+		}
+
+		samples := append(t.samples[queue], sample{at: now, elapsed: elapsed, stuck: isStuck})
+		cutoff := now.Add(-t.cfg.BurnRateWindow)
+		for len(samples) > 0 && !samples[0].at.After(cutoff) {
+			samples = samples[1:]
+		}
+		t.samples[queue] = samples
+
+		if t.cfg.BurnRateAlert <= 0 {
+			continue
+		}
+		rate, covered := t.burnRate(queue)
+		burning := covered && rate >= t.cfg.BurnRateAlert
+		if burning != t.burning[queue] {
+			t.burning[queue] = burning
+			transitions = append(transitions, Transition{
+				Queue:                  queue,
+				Burning:                burning,
+				BurnRate:               rate,
+				BudgetRemainingPercent: t.budgetRemaining(u, t.month),
+				Timestamp:              now,
+			})
+		}
+	}
+	sort.Slice(transitions, func(i, j int) bool {
+		return transitions[i].Queue < transitions[j].Queue
+	})
+
+	return transitions, report, t.save()
+}
+
+// RecordIncident accounts a resolved incident's response times to the current month
+// timeToAck is ignored unless the incident was acknowledged
+func (t *Tracker) RecordIncident(queue string, timeToAck time.Duration, acknowledged bool, timeToRecover time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	u, exists := t.usage[queue]
+	if !exists {
+		u = &usage{}
+		t.usage[queue] = u
+	}
+	u.Incidents++
+	u.RecoverSeconds += timeToRecover.Seconds()
+	if acknowledged {
+		u.Acknowledged++
+		u.AckSeconds += timeToAck.Seconds()
+	}
+}
+
+// burnRate returns how many times faster than sustainable the queue used its budget in the window
+// covered is false until at least half the window has been observed
+func (t *Tracker) burnRate(queue string) (float64, bool) {
+	var total, stuck time.Duration
+	for _, s := range t.samples[queue] {
+		total += s.elapsed
+		if s.stuck {
+			stuck += s.elapsed
+		}
+	}
+	if total == 0 || t.cfg.Target >= 100 {
+		return 0, false
+	}
+	rate := (stuck.Seconds() / total.Seconds()) / (1 - t.cfg.Target/100)
+	return rate, total >= t.cfg.BurnRateWindow/2
+}
+
+// budgetRemaining returns the percentage of the month's error budget not yet used
+func (t *Tracker) budgetRemaining(u *usage, month string) float64 {
+	start, err := time.ParseInLocation("2006-01", month, t.cfg.Location)
+	if err != nil || t.cfg.Target >= 100 {
+		return 0
+	}
+	budget := start.AddDate(0, 1, 0).Sub(start).Seconds() * (1 - t.cfg.Target/100)
+	return 100 * (1 - u.StuckSeconds/budget)
+}
+
+// statusLocked returns the status of every queue for a month, ordered by queue name
+func (t *Tracker) statusLocked(month string) []QueueStatus {
+	result := make([]QueueStatus, 0, len(t.usage))
+	for queue, u := range t.usage {
+		status := QueueStatus{
+			Queue:                  queue,
+			AvailabilityPercent:    100,
+			BudgetRemainingPercent: t.budgetRemaining(u, month),
+			Burning:                t.burning[queue],
+			MonitoredSeconds:       u.MonitoredSeconds,
+			StuckSeconds:           u.StuckSeconds,
+			Incidents:              u.Incidents,
+		}
+		if u.MonitoredSeconds > 0 {
+			status.AvailabilityPercent = 100 * (1 - u.StuckSeconds/u.MonitoredSeconds)
+		}
+		if u.Incidents > 0 {
+			status.MTTRSeconds = u.RecoverSeconds / float64(u.Incidents)
+		}
+		if u.Acknowledged > 0 {
+			status.MTTASeconds = u.AckSeconds / float64(u.Acknowledged)
+		}
+		status.BurnRate, _ = t.burnRate(queue)
+		result = append(result, status)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Queue < result[j].Queue
+	})
+	return result
+}
+
+// save writes the month's accounting atomically
+func (t *Tracker) save() error {
+	data, err := json.Marshal(persisted{Month: t.month, Queues: t.usage})
+	if err != nil {
+		return fmt.Errorf("failed to encode SLO state: %w", err)
+	}
+	tmp := t.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write SLO state: %w", err)
+	}
+	if err := os.Rename(tmp, t.path); err != nil {
+		return fmt.Errorf("failed to write SLO state: %w", err)
+	}
+	return nil
+}
+
+// Current returns the availability of every queue in the current month
+func (t *Tracker) Current() Report {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return Report{Month: t.month, Target: t.cfg.Target, Queues: t.statusLocked(t.month)}
+}
+
+// HandleStatus returns the current month's availability as JSON
+func (t *Tracker) HandleStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(t.Current())
+}
@@ -0,0 +1,222 @@
+package streams
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"go-rmq-monitor/internal/rabbitmq"
+)
+
+// Config contains stream consumer lag detection parameters
+type Config struct {
+	MaxOffsetLag    int64 // Alert when a group lags more than this many messages (0 disables)
+	LagGrowthChecks int   // Alert when a group's lag grew for this many consecutive checks (0 disables)
+	AlertOnDetach   bool  // Alert when a previously seen group has no consumers left
+}
+
+// Transition is a change in the health of a stream consumer group
+type Transition struct {
+	Stream    string
+	Group     string
+	Alerting  bool
+	Reason    string // Why the group is alerting, empty for recoveries
+	OffsetLag int64
+	Duration  time.Duration // How long the group was alerting, for recoveries
+	Timestamp time.Time
+}
+
+// GroupStatus describes the tracked state of a consumer group
+type GroupStatus struct {
+	Stream    string    `json:"stream"`
+	Group     string    `json:"group"`
+	OffsetLag int64     `json:"offset_lag"`
+	Attached  bool      `json:"attached"`
+	Alerting  bool      `json:"alerting"`
+	Reason    string    `json:"reason,omitempty"`
+	Since     time.Time `json:"since,omitempty"`
+}
+
+// groupKey identifies a consumer group on a stream
+type groupKey struct {
+	stream string
+	group  string
+}
+
+// groupState tracks a consumer group across checks
+type groupState struct {
+	lag      int64
+	growing  int // Consecutive checks with growing lag
+	attached bool
+	alerting bool
+	reason   string
+	since    time.Time
+}
+
+// Tracker follows consumer group lag per stream and reports state changes
+type Tracker struct {
+	cfg    Config
+	groups map[groupKey]*groupState
+	mu     sync.RWMutex
+}
+
+// New creates a new stream tracker
+func New(cfg Config) *Tracker {
+	return &Tracker{
+		cfg:    cfg,
+		groups: make(map[groupKey]*groupState),
+	}
+}
+
+// Observe records the current consumers of the monitored streams
+// Returns the groups that started or stopped alerting
+func (t *Tracker) Observe(streams []string, consumers []rabbitmq.StreamConsumer, now time.Time) []Transition {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	monitored := make(map[string]bool, len(streams))
+	for _, stream := range streams {
+		monitored[stream] = true
+	}
+
+	// A group's lag is the lag of its furthest behind active consumer
+	current := make(map[groupKey]int64)
+	for _, consumer := range consumers {
+		if !monitored[consumer.Stream] {
+			continue
+		}
+		key := groupKey{stream: consumer.Stream, group: consumer.Group}
+		lag, seen := current[key]
+		if !consumer.Active {
+			if !seen {
+				current[key] = 0
+			}
+			continue
+		}
+		if !seen || consumer.OffsetLag > lag {
+			current[key] = consumer.OffsetLag
+		}
+	}
+
+	transitions := make([]Transition, 0)
+	for key, lag := range current {
+		state, exists := t.groups[key]
+		if !exists {
+			state = &groupState{lag: lag}
+			t.groups[key] = state
+		}
+		if exists && state.attached && lag > state.lag {
+			state.growing++
+		} else {
+			state.growing = 0
+		}
+		state.lag = lag
+		state.attached = true
+
+		transitions = t.update(key, state, t.lagProblem(key, state), now, transitions)
+	}
+
+	for key, state := range t.groups {
+		if _, present := current[key]; present {
+			continue
+		}
+		// Streams no longer monitored are forgotten
+		if !monitored[key.stream] {
+			delete(t.groups, key)
+			continue
+		}
+		state.attached = false
+		state.growing = 0
+		reason := ""
+		if t.cfg.AlertOnDetach {
+			reason = fmt.Sprintf("consumer group %s detached from stream", key.group)
+		}
+		transitions = t.update(key, state, reason, now, transitions)
+		if !state.alerting {
+			delete(t.groups, key)
+		}
+	}
+
+	sort.Slice(transitions, func(i, j int) bool {
+		if transitions[i].Stream != transitions[j].Stream {
+			return transitions[i].Stream < transitions[j].Stream
+		}
+		return transitions[i].Group < transitions[j].Group
+	})
+	return transitions
+}
+
+// lagProblem returns why an attached group is alerting, or "" if it is healthy
+func (t *Tracker) lagProblem(key groupKey, state *groupState) string {
+	if t.cfg.MaxOffsetLag > 0 && state.lag > t.cfg.MaxOffsetLag {
+		return fmt.Sprintf("consumer group %s lags %d messages behind (max %d)", key.group, state.lag, t.cfg.MaxOffsetLag)
+	}
+	if t.cfg.LagGrowthChecks > 0 && state.growing >= t.cfg.LagGrowthChecks {
+		return fmt.Sprintf("consumer group %s lag grew for %d consecutive checks to %d messages", key.group, state.growing, state.lag)
+	}
+	return ""
+}
+
+// update applies the current problem to a group and appends a transition if its state changed
+func (t *Tracker) update(key groupKey, state *groupState, reason string, now time.Time, transitions []Transition) []Transition {
+	alerting := reason != ""
+	if alerting == state.alerting {
+		state.reason = reason
+		return transitions
+	}
+
+	transition := Transition{
+		Stream:    key.stream,
+		Group:     key.group,
+		Alerting:  alerting,
+		Reason:    reason,
+		OffsetLag: state.lag,
+		Timestamp: now,
+	}
+	if alerting {
+		state.since = now
+	} else {
+		transition.Duration = now.Sub(state.since)
+	}
+	state.alerting = alerting
+	state.reason = reason
+	return append(transitions, transition)
+}
+
+// Status returns all tracked consumer groups ordered by stream and group
+func (t *Tracker) Status() []GroupStatus {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	result := make([]GroupStatus, 0, len(t.groups))
+	for key, state := range t.groups {
+		status := GroupStatus{
+			Stream:    key.stream,
+			Group:     key.group,
+			OffsetLag: state.lag,
+			Attached:  state.attached,
+			Alerting:  state.alerting,
+			Reason:    state.reason,
+		}
+		if state.alerting {
+			status.Since = state.since
+		}
+		result = append(result, status)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Stream != result[j].Stream {
+			return result[i].Stream < result[j].Stream
+		}
+		return result[i].Group < result[j].Group
+	})
+	return result
+}
+
+// HandleList returns the tracked consumer groups as JSON
+func (t *Tracker) HandleList(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(t.Status())
+}
@@ -0,0 +1,172 @@
+// Package tail streams monitor events to live subscribers over Server-Sent Events
+package tail
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"go-rmq-monitor/internal/sink"
+)
+
+// bufferSize is the number of events queued per subscriber before events are dropped
+const bufferSize = 64
+
+// keepaliveInterval is how often an idle stream receives a comment, so proxies keep it open
+const keepaliveInterval = 15 * time.Second
+
+// Hub fans published events out to the connected streams
+// Slow subscribers lose events rather than delay checks
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+	closed      chan struct{}
+	closeOnce   sync.Once
+}
+
+// subscriber is one connected stream
+type subscriber struct {
+	events  chan sink.Event
+	queue   string          // Glob pattern of the queues streamed, empty for all events
+	types   map[string]bool // Event types streamed, empty for all
+	dropped int             // Events dropped since the last one delivered, guarded by the hub's mutex
+}
+
+// NewHub creates a hub without subscribers
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[*subscriber]struct{}),
+		closed:      make(chan struct{}),
+	}
+}
+
+// Publish sends an event to every subscriber whose filters match, without blocking
+// Does nothing on a nil hub
+func (h *Hub) Publish(event sink.Event) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subscribers {
+		if !sub.matches(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			sub.dropped++
+		}
+	}
+}
+
+// Subscribers returns the number of connected streams
+func (h *Hub) Subscribers() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subscribers)
+}
+
+// Close ends every stream, e.g. before the server shuts down
+func (h *Hub) Close() {
+	if h == nil {
+		return
+	}
+	h.closeOnce.Do(func() { close(h.closed) })
+}
+
+func (h *Hub) subscribe(sub *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subscribers[sub] = struct{}{}
+}
+
+func (h *Hub) unsubscribe(sub *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subscribers, sub)
+}
+
+// takeDropped returns and resets the number of events a subscriber lost
+func (h *Hub) takeDropped(sub *subscriber) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	dropped := sub.dropped
+	sub.dropped = 0
+	return dropped
+}
+
+// matches reports whether an event passes the subscriber's filters
+// A queue filter drops events that are not about a queue, such as check events
+func (s *subscriber) matches(event sink.Event) bool {
+	if len(s.types) > 0 && !s.types[event.Type] {
+		return false
+	}
+	if s.queue == "" {
+		return true
+	}
+	matched, _ := path.Match(s.queue, event.Queue)
+	return event.Queue != "" && matched
+}
+
+// HandleStream streams events as Server-Sent Events until the client disconnects or the hub closes
+// The optional "queue" query parameter is a glob pattern of queue names, and "type" a
+// comma-separated list of event types; each event is sent with its type as the SSE event name
+// Registered as GET /api/v1/tail
+func (h *Hub) HandleStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	sub := &subscriber{
+		events: make(chan sink.Event, bufferSize),
+		queue:  r.URL.Query().Get("queue"),
+		types:  make(map[string]bool),
+	}
+	if _, err := path.Match(sub.queue, ""); err != nil {
+		http.Error(w, "invalid queue pattern", http.StatusBadRequest)
+		return
+	}
+	for _, eventType := range strings.Split(r.URL.Query().Get("type"), ",") {
+		if eventType = strings.TrimSpace(eventType); eventType != "" {
+			sub.types[eventType] = true
+		}
+	}
+
+	h.subscribe(sub)
+	defer h.unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable response buffering in nginx
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	keepalive := time.NewTicker(keepaliveInterval)
+	defer keepalive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-h.closed:
+			return
+		case <-keepalive.C:
+			fmt.Fprint(w, ": keepalive\n\n")
+		case event := <-sub.events:
+			if dropped := h.takeDropped(sub); dropped > 0 {
+				fmt.Fprintf(w, ": dropped %d events\n\n", dropped)
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+		}
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,558 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go-rmq-monitor/internal/analyzer"
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/history"
+	"go-rmq-monitor/internal/queueerrors"
+	"go-rmq-monitor/internal/queuename"
+	"go-rmq-monitor/internal/rabbitmq"
+	"go-rmq-monitor/internal/slack"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Sort columns available in the queue table
+var sortColumns = []string{"name", "messages", "consumers", "consume_rate", "state"}
+
+// maxRecentAlerts is the number of state transitions kept in the alerts pane
+const maxRecentAlerts = 8
+
+// Timeline pane settings: the range shown, how often the history file is reread and the rows shown
+const (
+	timelineRange   = 24 * time.Hour
+	timelineRefresh = time.Minute
+	maxTimelineRows = 10
+)
+
+// minTableRows is the number of queue rows shown however many lines the other panes take
+const minTableRows = 3
+
+var (
+	headerStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
+	alertingStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true)
+	suspectStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+	recoverStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("14"))
+	healthyStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	dimStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+)
+
+// queueRow is a queue with its latest metrics and analyzer state
+type queueRow struct {
+	info             rabbitmq.QueueInfo
+	alerting         bool
+	recovering       bool // Alerting but the backlog is decreasing
+	consecutiveStuck int
+	noData           bool // Metrics are stale since a failed poll
+}
+
+// pollResult is delivered after each fetch of queue metrics
+type pollResult struct {
+	queues    []rabbitmq.QueueInfo
+	timelines map[string]history.Timeline // Reread from the history file, nil if not due
+	err       error
+	at        time.Time
+}
+
+// tickMsg triggers the next poll
+type tickMsg time.Time
+
+// TopModel is the bubbletea model of the `top` command
+type TopModel struct {
+	client     *rabbitmq.Client
+	cfg        *config.Config
+	analyzer   *analyzer.Analyzer
+	refresh    time.Duration
+	rows       []queueRow
+	alerts     []analyzer.StateTransition
+	lastUpdate time.Time
+	lastErr    error
+	sortIndex  int
+	reverse    bool
+	filter     string
+	filtering  bool
+	width      int
+	height     int              // Window height, 0 until the first resize, which leaves the view unclipped
+	offset     int              // First queue row shown when the table does not fit the window
+	pageRows   int              // Queue rows that fit the window at the last render
+	queueNames *queuename.Namer // Display names from notifications.display.queue_names
+	errors     *queueerrors.Tracker
+
+	showTimeline bool // Timeline pane from the history file, toggled with t
+	timelines    map[string]history.Timeline
+	timelineAt   time.Time
+}
+
+// NewTopModel creates the TUI model polling the broker at the given refresh interval
+func NewTopModel(client *rabbitmq.Client, cfg *config.Config, refresh time.Duration) *TopModel {
+	queueAnalyzer := analyzer.New(&cfg.Monitor.Detection)
+	for _, queueCfg := range cfg.Monitor.Queues {
+		if queueCfg.IsEnabled() {
+			queueAnalyzer.SetQueueConfig(queueCfg.Name, cfg.Monitor.GetQueueDetectionConfig(&queueCfg))
+		}
+	}
+	if rules, err := analyzer.CompileRules(cfg.Monitor.Rules); err == nil {
+		queueAnalyzer.SetRules(rules)
+	}
+
+	return &TopModel{
+		client:   client,
+		cfg:      cfg,
+		analyzer: queueAnalyzer,
+		refresh:  refresh,
+		width:    120,
+
+		queueNames:   cfg.Notifications.Display.QueueNamer(),
+		errors:       queueerrors.New(),
+		showTimeline: cfg.History.Enabled,
+	}
+}
+
+// Init starts the first poll
+func (m *TopModel) Init() tea.Cmd {
+	return m.poll()
+}
+
+// poll fetches queue metrics in the background, and rereads the history file when the timeline is due
+func (m *TopModel) poll() tea.Cmd {
+	loadTimelines := m.showTimeline && time.Since(m.timelineAt) >= timelineRefresh
+	return func() tea.Msg {
+		queues, err := m.client.GetQueues()
+		if err != nil {
+			return pollResult{err: err, at: time.Now()}
+		}
+		result := pollResult{queues: rabbitmq.FilterQueues(queues, m.cfg.Monitor.Queues, m.cfg.Monitor.AutoExclude), at: time.Now()}
+		if loadTimelines {
+			result.timelines = loadTimelinePane(m.cfg.History.FilePath, result.at)
+		}
+		return result
+	}
+}
+
+// loadTimelinePane builds the timeline of every queue in the history file over the timeline range
+// Returns an empty map if the file cannot be read, e.g. when the monitor runs on another host
+func loadTimelinePane(path string, now time.Time) map[string]history.Timeline {
+	from := now.Add(-timelineRange)
+	timelines := make(map[string]history.Timeline)
+	records, err := history.Load(path, from.Add(-time.Hour))
+	if err != nil {
+		return timelines
+	}
+	for queue, queueRecords := range records {
+		timelines[queue] = history.BuildTimeline(queue, queueRecords, from, now)
+	}
+	return timelines
+}
+
+// Update handles keyboard input, window resizes and poll results
+func (m *TopModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+
+	case pollResult:
+		m.lastUpdate = msg.at
+		m.lastErr = msg.err
+		if msg.err == nil {
+			m.applyPoll(msg.queues, msg.at)
+		} else {
+			m.failPoll(msg.err, msg.at)
+		}
+		if msg.timelines != nil {
+			m.timelines = msg.timelines
+			m.timelineAt = msg.at
+		}
+		return m, tea.Tick(m.refresh, func(t time.Time) tea.Msg { return tickMsg(t) })
+
+	case tickMsg:
+		return m, m.poll()
+	}
+
+	return m, nil
+}
+
+// handleKey processes keyboard input for sorting, filtering, scrolling and quitting
+func (m *TopModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.filtering {
+		switch msg.Type {
+		case tea.KeyEnter:
+			m.filtering = false
+		case tea.KeyEsc:
+			m.filtering = false
+			m.filter = ""
+			m.offset = 0
+		case tea.KeyBackspace:
+			if filter := []rune(m.filter); len(filter) > 0 {
+				m.filter = string(filter[:len(filter)-1])
+				m.offset = 0
+			}
+		case tea.KeyRunes:
+			m.filter += string(msg.Runes)
+			m.offset = 0
+		case tea.KeyCtrlC:
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "s":
+		m.sortIndex = (m.sortIndex + 1) % len(sortColumns)
+	case "r":
+		m.reverse = !m.reverse
+	case "/":
+		m.filtering = true
+	case "esc":
+		m.filter = ""
+		m.offset = 0
+	case "up", "k":
+		m.offset--
+	case "down", "j":
+		m.offset++
+	case "pgup":
+		m.offset -= m.pageRows
+	case "pgdown", " ":
+		m.offset += m.pageRows
+	case "home", "g":
+		m.offset = 0
+	case "end", "G":
+		m.offset = len(m.rows)
+	case "t":
+		m.showTimeline = !m.showTimeline
+		m.timelineAt = time.Time{}
+	}
+	return m, nil
+}
+
+// applyPoll runs the analyzer on fresh metrics and records transitions
+func (m *TopModel) applyPoll(queues []rabbitmq.QueueInfo, now time.Time) {
+	m.trackErrors(queues, now)
+	result := m.analyzer.Analyze(queues)
+
+	// Alerts right after a gap may have missed how the queue got stuck
+	window := m.refresh * time.Duration(m.cfg.Monitor.Detection.ThresholdChecks+1)
+	for i := range result.Transitions {
+		if result.Transitions[i].ToState != "alerting" {
+			continue
+		}
+		if gap, recent := m.errors.RecentGap(result.Transitions[i].QueueName, window, now); recent {
+			result.Transitions[i].Reason += fmt.Sprintf(" (after a data gap of %s)", slack.FormatDuration(gap, "en"))
+		}
+	}
+
+	m.alerts = append(append(result.Transitions, result.Recovering...), m.alerts...)
+	if len(m.alerts) > maxRecentAlerts {
+		m.alerts = m.alerts[:maxRecentAlerts]
+	}
+
+	rows := make([]queueRow, 0, len(queues))
+	for _, queue := range queues {
+		row := queueRow{info: queue}
+		if state := m.analyzer.GetQueueState(queue.Name); state != nil {
+			row.alerting = state.LastKnownState == "alerting"
+			row.recovering = row.alerting && state.IsRecovering()
+			row.consecutiveStuck = state.ConsecutiveStuck
+		}
+		rows = append(rows, row)
+	}
+	m.rows = rows
+}
+
+// failPoll marks every shown queue as without data after a failed poll
+func (m *TopModel) failPoll(err error, now time.Time) {
+	names := make([]string, 0, len(m.rows))
+	for i := range m.rows {
+		m.rows[i].noData = true
+		names = append(names, m.rows[i].info.Name)
+	}
+	m.errors.Failed(names, fmt.Sprintf("failed to fetch queues: %v", err), now)
+}
+
+// trackErrors ends the data gaps of polled queues and records configured queues missing from the broker
+func (m *TopModel) trackErrors(queues []rabbitmq.QueueInfo, now time.Time) {
+	names := make([]string, 0, len(queues))
+	polled := make(map[string]bool, len(queues))
+	for _, queue := range queues {
+		names = append(names, queue.Name)
+		polled[queue.Name] = true
+	}
+	// Metrics from before a long gap would make the analyzer compare snapshots far apart
+	maxMissed := m.cfg.Monitor.MaxMissedChecks
+	for queue, gap := range m.errors.Observed(names, now) {
+		if maxMissed > 0 && gap > m.refresh*time.Duration(maxMissed+1) {
+			m.analyzer.DiscardHistory(queue)
+		}
+	}
+
+	var missing []string
+	for _, queueCfg := range m.cfg.Monitor.Queues {
+		if queueCfg.IsEnabled() && !polled[queueCfg.Name] {
+			missing = append(missing, queueCfg.Name)
+		}
+	}
+	m.errors.Failed(missing, queueerrors.MissingQueue, now)
+}
+
+// visibleRows returns the filtered and sorted rows
+func (m *TopModel) visibleRows() []queueRow {
+	rows := make([]queueRow, 0, len(m.rows))
+	for _, row := range m.rows {
+		// The filter matches full names too, as shown in logs and alerts' payloads
+		filter := strings.ToLower(m.filter)
+		if filter == "" || strings.Contains(strings.ToLower(row.info.Name), filter) || strings.Contains(strings.ToLower(m.queueNames.Name(row.info.Name)), filter) {
+			rows = append(rows, row)
+		}
+	}
+
+	less := func(a, b queueRow) bool {
+		switch sortColumns[m.sortIndex] {
+		case "messages":
+			return a.info.MessagesReady > b.info.MessagesReady
+		case "consumers":
+			return a.info.Consumers < b.info.Consumers
+		case "consume_rate":
+			return a.info.ConsumeRate < b.info.ConsumeRate
+		case "state":
+			if a.alerting != b.alerting {
+				return a.alerting
+			}
+			return a.consecutiveStuck > b.consecutiveStuck
+		default:
+			return a.info.Name < b.info.Name
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if m.reverse {
+			return less(rows[j], rows[i])
+		}
+		return less(rows[i], rows[j])
+	})
+	return rows
+}
+
+// View renders the queue table, recent alerts and key help
+// Once the window height is known, the table shows the rows that fit below the header and above the other panes
+func (m *TopModel) View() string {
+	var b strings.Builder
+
+	status := fmt.Sprintf("go-rmq-monitor top — %s vhost %s — refresh %s", m.cfg.RabbitMQ.Host, m.cfg.RabbitMQ.VHost, m.refresh)
+	if !m.lastUpdate.IsZero() {
+		status += " — updated " + m.lastUpdate.Format("15:04:05")
+	}
+	b.WriteString(headerStyle.Render(status) + "\n")
+	if m.lastErr != nil {
+		b.WriteString(alertingStyle.Render("error: "+m.lastErr.Error()) + "\n")
+	}
+	b.WriteString("\n")
+
+	nameWidth := m.width - 70
+	if nameWidth < 20 {
+		nameWidth = 20
+	}
+	b.WriteString(headerStyle.Render(fmt.Sprintf("%-*s %10s %9s %10s %10s %10s  %s",
+		nameWidth, "QUEUE", "READY", "CONSUMERS", "CONSUME/s", "ACK/s", "PUBLISH/s", "STATE")) + "\n")
+
+	var below strings.Builder
+	if m.showTimeline {
+		below.WriteString(m.timelineView(nameWidth))
+	}
+
+	below.WriteString(m.errorsView(time.Now()))
+
+	below.WriteString("\n" + headerStyle.Render("RECENT ALERTS") + "\n")
+	if len(m.alerts) == 0 {
+		below.WriteString(dimStyle.Render("(none this session)") + "\n")
+	}
+	for _, transition := range m.alerts {
+		line := fmt.Sprintf("%s  %-30s %s → %s", transition.Timestamp.Format("15:04:05"), transition.QueueName, transition.FromState, transition.ToState)
+		switch transition.ToState {
+		case "alerting":
+			below.WriteString(alertingStyle.Render(line) + "  " + transition.Reason + "\n")
+		case "recovering":
+			below.WriteString(recoverStyle.Render(line) + "  " + transition.Reason + "\n")
+		default:
+			below.WriteString(healthyStyle.Render(line) + "\n")
+		}
+	}
+
+	// The help is two lines: a blank one and the keys
+	rows := m.visibleRows()
+	m.pageRows = len(rows)
+	if m.height > 0 {
+		fit := m.height - strings.Count(b.String(), "\n") - strings.Count(below.String(), "\n") - 2
+		if fit < minTableRows {
+			fit = minTableRows
+		}
+		if fit < m.pageRows {
+			m.pageRows = fit
+		}
+	}
+	if m.offset > len(rows)-m.pageRows {
+		m.offset = len(rows) - m.pageRows
+	}
+	if m.offset < 0 {
+		m.offset = 0
+	}
+
+	numbers := m.cfg.Notifications.Display.NumberFormat()
+	for _, row := range rows[m.offset : m.offset+m.pageRows] {
+		name := queuename.Truncate(m.queueNames.Name(row.info.Name), nameWidth)
+		line := fmt.Sprintf("%-*s %10s %9s %10s %10s %10s  ",
+			nameWidth, name, numbers.Int(row.info.MessagesReady), numbers.Int(row.info.Consumers),
+			numbers.Float(row.info.ConsumeRate, 2), numbers.Float(row.info.AckRate, 2), numbers.Float(row.info.PublishRate, 2))
+
+		switch {
+		case row.noData:
+			line += suspectStyle.Render("NO DATA")
+		case row.recovering:
+			line += recoverStyle.Render("RECOVERING")
+		case row.alerting:
+			line += alertingStyle.Render("ALERTING")
+		case row.consecutiveStuck > 0:
+			line += suspectStyle.Render(fmt.Sprintf("SUSPECT (%d)", row.consecutiveStuck))
+		default:
+			line += healthyStyle.Render("OK")
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString(below.String())
+
+	// Panes taller than the window are cut at the bottom, keeping the help visible
+	view := b.String()
+	if m.height > 2 {
+		if lines := strings.SplitAfter(view, "\n"); len(lines)-1 > m.height-2 {
+			view = strings.Join(lines[:m.height-2], "")
+		}
+	}
+
+	help := fmt.Sprintf("\n[s] sort: %s  [r] reverse  [t] timeline  [/] filter", sortColumns[m.sortIndex])
+	if m.filter != "" || m.filtering {
+		help += fmt.Sprintf(": %s", m.filter)
+		if m.filtering {
+			help += "▌"
+		}
+		help += "  [esc] clear"
+	}
+	if m.pageRows < len(rows) {
+		help += fmt.Sprintf("  [↑↓ pgup pgdn] rows %d-%d of %d", m.offset+1, m.offset+m.pageRows, len(rows))
+	}
+	help += "  [q] quit"
+
+	return view + dimStyle.Render(help)
+}
+
+// errorsView lists the last error of queues that had one this session, with the data gap it left
+func (m *TopModel) errorsView(now time.Time) string {
+	entries := m.errors.Entries()
+	if len(entries) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n" + headerStyle.Render("QUEUE ERRORS") + "\n")
+	for _, entry := range entries {
+		line := fmt.Sprintf("%s  %-30s %s", entry.At.Format("15:04:05"), m.queueNames.Name(entry.Queue), entry.Error)
+		if entry.NoData {
+			b.WriteString(alertingStyle.Render(line) + dimStyle.Render(" (no data for "+slack.FormatDuration(entry.Gap(now), "en")+")") + "\n")
+			continue
+		}
+		if gap := entry.Gap(now); gap > 0 {
+			line += " (data gap of " + slack.FormatDuration(gap, "en") + ")"
+		}
+		b.WriteString(dimStyle.Render(line) + "\n")
+	}
+	return b.String()
+}
+
+// timelineView renders a bar per visible queue showing its states over the timeline range
+// Each cell shows the most severe state within its slice of the range
+func (m *TopModel) timelineView(nameWidth int) string {
+	var b strings.Builder
+	b.WriteString("\n" + headerStyle.Render(fmt.Sprintf("TIMELINE (last %s)", timelineRange)) + "  ")
+	b.WriteString(healthyStyle.Render("▁ healthy") + " " + alertingStyle.Render("█ alerting") + " " + recoverStyle.Render("▆ recovering") + "\n")
+	if m.timelines == nil {
+		b.WriteString(dimStyle.Render("(loading history)") + "\n")
+		return b.String()
+	}
+
+	barWidth := m.width - nameWidth - 2
+	if barWidth < 24 {
+		barWidth = 24
+	}
+	shown := 0
+	for _, row := range m.visibleRows() {
+		timeline, exists := m.timelines[row.info.Name]
+		if !exists {
+			continue
+		}
+		if shown == maxTimelineRows {
+			b.WriteString(dimStyle.Render("(filter to see more queues)") + "\n")
+			break
+		}
+		name := queuename.Truncate(m.queueNames.Name(row.info.Name), nameWidth)
+		b.WriteString(fmt.Sprintf("%-*s  %s\n", nameWidth, name, timelineBar(timeline, barWidth)))
+		shown++
+	}
+	if shown == 0 {
+		b.WriteString(dimStyle.Render("(no history for these queues; enable history in the monitor's config)") + "\n")
+	}
+	return b.String()
+}
+
+// timelineSeverity orders states so that a cell spanning several shows the most severe
+var timelineSeverity = map[string]int{
+	history.StateUnknown:    1,
+	history.StateHealthy:    2,
+	history.StateRecovering: 3,
+	history.StateAlerting:   4,
+}
+
+// timelineBar renders a timeline as width cells from its start to its end
+func timelineBar(timeline history.Timeline, width int) string {
+	var b strings.Builder
+	cell := timeline.To.Sub(timeline.From) / time.Duration(width)
+	next := 0
+	for i := 0; i < width; i++ {
+		start := timeline.From.Add(time.Duration(i) * cell)
+		end := start.Add(cell)
+		state := ""
+		for j := next; j < len(timeline.Intervals); j++ {
+			interval := timeline.Intervals[j]
+			if !interval.Start.Before(end) {
+				break
+			}
+			if !interval.End.After(start) {
+				next = j + 1
+				continue
+			}
+			if timelineSeverity[interval.State] > timelineSeverity[state] {
+				state = interval.State
+			}
+		}
+		switch state {
+		case history.StateAlerting:
+			b.WriteString(alertingStyle.Render("█"))
+		case history.StateRecovering:
+			b.WriteString(recoverStyle.Render("▆"))
+		case history.StateHealthy:
+			b.WriteString(healthyStyle.Render("▁"))
+		case history.StateUnknown:
+			b.WriteString(dimStyle.Render("▁"))
+		default:
+			b.WriteString(" ")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,275 @@
+package tuning
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"go-rmq-monitor/internal/analyzer"
+	"go-rmq-monitor/internal/config"
+	"go-rmq-monitor/internal/feedback"
+	"go-rmq-monitor/internal/history"
+	"go-rmq-monitor/internal/rabbitmq"
+)
+
+// minSamples is the number of samples needed before suggesting changes for a queue
+const minSamples = 20
+
+// Suggestion contains tuned detection parameters for a queue
+type Suggestion struct {
+	QueueName            string
+	Samples              int
+	Current              config.DetectionConfig
+	Suggested            config.DetectionConfig
+	AlertEpisodes        int  // Alerts the current settings would have raised
+	ShortEpisodes        int  // Alerts that recovered on their own shortly after
+	FalsePositives       int  // Alerts operators marked as false positives
+	LikelyFalsePositives bool // Current settings are likely to cause false positives
+	Notes                []string
+}
+
+// Changed reports whether any suggested parameter differs from the current config
+func (s Suggestion) Changed() bool {
+	return s.Current != s.Suggested
+}
+
+// Analyze replays stored history through the analyzer with the current settings
+// and suggests detection parameters based on observed distributions and on
+// alerts operators marked as false positives
+func Analyze(records map[string][]history.Record, marks map[string][]feedback.Mark, cfg *config.Config) []Suggestion {
+	queueConfigs := make(map[string]config.QueueConfig)
+	for _, queueCfg := range cfg.Monitor.Queues {
+		queueConfigs[queueCfg.Name] = queueCfg
+	}
+
+	queueNames := make(map[string]bool)
+	for queueName := range records {
+		queueNames[queueName] = true
+	}
+	for queueName := range marks {
+		queueNames[queueName] = true
+	}
+
+	suggestions := make([]Suggestion, 0, len(queueNames))
+	for queueName := range queueNames {
+		current := cfg.Monitor.Detection
+		if queueCfg, exists := queueConfigs[queueName]; exists {
+			if !queueCfg.IsEnabled() {
+				continue
+			}
+			current = cfg.Monitor.GetQueueDetectionConfig(&queueCfg)
+		}
+		suggestion := analyzeQueue(queueName, records[queueName], current)
+		applyFeedback(&suggestion, marks[queueName])
+		suggestions = append(suggestions, suggestion)
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		return suggestions[i].QueueName < suggestions[j].QueueName
+	})
+	return suggestions
+}
+
+// analyzeQueue builds a suggestion for a single queue
+func analyzeQueue(queueName string, records []history.Record, current config.DetectionConfig) Suggestion {
+	suggestion := Suggestion{
+		QueueName: queueName,
+		Samples:   len(records),
+		Current:   current,
+		Suggested: current,
+	}
+
+	if len(records) < minSamples {
+		suggestion.Notes = append(suggestion.Notes, "not enough history to suggest changes")
+		return suggestion
+	}
+
+	// Replay history with the current settings to find alert episodes
+	episodes := replay(queueName, records, current)
+	suggestion.AlertEpisodes = len(episodes)
+	longestShort := 0
+	for _, length := range episodes {
+		if length <= current.ThresholdChecks*2 {
+			suggestion.ShortEpisodes++
+			if length > longestShort {
+				longestShort = length
+			}
+		}
+	}
+	suggestion.LikelyFalsePositives = suggestion.ShortEpisodes > 0
+
+	// threshold_checks: require stuck runs longer than self-resolving ones
+	if longestShort > 0 {
+		suggestion.Suggested.ThresholdChecks = minInt(current.ThresholdChecks+longestShort, 20)
+		suggestion.Notes = append(suggestion.Notes, "alerts recovered on their own within a few checks")
+	}
+
+	// min_message_count: stay above the standing backlog of an actively processed queue
+	processedBacklog := make([]float64, 0)
+	for _, record := range records {
+		if record.MessagesReady > 0 && (record.ConsumeRate > 0 || record.AckRate > 0) {
+			processedBacklog = append(processedBacklog, float64(record.MessagesReady))
+		}
+	}
+	if len(processedBacklog) > 0 {
+		standing := roundUpNice(percentile(processedBacklog, 0.9))
+		if standing > current.MinMessageCount {
+			suggestion.Suggested.MinMessageCount = standing
+			suggestion.Notes = append(suggestion.Notes, "queue routinely holds a backlog while being processed")
+		}
+	}
+
+	// min_consume_rate: disable rate checks for batch/cron consumers, otherwise
+	// use a fraction of the lowest typical consume rate
+	withMessages, idle := 0, 0
+	rates := make([]float64, 0)
+	for _, record := range records {
+		if record.MessagesReady == 0 {
+			continue
+		}
+		withMessages++
+		if record.ConsumeRate == 0 && record.AckRate == 0 {
+			idle++
+		} else {
+			rates = append(rates, math.Max(record.ConsumeRate, record.AckRate))
+		}
+	}
+	if withMessages > 0 && float64(idle)/float64(withMessages) > 0.5 {
+		if current.MinConsumeRate >= 0 {
+			suggestion.Suggested.MinConsumeRate = -1
+			suggestion.Notes = append(suggestion.Notes, "consumption is bursty (batch/cron pattern), rate checks disabled")
+		}
+	} else if len(rates) > 0 {
+		typical := math.Round(percentile(rates, 0.1)*0.5*100) / 100
+		if typical < 0.01 {
+			typical = 0.01
+		}
+		if current.MinConsumeRate >= 0 && typical < current.MinConsumeRate {
+			suggestion.Suggested.MinConsumeRate = typical
+			suggestion.Notes = append(suggestion.Notes, "normal consume rate is often below min_consume_rate")
+		}
+	}
+
+	return suggestion
+}
+
+// applyFeedback adjusts a suggestion so alerts marked as false positives would not fire again
+// Marks are applied even without enough history, since they record operator judgement
+func applyFeedback(suggestion *Suggestion, marks []feedback.Mark) {
+	if len(marks) == 0 {
+		return
+	}
+	suggestion.FalsePositives = len(marks)
+	suggestion.LikelyFalsePositives = true
+	suggestion.Notes = append(suggestion.Notes, fmt.Sprintf("%d alert(s) marked as false positive", len(marks)))
+
+	maxConsumedBacklog, minMarkedRate := 0, math.Inf(1)
+	selfResolved, withoutConsumers := 0, 0
+	for _, mark := range marks {
+		if !mark.Context.Alerting {
+			selfResolved++
+		}
+		if mark.Context.Consumers == 0 {
+			withoutConsumers++
+			continue
+		}
+		if mark.Context.ConsumeRate > 0 || mark.Context.AckRate > 0 {
+			if mark.Context.MessagesReady > maxConsumedBacklog {
+				maxConsumedBacklog = mark.Context.MessagesReady
+			}
+			rate := math.Max(mark.Context.ConsumeRate, mark.Context.AckRate)
+			minMarkedRate = math.Min(minMarkedRate, rate)
+		}
+	}
+
+	// min_message_count: stay above the backlogs of marked alerts that were still being consumed
+	if maxConsumedBacklog > 0 {
+		above := roundUpNice(float64(maxConsumedBacklog) * 1.1)
+		if above > suggestion.Suggested.MinMessageCount {
+			suggestion.Suggested.MinMessageCount = above
+			suggestion.Notes = append(suggestion.Notes, fmt.Sprintf("marked alerts had backlogs up to %d while being consumed", maxConsumedBacklog))
+		}
+	}
+
+	// min_consume_rate: marked alerts were consuming below the configured minimum
+	current := suggestion.Suggested.MinConsumeRate
+	if !math.IsInf(minMarkedRate, 1) && current > 0 && minMarkedRate < current {
+		lowered := math.Max(math.Round(minMarkedRate*0.5*100)/100, 0.01)
+		if lowered < current {
+			suggestion.Suggested.MinConsumeRate = lowered
+			suggestion.Notes = append(suggestion.Notes, "marked alerts were consuming below min_consume_rate")
+		}
+	}
+
+	// threshold_checks: marked alerts that recovered on their own were short-lived stalls
+	if selfResolved > 0 {
+		doubled := minInt(suggestion.Current.ThresholdChecks*2, 20)
+		if doubled > suggestion.Suggested.ThresholdChecks {
+			suggestion.Suggested.ThresholdChecks = doubled
+		}
+	}
+
+	if withoutConsumers == len(marks) {
+		suggestion.Notes = append(suggestion.Notes, "alerts were marked while the queue had no consumers, consider observe_only")
+	}
+}
+
+// replay feeds records through a fresh analyzer and returns the length in
+// checks of each alert episode that ended within the history
+func replay(queueName string, records []history.Record, cfg config.DetectionConfig) []int {
+	queueAnalyzer := analyzer.New(&cfg)
+	episodes := make([]int, 0)
+	alertingSince := -1
+
+	for i, record := range records {
+		result := queueAnalyzer.Analyze([]rabbitmq.QueueInfo{{
+			Name:            queueName,
+			MessagesReady:   record.MessagesReady,
+			MessagesUnacked: record.MessagesUnacked,
+			Consumers:       record.Consumers,
+			ConsumeRate:     record.ConsumeRate,
+			AckRate:         record.AckRate,
+			PublishRate:     record.PublishRate,
+			RedeliverRate:   record.RedeliverRate,
+		}})
+
+		for _, transition := range result.Transitions {
+			if transition.ToState == "alerting" {
+				alertingSince = i
+			} else if alertingSince >= 0 {
+				episodes = append(episodes, i-alertingSince)
+				alertingSince = -1
+			}
+		}
+	}
+
+	return episodes
+}
+
+// percentile returns the p-th percentile (0..1) of values
+func percentile(values []float64, p float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	index := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	return sorted[index]
+}
+
+// roundUpNice rounds a count up to one or two significant digits
+func roundUpNice(value float64) int {
+	if value <= 10 {
+		return int(math.Ceil(value))
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(value))-1)
+	return int(math.Ceil(value/magnitude) * magnitude)
+}
+
+// minInt returns the smaller of two ints
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -1,10 +1,8 @@
 package main
 
 import (
-	"fmt"
-	"os"
-
 	"go-rmq-monitor/cmd"
+	"go-rmq-monitor/internal/buildinfo"
 )
 
 var (
@@ -14,13 +12,6 @@ var (
 )
 
 func main() {
-	// Handle version command
-	if len(os.Args) > 1 && os.Args[1] == "version" {
-		fmt.Printf("go-rmq-monitor version %s\n", version)
-		fmt.Printf("commit: %s\n", commit)
-		fmt.Printf("built: %s\n", date)
-		os.Exit(0)
-	}
-
+	buildinfo.Set(version, commit, date)
 	cmd.Execute()
 }
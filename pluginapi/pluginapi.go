@@ -0,0 +1,71 @@
+// Package pluginapi defines the interfaces Go plugins implement to extend the monitor
+//
+// A plugin is a main package built with `go build -buildmode=plugin` from the same module
+// version and Go toolchain as the monitor binary. It exports a variable named Plugin whose
+// value implements Plugin and one or both of Detector and Notifier:
+//
+//	var Plugin billingRules
+//
+//	func (p *billingRules) Init(options map[string]string) error { ... }
+//	func (p *billingRules) Detect(queue string, history []pluginapi.Snapshot) (bool, string) { ... }
+package pluginapi
+
+import (
+	"time"
+)
+
+// Plugin is implemented by the value a plugin exports as Plugin
+type Plugin interface {
+	// Init configures the plugin with the options from its config entry, before any other call
+	// Go plugins cannot be unloaded, so Init may run again, e.g. when notification channels are checked
+	Init(options map[string]string) error
+}
+
+// Detector is implemented by plugins adding a detection check
+// Detect runs for every checked queue that no built-in check or rule finds stuck
+type Detector interface {
+	// Detect reports whether a queue is stuck from its recent checks, oldest first, and why
+	Detect(queue string, history []Snapshot) (bool, string)
+}
+
+// Notifier is implemented by plugins delivering queue alerts
+type Notifier interface {
+	// SendAlert delivers a queue stuck, recovering or recovery alert
+	SendAlert(alert Alert) error
+	// Ping checks the plugin's endpoints without notifying anyone
+	Ping() error
+}
+
+// Snapshot is a queue's metrics at one check
+type Snapshot struct {
+	Timestamp       time.Time
+	MessagesReady   int
+	MessagesUnacked int
+	Consumers       int
+	PublishRate     float64
+	ConsumeRate     float64
+	AckRate         float64
+	RedeliverRate   float64
+	ExpireRate      float64
+	// Share of time consumers could take deliveries (0-1), -1 if not reported
+	ConsumerUtilisation float64
+}
+
+// Alert is a queue alert as sent to notifiers
+type Alert struct {
+	Type             string // "alerting", "not_alerting", "recovering" or "reminder"
+	Queue            string
+	VHost            string
+	Node             string
+	Priority         string
+	Reason           string
+	MessagesReady    int
+	Consumers        int
+	ConsumeRate      float64
+	AckRate          float64
+	PublishRate      float64
+	ConsecutiveStuck int
+	Timestamp        time.Time
+	StuckDuration    time.Duration // For recovery alerts
+	IncidentID       string
+}